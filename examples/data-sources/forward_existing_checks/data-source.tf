@@ -0,0 +1,11 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+data "forward_existing_checks" "brownfield" {
+  snapshot_id = "123456"
+}
+
+resource "local_file" "adopt_checks" {
+  filename = "${path.module}/adopted_checks.tf"
+  content  = "${data.forward_existing_checks.brownfield.import_blocks}\n${data.forward_existing_checks.brownfield.config}"
+}
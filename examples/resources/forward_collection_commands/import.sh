@@ -0,0 +1,5 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+terraform import forward_collection_commands.example network_id/id
+terraform import forward_collection_commands.example profile/network_id/id
@@ -0,0 +1,5 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+terraform import forward_nqe_check.example snapshot_id/check_id
+terraform import forward_nqe_check.example profile/snapshot_id/check_id
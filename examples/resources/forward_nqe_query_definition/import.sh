@@ -0,0 +1,5 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+terraform import forward_nqe_query_definition.example query_id
+terraform import forward_nqe_query_definition.example profile/query_id
@@ -0,0 +1,6 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+terraform import forward_snapshot.example snapshot_id
+terraform import forward_snapshot.example network_id/snapshot_id
+terraform import forward_snapshot.example profile/network_id/snapshot_id
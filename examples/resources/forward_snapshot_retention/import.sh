@@ -0,0 +1,5 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+terraform import forward_snapshot_retention.example network_id
+terraform import forward_snapshot_retention.example profile/network_id
@@ -0,0 +1,1560 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fakeforward implements an in-memory subset of the Forward Enterprise API
+// for deterministic provider acceptance tests.
+package fakeforward
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// Fault forces matching requests to fail with the given status instead of being served.
+type Fault struct {
+	// Method matches the HTTP method; empty matches any method.
+	Method string
+	// PathPrefix matches the start of the request path; empty matches any path.
+	PathPrefix string
+	Status     int
+	Body       string
+	// Times limits how many requests the fault applies to; zero applies it indefinitely.
+	Times int
+	// AfterHandling applies the fault once the request has been handled, simulating a
+	// response lost on its way back to the client.
+	AfterHandling bool
+}
+
+// Server is a fake Forward Enterprise appliance backed by httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	nextID    int
+	latency   time.Duration
+	faults    []*Fault
+	requests  map[string]int
+	version   fwdclient.Version
+	networks  map[string][]string
+	names     map[string]string
+	snapshots map[string]*snapshotRecord
+	checks    map[string][]*fwdclient.CheckResult
+	persist   map[string][]fwdclient.CheckResult
+	devices   map[string][]fwdclient.Device
+	state     map[string]json.RawMessage
+	vips      map[string][]fwdclient.VirtualServer
+	tunnels   map[string][]fwdclient.Tunnel
+	overlays  map[string]fwdclient.Overlay
+	cloudSGs  map[string][]fwdclient.CloudSecurityGroup
+	// effectiveRules holds the rules returned for "snapshot/group" when effective rules
+	// are requested.
+	effectiveRules map[string][]fwdclient.CloudSecurityRule
+	cloudRouting   map[string]fwdclient.CloudRouting
+	kubernetes     map[string]fwdclient.KubernetesInventory
+	applications   map[string][]fwdclient.Application
+	userGroups     map[string][]fwdclient.UserGroup
+	// effectiveRoutes holds the routes returned for "snapshot/routeTable" when effective
+	// routes are requested.
+	effectiveRoutes map[string][]fwdclient.CloudRoute
+	queries         []fwdclient.NqeQuery
+	sources         map[string]string
+	nqe             map[string]fwdclient.NqeRunResult
+	nqeDiffs        map[string]fwdclient.NqeDiffResult
+	nqeJobs         map[string]*nqeJob
+	paths           map[string]fwdclient.PathSearchResult
+	l2paths         map[string]fwdclient.L2PathSearchResult
+	reach           map[string]fwdclient.ConnectivityTestResult
+	commands        map[string]map[string]fwdclient.CustomCommandSet
+	retention       map[string]fwdclient.SnapshotRetentionPolicy
+	diagnoses       map[string]fwdclient.CheckDiagnosis
+	webhooks        map[string]fwdclient.CheckWebhook
+	// persistent records the persistent flag each check was created or updated with.
+	persistent map[string]bool
+	// definitionDefaults are added to created check definitions that omit them.
+	definitionDefaults map[string]any
+	// replays holds the responses to requests carrying an Idempotency-Key header.
+	replays map[string]*httptest.ResponseRecorder
+	// queued holds checks whose execution was requested but has not started; they keep
+	// reporting their previous result until the next listing starts them.
+	queued map[*fwdclient.CheckResult]bool
+}
+
+// nqeJob is an asynchronous NQE execution. Jobs are reported running when submitted
+// and complete on the first poll.
+type nqeJob struct {
+	job    fwdclient.NqeJob
+	result fwdclient.NqeRunResult
+}
+
+type snapshotRecord struct {
+	networkID string
+	snapshot  fwdclient.Snapshot
+}
+
+// New starts a fake Forward server that is closed automatically when the test ends.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		requests:        map[string]int{},
+		version:         fwdclient.Version{Build: "fake", Release: "fake", Version: "25.1.0"},
+		networks:        map[string][]string{},
+		names:           map[string]string{},
+		snapshots:       map[string]*snapshotRecord{},
+		checks:          map[string][]*fwdclient.CheckResult{},
+		persist:         map[string][]fwdclient.CheckResult{},
+		devices:         map[string][]fwdclient.Device{},
+		state:           map[string]json.RawMessage{},
+		vips:            map[string][]fwdclient.VirtualServer{},
+		tunnels:         map[string][]fwdclient.Tunnel{},
+		overlays:        map[string]fwdclient.Overlay{},
+		cloudSGs:        map[string][]fwdclient.CloudSecurityGroup{},
+		effectiveRules:  map[string][]fwdclient.CloudSecurityRule{},
+		cloudRouting:    map[string]fwdclient.CloudRouting{},
+		kubernetes:      map[string]fwdclient.KubernetesInventory{},
+		applications:    map[string][]fwdclient.Application{},
+		userGroups:      map[string][]fwdclient.UserGroup{},
+		effectiveRoutes: map[string][]fwdclient.CloudRoute{},
+		sources:         map[string]string{},
+		nqe:             map[string]fwdclient.NqeRunResult{},
+		nqeDiffs:        map[string]fwdclient.NqeDiffResult{},
+		nqeJobs:         map[string]*nqeJob{},
+		paths:           map[string]fwdclient.PathSearchResult{},
+		l2paths:         map[string]fwdclient.L2PathSearchResult{},
+		reach:           map[string]fwdclient.ConnectivityTestResult{},
+		commands:        map[string]map[string]fwdclient.CustomCommandSet{},
+		retention:       map[string]fwdclient.SnapshotRetentionPolicy{},
+		diagnoses:       map[string]fwdclient.CheckDiagnosis{},
+		webhooks:        map[string]fwdclient.CheckWebhook{},
+		persistent:      map[string]bool{},
+		replays:         map[string]*httptest.ResponseRecorder{},
+		queued:          map[*fwdclient.CheckResult]bool{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/version", s.handleVersion)
+	mux.HandleFunc("GET /api/networks", s.handleListNetworks)
+	mux.HandleFunc("GET /api/networks/{network}/snapshots", s.handleListSnapshots)
+	mux.HandleFunc("GET /api/networks/{network}/snapshots/latestProcessed", s.handleLatestProcessedSnapshot)
+	mux.HandleFunc("POST /api/networks/{network}/snapshots", s.handleCreateSnapshot)
+	mux.HandleFunc("GET /api/networks/{network}/snapshots/{snapshot}", s.handleGetSnapshot)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}", s.handleGetSnapshotByID)
+	mux.HandleFunc("DELETE /api/snapshots/{snapshot}", s.handleDeleteSnapshot)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/processingStatus", s.handleProcessingStatus)
+	mux.HandleFunc("POST /api/snapshots/{snapshot}/cancel", s.handleCancelProcessing)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/checks", s.handleListChecks)
+	mux.HandleFunc("POST /api/snapshots/{snapshot}/checks", s.handleCreateCheck)
+	mux.HandleFunc("DELETE /api/snapshots/{snapshot}/checks", s.handleDeactivateChecks)
+	mux.HandleFunc("POST /api/snapshots/{snapshot}/checks/executions", s.handleExecuteChecks)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/checks/{check}", s.handleGetCheck)
+	mux.HandleFunc("PATCH /api/snapshots/{snapshot}/checks/{check}", s.handleUpdateCheck)
+	mux.HandleFunc("DELETE /api/snapshots/{snapshot}/checks/{check}", s.handleDeactivateCheck)
+	mux.HandleFunc("PUT /api/snapshots/{snapshot}/checks/{check}/owner", s.handleTransferCheckOwner)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/checks/{check}/webhook", s.handleGetCheckWebhook)
+	mux.HandleFunc("PUT /api/snapshots/{snapshot}/checks/{check}/webhook", s.handleSetCheckWebhook)
+	mux.HandleFunc("DELETE /api/snapshots/{snapshot}/checks/{check}/webhook", s.handleDeleteCheckWebhook)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/devices", s.handleListDevices)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/devices/{device}/state/{table}", s.handleDeviceState)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/loadBalancers/virtualServers", s.handleListVirtualServers)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/tunnels", s.handleListTunnels)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/overlays", s.handleGetOverlay)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/cloud/securityGroups", s.handleListCloudSecurityGroups)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/cloud/routing", s.handleGetCloudRouting)
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/kubernetes", s.handleGetKubernetesInventory)
+	mux.HandleFunc("POST /api/nqe", s.handleRunNQE)
+	mux.HandleFunc("POST /api/nqe/jobs", s.handleSubmitNQEJob)
+	mux.HandleFunc("GET /api/nqe/jobs/{job}", s.handleGetNQEJob)
+	mux.HandleFunc("GET /api/nqe/jobs/{job}/result", s.handleGetNQEJobResult)
+	mux.HandleFunc("GET /api/nqe/queries", s.handleListQueries)
+	mux.HandleFunc("POST /api/nqe/repos/{repo}/commits", s.handleCommitQueries)
+	mux.HandleFunc("POST /api/nqe-diffs/{before}/{after}", s.handleNQEDiff)
+	mux.HandleFunc("GET /api/networks/{network}/paths", s.handlePaths)
+	mux.HandleFunc("GET /api/networks/{network}/applications", s.handleListApplications)
+	mux.HandleFunc("GET /api/networks/{network}/userGroups", s.handleListUserGroups)
+	mux.HandleFunc("GET /api/networks/{network}/l2paths", s.handleL2Paths)
+	mux.HandleFunc("POST /api/networks/{network}/collector/connectivityTests", s.handleConnectivityTest)
+	mux.HandleFunc("POST /api/networks/{network}/customCommands", s.handleCreateCustomCommands)
+	mux.HandleFunc("GET /api/networks/{network}/customCommands/{set}", s.handleGetCustomCommands)
+	mux.HandleFunc("PUT /api/networks/{network}/customCommands/{set}", s.handleUpdateCustomCommands)
+	mux.HandleFunc("DELETE /api/networks/{network}/customCommands/{set}", s.handleDeleteCustomCommands)
+	mux.HandleFunc("GET /api/networks/{network}/snapshotRetention", s.handleGetSnapshotRetention)
+	mux.HandleFunc("PUT /api/networks/{network}/snapshotRetention", s.handleSetSnapshotRetention)
+
+	s.Server = httptest.NewServer(s.intercept(mux))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// InjectFault registers a fault evaluated before routing each request.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fault := f
+	s.faults = append(s.faults, &fault)
+}
+
+// RequestCount reports how many requests were received for method and path.
+func (s *Server) RequestCount(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[method+" "+path]
+}
+
+// SetVersion overrides the payload returned by /api/version.
+func (s *Server) SetVersion(v fwdclient.Version) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.version = v
+}
+
+// AddNetwork registers an empty network. Networks are also created implicitly by AddSnapshot.
+func (s *Server) AddNetwork(id, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.networks[id]; !ok {
+		s.networks[id] = nil
+	}
+	s.names[id] = name
+}
+
+// AddSnapshot registers a snapshot under networkID. An empty ID is assigned automatically.
+func (s *Server) AddSnapshot(networkID string, snapshot fwdclient.Snapshot) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addSnapshotLocked(networkID, snapshot)
+}
+
+// Snapshot returns the stored snapshot and whether it exists.
+func (s *Server) Snapshot(id string) (fwdclient.Snapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.snapshots[id]
+	if !ok {
+		return fwdclient.Snapshot{}, false
+	}
+	return record.snapshot, true
+}
+
+// AddCheck registers a check on snapshotID. An empty ID is assigned automatically.
+func (s *Server) AddCheck(snapshotID string, check fwdclient.CheckResult) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addCheckLocked(snapshotID, check)
+}
+
+// Check returns the stored check and whether it exists.
+func (s *Server) Check(snapshotID, checkID string) (fwdclient.CheckResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if check := s.findCheckLocked(snapshotID, checkID); check != nil {
+		return *check, true
+	}
+	return fwdclient.CheckResult{}, false
+}
+
+// SetCheckDefinitionDefaults registers fields added to the definition of checks created
+// without them, as Forward does for defaulted definition fields.
+func (s *Server) SetCheckDefinitionDefaults(defaults map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.definitionDefaults = defaults
+}
+
+// SetCheckDefinition replaces the stored definition of a check, simulating an edit made
+// outside Terraform.
+func (s *Server) SetCheckDefinition(snapshotID, checkID string, definition json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if check := s.findCheckLocked(snapshotID, checkID); check != nil {
+		check.Definition = definition
+	}
+}
+
+// SetCheckTags replaces the tags of a check, simulating checks created before the
+// provider tagged them or tags edited outside Terraform.
+func (s *Server) SetCheckTags(snapshotID, checkID string, tags []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if check := s.findCheckLocked(snapshotID, checkID); check != nil {
+		check.Tags = tags
+	}
+}
+
+// SetCheckDiagnosis registers the diagnosis returned when the check is read by ID.
+func (s *Server) SetCheckDiagnosis(checkID string, diagnosis fwdclient.CheckDiagnosis) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.diagnoses[checkID] = diagnosis
+}
+
+// CheckWebhook returns the failure webhook registered for a check and whether one exists.
+func (s *Server) CheckWebhook(checkID string) (fwdclient.CheckWebhook, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	webhook, ok := s.webhooks[checkID]
+	return webhook, ok
+}
+
+// CheckPersistent returns the persistent flag a check was last created or updated with,
+// and whether one was given.
+func (s *Server) CheckPersistent(checkID string) (persistent, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	persistent, ok = s.persistent[checkID]
+	return persistent, ok
+}
+
+// AddPersistentCheck registers a check that is added to every snapshot subsequently
+// created in networkID, as Forward does for persistent checks.
+func (s *Server) AddPersistentCheck(networkID string, check fwdclient.CheckResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.persist[networkID] = append(s.persist[networkID], check)
+}
+
+// AddDevice registers a device in the inventory of snapshotID.
+func (s *Server) AddDevice(snapshotID string, device fwdclient.Device) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.devices[snapshotID] = append(s.devices[snapshotID], device)
+}
+
+// SetDeviceState registers the rows returned for one state table of a device.
+func (s *Server) SetDeviceState(snapshotID, deviceName, table, rows string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state[snapshotID+"/"+deviceName+"/"+table] = json.RawMessage(rows)
+}
+
+// AddVirtualServer registers a load balancer virtual server in snapshotID.
+func (s *Server) AddVirtualServer(snapshotID string, server fwdclient.VirtualServer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vips[snapshotID] = append(s.vips[snapshotID], server)
+}
+
+// AddTunnel registers a tunnel in snapshotID.
+func (s *Server) AddTunnel(snapshotID string, tunnel fwdclient.Tunnel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tunnels[snapshotID] = append(s.tunnels[snapshotID], tunnel)
+}
+
+// SetOverlay registers the SD-WAN overlay of snapshotID.
+func (s *Server) SetOverlay(snapshotID string, overlay fwdclient.Overlay) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overlays[snapshotID] = overlay
+}
+
+// AddCloudSecurityGroup registers a cloud security group in snapshotID.
+func (s *Server) AddCloudSecurityGroup(snapshotID string, group fwdclient.CloudSecurityGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cloudSGs[snapshotID] = append(s.cloudSGs[snapshotID], group)
+}
+
+// SetEffectiveSecurityRules sets the rules returned for a security group when effective
+// rules are requested. Groups without them return their configured rules.
+func (s *Server) SetEffectiveSecurityRules(snapshotID, groupID string, rules []fwdclient.CloudSecurityRule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.effectiveRules[snapshotID+"/"+groupID] = rules
+}
+
+// SetCloudRouting registers the cloud route tables and attachments of snapshotID.
+func (s *Server) SetCloudRouting(snapshotID string, routing fwdclient.CloudRouting) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cloudRouting[snapshotID] = routing
+}
+
+// SetEffectiveRoutes sets the routes returned for a route table when effective routes
+// are requested. Route tables without them return their configured routes.
+func (s *Server) SetEffectiveRoutes(snapshotID, routeTableID string, routes []fwdclient.CloudRoute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.effectiveRoutes[snapshotID+"/"+routeTableID] = routes
+}
+
+// AddKubernetesCluster registers a Kubernetes cluster in snapshotID.
+func (s *Server) AddKubernetesCluster(snapshotID string, cluster fwdclient.KubernetesCluster) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inventory := s.kubernetes[snapshotID]
+	inventory.Clusters = append(inventory.Clusters, cluster)
+	s.kubernetes[snapshotID] = inventory
+}
+
+// AddApplication registers an application known to networkID.
+func (s *Server) AddApplication(networkID string, application fwdclient.Application) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applications[networkID] = append(s.applications[networkID], application)
+}
+
+// AddUserGroup registers a user group known to networkID.
+func (s *Server) AddUserGroup(networkID string, group fwdclient.UserGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.userGroups[networkID] = append(s.userGroups[networkID], group)
+}
+
+// AddNQEQuery registers a committed query in the NQE library.
+func (s *Server) AddNQEQuery(q fwdclient.NqeQuery) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries = append(s.queries, q)
+}
+
+// NQEQuerySource returns the committed source of the query at path and whether it exists.
+func (s *Server) NQEQuerySource(path string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	source, ok := s.sources[path]
+	return source, ok
+}
+
+// SetNQEResult registers the result returned for a query, keyed by query ID or inline query text.
+func (s *Server) SetNQEResult(key string, result fwdclient.NqeRunResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nqe[key] = result
+}
+
+// SetNQEDiffResult registers the diff result returned for a query ID.
+func (s *Server) SetNQEDiffResult(queryID string, result fwdclient.NqeDiffResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nqeDiffs[queryID] = result
+}
+
+// SetPathResult registers the path search result returned for a network.
+func (s *Server) SetPathResult(networkID string, result fwdclient.PathSearchResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paths[networkID] = result
+}
+
+// SetL2PathResult registers the L2 path search result returned for a network.
+func (s *Server) SetL2PathResult(networkID string, result fwdclient.L2PathSearchResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.l2paths[networkID] = result
+}
+
+// SetConnectivityResult registers the connectivity test result reported for a host.
+// Hosts without a result are reported unreachable.
+func (s *Server) SetConnectivityResult(host string, result fwdclient.ConnectivityTestResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reach[host] = result
+}
+
+func (s *Server) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.Method+" "+r.URL.Path]++
+		latency := s.latency
+		fault := s.matchFaultLocked(r)
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if fault != nil && !fault.AfterHandling {
+			writeFault(w, fault)
+			return
+		}
+
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" {
+			if fault != nil {
+				next.ServeHTTP(httptest.NewRecorder(), r)
+				writeFault(w, fault)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Retried requests with a known key get the original response instead of
+		// being applied twice.
+		s.mu.Lock()
+		recorded, ok := s.replays[key]
+		s.mu.Unlock()
+		if !ok {
+			recorded = httptest.NewRecorder()
+			next.ServeHTTP(recorded, r)
+			s.mu.Lock()
+			s.replays[key] = recorded
+			s.mu.Unlock()
+		}
+
+		if fault != nil {
+			writeFault(w, fault)
+			return
+		}
+		for name, values := range recorded.Header() {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(recorded.Code)
+		_, _ = w.Write(recorded.Body.Bytes())
+	})
+}
+
+func writeFault(w http.ResponseWriter, fault *Fault) {
+	w.WriteHeader(fault.Status)
+	_, _ = w.Write([]byte(fault.Body))
+}
+
+func (s *Server) matchFaultLocked(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+			continue
+		}
+		if f.PathPrefix != "" && !strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) newIDLocked(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", prefix, s.nextID)
+}
+
+func (s *Server) addSnapshotLocked(networkID string, snapshot fwdclient.Snapshot) string {
+	if snapshot.ID == "" {
+		snapshot.ID = s.newIDLocked("snap")
+	}
+	if snapshot.State == "" {
+		snapshot.State = "PROCESSED"
+	}
+	s.snapshots[snapshot.ID] = &snapshotRecord{networkID: networkID, snapshot: snapshot}
+	s.networks[networkID] = append(s.networks[networkID], snapshot.ID)
+	return snapshot.ID
+}
+
+func (s *Server) addCheckLocked(snapshotID string, check fwdclient.CheckResult) string {
+	if check.ID == "" {
+		check.ID = s.newIDLocked("check")
+	}
+	if check.Status == "" {
+		check.Status = "PASS"
+	}
+	s.checks[snapshotID] = append(s.checks[snapshotID], &check)
+	return check.ID
+}
+
+func (s *Server) findCheckLocked(snapshotID, checkID string) *fwdclient.CheckResult {
+	for _, check := range s.checks[snapshotID] {
+		if check.ID == checkID {
+			return check
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]string{"message": fmt.Sprintf(format, args...)})
+}
+
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.version)
+}
+
+func (s *Server) handleListNetworks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networks := make([]fwdclient.Network, 0, len(s.networks))
+	for id := range s.networks {
+		networks = append(networks, fwdclient.Network{ID: id, Name: s.names[id]})
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].ID < networks[j].ID })
+	writeJSON(w, http.StatusOK, networks)
+}
+
+func (s *Server) handleLatestProcessedSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networkID := r.PathValue("network")
+	if snapshot, ok := s.latestProcessedLocked(networkID); ok {
+		writeJSON(w, http.StatusOK, snapshot)
+		return
+	}
+	writeError(w, http.StatusNotFound, "network %s has no processed snapshot", networkID)
+}
+
+// latestProcessedLocked returns the newest processed snapshot of networkID.
+func (s *Server) latestProcessedLocked(networkID string) (fwdclient.Snapshot, bool) {
+	ids := s.networks[networkID]
+	for i := len(ids) - 1; i >= 0; i-- {
+		if snapshot := s.snapshots[ids[i]].snapshot; snapshot.State == "PROCESSED" {
+			return snapshot, true
+		}
+	}
+	return fwdclient.Snapshot{}, false
+}
+
+func (s *Server) handleListSnapshots(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networkID := r.PathValue("network")
+	ids, ok := s.networks[networkID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "network %s not found", networkID)
+		return
+	}
+
+	snapshots := make([]fwdclient.Snapshot, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		snapshots = append(snapshots, s.snapshots[ids[i]].snapshot)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": networkID, "snapshots": snapshots})
+}
+
+func (s *Server) handleCreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.SnapshotCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creation := time.Now().UnixMilli()
+	id := s.addSnapshotLocked(r.PathValue("network"), fwdclient.Snapshot{
+		Note:               body.Note,
+		ProcessingTrigger:  "COLLECTION",
+		CreationDateMillis: &creation,
+		ProcessedAtMillis:  &creation,
+	})
+	for _, check := range s.persist[r.PathValue("network")] {
+		check.ID = ""
+		s.addCheckLocked(id, check)
+	}
+	writeJSON(w, http.StatusOK, s.snapshots[id].snapshot)
+}
+
+func (s *Server) handleGetSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.snapshots[r.PathValue("snapshot")]
+	if !ok || record.networkID != r.PathValue("network") {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", r.PathValue("snapshot"))
+		return
+	}
+	writeJSON(w, http.StatusOK, record.snapshot)
+}
+
+func (s *Server) handleGetSnapshotByID(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.snapshots[r.PathValue("snapshot")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", r.PathValue("snapshot"))
+		return
+	}
+	writeJSON(w, http.StatusOK, fwdclient.SnapshotDetails{Snapshot: record.snapshot, NetworkID: record.networkID})
+}
+
+func (s *Server) handleProcessingStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.snapshots[r.PathValue("snapshot")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", r.PathValue("snapshot"))
+		return
+	}
+
+	total := int64(len(s.devices[record.snapshot.ID]))
+	parsed, percent := int64(0), float64(0)
+	if record.snapshot.State == "PROCESSED" {
+		parsed, percent = total, 100
+	}
+	writeJSON(w, http.StatusOK, fwdclient.SnapshotProcessingStatus{
+		State:           record.snapshot.State,
+		DevicesParsed:   &parsed,
+		DevicesTotal:    &total,
+		PercentComplete: &percent,
+	})
+}
+
+func (s *Server) handleCancelProcessing(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.snapshots[r.PathValue("snapshot")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", r.PathValue("snapshot"))
+		return
+	}
+	if record.snapshot.State != "PROCESSING" {
+		writeError(w, http.StatusConflict, "snapshot %s is not processing", record.snapshot.ID)
+		return
+	}
+	record.snapshot.State = "CANCELED"
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDeleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("snapshot")
+	record, ok := s.snapshots[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", id)
+		return
+	}
+
+	delete(s.snapshots, id)
+	delete(s.checks, id)
+	delete(s.devices, id)
+	ids := s.networks[record.networkID]
+	for i, existing := range ids {
+		if existing == id {
+			s.networks[record.networkID] = append(ids[:i:i], ids[i+1:]...)
+			break
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListChecks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotID := r.PathValue("snapshot")
+	if _, ok := s.snapshots[snapshotID]; !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", snapshotID)
+		return
+	}
+
+	query := r.URL.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	checks := make([]fwdclient.CheckResult, 0, len(s.checks[snapshotID]))
+	matched := 0
+	for _, check := range s.checks[snapshotID] {
+		if !matchesAny(query["status"], check.Status) || !matchesAny(query["priority"], check.Priority) {
+			continue
+		}
+		matched++
+		if matched <= offset || (limit > 0 && len(checks) >= limit) {
+			continue
+		}
+		checks = append(checks, *check)
+		// Executions start after being listed once with the previous result, are
+		// reported as processing once, then finish.
+		switch {
+		case s.queued[check]:
+			delete(s.queued, check)
+			check.Status = checkProcessing
+		case check.Status == checkProcessing:
+			finishCheckLocked(check)
+		}
+	}
+	writeJSON(w, http.StatusOK, checks)
+}
+
+// checkProcessing is the status of a check that is being re-executed.
+const checkProcessing = "PROCESSING"
+
+func (s *Server) handleExecuteChecks(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.CheckExecutionRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotID := r.PathValue("snapshot")
+	if _, ok := s.snapshots[snapshotID]; !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", snapshotID)
+		return
+	}
+
+	targets := s.checks[snapshotID]
+	if len(body.CheckIDs) > 0 {
+		targets = make([]*fwdclient.CheckResult, 0, len(body.CheckIDs))
+		for _, id := range body.CheckIDs {
+			check := s.findCheckLocked(snapshotID, id)
+			if check == nil {
+				writeError(w, http.StatusNotFound, "check %s not found", id)
+				return
+			}
+			targets = append(targets, check)
+		}
+	}
+	// Like Forward, execution is asynchronous: the checks are only queued here.
+	for _, check := range targets {
+		s.queued[check] = true
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// finishCheckLocked completes an execution, failing checks that have violations.
+func finishCheckLocked(check *fwdclient.CheckResult) {
+	check.Status = "PASS"
+	if check.NumViolations != nil && *check.NumViolations > 0 {
+		check.Status = "FAIL"
+	}
+	executed := time.Now().UnixMilli()
+	if check.ExecutionDateMillis != nil && executed <= *check.ExecutionDateMillis {
+		executed = *check.ExecutionDateMillis + 1
+	}
+	check.ExecutionDateMillis = &executed
+}
+
+func (s *Server) handleCreateCheck(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.NewCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+	if body.Definition == nil {
+		writeJSON(w, http.StatusBadRequest, map[string]any{
+			"message": "definition is required",
+			"details": []map[string]string{{"field": "definition", "message": "must be provided"}},
+		})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, value := range s.definitionDefaults {
+		if _, ok := body.Definition[key]; !ok {
+			body.Definition[key] = value
+		}
+	}
+	definition, err := json.Marshal(body.Definition)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid definition: %s", err)
+		return
+	}
+
+	snapshotID := r.PathValue("snapshot")
+	if _, ok := s.snapshots[snapshotID]; !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", snapshotID)
+		return
+	}
+
+	enabled := true
+	if body.Enabled != nil {
+		enabled = *body.Enabled
+	}
+	violations := int64(0)
+	created := time.Now().UnixMilli()
+	id := s.addCheckLocked(snapshotID, fwdclient.CheckResult{
+		Name:                  body.Name,
+		Description:           body.Description,
+		Note:                  body.Note,
+		Priority:              body.Priority,
+		Tags:                  body.Tags,
+		Enabled:               &enabled,
+		PerfMonitoringEnabled: body.PerfMonitoringEnabled,
+		NumViolations:         &violations,
+		CreationDateMillis:    &created,
+		Definition:            definition,
+	})
+	if persistent, err := strconv.ParseBool(r.URL.Query().Get("persistent")); err == nil {
+		s.persistent[id] = persistent
+	}
+	writeJSON(w, http.StatusOK, s.findCheckLocked(snapshotID, id))
+}
+
+func (s *Server) handleGetCheck(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	check := s.findCheckLocked(r.PathValue("snapshot"), r.PathValue("check"))
+	if check == nil {
+		writeError(w, http.StatusNotFound, "check %s not found", r.PathValue("check"))
+		return
+	}
+	result := fwdclient.CheckResultWithDiagnosis{CheckResult: *check}
+	if diagnosis, ok := s.diagnoses[check.ID]; ok {
+		result.Diagnosis = &diagnosis
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleUpdateCheck(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.CheckUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	check := s.findCheckLocked(r.PathValue("snapshot"), r.PathValue("check"))
+	if check == nil {
+		writeError(w, http.StatusNotFound, "check %s not found", r.PathValue("check"))
+		return
+	}
+	if body.Name != nil {
+		check.Name = *body.Name
+	}
+	if body.Description != nil {
+		check.Description = *body.Description
+	}
+	if body.Note != nil {
+		check.Note = *body.Note
+	}
+	if body.Priority != nil {
+		check.Priority = *body.Priority
+	}
+	if body.Tags != nil {
+		check.Tags = body.Tags
+	}
+	if body.Enabled != nil {
+		check.Enabled = body.Enabled
+	}
+	if body.PerfMonitoringEnabled != nil {
+		check.PerfMonitoringEnabled = body.PerfMonitoringEnabled
+	}
+	if body.Persistent != nil {
+		s.persistent[check.ID] = *body.Persistent
+	}
+	writeJSON(w, http.StatusOK, check)
+}
+
+func (s *Server) handleGetCheckWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook, ok := s.webhooks[r.PathValue("check")]
+	if !ok || s.findCheckLocked(r.PathValue("snapshot"), r.PathValue("check")) == nil {
+		writeError(w, http.StatusNotFound, "check %s has no webhook", r.PathValue("check"))
+		return
+	}
+	writeJSON(w, http.StatusOK, webhook)
+}
+
+func (s *Server) handleSetCheckWebhook(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.CheckWebhook
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+	if body.URL == "" {
+		writeError(w, http.StatusBadRequest, "url must be provided")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findCheckLocked(r.PathValue("snapshot"), r.PathValue("check")) == nil {
+		writeError(w, http.StatusNotFound, "check %s not found", r.PathValue("check"))
+		return
+	}
+	s.webhooks[r.PathValue("check")] = body
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDeleteCheckWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[r.PathValue("check")]; !ok {
+		writeError(w, http.StatusNotFound, "check %s has no webhook", r.PathValue("check"))
+		return
+	}
+	delete(s.webhooks, r.PathValue("check"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleTransferCheckOwner records the new owner as both creator name and ID, since the
+// fake server has no user directory.
+func (s *Server) handleTransferCheckOwner(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.CheckOwnerRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	check := s.findCheckLocked(r.PathValue("snapshot"), r.PathValue("check"))
+	if check == nil {
+		writeError(w, http.StatusNotFound, "check %s not found", r.PathValue("check"))
+		return
+	}
+	check.Creator = body.Owner
+	check.CreatorID = body.Owner
+	writeJSON(w, http.StatusOK, check)
+}
+
+func (s *Server) handleDeactivateCheck(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotID := r.PathValue("snapshot")
+	checks := s.checks[snapshotID]
+	for i, check := range checks {
+		if check.ID == r.PathValue("check") {
+			s.checks[snapshotID] = append(checks[:i:i], checks[i+1:]...)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "check %s not found", r.PathValue("check"))
+}
+
+func (s *Server) handleDeactivateChecks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.checks, r.PathValue("snapshot"))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleListDevices(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotID := r.PathValue("snapshot")
+	if _, ok := s.snapshots[snapshotID]; !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", snapshotID)
+		return
+	}
+
+	devices := append([]fwdclient.Device{}, s.devices[snapshotID]...)
+	writeJSON(w, http.StatusOK, devices)
+}
+
+func (s *Server) handleDeviceState(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.PathValue("snapshot") + "/" + r.PathValue("device") + "/" + r.PathValue("table")
+	rows, ok := s.state[key]
+	if !ok {
+		writeError(w, http.StatusNotFound, "no %s state for device %s", r.PathValue("table"), r.PathValue("device"))
+		return
+	}
+	writeJSON(w, http.StatusOK, rows)
+}
+
+func (s *Server) handleListVirtualServers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotID := r.PathValue("snapshot")
+	if _, ok := s.snapshots[snapshotID]; !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", snapshotID)
+		return
+	}
+
+	servers := append([]fwdclient.VirtualServer{}, s.vips[snapshotID]...)
+	writeJSON(w, http.StatusOK, servers)
+}
+
+func (s *Server) handleListTunnels(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotID := r.PathValue("snapshot")
+	if _, ok := s.snapshots[snapshotID]; !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", snapshotID)
+		return
+	}
+
+	tunnels := append([]fwdclient.Tunnel{}, s.tunnels[snapshotID]...)
+	writeJSON(w, http.StatusOK, tunnels)
+}
+
+func (s *Server) handleGetOverlay(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotID := r.PathValue("snapshot")
+	if _, ok := s.snapshots[snapshotID]; !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", snapshotID)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.overlays[snapshotID])
+}
+
+func (s *Server) handleListCloudSecurityGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotID := r.PathValue("snapshot")
+	if _, ok := s.snapshots[snapshotID]; !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", snapshotID)
+		return
+	}
+
+	groups := append([]fwdclient.CloudSecurityGroup{}, s.cloudSGs[snapshotID]...)
+	if r.URL.Query().Get("effective") == "true" {
+		for i := range groups {
+			if rules, ok := s.effectiveRules[snapshotID+"/"+groups[i].ID]; ok {
+				groups[i].Rules = rules
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, groups)
+}
+
+func (s *Server) handleGetCloudRouting(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotID := r.PathValue("snapshot")
+	if _, ok := s.snapshots[snapshotID]; !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", snapshotID)
+		return
+	}
+
+	routing := s.cloudRouting[snapshotID]
+	routing.RouteTables = append([]fwdclient.CloudRouteTable{}, routing.RouteTables...)
+	if r.URL.Query().Get("effective") == "true" {
+		for i := range routing.RouteTables {
+			if routes, ok := s.effectiveRoutes[snapshotID+"/"+routing.RouteTables[i].ID]; ok {
+				routing.RouteTables[i].Routes = routes
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, routing)
+}
+
+func (s *Server) handleGetKubernetesInventory(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotID := r.PathValue("snapshot")
+	if _, ok := s.snapshots[snapshotID]; !ok {
+		writeError(w, http.StatusNotFound, "snapshot %s not found", snapshotID)
+		return
+	}
+
+	inventory := s.kubernetes[snapshotID]
+	inventory.Clusters = append([]fwdclient.KubernetesCluster{}, inventory.Clusters...)
+	writeJSON(w, http.StatusOK, inventory)
+}
+
+func (s *Server) handleListApplications(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	applications := append([]fwdclient.Application{}, s.applications[r.PathValue("network")]...)
+	writeJSON(w, http.StatusOK, applications)
+}
+
+func (s *Server) handleListUserGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := append([]fwdclient.UserGroup{}, s.userGroups[r.PathValue("network")]...)
+	writeJSON(w, http.StatusOK, groups)
+}
+
+func (s *Server) handleRunNQE(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.NqeQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, key, ok := s.nqeResultLocked(body, r)
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]any{
+			"message": "no result registered for query",
+			"details": []map[string]string{{"field": "queryId", "message": fmt.Sprintf("unknown query %q", key)}},
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// nqeResultLocked returns the result registered for the query in body, with the
+// snapshot the request targets.
+func (s *Server) nqeResultLocked(body fwdclient.NqeQueryRequest, r *http.Request) (fwdclient.NqeRunResult, string, bool) {
+	key := ""
+	switch {
+	case body.QueryID != nil:
+		key = *body.QueryID
+	case body.Query != nil:
+		key = *body.Query
+	}
+
+	result, ok := s.nqe[key]
+	if !ok {
+		return fwdclient.NqeRunResult{}, key, false
+	}
+	if result.SnapshotID == "" {
+		result.SnapshotID = r.URL.Query().Get("snapshotId")
+	}
+	if result.SnapshotID == "" {
+		if snapshot, ok := s.latestProcessedLocked(r.URL.Query().Get("networkId")); ok {
+			result.SnapshotID = snapshot.ID
+		}
+	}
+	return result, key, true
+}
+
+func (s *Server) handleSubmitNQEJob(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.NqeQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := &nqeJob{job: fwdclient.NqeJob{ID: fmt.Sprintf("job-%d", len(s.nqeJobs)+1), State: fwdclient.NqeJobRunning}}
+	result, key, ok := s.nqeResultLocked(body, r)
+	if ok {
+		job.result = result
+	} else {
+		job.job.ErrorMessage = fmt.Sprintf("unknown query %q", key)
+	}
+	s.nqeJobs[job.job.ID] = job
+	writeJSON(w, http.StatusAccepted, job.job)
+}
+
+func (s *Server) handleGetNQEJob(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.nqeJobs[r.PathValue("job")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "job %s not found", r.PathValue("job"))
+		return
+	}
+	if job.job.State == fwdclient.NqeJobRunning {
+		job.job.State = fwdclient.NqeJobCompleted
+		if job.job.ErrorMessage != "" {
+			job.job.State = fwdclient.NqeJobFailed
+		}
+	}
+	writeJSON(w, http.StatusOK, job.job)
+}
+
+func (s *Server) handleGetNQEJobResult(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.nqeJobs[r.PathValue("job")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "job %s not found", r.PathValue("job"))
+		return
+	}
+	if job.job.State != fwdclient.NqeJobCompleted {
+		writeError(w, http.StatusConflict, "job %s is %s", job.job.ID, job.job.State)
+		return
+	}
+	writeJSON(w, http.StatusOK, job.result)
+}
+
+func (s *Server) handleListQueries(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := r.URL.Query().Get("dir")
+	queries := make([]fwdclient.NqeQuery, 0, len(s.queries))
+	for _, q := range s.queries {
+		if dir == "" || strings.HasPrefix(q.Path, dir) {
+			queries = append(queries, q)
+		}
+	}
+	sort.Slice(queries, func(i, j int) bool { return queries[i].Path < queries[j].Path })
+	writeJSON(w, http.StatusOK, queries)
+}
+
+func (s *Server) handleCommitQueries(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.NqeCommitRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+	if len(body.Changes) == 0 {
+		writeError(w, http.StatusBadRequest, "commit has no changes")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repository := strings.ToUpper(r.PathValue("repo"))
+	commitID := s.newIDLocked("commit")
+	for _, change := range body.Changes {
+		index := -1
+		for i, q := range s.queries {
+			if q.Path == change.Path && q.Repository == repository {
+				index = i
+				break
+			}
+		}
+
+		switch {
+		case change.Delete && index >= 0:
+			s.queries = append(s.queries[:index], s.queries[index+1:]...)
+			delete(s.sources, change.Path)
+		case change.Delete:
+		case index >= 0:
+			s.queries[index].LastCommitID = commitID
+			s.sources[change.Path] = change.Source
+		default:
+			s.queries = append(s.queries, fwdclient.NqeQuery{QueryID: s.newIDLocked("FQ"), Repository: repository, Path: change.Path, LastCommitID: commitID})
+			s.sources[change.Path] = change.Source
+		}
+	}
+
+	writeJSON(w, http.StatusOK, fwdclient.NqeCommit{ID: commitID, Message: body.Message})
+}
+
+func (s *Server) handleNQEDiff(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.NqeDiffRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, ok := s.nqeDiffs[body.QueryID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "query %s not found", body.QueryID)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handlePaths(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networkID := r.PathValue("network")
+	result, ok := s.paths[networkID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "network %s not found", networkID)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleL2Paths(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networkID := r.PathValue("network")
+	result, ok := s.l2paths[networkID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "network %s not found", networkID)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleConnectivityTest(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.ConnectivityTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networkID := r.PathValue("network")
+	if _, ok := s.networks[networkID]; !ok {
+		writeError(w, http.StatusNotFound, "network %s not found", networkID)
+		return
+	}
+
+	result, ok := s.reach[body.Host]
+	if !ok {
+		unreachable := false
+		result = fwdclient.ConnectivityTestResult{Status: "UNREACHABLE", PingSucceeded: &unreachable, Message: "no response from " + body.Host}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleCreateCustomCommands(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.CustomCommandSet
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+	if len(body.Commands) == 0 {
+		writeError(w, http.StatusBadRequest, "commands must not be empty")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networkID := r.PathValue("network")
+	if _, ok := s.networks[networkID]; !ok {
+		writeError(w, http.StatusNotFound, "network %s not found", networkID)
+		return
+	}
+
+	body.ID = s.newIDLocked("commands")
+	if s.commands[networkID] == nil {
+		s.commands[networkID] = map[string]fwdclient.CustomCommandSet{}
+	}
+	s.commands[networkID][body.ID] = body
+	writeJSON(w, http.StatusCreated, body)
+}
+
+func (s *Server) handleGetCustomCommands(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set, ok := s.commands[r.PathValue("network")][r.PathValue("set")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "custom commands %s not found", r.PathValue("set"))
+		return
+	}
+	writeJSON(w, http.StatusOK, set)
+}
+
+func (s *Server) handleUpdateCustomCommands(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.CustomCommandSet
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networkID, setID := r.PathValue("network"), r.PathValue("set")
+	if _, ok := s.commands[networkID][setID]; !ok {
+		writeError(w, http.StatusNotFound, "custom commands %s not found", setID)
+		return
+	}
+	body.ID = setID
+	s.commands[networkID][setID] = body
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *Server) handleDeleteCustomCommands(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networkID, setID := r.PathValue("network"), r.PathValue("set")
+	if _, ok := s.commands[networkID][setID]; !ok {
+		writeError(w, http.StatusNotFound, "custom commands %s not found", setID)
+		return
+	}
+	delete(s.commands[networkID], setID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleGetSnapshotRetention(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networkID := r.PathValue("network")
+	if _, ok := s.networks[networkID]; !ok {
+		writeError(w, http.StatusNotFound, "network %s not found", networkID)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.retention[networkID])
+}
+
+func (s *Server) handleSetSnapshotRetention(w http.ResponseWriter, r *http.Request) {
+	var body fwdclient.SnapshotRetentionPolicy
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+	if body.Enabled {
+		if body.MaxAgeDays == nil && body.MaxCount == nil {
+			writeError(w, http.StatusBadRequest, "maxAgeDays or maxCount must be set")
+			return
+		}
+		if body.Action != fwdclient.SnapshotRetentionArchive && body.Action != fwdclient.SnapshotRetentionDelete {
+			writeError(w, http.StatusBadRequest, "unsupported action %q", body.Action)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	networkID := r.PathValue("network")
+	if _, ok := s.networks[networkID]; !ok {
+		writeError(w, http.StatusNotFound, "network %s not found", networkID)
+		return
+	}
+	s.retention[networkID] = body
+	writeJSON(w, http.StatusOK, body)
+}
+
+func matchesAny(filters []string, value string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, f := range filters {
+		if strings.EqualFold(f, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeforward
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func newClient(t *testing.T, s *Server) *fwdclient.Client {
+	t.Helper()
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{
+		BaseURL:    s.URL,
+		APIKey:     "token",
+		MaxRetries: 1,
+		RetryDelay: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return client
+}
+
+func TestServer_CheckLifecycle(t *testing.T) {
+	t.Parallel()
+
+	s := New(t)
+	snapshotID := s.AddSnapshot("net-1", fwdclient.Snapshot{})
+	client := newClient(t, s)
+
+	created, err := client.AddSnapshotCheck(context.Background(), snapshotID, fwdclient.NewCheckRequest{
+		Definition: fwdclient.CheckDefinition{"checkType": "NQE", "queryId": "FQ_test"},
+		Name:       "Reachability",
+	}, nil)
+	if err != nil {
+		t.Fatalf("AddSnapshotCheck: %v", err)
+	}
+
+	checks, err := client.ListSnapshotChecks(context.Background(), snapshotID, fwdclient.CheckListOptions{})
+	if err != nil {
+		t.Fatalf("ListSnapshotChecks: %v", err)
+	}
+	if len(checks) != 1 || checks[0].ID != created.ID {
+		t.Fatalf("unexpected checks: %#v", checks)
+	}
+
+	if err := client.DeactivateSnapshotCheck(context.Background(), snapshotID, created.ID); err != nil {
+		t.Fatalf("DeactivateSnapshotCheck: %v", err)
+	}
+	if _, err := client.GetSnapshotCheck(context.Background(), snapshotID, created.ID); !fwdclient.IsNotFound(err) {
+		t.Fatalf("expected not found after deactivation, got %v", err)
+	}
+}
+
+func TestServer_FaultInjection(t *testing.T) {
+	t.Parallel()
+
+	s := New(t)
+	s.InjectFault(Fault{Method: http.MethodGet, PathPrefix: "/api/version", Status: http.StatusForbidden, Times: 1})
+	client := newClient(t, s)
+
+	if _, err := client.GetVersion(context.Background()); !fwdclient.IsForbidden(err) {
+		t.Fatalf("expected injected 403, got %v", err)
+	}
+	if _, err := client.GetVersion(context.Background()); err != nil {
+		t.Fatalf("expected fault to expire, got %v", err)
+	}
+	if got := s.RequestCount(http.MethodGet, "/api/version"); got != 2 {
+		t.Fatalf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestServer_IdempotentRetryAfterLostResponse(t *testing.T) {
+	t.Parallel()
+
+	s := New(t)
+	snapshotID := s.AddSnapshot("net-1", fwdclient.Snapshot{})
+	s.InjectFault(Fault{Method: http.MethodPost, PathPrefix: "/api/snapshots/", Status: http.StatusBadGateway, Times: 1, AfterHandling: true})
+	client := newClient(t, s)
+
+	created, err := client.AddSnapshotCheck(context.Background(), snapshotID, fwdclient.NewCheckRequest{
+		Definition: fwdclient.CheckDefinition{"checkType": "NQE", "queryId": "FQ_test"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("AddSnapshotCheck: %v", err)
+	}
+
+	checks, err := client.ListSnapshotChecks(context.Background(), snapshotID, fwdclient.CheckListOptions{})
+	if err != nil {
+		t.Fatalf("ListSnapshotChecks: %v", err)
+	}
+	if len(checks) != 1 || checks[0].ID != created.ID {
+		t.Fatalf("expected the retried create to apply once, got %#v", checks)
+	}
+	if got := s.RequestCount(http.MethodPost, "/api/snapshots/"+snapshotID+"/checks"); got != 2 {
+		t.Fatalf("expected 2 create attempts, got %d", got)
+	}
+}
+
+func TestServer_ListChecksPaging(t *testing.T) {
+	t.Parallel()
+
+	s := New(t)
+	snapshotID := s.AddSnapshot("net-1", fwdclient.Snapshot{})
+	client := newClient(t, s)
+
+	var ids []string
+	for _, name := range []string{"a", "b", "c"} {
+		created, err := client.AddSnapshotCheck(context.Background(), snapshotID, fwdclient.NewCheckRequest{
+			Definition: fwdclient.CheckDefinition{"checkType": "NQE", "queryId": "FQ_test"},
+			Name:       name,
+		}, nil)
+		if err != nil {
+			t.Fatalf("AddSnapshotCheck: %v", err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	checks, err := client.ListSnapshotChecks(context.Background(), snapshotID, fwdclient.CheckListOptions{Offset: 1, Limit: 1})
+	if err != nil {
+		t.Fatalf("ListSnapshotChecks: %v", err)
+	}
+	if len(checks) != 1 || checks[0].ID != ids[1] {
+		t.Fatalf("expected only the second check, got %#v", checks)
+	}
+}
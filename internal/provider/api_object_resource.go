@@ -0,0 +1,442 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &APIObjectResource{}
+
+// APIObjectResource manages an object through API endpoints the provider does not
+// model yet.
+type APIObjectResource struct {
+	providerData *ForwardProviderData
+}
+
+// APIObjectResourceModel maps Terraform schema data.
+type APIObjectResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Profile      types.String `tfsdk:"profile"`
+	Path         types.String `tfsdk:"path"`
+	CreateMethod types.String `tfsdk:"create_method"`
+	ReadPath     types.String `tfsdk:"read_path"`
+	UpdateMethod types.String `tfsdk:"update_method"`
+	UpdatePath   types.String `tfsdk:"update_path"`
+	DeleteMethod types.String `tfsdk:"delete_method"`
+	DeletePath   types.String `tfsdk:"delete_path"`
+	Body         types.String `tfsdk:"body"`
+	IDJSONPath   types.String `tfsdk:"id_jsonpath"`
+	Response     types.String `tfsdk:"response"`
+}
+
+func NewAPIObjectResource() resource.Resource {
+	return &APIObjectResource{}
+}
+
+func (r *APIObjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_object"
+}
+
+func (r *APIObjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	pathTemplate := "Paths are relative to the base URL and may reference `{id}`, the object identifier, and " +
+		"`{network_id}`, the provider's `network_id`."
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage an object through Forward Enterprise API endpoints the provider does not model yet. The " +
+			"object is created by sending `body` to `path`, read back, updated, and deleted through the templated paths, " +
+			"with the provider handling authentication, retries, and the base URL. Prefer a dedicated resource when one exists.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Object identifier, read from the create response at `id_jsonpath`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path the object is created at, such as `/api/networks/{network_id}/aliases`. " + pathTemplate,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"create_method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "HTTP method that creates the object. Defaults to `POST`.",
+				Default:             stringdefault.StaticString("POST"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("POST", "PUT", "PATCH"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"read_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path the object is read from. Defaults to `path` followed by `/{id}`.",
+			},
+			"update_method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "HTTP method that sends a changed `body`. Defaults to `PUT`.",
+				Default:             stringdefault.StaticString("PUT"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("POST", "PUT", "PATCH"),
+				},
+			},
+			"update_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path a changed `body` is sent to. Defaults to the read path.",
+			},
+			"delete_method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "HTTP method that deletes the object. Defaults to `DELETE`.",
+				Default:             stringdefault.StaticString("DELETE"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("DELETE", "POST", "PUT", "PATCH"),
+				},
+			},
+			"delete_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path the object is deleted at. Defaults to the read path.",
+			},
+			"body": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "JSON request body sent on create and update, typically built with `jsonencode`.",
+			},
+			"id_jsonpath": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Location of the identifier in the create response, such as `id`, `$.data.id`, or `items[0].id`. Defaults to `id`.",
+				Default:             stringdefault.StaticString("id"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"response": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Compacted JSON body of the last create, update, or read response.",
+			},
+		},
+	}
+}
+
+func (r *APIObjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *APIObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan APIObjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := apiObjectBody(plan.Body)
+	resp.Diagnostics.Append(diags...)
+	createPath, diags := expandAPIPath(path.Root("path"), plan.Path.ValueString(), "", providerData.NetworkID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	raw, err := providerData.Client.RawRequest(ctx, plan.CreateMethod.ValueString(), createPath, body)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error creating API object", err)
+		return
+	}
+
+	id, err := jsonPathString(raw, plan.IDJSONPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("id_jsonpath"), "Object Identifier Not Found",
+			fmt.Sprintf("The object was created, but its identifier could not be read from the response: %s. "+
+				"The object must be deleted manually. Response: %s", err, truncateForDiagnostic(raw)))
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	plan.Response = compactJSONString(raw)
+	tflog.Debug(ctx, "created forward API object", map[string]any{"path": createPath, "id": id})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *APIObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state APIObjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readPath, diags := state.objectPath(state.ReadPath, path.Root("read_path"), providerData.NetworkID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	raw, err := providerData.Client.RawRequest(ctx, "GET", readPath, nil)
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error reading API object", err)
+		return
+	}
+
+	state.Response = compactJSONString(raw)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *APIObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan, state APIObjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Response = state.Response
+	if !plan.Body.Equal(state.Body) {
+		body, diags := apiObjectBody(plan.Body)
+		resp.Diagnostics.Append(diags...)
+		updatePath, diags := plan.objectPath(plan.UpdatePath, path.Root("update_path"), providerData.NetworkID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		raw, err := providerData.Client.RawRequest(ctx, plan.UpdateMethod.ValueString(), updatePath, body)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error updating API object", err)
+			return
+		}
+		if len(bytes.TrimSpace(raw)) > 0 {
+			plan.Response = compactJSONString(raw)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *APIObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state APIObjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deletePath, diags := state.objectPath(state.DeletePath, path.Root("delete_path"), providerData.NetworkID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := providerData.Client.RawRequest(ctx, state.DeleteMethod.ValueString(), deletePath, nil)
+	if err != nil && !isNotFoundError(err) {
+		addAPIError(&resp.Diagnostics, "Error deleting API object", err)
+	}
+}
+
+// objectPath expands template, or the read path when template is null, for the object.
+func (m APIObjectResourceModel) objectPath(template types.String, attribute path.Path, networkID string) (string, diag.Diagnostics) {
+	if template.IsNull() || template.ValueString() == "" {
+		template, attribute = m.ReadPath, path.Root("read_path")
+	}
+	if template.IsNull() || template.ValueString() == "" {
+		template, attribute = types.StringValue(strings.TrimSuffix(m.Path.ValueString(), "/")+"/{id}"), path.Root("path")
+	}
+	return expandAPIPath(attribute, template.ValueString(), m.ID.ValueString(), networkID)
+}
+
+// expandAPIPath substitutes the `{id}` and `{network_id}` placeholders of a path
+// template with path-escaped values.
+func expandAPIPath(attribute path.Path, template, id, networkID string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if strings.Contains(template, "{network_id}") && networkID == "" {
+		diags.AddAttributeError(attribute, "Missing Network ID",
+			"The path references {network_id}, but the provider has no network_id configured.")
+		return "", diags
+	}
+	if strings.Contains(template, "{id}") && id == "" {
+		diags.AddAttributeError(attribute, "Unknown Object Identifier", "The path references {id} before the object exists.")
+		return "", diags
+	}
+
+	expanded := strings.NewReplacer("{id}", url.PathEscape(id), "{network_id}", url.PathEscape(networkID)).Replace(template)
+	if !strings.HasPrefix(expanded, "/") {
+		diags.AddAttributeError(attribute, "Invalid API Path", fmt.Sprintf("Paths must start with /, got %q.", expanded))
+	}
+	return expanded, diags
+}
+
+func apiObjectBody(body types.String) ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	raw := []byte(body.ValueString())
+	if !json.Valid(raw) {
+		diags.AddAttributeError(path.Root("body"), "Invalid Body JSON", "body must be a JSON document; build it with jsonencode.")
+		return nil, diags
+	}
+	return raw, diags
+}
+
+// jsonPathString returns the string or number at expr in a JSON document. expr is a
+// dotted path with optional array indexes, such as `$.data.items[0].id`.
+func jsonPathString(document []byte, expr string) (string, error) {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("response is not JSON: %w", err)
+	}
+
+	expr = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(expr), "$"), ".")
+	if expr != "" {
+		for _, segment := range strings.Split(expr, ".") {
+			key, indexes, _ := strings.Cut(segment, "[")
+			if key != "" {
+				object, ok := value.(map[string]any)
+				if !ok {
+					return "", fmt.Errorf("%q is not an object", key)
+				}
+				if value, ok = object[key]; !ok {
+					return "", fmt.Errorf("no %q field", key)
+				}
+			}
+			for indexes != "" {
+				var index string
+				index, indexes, _ = strings.Cut(indexes, "]")
+				indexes = strings.TrimPrefix(indexes, "[")
+				i, err := strconv.Atoi(index)
+				if err != nil {
+					return "", fmt.Errorf("invalid index %q", index)
+				}
+				list, ok := value.([]any)
+				if !ok || i < 0 || i >= len(list) {
+					return "", fmt.Errorf("index %d of %q is out of range", i, segment)
+				}
+				value = list[i]
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return v, nil
+		}
+	case json.Number:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("%q is not a non-empty string or number", expr)
+}
+
+func compactJSONString(raw []byte) types.String {
+	var buf bytes.Buffer
+	if len(bytes.TrimSpace(raw)) == 0 || json.Compact(&buf, raw) != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(buf.String())
+}
+
+// truncateForDiagnostic shortens a response body for inclusion in a diagnostic.
+func truncateForDiagnostic(raw []byte) string {
+	const limit = 512
+	if len(raw) > limit {
+		return string(raw[:limit]) + "..."
+	}
+	return string(raw)
+}
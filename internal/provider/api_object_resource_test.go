@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestJSONPathString(t *testing.T) {
+	t.Parallel()
+
+	document := []byte(`{"id": "a-1", "data": {"items": [{"id": 7}, {"id": "x"}]}, "empty": ""}`)
+	for expr, want := range map[string]string{
+		"id":                 "a-1",
+		"$.id":               "a-1",
+		"data.items[0].id":   "7",
+		"$.data.items[1].id": "x",
+	} {
+		if got, err := jsonPathString(document, expr); err != nil || got != want {
+			t.Errorf("%s: got %q, %v; want %q", expr, got, err, want)
+		}
+	}
+	for _, expr := range []string{"missing", "data.items[2].id", "data.items", "empty", "id[0]"} {
+		if got, err := jsonPathString(document, expr); err == nil {
+			t.Errorf("%s: expected an error, got %q", expr, got)
+		}
+	}
+}
+
+func TestAPIObjectPaths(t *testing.T) {
+	t.Parallel()
+
+	model := APIObjectResourceModel{
+		ID:         types.StringValue("a 1"),
+		Path:       types.StringValue("/api/networks/{network_id}/aliases/"),
+		ReadPath:   types.StringNull(),
+		DeletePath: types.StringValue("/api/aliases/{id}"),
+	}
+	if got, diags := model.objectPath(model.UpdatePath, path.Root("update_path"), "net-1"); diags.HasError() || got != "/api/networks/net-1/aliases/a%201" {
+		t.Fatalf("unexpected update path %q: %v", got, diags)
+	}
+	if got, diags := model.objectPath(model.DeletePath, path.Root("delete_path"), "net-1"); diags.HasError() || got != "/api/aliases/a%201" {
+		t.Fatalf("unexpected delete path %q: %v", got, diags)
+	}
+	if _, diags := expandAPIPath(path.Root("path"), "/api/networks/{network_id}", "", ""); !diags.HasError() {
+		t.Fatal("expected an error without a network ID")
+	}
+	if _, diags := expandAPIPath(path.Root("path"), "api/aliases", "", ""); !diags.HasError() {
+		t.Fatal("expected an error for a relative path")
+	}
+}
+
+func TestAccAPIObjectResource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_api_object" "test" {
+  path          = "/api/snapshots/snap-1/checks"
+  update_method = "PATCH"
+  body = jsonencode({
+    name       = %q
+    definition = { checkType = "NQE", queryId = "FQ_1" }
+  })
+}
+`, server.URL, name)
+	}
+
+	var checkID string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Raw check"),
+				Check: resource.TestCheckResourceAttrWith("forward_api_object.test", "id", func(value string) error {
+					checkID = value
+					if check, ok := server.Check("snap-1", value); !ok || check.Name != "Raw check" {
+						return fmt.Errorf("expected created check, got %+v", check)
+					}
+					return nil
+				}),
+			},
+			{
+				Config: config("Renamed check"),
+				Check: func(*terraform.State) error {
+					if check, ok := server.Check("snap-1", checkID); !ok || check.Name != "Renamed check" {
+						return fmt.Errorf("expected updated check, got %+v", check)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
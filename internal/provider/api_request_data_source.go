@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &APIRequestDataSource{}
+
+// NewAPIRequestDataSource instantiates the raw API request data source.
+func NewAPIRequestDataSource() datasource.DataSource {
+	return &APIRequestDataSource{}
+}
+
+// APIRequestDataSource calls an arbitrary API path and exposes the response.
+type APIRequestDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type apiRequestDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Profile  types.String `tfsdk:"profile"`
+	Path     types.String `tfsdk:"path"`
+	Method   types.String `tfsdk:"method"`
+	Query    types.Map    `tfsdk:"query"`
+	Body     types.String `tfsdk:"body"`
+	Response types.String `tfsdk:"response"`
+}
+
+func (d *APIRequestDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_request"
+}
+
+func (d *APIRequestDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Call a Forward Enterprise API path the provider does not model yet and expose the response, " +
+			"with the provider handling authentication, retries, and the base URL. Decode `response` with `jsondecode`. " +
+			"Only use `POST` for endpoints that do not change anything, such as searches, as the request is sent on every read.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Expanded request path.",
+				Computed:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "API path to call, such as `/api/networks/{network_id}/snapshots`. `{network_id}` is " +
+					"replaced with the provider's `network_id`.",
+				Required: true,
+			},
+			"method": schema.StringAttribute{
+				MarkdownDescription: "HTTP method: `GET` (the default) or `POST`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("GET", "POST"),
+				},
+			},
+			"query": schema.MapAttribute{
+				MarkdownDescription: "Query parameters added to the path.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "JSON request body for `POST`, typically built with `jsonencode`.",
+				Optional:            true,
+			},
+			"response": schema.StringAttribute{
+				MarkdownDescription: "Response body, compacted when it is JSON.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *APIRequestDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *APIRequestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data apiRequestDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestPath, diags := expandAPIPath(path.Root("path"), data.Path.ValueString(), "", providerData.NetworkID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.Query.IsNull() {
+		var query map[string]string
+		resp.Diagnostics.Append(data.Query.ElementsAs(ctx, &query, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		requestPath = withQuery(requestPath, query)
+	}
+
+	method := "GET"
+	if !data.Method.IsNull() {
+		method = data.Method.ValueString()
+	}
+	var body []byte
+	if !data.Body.IsNull() {
+		if method != "POST" {
+			resp.Diagnostics.AddAttributeError(path.Root("body"), "Unexpected Request Body", "body is only sent with method POST.")
+			return
+		}
+		body, diags = apiObjectBody(data.Body)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	raw, err := providerData.Client.RawRequest(ctx, method, requestPath, body)
+	if err != nil {
+		if !providerData.tolerateMissing(err, "API Path Not Found", &resp.Diagnostics) {
+			addAPIError(&resp.Diagnostics, "Unable to Call API", err)
+			return
+		}
+		raw = nil
+	}
+
+	data.ID = types.StringValue(requestPath)
+	data.Response = compactJSONString(raw)
+	if data.Response.IsNull() && len(raw) > 0 {
+		data.Response = types.StringValue(string(raw))
+	}
+
+	tflog.Trace(ctx, "called forward api", map[string]any{"method": method, "path": requestPath, "bytes": len(raw)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// withQuery appends query parameters to a path that may already carry some.
+func withQuery(requestPath string, query map[string]string) string {
+	if len(query) == 0 {
+		return requestPath
+	}
+
+	values := url.Values{}
+	for key, value := range query {
+		values.Set(key, value)
+	}
+	separator := "?"
+	if strings.Contains(requestPath, "?") {
+		separator = "&"
+	}
+	return requestPath + separator + values.Encode()
+}
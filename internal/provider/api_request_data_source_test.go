@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestWithQuery(t *testing.T) {
+	t.Parallel()
+
+	if got := withQuery("/api/checks", nil); got != "/api/checks" {
+		t.Fatalf("unexpected path %q", got)
+	}
+	if got := withQuery("/api/checks", map[string]string{"type": "NQE", "status": "FAIL"}); got != "/api/checks?status=FAIL&type=NQE" {
+		t.Fatalf("unexpected path %q", got)
+	}
+	if got := withQuery("/api/checks?limit=5", map[string]string{"q": "a b"}); got != "/api/checks?limit=5&q=a+b" {
+		t.Fatalf("unexpected path %q", got)
+	}
+}
+
+func TestAccAPIRequestDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddApplication("net-1", fwdclient.Application{ID: "app-1", Name: "Payroll"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_api_request" "test" {
+  path = "/api/networks/{network_id}/applications"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_api_request.test", "id", "/api/networks/net-1/applications"),
+					resource.TestCheckResourceAttr("data.forward_api_request.test", "response", `[{"id":"app-1","name":"Payroll"}]`),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &CapabilitiesDataSource{}
+
+// NewCapabilitiesDataSource instantiates the capabilities data source.
+func NewCapabilitiesDataSource() datasource.DataSource {
+	return &CapabilitiesDataSource{}
+}
+
+// CapabilitiesDataSource reports which optional API features the instance serves.
+type CapabilitiesDataSource struct {
+	providerData *ForwardProviderData
+}
+
+// capabilitiesDataSourceModel represents the Terraform state.
+type capabilitiesDataSourceModel struct {
+	Profile      types.String `tfsdk:"profile"`
+	NetworkID    types.String `tfsdk:"network_id"`
+	Capabilities types.Map    `tfsdk:"capabilities"`
+	Available    types.List   `tfsdk:"available"`
+}
+
+func (d *CapabilitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_capabilities"
+}
+
+func (d *CapabilitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Discover which optional API features the connected Forward Enterprise instance serves, so modules " +
+			"can enable functionality conditionally across instances running different versions. Each feature is probed " +
+			"with a read-only request; a feature the API key is not permitted to use is reported as unavailable.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "Network used to probe network-scoped features. Defaults to the provider `network_id` when omitted.",
+				Optional:            true,
+			},
+			"capabilities": schema.MapAttribute{
+				ElementType: types.BoolType,
+				MarkdownDescription: "Whether each feature is available, keyed by `nqe_diff` (NQE diffs between snapshots), " +
+					"`nqe_jobs` (asynchronous NQE queries), `bulk_path_search`, `l2_path_search`, `vulnerabilities`, and " +
+					"`check_execution` (on-demand intent check execution).",
+				Computed: true,
+			},
+			"available": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Sorted names of the available features, for use with `contains()`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CapabilitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *CapabilitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data capabilitiesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networkID := providerData.NetworkID
+	if !data.NetworkID.IsNull() && !data.NetworkID.IsUnknown() {
+		networkID = data.NetworkID.ValueString()
+	}
+
+	if networkID == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("network_id"),
+			"Missing Network ID",
+			"Network ID must be specified either on the provider or data source.",
+		)
+		return
+	}
+
+	capabilities, err := providerData.Client.ProbeCapabilities(ctx, networkID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Probe Capabilities", err)
+		return
+	}
+
+	available := make([]string, 0, len(capabilities))
+	for name, ok := range capabilities {
+		if ok {
+			available = append(available, name)
+		}
+	}
+	sort.Strings(available)
+
+	data.Capabilities, diags = types.MapValueFrom(ctx, types.BoolType, capabilities)
+	resp.Diagnostics.Append(diags...)
+	data.Available, diags = types.ListValueFrom(ctx, types.StringType, available)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "probed forward capabilities", map[string]any{"available": available})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+)
+
+func TestAccCapabilitiesDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+
+	config := fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "test-token"
+  network_id = %q
+}
+
+data "forward_capabilities" "current" {}
+`, server.URL, testNetworkID)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_capabilities.current", "capabilities.%", "6"),
+					resource.TestCheckResourceAttr("data.forward_capabilities.current", "capabilities.nqe_diff", "true"),
+					resource.TestCheckResourceAttr("data.forward_capabilities.current", "capabilities.vulnerabilities", "false"),
+					resource.TestCheckResourceAttr("data.forward_capabilities.current", "available.#", "3"),
+					resource.TestCheckResourceAttr("data.forward_capabilities.current", "available.0", "check_execution"),
+					resource.TestCheckResourceAttr("data.forward_capabilities.current", "available.1", "nqe_diff"),
+					resource.TestCheckResourceAttr("data.forward_capabilities.current", "available.2", "nqe_jobs"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &CheckBundleDataSource{}
+
+// NewCheckBundleDataSource instantiates the check bundle data source.
+func NewCheckBundleDataSource() datasource.DataSource {
+	return &CheckBundleDataSource{}
+}
+
+// CheckBundleDataSource exports a snapshot's checks as a bundle that instances not
+// managed by Terraform can import.
+type CheckBundleDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type checkBundleDataSourceModel struct {
+	Profile          types.String   `tfsdk:"profile"`
+	SnapshotID       types.String   `tfsdk:"snapshot_id"`
+	CheckIDs         types.List     `tfsdk:"check_ids"`
+	Tags             types.List     `tfsdk:"tags"`
+	ManagedOnly      types.Bool     `tfsdk:"managed_only"`
+	ExportedCheckIDs []types.String `tfsdk:"exported_check_ids"`
+	BundleJSON       types.String   `tfsdk:"bundle_json"`
+}
+
+// checkBundle is the exported document: the checks as the create requests Forward
+// Enterprise accepts, so each entry can be posted to a snapshot's checks unchanged.
+type checkBundle struct {
+	Checks []fwdclient.NewCheckRequest `json:"checks"`
+}
+
+func (d *CheckBundleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_bundle"
+}
+
+func (d *CheckBundleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Export checks from a snapshot as a JSON bundle of check definitions, for importing them into " +
+			"Forward Enterprise instances that are not managed by Terraform, such as partner or lab environments. Write " +
+			"`bundle_json` to a file with `local_file`. Each entry of `checks` is a create request accepted by " +
+			"`POST /api/snapshots/{snapshotId}/checks`. The `managed-by:terraform` tag is not exported.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot whose checks are exported.",
+				Required:            true,
+			},
+			"check_ids": schema.ListAttribute{
+				MarkdownDescription: "Export only these checks. Fails when one of them does not exist on the snapshot.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Export only checks with at least one of these tags.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"managed_only": schema.BoolAttribute{
+				MarkdownDescription: "Export only checks created by Terraform, identified by their `managed-by:terraform` tag. " +
+					"Defaults to `true`.",
+				Optional: true,
+			},
+			"exported_check_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the exported checks, in bundle order.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"bundle_json": schema.StringAttribute{
+				MarkdownDescription: "The bundle, a JSON object whose `checks` array holds one check definition per exported " +
+					"check, sorted by name and then ID.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *CheckBundleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *CheckBundleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data checkBundleDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checks, err := providerData.Client.ListSnapshotChecks(ctx, data.SnapshotID.ValueString(), fwdclient.CheckListOptions{})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Intent Checks", err)
+		return
+	}
+
+	selected, missing := selectBundleChecks(checks, stringList(data.CheckIDs), stringList(data.Tags), data.ManagedOnly.IsNull() || data.ManagedOnly.ValueBool())
+	if len(missing) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("check_ids"), "Check Not Found",
+			fmt.Sprintf("Snapshot %s has no checks with IDs %s.", data.SnapshotID.ValueString(), strings.Join(missing, ", ")))
+		return
+	}
+
+	bundle := checkBundle{Checks: make([]fwdclient.NewCheckRequest, 0, len(selected))}
+	data.ExportedCheckIDs = make([]types.String, 0, len(selected))
+	for _, check := range selected {
+		request, diags := copyCheckRequest(&check)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		request.Tags = withoutManagedByTag(request.Tags)
+		bundle.Checks = append(bundle.Checks, request)
+		data.ExportedCheckIDs = append(data.ExportedCheckIDs, types.StringValue(check.ID))
+	}
+
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Encode Check Bundle", err.Error())
+		return
+	}
+	data.BundleJSON = types.StringValue(string(content))
+
+	tflog.Trace(ctx, "exported forward check bundle", map[string]any{"count": len(selected)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// selectBundleChecks filters checks by ID, tag, and managed-by marker, sorted by name
+// and then ID. It also returns the requested IDs that matched no check.
+func selectBundleChecks(checks []fwdclient.CheckResult, ids, tags []string, managedOnly bool) ([]fwdclient.CheckResult, []string) {
+	found := map[string]bool{}
+	var selected []fwdclient.CheckResult
+	for _, check := range checks {
+		if len(ids) > 0 && !slices.Contains(ids, check.ID) {
+			continue
+		}
+		found[check.ID] = true
+		if len(tags) > 0 && !slices.ContainsFunc(check.Tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+			continue
+		}
+		if managedOnly && !slices.Contains(check.Tags, managedByTag) {
+			continue
+		}
+		selected = append(selected, check)
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Name != selected[j].Name {
+			return selected[i].Name < selected[j].Name
+		}
+		return selected[i].ID < selected[j].ID
+	})
+
+	var missing []string
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	return selected, missing
+}
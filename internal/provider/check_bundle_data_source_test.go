@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestSelectBundleChecks(t *testing.T) {
+	t.Parallel()
+
+	checks := []fwdclient.CheckResult{
+		{ID: "C-3", Name: "Isolation", Tags: []string{managedByTag, "pci"}},
+		{ID: "C-1", Name: "Reachability", Tags: []string{managedByTag}},
+		{ID: "C-2", Name: "Created in the UI", Tags: []string{"pci"}},
+	}
+	ids := func(selected []fwdclient.CheckResult) string {
+		var result []string
+		for _, check := range selected {
+			result = append(result, check.ID)
+		}
+		return fmt.Sprint(result)
+	}
+
+	selected, _ := selectBundleChecks(checks, nil, nil, true)
+	if got := ids(selected); got != "[C-3 C-1]" {
+		t.Fatalf("expected managed checks sorted by name, got %s", got)
+	}
+	selected, _ = selectBundleChecks(checks, nil, []string{"pci"}, false)
+	if got := ids(selected); got != "[C-2 C-3]" {
+		t.Fatalf("expected checks tagged pci, got %s", got)
+	}
+	selected, missing := selectBundleChecks(checks, []string{"C-2", "C-9"}, nil, false)
+	if got := ids(selected); got != "[C-2]" || fmt.Sprint(missing) != "[C-9]" {
+		t.Fatalf("expected C-2 with C-9 missing, got %s and %v", got, missing)
+	}
+}
+
+func TestAccCheckBundleDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddCheck("snap-1", fwdclient.CheckResult{
+		Name:       "Reachability",
+		Priority:   "HIGH",
+		Tags:       []string{managedByTag, "iac"},
+		Definition: []byte(`{"checkType":"NQE","queryId":"FQ_test"}`),
+	})
+	server.AddCheck("snap-1", fwdclient.CheckResult{
+		Name:       "Created in the UI",
+		Definition: []byte(`{"checkType":"NQE","queryId":"FQ_other"}`),
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+data "forward_check_bundle" "managed" {
+  snapshot_id = "snap-1"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_check_bundle.managed", "exported_check_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.forward_check_bundle.managed", "bundle_json", `{
+  "checks": [
+    {
+      "definition": {
+        "checkType": "NQE",
+        "queryId": "FQ_test"
+      },
+      "name": "Reachability",
+      "priority": "HIGH",
+      "tags": [
+        "iac"
+      ]
+    }
+  ]
+}`),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// checkCache lists each snapshot's checks at most once per provider configuration, so
+// refreshing many intent check resources on one snapshot costs a single request rather
+// than one request per check. Terraform configures a fresh provider for every plan and
+// apply, which bounds how stale an entry can become.
+type checkCache struct {
+	mu        sync.Mutex
+	snapshots map[string]*checkCacheEntry
+	// hits counts lookups answered from an earlier listing.
+	hits atomic.Int64
+}
+
+type checkCacheEntry struct {
+	once   sync.Once
+	checks map[string]fwdclient.CheckResult
+	err    error
+}
+
+func newCheckCache() *checkCache {
+	return &checkCache{snapshots: map[string]*checkCacheEntry{}}
+}
+
+// lookup returns the check from the snapshot listing, loading the listing on first use.
+// It returns false when the listing failed or does not contain the check, in which case
+// the caller should fetch the check directly.
+func (c *checkCache) lookup(ctx context.Context, client *fwdclient.Client, snapshotID, checkID string) (*fwdclient.CheckResult, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.snapshots[snapshotID]
+	if !ok {
+		entry = &checkCacheEntry{}
+		c.snapshots[snapshotID] = entry
+	}
+	c.mu.Unlock()
+
+	// Concurrent reads for the same snapshot wait for a single listing request.
+	loaded := false
+	entry.once.Do(func() {
+		loaded = true
+		checks, err := client.ListSnapshotChecks(ctx, snapshotID, fwdclient.CheckListOptions{})
+		if err != nil {
+			entry.err = err
+			return
+		}
+		entry.checks = make(map[string]fwdclient.CheckResult, len(checks))
+		for _, check := range checks {
+			entry.checks[check.ID] = check
+		}
+	})
+
+	if entry.err != nil {
+		return nil, false
+	}
+	check, ok := entry.checks[checkID]
+	if !ok {
+		return nil, false
+	}
+	if !loaded {
+		c.hits.Add(1)
+	}
+	return &check, true
+}
+
+// invalidate drops the cached listing for a snapshot after its checks change.
+func (c *checkCache) invalidate(snapshotID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.snapshots, snapshotID)
+}
+
+// readSnapshotCheck returns a check from the per-snapshot cache, falling back to a
+// direct request so not-found and permission errors surface exactly as before.
+func (d *ForwardProviderData) readSnapshotCheck(ctx context.Context, snapshotID, checkID string) (*fwdclient.CheckResult, error) {
+	if check, ok := d.checks.lookup(ctx, d.Client, snapshotID, checkID); ok {
+		return check, nil
+	}
+
+	result, err := d.Client.GetSnapshotCheck(ctx, snapshotID, checkID)
+	if err != nil {
+		return nil, err
+	}
+	return &result.CheckResult, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestReadSnapshotCheckListsOncePerSnapshot(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	ids := make([]string, 0, 20)
+	for i := 0; i < 20; i++ {
+		ids = append(ids, server.AddCheck("snap-1", fwdclient.CheckResult{Name: "check"}))
+	}
+
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	data := &ForwardProviderData{Client: client, checks: newCheckCache()}
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			check, err := data.readSnapshotCheck(context.Background(), "snap-1", id)
+			if err != nil {
+				t.Errorf("read %s: %v", id, err)
+				return
+			}
+			if check.ID != id {
+				t.Errorf("expected check %s, got %s", id, check.ID)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if got := server.RequestCount(http.MethodGet, "/api/snapshots/snap-1/checks"); got != 1 {
+		t.Fatalf("expected a single list request, got %d", got)
+	}
+	if hits := data.cacheHits(); hits != int64(len(ids)-1) {
+		t.Fatalf("expected every read but the first to hit the cache, got %d hits", hits)
+	}
+
+	// Checks missing from the listing fall back to a direct read so 404s surface unchanged.
+	if _, err := data.readSnapshotCheck(context.Background(), "snap-1", "check-missing"); !fwdclient.IsNotFound(err) {
+		t.Fatalf("expected not found for missing check, got %v", err)
+	}
+
+	// Mutations invalidate the listing so later reads observe the change.
+	created := server.AddCheck("snap-1", fwdclient.CheckResult{Name: "new"})
+	data.checks.invalidate("snap-1")
+	if _, err := data.readSnapshotCheck(context.Background(), "snap-1", created); err != nil {
+		t.Fatalf("read after invalidate: %v", err)
+	}
+	if got := server.RequestCount(http.MethodGet, "/api/snapshots/snap-1/checks"); got != 2 {
+		t.Fatalf("expected listing to be reloaded after invalidate, got %d requests", got)
+	}
+}
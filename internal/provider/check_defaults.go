@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	envDefaultCheckPriority = "FORWARD_DEFAULT_CHECK_PRIORITY"
+	envDefaultCheckTags     = "FORWARD_DEFAULT_CHECK_TAGS"
+)
+
+// checkPriorities are the priorities Forward Enterprise accepts for checks.
+var checkPriorities = []string{"NOT_SET", "LOW", "MEDIUM", "HIGH"}
+
+// checkDefaults holds the provider `default_check_priority` and `default_check_tags`,
+// applied to checks created without a priority or tags.
+type checkDefaults struct {
+	Priority string
+	Tags     []string
+}
+
+// checkDefaultsSetting reads the check defaults from the provider configuration or, when
+// omitted, from FORWARD_DEFAULT_CHECK_PRIORITY and the comma-separated FORWARD_DEFAULT_CHECK_TAGS.
+func checkDefaultsSetting(ctx context.Context, priority types.String, tags types.List) (checkDefaults, diag.Diagnostics) {
+	defaults := checkDefaults{Priority: stringSetting(priority, envDefaultCheckPriority)}
+	if !tags.IsNull() && !tags.IsUnknown() {
+		diags := tags.ElementsAs(ctx, &defaults.Tags, false)
+		return defaults, diags
+	}
+	for _, tag := range strings.Split(os.Getenv(envDefaultCheckTags), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			defaults.Tags = append(defaults.Tags, tag)
+		}
+	}
+	return defaults, nil
+}
+
+// planCheckDefaults plans the provider check defaults for the `priority` and `tags` of a
+// check being created when its configuration omits them. Omitted values of existing
+// checks are kept from state, so changing the defaults does not touch them.
+func planCheckDefaults(ctx context.Context, providerData *ForwardProviderData, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() {
+		return
+	}
+
+	var defaults checkDefaults
+	if providerData != nil {
+		defaults = providerData.checkDefaults
+	}
+
+	var priority types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("priority"), &priority)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if priority.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("priority"), stringOrNull(defaults.Priority))...)
+	}
+
+	var tags types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if tags.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags"), stringSliceToList(defaults.Tags))...)
+	}
+}
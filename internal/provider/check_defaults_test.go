@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestCheckDefaultsSetting(t *testing.T) {
+	t.Setenv(envDefaultCheckPriority, "LOW")
+	t.Setenv(envDefaultCheckTags, " iac, ,team-net ")
+
+	ctx := context.Background()
+	defaults, diags := checkDefaultsSetting(ctx, types.StringNull(), types.ListNull(types.StringType))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if defaults.Priority != "LOW" || fmt.Sprint(defaults.Tags) != "[iac team-net]" {
+		t.Fatalf("expected the environment defaults, got %+v", defaults)
+	}
+
+	defaults, diags = checkDefaultsSetting(ctx, types.StringValue("HIGH"), listOfStrings([]string{"terraform"}))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if defaults.Priority != "HIGH" || fmt.Sprint(defaults.Tags) != "[terraform]" {
+		t.Fatalf("expected configuration to win, got %+v", defaults)
+	}
+}
+
+func TestAccIntentCheckResourceProviderDefaults(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+
+	config := func(priority string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url               = %q
+  network_id             = "net-1"
+  api_key                = "token"
+  default_check_priority = %q
+  default_check_tags     = ["iac"]
+}
+
+resource "forward_intent_check" "defaulted" {
+  snapshot_id     = "snap-1"
+  name            = "Reachability"
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+}
+
+resource "forward_intent_check" "explicit" {
+  snapshot_id     = "snap-1"
+  name            = "Isolation"
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+  priority        = "LOW"
+  tags            = ["team-net"]
+}
+`, server.URL, priority)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("HIGH"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_intent_check.defaulted", "priority", "HIGH"),
+					resource.TestCheckResourceAttr("forward_intent_check.defaulted", "tags.#", "1"),
+					resource.TestCheckResourceAttr("forward_intent_check.defaulted", "tags.0", "iac"),
+					resource.TestCheckResourceAttr("forward_intent_check.explicit", "priority", "LOW"),
+					resource.TestCheckResourceAttr("forward_intent_check.explicit", "tags.0", "team-net"),
+				),
+			},
+			{
+				// Changing the default leaves existing checks untouched.
+				Config:   config("MEDIUM"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
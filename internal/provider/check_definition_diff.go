@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// definitionIgnorePaths parses `definition_ignore_paths` into path segments. Segments are
+// separated by dots, and `*` matches any object key or list index.
+func definitionIgnorePaths(ctx context.Context, value types.List) ([][]string, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+
+	var raw []string
+	diags := value.ElementsAs(ctx, &raw, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	paths := make([][]string, 0, len(raw))
+	for i, entry := range raw {
+		segments := strings.Split(strings.TrimPrefix(strings.TrimSpace(entry), "$."), ".")
+		for _, segment := range segments {
+			if segment == "" {
+				diags.AddAttributeError(path.Root("definition_ignore_paths").AtListIndex(i), "Invalid Ignore Path",
+					fmt.Sprintf("%q must be dot-separated keys such as filters.from.location, with * matching any key or list index.", entry))
+				return nil, diags
+			}
+		}
+		paths = append(paths, segments)
+	}
+	return paths, diags
+}
+
+// refreshCheckDefinition returns the definition_json to store after reading the check:
+// the prior value while it still matches the definition stored in Forward, otherwise
+// the stored definition, so out-of-band edits surface as drift.
+func refreshCheckDefinition(prior types.String, stored json.RawMessage, ignore [][]string) types.String {
+	remote := checkDefinitionString(stored)
+	if prior.IsNull() || prior.IsUnknown() || remote.IsNull() {
+		return remote
+	}
+	if checkDefinitionsEquivalent(prior.ValueString(), stored, ignore) {
+		return prior
+	}
+	return remote
+}
+
+// checkDefinitionsEquivalent reports whether the stored definition matches the configured
+// one. Key order, formatting, and number notation are not significant. Fields the server
+// added that the configuration omits are its defaults and are ignored, as are values
+// under the ignore paths.
+func checkDefinitionsEquivalent(configured string, stored json.RawMessage, ignore [][]string) bool {
+	want, err := decodeDefinitionJSON([]byte(configured))
+	if err != nil {
+		return false
+	}
+	got, err := decodeDefinitionJSON(stored)
+	if err != nil {
+		return false
+	}
+	return definitionValuesEquivalent(want, got, nil, ignore)
+}
+
+func decodeDefinitionJSON(raw []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func definitionValuesEquivalent(want, got any, at []string, ignore [][]string) bool {
+	if definitionPathIgnored(at, ignore) {
+		return true
+	}
+
+	switch want := want.(type) {
+	case map[string]any:
+		got, ok := got.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, value := range want {
+			stored, ok := got[key]
+			if !ok {
+				// The server drops null fields.
+				if value == nil || definitionPathIgnored(append(at, key), ignore) {
+					continue
+				}
+				return false
+			}
+			if !definitionValuesEquivalent(value, stored, append(at, key), ignore) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		got, ok := got.([]any)
+		if !ok || len(want) != len(got) {
+			return false
+		}
+		for i := range want {
+			if !definitionValuesEquivalent(want[i], got[i], append(at, strconv.Itoa(i)), ignore) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		got, ok := got.(json.Number)
+		if !ok {
+			return false
+		}
+		a, _, errA := big.ParseFloat(want.String(), 10, 256, big.ToNearestEven)
+		b, _, errB := big.ParseFloat(got.String(), 10, 256, big.ToNearestEven)
+		if errA != nil || errB != nil {
+			return want == got
+		}
+		return a.Cmp(b) == 0
+	default:
+		return reflect.DeepEqual(want, got)
+	}
+}
+
+func definitionPathIgnored(at []string, ignore [][]string) bool {
+	for _, pattern := range ignore {
+		if len(pattern) != len(at) {
+			continue
+		}
+		matched := true
+		for i, segment := range pattern {
+			if segment != "*" && segment != at[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
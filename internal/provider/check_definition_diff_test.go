@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckDefinitionsEquivalent(t *testing.T) {
+	t.Parallel()
+
+	ignore := [][]string{{"filters", "*", "location"}, {"maxResults"}}
+	cases := map[string]struct {
+		configured string
+		stored     string
+		want       bool
+	}{
+		"formatting and key order": {`{ "queryId": "FQ_1", "checkType": "NQE" }`, `{"checkType":"NQE","queryId":"FQ_1"}`, true},
+		"server defaults":          {`{"checkType":"NQE"}`, `{"checkType":"NQE","queryId":"FQ_1","strict":false}`, true},
+		"nested server defaults":   {`{"filters":{"from":{"ip":"10.0.0.1"}}}`, `{"filters":{"from":{"ip":"10.0.0.1","type":"SUBNET"}}}`, true},
+		"number notation":          {`{"maxSeconds":30}`, `{"maxSeconds":30.0}`, true},
+		"dropped null":             {`{"checkType":"NQE","note":null}`, `{"checkType":"NQE"}`, true},
+		"changed value":            {`{"checkType":"NQE","queryId":"FQ_1"}`, `{"checkType":"NQE","queryId":"FQ_2"}`, false},
+		"removed field":            {`{"checkType":"NQE","queryId":"FQ_1"}`, `{"checkType":"NQE"}`, false},
+		"list length":              {`{"ports":[80,443]}`, `{"ports":[80]}`, false},
+		"list order":               {`{"ports":[80,443]}`, `{"ports":[443,80]}`, false},
+		"ignored wildcard path":    {`{"filters":{"from":{"location":"a"}}}`, `{"filters":{"from":{"location":"A"}}}`, true},
+		"ignored removed field":    {`{"checkType":"NQE","maxResults":5}`, `{"checkType":"NQE"}`, true},
+		"type change":              {`{"maxSeconds":"30"}`, `{"maxSeconds":30}`, false},
+		"invalid configuration":    {`{`, `{}`, false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := checkDefinitionsEquivalent(tc.configured, json.RawMessage(tc.stored), ignore); got != tc.want {
+				t.Fatalf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRefreshCheckDefinition(t *testing.T) {
+	t.Parallel()
+
+	stored := json.RawMessage(`{"checkType": "NQE", "queryId": "FQ_1", "strict": false}`)
+
+	if got := refreshCheckDefinition(types.StringNull(), stored, nil); got.ValueString() != `{"checkType":"NQE","queryId":"FQ_1","strict":false}` {
+		t.Fatalf("expected import to adopt the stored definition, got %s", got)
+	}
+	prior := types.StringValue(`{"queryId":"FQ_1","checkType":"NQE"}`)
+	if got := refreshCheckDefinition(prior, stored, nil); !got.Equal(prior) {
+		t.Fatalf("expected equivalent definition to be kept, got %s", got)
+	}
+	changed := types.StringValue(`{"checkType":"NQE","queryId":"FQ_2"}`)
+	if got := refreshCheckDefinition(changed, stored, nil); got.ValueString() != `{"checkType":"NQE","queryId":"FQ_1","strict":false}` {
+		t.Fatalf("expected drift to adopt the stored definition, got %s", got)
+	}
+}
+
+func TestDefinitionIgnorePaths(t *testing.T) {
+	t.Parallel()
+
+	value, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"$.filters.*.location", "maxResults"})
+	paths, diags := definitionIgnorePaths(context.Background(), value)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(paths) != 2 || len(paths[0]) != 3 || paths[0][1] != "*" || paths[1][0] != "maxResults" {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+
+	value, _ = types.ListValueFrom(context.Background(), types.StringType, []string{"filters..location"})
+	if _, diags := definitionIgnorePaths(context.Background(), value); !diags.HasError() {
+		t.Fatal("expected an empty segment to be rejected")
+	}
+}
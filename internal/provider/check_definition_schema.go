@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// checkSchemaFiles holds a JSON Schema for each check type whose definition is validated
+// at plan time. Each schema names its type with a `const` on `checkType`.
+//
+//go:embed check_schemas/*.json
+var checkSchemaFiles embed.FS
+
+// jsonSchema is the subset of JSON Schema the embedded check schemas use.
+type jsonSchema struct {
+	Type                 jsonSchemaTypes        `json:"type"`
+	Const                any                    `json:"const"`
+	Enum                 []any                  `json:"enum"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	MinLength            *int                   `json:"minLength"`
+	MinItems             *int                   `json:"minItems"`
+	Minimum              *json.Number           `json:"minimum"`
+}
+
+// jsonSchemaTypes accepts `type` as a single name or a list of names.
+type jsonSchemaTypes []string
+
+func (t *jsonSchemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = jsonSchemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+var loadCheckSchemas = sync.OnceValues(func() (map[string]*jsonSchema, error) {
+	entries, err := checkSchemaFiles.ReadDir("check_schemas")
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*jsonSchema, len(entries))
+	for _, entry := range entries {
+		raw, err := checkSchemaFiles.ReadFile("check_schemas/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		var schema jsonSchema
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		checkType, ok := schema.Properties["checkType"]
+		if !ok {
+			return nil, fmt.Errorf("%s: checkType must be constrained with const", entry.Name())
+		}
+		name, ok := checkType.Const.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: checkType must be constrained with const", entry.Name())
+		}
+		schemas[name] = &schema
+	}
+	return schemas, nil
+})
+
+// checkDefinitionErrors validates a definition against the schema of its `checkType`,
+// returning one message per violation, each prefixed with the JSON path of the
+// offending property. Definitions of check types without a schema only need a
+// `checkType`.
+func checkDefinitionErrors(definition string) ([]string, error) {
+	value, err := decodeDefinitionJSON([]byte(definition))
+	if err != nil {
+		return []string{fmt.Sprintf("$: invalid JSON: %s", err)}, nil
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return []string{"$: must be a JSON object"}, nil
+	}
+	checkType, ok := object["checkType"].(string)
+	if !ok || checkType == "" {
+		return []string{"$.checkType: must be a non-empty string naming the check type"}, nil
+	}
+
+	schemas, err := loadCheckSchemas()
+	if err != nil {
+		return nil, err
+	}
+	schema, ok := schemas[checkType]
+	if !ok {
+		return nil, nil
+	}
+	var problems []string
+	schema.validate("$", value, &problems)
+	return problems, nil
+}
+
+func (s *jsonSchema) validate(at string, value any, problems *[]string) {
+	if len(s.Type) > 0 && !jsonTypeMatches(s.Type, value) {
+		*problems = append(*problems, fmt.Sprintf("%s: must be of type %s, got %s", at, strings.Join(s.Type, " or "), jsonTypeName(value)))
+		return
+	}
+	if s.Const != nil && !jsonValuesEqual(s.Const, value) {
+		*problems = append(*problems, fmt.Sprintf("%s: must be %s", at, jsonLiteral(s.Const)))
+	}
+	if len(s.Enum) > 0 {
+		allowed := make([]string, len(s.Enum))
+		matched := false
+		for i, option := range s.Enum {
+			allowed[i] = jsonLiteral(option)
+			matched = matched || jsonValuesEqual(option, value)
+		}
+		if !matched {
+			*problems = append(*problems, fmt.Sprintf("%s: must be one of %s", at, strings.Join(allowed, ", ")))
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := value[name]; !ok {
+				*problems = append(*problems, fmt.Sprintf("%s: missing required property %q", jsonPathKey(at, name), name))
+			}
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			property, ok := s.Properties[key]
+			switch {
+			case ok:
+				property.validate(jsonPathKey(at, key), value[key], problems)
+			case s.AdditionalProperties != nil && !*s.AdditionalProperties:
+				*problems = append(*problems, fmt.Sprintf("%s: unknown property, expected one of %s", jsonPathKey(at, key), strings.Join(s.propertyNames(), ", ")))
+			}
+		}
+	case []any:
+		if s.MinItems != nil && len(value) < *s.MinItems {
+			*problems = append(*problems, fmt.Sprintf("%s: must have at least %d items", at, *s.MinItems))
+		}
+		if s.Items != nil {
+			for i, item := range value {
+				s.Items.validate(at+"["+strconv.Itoa(i)+"]", item, problems)
+			}
+		}
+	case string:
+		if s.MinLength != nil && len(value) < *s.MinLength {
+			*problems = append(*problems, fmt.Sprintf("%s: must be at least %d characters", at, *s.MinLength))
+		}
+	case json.Number:
+		if s.Minimum != nil {
+			minimum, _, errA := big.ParseFloat(s.Minimum.String(), 10, 256, big.ToNearestEven)
+			number, _, errB := big.ParseFloat(value.String(), 10, 256, big.ToNearestEven)
+			if errA == nil && errB == nil && number.Cmp(minimum) < 0 {
+				*problems = append(*problems, fmt.Sprintf("%s: must be at least %s", at, s.Minimum))
+			}
+		}
+	}
+}
+
+func (s *jsonSchema) propertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func jsonTypeMatches(types []string, value any) bool {
+	actual := jsonTypeName(value)
+	for _, name := range types {
+		if name == actual || (name == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonTypeName(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// jsonValuesEqual compares a schema literal with a decoded value, which carries numbers
+// as json.Number.
+func jsonValuesEqual(literal, value any) bool {
+	if number, ok := value.(json.Number); ok {
+		if f, ok := literal.(float64); ok {
+			parsed, err := number.Float64()
+			return err == nil && parsed == f
+		}
+	}
+	return reflect.DeepEqual(literal, value)
+}
+
+func jsonLiteral(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// jsonPathKey appends an object key to a JSON path, quoting keys that are not plain
+// identifiers.
+func jsonPathKey(at, key string) string {
+	for _, r := range key {
+		if !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return at + "[" + strconv.Quote(key) + "]"
+		}
+	}
+	return at + "." + key
+}
+
+var _ validator.String = checkDefinitionValidator{}
+
+// checkDefinitionValidator validates definition_json against the embedded schema of its
+// check type, so malformed definitions fail at plan time.
+type checkDefinitionValidator struct{}
+
+func (v checkDefinitionValidator) Description(ctx context.Context) string {
+	return "value must be a check definition valid for its checkType"
+}
+
+func (v checkDefinitionValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a check definition valid for its `checkType`"
+}
+
+func (v checkDefinitionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	problems, err := checkDefinitionErrors(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Unable to Load Check Schemas",
+			fmt.Sprintf("%s. Please report this issue to the provider developers.", err))
+		return
+	}
+	for _, problem := range problems {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Check Definition", problem)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckSchemasLoad(t *testing.T) {
+	t.Parallel()
+
+	schemas, err := loadCheckSchemas()
+	if err != nil {
+		t.Fatalf("loadCheckSchemas: %v", err)
+	}
+	for _, checkType := range []string{"NQE", "Existential", "Isolation", "Reachability"} {
+		if _, ok := schemas[checkType]; !ok {
+			t.Errorf("missing schema for %s", checkType)
+		}
+	}
+}
+
+func TestCheckDefinitionErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		definition string
+		want       []string
+	}{
+		"valid nqe":          {`{"checkType":"NQE","queryId":"FQ_1","maxViolations":0}`, nil},
+		"valid existential":  {`{"checkType":"Existential","filters":{"from":{},"flow":{"dstPort":443}}}`, nil},
+		"unknown check type": {`{"checkType":"Predefined","anything":true}`, nil},
+		"not an object":      {`[]`, []string{"$: must be a JSON object"}},
+		"missing check type": {`{"queryId":"FQ_1"}`, []string{"$.checkType: must be a non-empty string naming the check type"}},
+		"missing query": {
+			`{"checkType":"NQE"}`,
+			[]string{`$.queryId: missing required property "queryId"`},
+		},
+		"wrong type": {
+			`{"checkType":"NQE","queryId":"FQ_1","maxViolations":"5"}`,
+			[]string{"$.maxViolations: must be of type integer, got string"},
+		},
+		"below minimum": {
+			`{"checkType":"NQE","queryId":"FQ_1","maxViolations":-1}`,
+			[]string{"$.maxViolations: must be at least 0"},
+		},
+		"nested property": {
+			`{"checkType":"NQE","queryId":"FQ_1","violationPredicate":{"column":"x"}}`,
+			[]string{
+				`$.violationPredicate.columnName: missing required property "columnName"`,
+				"$.violationPredicate.column: unknown property, expected one of columnName, value",
+			},
+		},
+		"misspelled filter": {
+			`{"checkType":"Isolation","filters":{"form":{},"flow":{"dstPort":true}}}`,
+			[]string{
+				"$.filters.flow.dstPort: must be of type string or integer, got boolean",
+				"$.filters.form: unknown property, expected one of chain, flow, from, to",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := checkDefinitionErrors(tc.definition)
+			if err != nil {
+				t.Fatalf("checkDefinitionErrors: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckDefinitionValidator(t *testing.T) {
+	t.Parallel()
+
+	req := validator.StringRequest{
+		Path:        path.Root("definition_json"),
+		ConfigValue: types.StringValue(`{"checkType":"NQE","queryId":""}`),
+	}
+	var resp validator.StringResponse
+	checkDefinitionValidator{}.ValidateString(context.Background(), req, &resp)
+	if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics[0].Detail(), "$.queryId") {
+		t.Fatalf("expected an error naming $.queryId, got %v", resp.Diagnostics)
+	}
+
+	resp = validator.StringResponse{}
+	req.ConfigValue = types.StringUnknown()
+	checkDefinitionValidator{}.ValidateString(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected unknown values to be skipped, got %v", resp.Diagnostics)
+	}
+}
+
+func TestLoadCheckLibraryInvalidDefinition(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "mtu.yaml"), "name: MTU\ndefinition:\n  checkType: NQE\n  maxViolations: many\n")
+
+	_, err := loadCheckLibrary(dir)
+	if err == nil || !strings.Contains(err.Error(), "mtu.yaml") || !strings.Contains(err.Error(), "$.queryId") {
+		t.Fatalf("expected an error naming the file and property, got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/wait"
+)
+
+var _ resource.Resource = &CheckExecutionResource{}
+var _ resource.ResourceWithConfigValidators = &CheckExecutionResource{}
+
+const (
+	checkExecutionPollInterval    = 2 * time.Second
+	checkExecutionMaxPollInterval = 30 * time.Second
+)
+
+// CheckExecutionResource re-executes intent checks on a snapshot and waits for the results.
+type CheckExecutionResource struct {
+	providerData *ForwardProviderData
+}
+
+// CheckExecutionResourceModel maps Terraform schema data.
+type CheckExecutionResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Profile        types.String `tfsdk:"profile"`
+	SnapshotID     types.String `tfsdk:"snapshot_id"`
+	CheckIDs       types.Set    `tfsdk:"check_ids"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+	Statuses       types.Map    `tfsdk:"statuses"`
+}
+
+func NewCheckExecutionResource() resource.Resource {
+	return &CheckExecutionResource{}
+}
+
+func (r *CheckExecutionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_execution"
+}
+
+func (r *CheckExecutionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Re-execute intent checks on a snapshot immediately and wait for them to finish, for example " +
+			"after changing aliases or NQE query commits that the checks depend on. Checks run when the resource is " +
+			"created and again whenever `snapshot_id`, `check_ids`, or `triggers` change; destroying the resource " +
+			"has no effect on the checks.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Snapshot identifier the checks were executed on.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"snapshot_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Snapshot whose checks are executed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"check_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Checks to execute. Every check on the snapshot is executed when omitted.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Arbitrary values that re-execute the checks when they change, such as the `commit_id` " +
+					"of a `forward_nqe_query_definition` the checks use.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Maximum time to wait for the checks to finish. Defaults to 600 seconds.",
+				Default:             int64default.StaticInt64(600),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"statuses": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Status of each executed check (PASS, FAIL, ERROR, or TIMEOUT), keyed by check ID.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CheckExecutionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validateSnapshotReferences(func() *ForwardProviderData { return r.providerData }, "snapshot_id"),
+	}
+}
+
+func (r *CheckExecutionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *CheckExecutionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan CheckExecutionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var checkIDs []string
+	resp.Diagnostics.Append(plan.CheckIDs.ElementsAs(ctx, &checkIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !providerData.requireFeature(ctx, featureCheckExecution, path.Empty(), &resp.Diagnostics) {
+		return
+	}
+
+	snapshotID := plan.SnapshotID.ValueString()
+	// Execution is asynchronous, so the previous execution dates tell the new results
+	// apart from the ones already stored.
+	previous, err := checkExecutionDates(ctx, providerData.Client, snapshotID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading intent checks", err)
+		return
+	}
+	err = providerData.Client.ExecuteSnapshotChecks(ctx, snapshotID, checkIDs)
+	providerData.checks.invalidate(snapshotID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error executing intent checks", err)
+		return
+	}
+	tflog.Debug(ctx, "executing forward checks", map[string]any{"snapshot_id": snapshotID, "check_ids": checkIDs})
+
+	poller := wait.Poller{
+		Backoff: wait.NewBackoff(checkExecutionPollInterval, checkExecutionMaxPollInterval),
+		Timeout: time.Duration(defaultInt(plan.TimeoutSeconds, 600)) * time.Second,
+	}
+	statuses, err := waitForCheckStatuses(ctx, providerData.Client, snapshotID, checkIDs, previous, poller)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error waiting for intent checks", err)
+		return
+	}
+
+	plan.ID = types.StringValue(snapshotID)
+	plan.Statuses, diags = types.MapValueFrom(ctx, types.StringType, statuses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CheckExecutionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The statuses record the execution this resource performed; later executions are
+	// reported by forward_intent_checks rather than planned as drift.
+	var state CheckExecutionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CheckExecutionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only timeout_seconds can change in place, and it only applies to the next execution.
+	var plan CheckExecutionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CheckExecutionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Executions cannot be undone; removing the resource from state is sufficient.
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/internal/wait"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestCheckExecutionResource(t *testing.T) {
+	t.Parallel()
+
+	violations := int64(3)
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddCheck("snap-1", fwdclient.CheckResult{ID: "check-1"})
+	server.AddCheck("snap-1", fwdclient.CheckResult{ID: "check-2", NumViolations: &violations})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: checkExecutionTestConfig(server.URL, "commit-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_check_execution.test", "id", "snap-1"),
+					resource.TestCheckResourceAttr("forward_check_execution.test", "statuses.check-1", "PASS"),
+					resource.TestCheckResourceAttr("forward_check_execution.test", "statuses.check-2", "FAIL"),
+				),
+			},
+			{
+				// Changing a trigger executes the checks again.
+				Config: checkExecutionTestConfig(server.URL, "commit-2"),
+				Check:  resource.TestCheckResourceAttr("forward_check_execution.test", "triggers.query_commit", "commit-2"),
+			},
+		},
+	})
+}
+
+func checkExecutionTestConfig(host, commit string) string {
+	return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_check_execution" "test" {
+  snapshot_id = "snap-1"
+  triggers = {
+    query_commit = %q
+  }
+}
+`, host, commit)
+}
+
+func TestWaitForCheckStatuses(t *testing.T) {
+	t.Parallel()
+
+	violations := int64(1)
+	executed := int64(1700000000000)
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddCheck("snap-1", fwdclient.CheckResult{ID: "check-1"})
+	server.AddCheck("snap-1", fwdclient.CheckResult{ID: "check-2", NumViolations: &violations})
+	// The stored result is stale: the check no longer has violations.
+	server.AddCheck("snap-1", fwdclient.CheckResult{ID: "check-3", Status: "FAIL", ExecutionDateMillis: &executed})
+
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx := context.Background()
+	poller := wait.Poller{Timeout: time.Minute, Clock: wait.NewFakeClock(time.Unix(1700000000, 0))}
+	previous, err := checkExecutionDates(ctx, client, "snap-1")
+	if err != nil || previous["check-3"] != executed || previous["check-2"] != 0 {
+		t.Fatalf("unexpected execution dates %v: %v", previous, err)
+	}
+	if err := client.ExecuteSnapshotChecks(ctx, "snap-1", []string{"check-2", "check-3"}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	statuses, err := waitForCheckStatuses(ctx, client, "snap-1", []string{"check-2", "check-3"}, previous, poller)
+	if err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if len(statuses) != 2 || statuses["check-2"] != "FAIL" || statuses["check-3"] != "PASS" {
+		t.Fatalf("expected the new execution's statuses, got %v", statuses)
+	}
+
+	// Every check is reported when no IDs are given.
+	statuses, err = waitForCheckStatuses(ctx, client, "snap-1", nil, nil, poller)
+	if err != nil || len(statuses) != 3 || statuses["check-1"] != "PASS" {
+		t.Fatalf("unexpected statuses %v: %v", statuses, err)
+	}
+
+	if _, err := waitForCheckStatuses(ctx, client, "snap-1", []string{"check-missing"}, nil, poller); err == nil || !strings.Contains(err.Error(), "check-missing") {
+		t.Fatalf("expected missing check error, got %v", err)
+	}
+}
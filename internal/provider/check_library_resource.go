@@ -0,0 +1,412 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ resource.Resource = &CheckLibraryResource{}
+var _ resource.ResourceWithModifyPlan = &CheckLibraryResource{}
+var _ resource.ResourceWithConfigValidators = &CheckLibraryResource{}
+
+// checkLibraryExtensions lists the file extensions read as check definitions.
+var checkLibraryExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// CheckLibraryResource reconciles a local directory of check definitions against a snapshot.
+type CheckLibraryResource struct {
+	providerData *ForwardProviderData
+}
+
+// CheckLibraryResourceModel maps Terraform schema data.
+type CheckLibraryResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Profile     types.String `tfsdk:"profile"`
+	SnapshotID  types.String `tfsdk:"snapshot_id"`
+	SourceDir   types.String `tfsdk:"source_dir"`
+	Persistent  types.Bool   `tfsdk:"persistent"`
+	CheckHashes types.Map    `tfsdk:"check_hashes"`
+	CheckIDs    types.Map    `tfsdk:"check_ids"`
+}
+
+// checkLibraryEntry is the file format of a single check definition.
+type checkLibraryEntry struct {
+	Name                  string         `yaml:"name"`
+	Note                  string         `yaml:"note"`
+	Priority              string         `yaml:"priority"`
+	Enabled               *bool          `yaml:"enabled"`
+	PerfMonitoringEnabled *bool          `yaml:"perf_monitoring_enabled"`
+	Tags                  []string       `yaml:"tags"`
+	Definition            map[string]any `yaml:"definition"`
+}
+
+func NewCheckLibraryResource() resource.Resource {
+	return &CheckLibraryResource{}
+}
+
+func (r *CheckLibraryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_library"
+}
+
+func (r *CheckLibraryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage a library of intent checks from a local directory of YAML or JSON definitions. Each file " +
+			"defines one check; checks are created, replaced, or deactivated so the snapshot matches the directory, and the " +
+			"plan lists every check that changes.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Internal Terraform identifier (mirrors snapshot_id).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"snapshot_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Snapshot identifier the checks are created against.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_dir": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "Local directory of `.yaml`, `.yml`, or `.json` check definitions. Each file sets `definition` " +
+					"(the Forward check definition) and optionally `name`, `note`, `priority`, `enabled`, " +
+					"`perf_monitoring_enabled`, and `tags`. Checks are keyed by their path relative to this directory, without extension.",
+			},
+			"persistent": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the checks should persist to future snapshots.",
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"check_hashes": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "SHA-256 checksum of each check definition, keyed by check key.",
+			},
+			"check_ids": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Forward Enterprise check identifier of each check, keyed by check key.",
+			},
+		},
+	}
+}
+
+func (r *CheckLibraryResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validateSnapshotReferences(func() *ForwardProviderData { return r.providerData }, "snapshot_id"),
+	}
+}
+
+func (r *CheckLibraryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// ModifyPlan hashes the local definitions so the plan shows which checks are added,
+// replaced, or removed. Checks that will be recreated have an unknown check_ids entry.
+func (r *CheckLibraryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan CheckLibraryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.SourceDir.IsUnknown() {
+		return
+	}
+
+	entries, err := loadCheckLibrary(plan.SourceDir.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source_dir"), "Error Reading Check Library", err.Error())
+		return
+	}
+
+	hashes, err := checkLibraryHashes(entries)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source_dir"), "Error Reading Check Library", err.Error())
+		return
+	}
+
+	planCheckSet(ctx, req, resp, hashes)
+}
+
+func (r *CheckLibraryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan CheckLibraryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcile(ctx, &plan, checkSet{hashes: map[string]string{}, ids: map[string]string{}}, &resp.Diagnostics, &resp.State)
+}
+
+func (r *CheckLibraryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state CheckLibraryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, diags := checkSetFromMaps(ctx, state.CheckHashes, state.CheckIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.refreshCheckSet(ctx, state.SnapshotID.ValueString(), set)...)
+	resp.Diagnostics.Append(set.toMaps(ctx, &state.CheckHashes, &state.CheckIDs)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CheckLibraryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan, state CheckLibraryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, diags := checkSetFromMaps(ctx, state.CheckHashes, state.CheckIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcile(ctx, &plan, set, &resp.Diagnostics, &resp.State)
+}
+
+func (r *CheckLibraryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state CheckLibraryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, diags := checkSetFromMaps(ctx, state.CheckHashes, state.CheckIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.deactivateCheckSet(ctx, state.SnapshotID.ValueString(), set)...)
+}
+
+// reconcile brings the checks on the snapshot in line with the library, saving state
+// even when it fails part way.
+func (r *CheckLibraryResource) reconcile(ctx context.Context, plan *CheckLibraryResourceModel, set checkSet, diags *diag.Diagnostics, state *tfsdk.State) {
+	providerData, profileDiags := r.providerData.forProfile(plan.Profile)
+	diags.Append(profileDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	entries, err := loadCheckLibrary(plan.SourceDir.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("source_dir"), "Error Reading Check Library", err.Error())
+		return
+	}
+	desired, err := checkLibraryHashes(entries)
+	if err != nil {
+		diags.AddAttributeError(path.Root("source_dir"), "Error Reading Check Library", err.Error())
+		return
+	}
+
+	diags.Append(providerData.reconcileCheckSet(ctx, plan.SnapshotID.ValueString(), boolPointer(plan.Persistent), set, desired,
+		func(key string) fwdclient.NewCheckRequest { return entries[key].request() })...)
+
+	plan.ID = plan.SnapshotID
+	diags.Append(set.toMaps(ctx, &plan.CheckHashes, &plan.CheckIDs)...)
+	diags.Append(state.Set(ctx, plan)...)
+}
+
+// loadCheckLibrary reads every check definition under dir, keyed by its slash-separated
+// path relative to dir without the extension.
+func loadCheckLibrary(dir string) (map[string]checkLibraryEntry, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	entries := map[string]checkLibraryEntry{}
+	sources := map[string]string{}
+	err = filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !checkLibraryExtensions[filepath.Ext(name)] {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		key := strings.TrimSuffix(filepath.ToSlash(rel), filepath.Ext(name))
+		if previous, ok := sources[key]; ok {
+			return fmt.Errorf("%s and %s both define check %q", previous, rel, key)
+		}
+
+		entry, err := parseCheckLibraryFile(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rel, err)
+		}
+		entries[key] = entry
+		sources[key] = rel
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// parseCheckLibraryFile decodes a single YAML or JSON definition. JSON is parsed by the
+// YAML decoder, which rejects keys the file format does not define.
+func parseCheckLibraryFile(name string) (checkLibraryEntry, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return checkLibraryEntry{}, err
+	}
+
+	var entry checkLibraryEntry
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&entry); err != nil && !errors.Is(err, io.EOF) {
+		return checkLibraryEntry{}, err
+	}
+	if len(entry.Definition) == 0 {
+		return checkLibraryEntry{}, fmt.Errorf("definition must be set")
+	}
+
+	definition, err := json.Marshal(entry.Definition)
+	if err != nil {
+		return checkLibraryEntry{}, fmt.Errorf("definition: %w", err)
+	}
+	problems, err := checkDefinitionErrors(string(definition))
+	if err != nil {
+		return checkLibraryEntry{}, err
+	}
+	if len(problems) > 0 {
+		return checkLibraryEntry{}, fmt.Errorf("invalid definition: %s", strings.Join(problems, "; "))
+	}
+
+	return entry, nil
+}
+
+func (e checkLibraryEntry) request() fwdclient.NewCheckRequest {
+	return fwdclient.NewCheckRequest{
+		Definition:            fwdclient.CheckDefinition(e.Definition),
+		Enabled:               e.Enabled,
+		Name:                  e.Name,
+		Note:                  e.Note,
+		PerfMonitoringEnabled: e.PerfMonitoringEnabled,
+		Priority:              e.Priority,
+		Tags:                  e.Tags,
+	}
+}
+
+// checkLibraryHashes checksums the request each entry produces, so formatting-only
+// edits to a file do not recreate its check.
+func checkLibraryHashes(entries map[string]checkLibraryEntry) (map[string]string, error) {
+	hashes := make(map[string]string, len(entries))
+	for key, entry := range entries {
+		content, err := json.Marshal(entry.request())
+		if err != nil {
+			return nil, fmt.Errorf("encode check %q: %w", key, err)
+		}
+		hashes[key] = contentChecksum(content)
+	}
+	return hashes, nil
+}
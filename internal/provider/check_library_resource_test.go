@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestLoadCheckLibrary(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "mtu.yaml"), "name: MTU\npriority: HIGH\ndefinition:\n  checkType: NQE\n  queryId: FQ_1\n")
+	writeTestFile(t, filepath.Join(dir, "l2", "vlan.json"), `{"name": "VLAN", "definition": {"checkType": "Existential"}}`)
+	writeTestFile(t, filepath.Join(dir, "notes.txt"), "ignored")
+
+	entries, err := loadCheckLibrary(dir)
+	if err != nil {
+		t.Fatalf("loadCheckLibrary: %v", err)
+	}
+	if len(entries) != 2 || entries["mtu"].Priority != "HIGH" || entries["l2/vlan"].Definition["checkType"] != "Existential" {
+		t.Fatalf("unexpected entries: %#v", entries)
+	}
+
+	// Formatting differences between equivalent files produce the same hash.
+	other := t.TempDir()
+	writeTestFile(t, filepath.Join(other, "mtu.json"), `{"definition": {"queryId": "FQ_1", "checkType": "NQE"}, "priority": "HIGH", "name": "MTU"}`)
+	reformatted, err := loadCheckLibrary(other)
+	if err != nil {
+		t.Fatalf("loadCheckLibrary: %v", err)
+	}
+	first, _ := checkLibraryHashes(entries)
+	second, _ := checkLibraryHashes(reformatted)
+	if first["mtu"] != second["mtu"] {
+		t.Fatal("expected equivalent definitions to hash identically")
+	}
+}
+
+func TestLoadCheckLibraryErrors(t *testing.T) {
+	t.Parallel()
+
+	for name, files := range map[string]map[string]string{
+		"duplicate key":      {"mtu.yaml": "definition: {a: 1}", "mtu.json": `{"definition": {"a": 1}}`},
+		"unknown field":      {"mtu.yaml": "definition: {a: 1}\nseverity: HIGH"},
+		"missing definition": {"mtu.yaml": "name: MTU"},
+	} {
+		dir := t.TempDir()
+		for file, content := range files {
+			writeTestFile(t, filepath.Join(dir, file), content)
+		}
+		if _, err := loadCheckLibrary(dir); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestAccCheckLibraryResource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "mtu.yaml"), "name: MTU\ndefinition:\n  checkType: NQE\n")
+	writeTestFile(t, filepath.Join(dir, "vlan.yaml"), "name: VLAN\ndefinition:\n  checkType: NQE\n")
+
+	config := fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_check_library" "test" {
+  snapshot_id = "snap-1"
+  source_dir  = %q
+}
+`, server.URL, dir)
+
+	var vlanID string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_check_library.test", "check_ids.%", "2"),
+					resource.TestCheckResourceAttrWith("forward_check_library.test", "check_ids.vlan", func(value string) error {
+						vlanID = value
+						return nil
+					}),
+				),
+			},
+			{
+				PreConfig: func() {
+					writeTestFile(t, filepath.Join(dir, "mtu.yaml"), "name: MTU renamed\ndefinition:\n  checkType: NQE\n")
+					if err := os.Remove(filepath.Join(dir, "vlan.yaml")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_check_library.test", "check_ids.%", "1"),
+					resource.TestCheckResourceAttrWith("forward_check_library.test", "check_ids.mtu", func(value string) error {
+						if check, ok := server.Check("snap-1", value); !ok || check.Name != "MTU renamed" {
+							return fmt.Errorf("expected recreated check, got %+v", check)
+						}
+						return nil
+					}),
+					func(*terraform.State) error {
+						if _, ok := server.Check("snap-1", vlanID); ok {
+							return fmt.Errorf("expected removed check %s to be deactivated", vlanID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
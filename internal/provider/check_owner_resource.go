@@ -0,0 +1,248 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ resource.Resource = &CheckOwnerResource{}
+var _ resource.ResourceWithImportState = &CheckOwnerResource{}
+var _ resource.ResourceWithConfigValidators = &CheckOwnerResource{}
+
+// CheckOwnerResource assigns the owner of an existing intent check.
+type CheckOwnerResource struct {
+	providerData *ForwardProviderData
+}
+
+// CheckOwnerResourceModel maps Terraform schema data.
+type CheckOwnerResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Profile    types.String `tfsdk:"profile"`
+	SnapshotID types.String `tfsdk:"snapshot_id"`
+	CheckID    types.String `tfsdk:"check_id"`
+	Owner      types.String `tfsdk:"owner"`
+
+	Creator   types.String `tfsdk:"creator"`
+	CreatorID types.String `tfsdk:"creator_id"`
+}
+
+func NewCheckOwnerResource() resource.Resource {
+	return &CheckOwnerResource{}
+}
+
+func (r *CheckOwnerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_owner"
+}
+
+func (r *CheckOwnerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assign the owner of an existing Forward Enterprise intent check, for example to re-home checks " +
+			"created by someone who has left. Requires administrator permissions. Destroying the resource leaves the " +
+			"check with its current owner.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `snapshot_id/check_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"snapshot_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Snapshot identifier that owns the check.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"check_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the intent check.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "User ID or username that should own the check.",
+			},
+			"creator": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Creator name Forward Enterprise reports for the check.",
+			},
+			"creator_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Creator user ID Forward Enterprise reports for the check.",
+			},
+		},
+	}
+}
+
+func (r *CheckOwnerResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validateSnapshotReferences(func() *ForwardProviderData { return r.providerData }, "snapshot_id"),
+	}
+}
+
+func (r *CheckOwnerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *CheckOwnerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CheckOwnerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.transfer(ctx, &plan, &resp.State, &resp.Diagnostics)
+}
+
+func (r *CheckOwnerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state CheckOwnerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := providerData.readSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.CheckID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error reading intent check owner", err)
+		return
+	}
+
+	setCheckOwnerState(&state, result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CheckOwnerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CheckOwnerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.transfer(ctx, &plan, &resp.State, &resp.Diagnostics)
+}
+
+func (r *CheckOwnerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Ownership cannot be returned to a previous owner who may no longer exist, so the
+	// check keeps its current owner and is only removed from state.
+}
+
+func (r *CheckOwnerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) == 3 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile"), parts[0])...)
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError("Invalid import format", "Use: snapshot_id/check_id or profile/snapshot_id/check_id")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[0]+"/"+parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("snapshot_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("check_id"), parts[1])...)
+}
+
+// transfer assigns the planned owner and stores the resulting check ownership.
+func (r *CheckOwnerResource) transfer(ctx context.Context, plan *CheckOwnerResourceModel, state *tfsdk.State, diags *diag.Diagnostics) {
+	if r.providerData == nil {
+		diags.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	providerData, d := r.providerData.forProfile(plan.Profile)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	if !providerData.requireFeature(ctx, featureCheckOwner, path.Root("owner"), diags) {
+		return
+	}
+
+	snapshotID := plan.SnapshotID.ValueString()
+	result, err := providerData.Client.TransferSnapshotCheckOwner(ctx, snapshotID, plan.CheckID.ValueString(), plan.Owner.ValueString())
+	providerData.checks.invalidate(snapshotID)
+	if err != nil {
+		addAPIError(diags, "Error transferring intent check owner", err)
+		return
+	}
+
+	plan.ID = types.StringValue(snapshotID + "/" + plan.CheckID.ValueString())
+	setCheckOwnerState(plan, result)
+	diags.Append(state.Set(ctx, plan)...)
+}
+
+// setCheckOwnerState records the reported creator. owner keeps its configured form
+// while it still matches the creator name or ID, so either may be configured, and
+// otherwise takes the reported owner so the drift is planned away.
+func setCheckOwnerState(model *CheckOwnerResourceModel, result *fwdclient.CheckResult) {
+	if result == nil {
+		return
+	}
+
+	model.Creator = stringOrNull(result.Creator)
+	model.CreatorID = stringOrNull(result.CreatorID)
+
+	owner := model.Owner.ValueString()
+	if owner == result.Creator || owner == result.CreatorID {
+		return
+	}
+	if result.CreatorID != "" {
+		model.Owner = types.StringValue(result.CreatorID)
+	} else {
+		model.Owner = stringOrNull(result.Creator)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestCheckOwnerResource(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddCheck("snap-1", fwdclient.CheckResult{ID: "check-1", Name: "Reachability", Creator: "alice", CreatorID: "u-1"})
+
+	providerFactory := providerserver.NewProtocol6WithError(New("test")())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"forward": providerFactory,
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: checkOwnerTestConfig(server.URL, "bob"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_check_owner.test", "id", "snap-1/check-1"),
+					resource.TestCheckResourceAttr("forward_check_owner.test", "creator", "bob"),
+				),
+			},
+			{
+				Config: checkOwnerTestConfig(server.URL, "carol"),
+				Check:  resource.TestCheckResourceAttr("forward_check_owner.test", "creator_id", "carol"),
+			},
+			{
+				ResourceName:            "forward_check_owner.test",
+				ImportState:             true,
+				ImportStateId:           "snap-1/check-1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"profile"},
+			},
+		},
+	})
+}
+
+func TestSetCheckOwnerState(t *testing.T) {
+	t.Parallel()
+
+	result := &fwdclient.CheckResult{Creator: "alice", CreatorID: "u-1"}
+	tests := map[string]struct {
+		owner types.String
+		want  string
+	}{
+		"username": {owner: types.StringValue("alice"), want: "alice"},
+		"user id":  {owner: types.StringValue("u-1"), want: "u-1"},
+		"drifted":  {owner: types.StringValue("bob"), want: "u-1"},
+		"imported": {owner: types.StringNull(), want: "u-1"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := CheckOwnerResourceModel{Owner: tc.owner}
+			setCheckOwnerState(&model, result)
+			if model.Owner.ValueString() != tc.want {
+				t.Fatalf("expected owner %q, got %q", tc.want, model.Owner.ValueString())
+			}
+		})
+	}
+}
+
+func checkOwnerTestConfig(host, owner string) string {
+	return fmt.Sprintf(`
+provider "forward" {
+  base_url   = "%s"
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_check_owner" "test" {
+  snapshot_id = "snap-1"
+  check_id    = "check-1"
+  owner       = %q
+}
+`, host, owner)
+}
@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &CheckResultDiffDataSource{}
+
+// checkStatusFail is the status of a check that found violations.
+const checkStatusFail = "FAIL"
+
+// NewCheckResultDiffDataSource instantiates the check result diff data source.
+func NewCheckResultDiffDataSource() datasource.DataSource {
+	return &CheckResultDiffDataSource{}
+}
+
+// CheckResultDiffDataSource compares intent check outcomes between two snapshots.
+type CheckResultDiffDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type checkResultDiffDataSourceModel struct {
+	Profile          types.String          `tfsdk:"profile"`
+	BeforeSnapshotID types.String          `tfsdk:"before_snapshot_id"`
+	AfterSnapshotID  types.String          `tfsdk:"after_snapshot_id"`
+	NewlyFailing     []checkResultDiffItem `tfsdk:"newly_failing"`
+	NewlyPassing     []checkResultDiffItem `tfsdk:"newly_passing"`
+	StillFailing     []checkResultDiffItem `tfsdk:"still_failing"`
+	Regressed        types.Bool            `tfsdk:"regressed"`
+}
+
+type checkResultDiffItem struct {
+	Name          types.String `tfsdk:"name"`
+	BeforeCheckID types.String `tfsdk:"before_check_id"`
+	AfterCheckID  types.String `tfsdk:"after_check_id"`
+	BeforeStatus  types.String `tfsdk:"before_status"`
+	AfterStatus   types.String `tfsdk:"after_status"`
+}
+
+// checkOutcome is the part of a check result the diff compares.
+type checkOutcome struct {
+	id     string
+	status string
+}
+
+func (d *CheckResultDiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_result_diff"
+}
+
+func (d *CheckResultDiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	diffItem := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":            types.StringType,
+			"before_check_id": types.StringType,
+			"after_check_id":  types.StringType,
+			"before_status":   types.StringType,
+			"after_status":    types.StringType,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Compare intent check results between two snapshots, typically before and after a change. " +
+			"Checks are matched by name, since persistent checks receive a new ID in every snapshot, and a check " +
+			"counts as failing when its status is FAIL. Each list is sorted by name.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"before_snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot taken before the change.",
+				Required:            true,
+			},
+			"after_snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot taken after the change.",
+				Required:            true,
+			},
+			"newly_failing": schema.ListAttribute{
+				MarkdownDescription: "Checks failing after the change that did not fail, or did not exist, before it.",
+				Computed:            true,
+				ElementType:         diffItem,
+			},
+			"newly_passing": schema.ListAttribute{
+				MarkdownDescription: "Checks that failed before the change and no longer fail after it.",
+				Computed:            true,
+				ElementType:         diffItem,
+			},
+			"still_failing": schema.ListAttribute{
+				MarkdownDescription: "Checks failing both before and after the change.",
+				Computed:            true,
+				ElementType:         diffItem,
+			},
+			"regressed": schema.BoolAttribute{
+				MarkdownDescription: "True when `newly_failing` is not empty, for use in `postcondition` blocks.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CheckResultDiffDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *CheckResultDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data checkResultDiffDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	before, err := checkOutcomes(ctx, providerData.Client, data.BeforeSnapshotID.ValueString())
+	if err != nil {
+		addAPIErrorWithPaths(&resp.Diagnostics, "Error listing checks of the before snapshot", err, map[string]path.Path{"snapshotId": path.Root("before_snapshot_id")})
+		return
+	}
+	after, err := checkOutcomes(ctx, providerData.Client, data.AfterSnapshotID.ValueString())
+	if err != nil {
+		addAPIErrorWithPaths(&resp.Diagnostics, "Error listing checks of the after snapshot", err, map[string]path.Path{"snapshotId": path.Root("after_snapshot_id")})
+		return
+	}
+
+	data.NewlyFailing, data.NewlyPassing, data.StillFailing = diffCheckOutcomes(before, after)
+	data.Regressed = types.BoolValue(len(data.NewlyFailing) > 0)
+
+	tflog.Trace(ctx, "compared forward check results", map[string]any{
+		"newly_failing": len(data.NewlyFailing),
+		"newly_passing": len(data.NewlyPassing),
+		"still_failing": len(data.StillFailing),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checkOutcomes streams a snapshot's checks into their outcomes keyed by check name,
+// or by ID for unnamed checks.
+func checkOutcomes(ctx context.Context, client *fwdclient.Client, snapshotID string) (map[string]checkOutcome, error) {
+	outcomes := map[string]checkOutcome{}
+	err := client.ForEachSnapshotCheck(ctx, snapshotID, fwdclient.CheckListOptions{}, func(check *fwdclient.CheckResult) error {
+		key := check.Name
+		if key == "" {
+			key = check.ID
+		}
+		if _, ok := outcomes[key]; !ok {
+			outcomes[key] = checkOutcome{id: check.ID, status: check.Status}
+		}
+		return nil
+	})
+	return outcomes, err
+}
+
+// diffCheckOutcomes classifies the checks of after against before. Checks that only
+// exist before the change are not reported.
+func diffCheckOutcomes(before, after map[string]checkOutcome) (newlyFailing, newlyPassing, stillFailing []checkResultDiffItem) {
+	newlyFailing, newlyPassing, stillFailing = []checkResultDiffItem{}, []checkResultDiffItem{}, []checkResultDiffItem{}
+
+	names := make([]string, 0, len(after))
+	for name := range after {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		now := after[name]
+		was, existed := before[name]
+		item := checkResultDiffItem{
+			Name:          types.StringValue(name),
+			BeforeCheckID: types.StringNull(),
+			AfterCheckID:  stringOrNull(now.id),
+			BeforeStatus:  types.StringNull(),
+			AfterStatus:   stringOrNull(now.status),
+		}
+		if existed {
+			item.BeforeCheckID = stringOrNull(was.id)
+			item.BeforeStatus = stringOrNull(was.status)
+		}
+
+		failedBefore := existed && was.status == checkStatusFail
+		switch {
+		case now.status == checkStatusFail && failedBefore:
+			stillFailing = append(stillFailing, item)
+		case now.status == checkStatusFail:
+			newlyFailing = append(newlyFailing, item)
+		case failedBefore:
+			newlyPassing = append(newlyPassing, item)
+		}
+	}
+
+	return newlyFailing, newlyPassing, stillFailing
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestDiffCheckOutcomes(t *testing.T) {
+	t.Parallel()
+
+	before := map[string]checkOutcome{
+		"bgp":  {id: "1", status: "FAIL"},
+		"mtu":  {id: "2", status: "FAIL"},
+		"ntp":  {id: "3", status: "PASS"},
+		"gone": {id: "4", status: "FAIL"},
+	}
+	after := map[string]checkOutcome{
+		"bgp": {id: "11", status: "PASS"},
+		"mtu": {id: "12", status: "FAIL"},
+		"ntp": {id: "13", status: "FAIL"},
+		"new": {id: "14", status: "FAIL"},
+		"ok":  {id: "15", status: "PASS"},
+	}
+
+	newlyFailing, newlyPassing, stillFailing := diffCheckOutcomes(before, after)
+
+	if len(newlyFailing) != 2 || newlyFailing[0].Name.ValueString() != "new" || newlyFailing[1].Name.ValueString() != "ntp" {
+		t.Fatalf("unexpected newly failing checks: %#v", newlyFailing)
+	}
+	if !newlyFailing[0].BeforeStatus.IsNull() || newlyFailing[1].BeforeCheckID.ValueString() != "3" {
+		t.Fatalf("unexpected before fields: %#v", newlyFailing)
+	}
+	if len(newlyPassing) != 1 || newlyPassing[0].Name.ValueString() != "bgp" {
+		t.Fatalf("unexpected newly passing checks: %#v", newlyPassing)
+	}
+	if len(stillFailing) != 1 || stillFailing[0].AfterCheckID.ValueString() != "12" {
+		t.Fatalf("unexpected still failing checks: %#v", stillFailing)
+	}
+}
+
+func TestAccCheckResultDiffDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-2"})
+	server.AddCheck("snap-1", fwdclient.CheckResult{Name: "mtu", Status: "PASS"})
+	server.AddCheck("snap-1", fwdclient.CheckResult{Name: "bgp", Status: "FAIL"})
+	server.AddCheck("snap-2", fwdclient.CheckResult{Name: "mtu", Status: "FAIL"})
+	server.AddCheck("snap-2", fwdclient.CheckResult{Name: "bgp", Status: "PASS"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_check_result_diff" "test" {
+  before_snapshot_id = "snap-1"
+  after_snapshot_id  = "snap-2"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_check_result_diff.test", "newly_failing.#", "1"),
+					resource.TestCheckResourceAttr("data.forward_check_result_diff.test", "newly_failing.0.name", "mtu"),
+					resource.TestCheckResourceAttr("data.forward_check_result_diff.test", "newly_passing.0.name", "bgp"),
+					resource.TestCheckResourceAttr("data.forward_check_result_diff.test", "still_failing.#", "0"),
+					resource.TestCheckResourceAttr("data.forward_check_result_diff.test", "regressed", "true"),
+				),
+			},
+		},
+	})
+}
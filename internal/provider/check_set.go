@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// checkSet is the set of checks a resource such as forward_check_library keeps on a
+// snapshot, stored in its `check_hashes` and `check_ids` attributes. Each check is keyed
+// by a stable name and recorded with a checksum of the request it was created from, so a
+// changed request replaces the check.
+type checkSet struct {
+	hashes map[string]string
+	ids    map[string]string
+}
+
+// checkSetFromMaps reads a check set from `check_hashes` and `check_ids` values.
+func checkSetFromMaps(ctx context.Context, hashes, ids types.Map) (checkSet, diag.Diagnostics) {
+	set := checkSet{hashes: map[string]string{}, ids: map[string]string{}}
+	var diags diag.Diagnostics
+	if !hashes.IsNull() && !hashes.IsUnknown() {
+		diags.Append(hashes.ElementsAs(ctx, &set.hashes, false)...)
+	}
+	if !ids.IsNull() && !ids.IsUnknown() {
+		diags.Append(ids.ElementsAs(ctx, &set.ids, false)...)
+	}
+	return set, diags
+}
+
+// toMaps converts the check set to `check_hashes` and `check_ids` values.
+func (s checkSet) toMaps(ctx context.Context, hashes, ids *types.Map) diag.Diagnostics {
+	var diags, mapDiags diag.Diagnostics
+	*hashes, mapDiags = types.MapValueFrom(ctx, types.StringType, s.hashes)
+	diags.Append(mapDiags...)
+	*ids, mapDiags = types.MapValueFrom(ctx, types.StringType, s.ids)
+	diags.Append(mapDiags...)
+	return diags
+}
+
+// planCheckSet sets the planned `check_hashes` to desired and `check_ids` to the IDs of
+// checks whose checksum is unchanged; checks that will be recreated have unknown IDs.
+// Replacing the resource recreates every check, so IDs are only kept when snapshot_id,
+// persistent, and profile are unchanged.
+func planCheckSet(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, desired map[string]string) {
+	prior := checkSet{}
+	if !req.State.Raw.IsNull() && !checkSetReplaced(ctx, req, &resp.Diagnostics) {
+		var hashes, ids types.Map
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("check_hashes"), &hashes)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("check_ids"), &ids)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		var diags diag.Diagnostics
+		prior, diags = checkSetFromMaps(ctx, hashes, ids)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := make(map[string]attr.Value, len(desired))
+	for key, hash := range desired {
+		if id, ok := prior.ids[key]; ok && prior.hashes[key] == hash {
+			ids[key] = types.StringValue(id)
+		} else {
+			ids[key] = types.StringUnknown()
+		}
+	}
+
+	hashValue, diags := types.MapValueFrom(ctx, types.StringType, desired)
+	resp.Diagnostics.Append(diags...)
+	idValue, diags := types.MapValue(types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("check_hashes"), hashValue)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("check_ids"), idValue)...)
+}
+
+// checkSetReplaced reports whether the plan changes an attribute that replaces the
+// resource.
+func checkSetReplaced(ctx context.Context, req resource.ModifyPlanRequest, diags *diag.Diagnostics) bool {
+	var plannedSnapshot, priorSnapshot, plannedProfile, priorProfile types.String
+	var plannedPersistent, priorPersistent types.Bool
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("snapshot_id"), &plannedSnapshot)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("snapshot_id"), &priorSnapshot)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("profile"), &plannedProfile)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("profile"), &priorProfile)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("persistent"), &plannedPersistent)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("persistent"), &priorPersistent)...)
+	return diags.HasError() || !plannedSnapshot.Equal(priorSnapshot) || !plannedProfile.Equal(priorProfile) ||
+		!plannedPersistent.Equal(priorPersistent)
+}
+
+// reconcileCheckSet deactivates the checks of removed and changed keys, then creates
+// checks for new and changed keys in desired, built by request. set records every
+// completed step, so state saved after a failure resumes where the apply stopped.
+func (d *ForwardProviderData) reconcileCheckSet(ctx context.Context, snapshotID string, persistent *bool, set checkSet, desired map[string]string, request func(key string) fwdclient.NewCheckRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+	defer d.checks.invalidate(snapshotID)
+
+	for _, key := range sortedKeys(set.ids) {
+		if desired[key] == set.hashes[key] {
+			continue
+		}
+		err := d.Client.DeactivateSnapshotCheck(ctx, snapshotID, set.ids[key])
+		if err != nil && !isNotFoundError(err) {
+			addAPIError(&diags, fmt.Sprintf("Error deactivating check %q", key), err)
+			return diags
+		}
+		tflog.Debug(ctx, "deactivated check", map[string]any{"key": key, "check_id": set.ids[key]})
+		delete(set.ids, key)
+		delete(set.hashes, key)
+	}
+
+	for _, key := range sortedKeys(desired) {
+		if _, ok := set.ids[key]; ok {
+			continue
+		}
+		checkRequest := request(key)
+		checkRequest.Tags = withManagedByTag(checkRequest.Tags)
+		result, err := d.Client.AddSnapshotCheck(ctx, snapshotID, checkRequest, persistent)
+		if err != nil {
+			addAPIError(&diags, fmt.Sprintf("Error creating check %q", key), err)
+			return diags
+		}
+		tflog.Debug(ctx, "created check", map[string]any{"key": key, "check_id": result.ID})
+		set.ids[key] = result.ID
+		set.hashes[key] = desired[key]
+	}
+
+	return diags
+}
+
+// refreshCheckSet forgets checks deactivated outside Terraform, so the next apply
+// recreates them.
+func (d *ForwardProviderData) refreshCheckSet(ctx context.Context, snapshotID string, set checkSet) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, key := range sortedKeys(set.ids) {
+		_, err := d.readSnapshotCheck(ctx, snapshotID, set.ids[key])
+		if isNotFoundError(err) {
+			delete(set.ids, key)
+			delete(set.hashes, key)
+			continue
+		}
+		if err != nil {
+			addAPIError(&diags, fmt.Sprintf("Error reading check %q", key), err)
+			return diags
+		}
+	}
+	return diags
+}
+
+// deactivateCheckSet deactivates every check in set. Checks that no longer exist are
+// skipped, so deletes stay idempotent.
+func (d *ForwardProviderData) deactivateCheckSet(ctx context.Context, snapshotID string, set checkSet) diag.Diagnostics {
+	var diags diag.Diagnostics
+	defer d.checks.invalidate(snapshotID)
+	for _, key := range sortedKeys(set.ids) {
+		err := d.Client.DeactivateSnapshotCheck(ctx, snapshotID, set.ids[key])
+		if err != nil && !isNotFoundError(err) {
+			addAPIError(&diags, fmt.Sprintf("Error deleting check %q", key), err)
+			return diags
+		}
+	}
+	return diags
+}
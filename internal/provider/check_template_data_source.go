@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// templatePlaceholder matches a `{{name}}` placeholder. Double braces keep placeholders
+// clear of Terraform's own `${...}` interpolation.
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_\-]+)\s*\}\}`)
+
+var _ datasource.DataSource = &CheckTemplateDataSource{}
+
+// NewCheckTemplateDataSource instantiates the check template data source.
+func NewCheckTemplateDataSource() datasource.DataSource {
+	return &CheckTemplateDataSource{}
+}
+
+// CheckTemplateDataSource renders one check definition per set of substitutions. It
+// makes no API calls.
+type CheckTemplateDataSource struct{}
+
+type checkTemplateDataSourceModel struct {
+	DefinitionJSON types.String              `tfsdk:"definition_json"`
+	Substitutions  []map[string]types.String `tfsdk:"substitutions"`
+	Key            types.String              `tfsdk:"key"`
+	Rendered       map[string]types.String   `tfsdk:"rendered"`
+}
+
+func (d *CheckTemplateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_template"
+}
+
+func (d *CheckTemplateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Render a check definition template once per set of substitutions, for creating many similar " +
+			"`forward_intent_check` resources with `for_each`. Rendering happens in the provider without contacting Forward Enterprise.",
+		Attributes: map[string]schema.Attribute{
+			"definition_json": schema.StringAttribute{
+				MarkdownDescription: "Check definition JSON, as accepted by `forward_intent_check`, with `{{name}}` placeholders " +
+					"in its string values. Placeholders are replaced inside JSON strings only, so substituted values cannot change " +
+					"the structure of the definition.",
+				Required: true,
+			},
+			"substitutions": schema.ListAttribute{
+				MarkdownDescription: "Values for the placeholders, one map per definition to render, for example one per site " +
+					"with its `src` and `dst`. Every placeholder in the template must have a value in each map.",
+				Required:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Template for the keys of `rendered`, for example `{{site}}-{{dst}}`, rendered with the same " +
+					"substitutions. Keys must be unique. Defaults to the position of each substitution in the list.",
+				Optional: true,
+			},
+			"rendered": schema.MapAttribute{
+				MarkdownDescription: "Rendered definitions as compact JSON, keyed by `key`. Pass to `for_each` on `forward_intent_check`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *CheckTemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data checkTemplateDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(data.DefinitionJSON.ValueString())))
+	decoder.UseNumber()
+	var template any
+	if err := decoder.Decode(&template); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition_json"), "Invalid Definition JSON", err.Error())
+		return
+	}
+	if _, ok := template.(map[string]any); !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("definition_json"), "Invalid Definition JSON", "The check definition must be a JSON object.")
+		return
+	}
+
+	rendered := make(map[string]types.String, len(data.Substitutions))
+	for i, substitution := range data.Substitutions {
+		attrPath := path.Root("substitutions").AtListIndex(i)
+		values := make(map[string]string, len(substitution))
+		for name, value := range substitution {
+			values[name] = value.ValueString()
+		}
+
+		key := strconv.Itoa(i)
+		if !data.Key.IsNull() {
+			var err error
+			key, err = renderTemplateString(data.Key.ValueString(), values)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(attrPath, "Undefined Template Variable", fmt.Sprintf("Rendering key: %s.", err))
+				continue
+			}
+		}
+		if _, exists := rendered[key]; exists {
+			resp.Diagnostics.AddAttributeError(path.Root("key"), "Duplicate Template Key",
+				fmt.Sprintf("Substitution %d renders key %q, which an earlier substitution already produced. Make `key` unique per substitution.", i, key))
+			continue
+		}
+
+		definition, err := renderTemplateValue(template, values)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(attrPath, "Undefined Template Variable", fmt.Sprintf("Rendering definition_json: %s.", err))
+			continue
+		}
+		encoded, err := json.Marshal(definition)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(attrPath, "Unable to Encode Definition", err.Error())
+			continue
+		}
+		rendered[key] = types.StringValue(string(encoded))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Rendered = rendered
+
+	tflog.Trace(ctx, "rendered forward check template", map[string]any{"definitions": len(rendered)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// renderTemplateValue returns a copy of a decoded JSON value with the placeholders in
+// every string value replaced.
+func renderTemplateValue(value any, values map[string]string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return renderTemplateString(v, values)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			rendered, err := renderTemplateValue(item, values)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = rendered
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			rendered, err := renderTemplateValue(item, values)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = rendered
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// renderTemplateString replaces the placeholders in text, failing on the first one
+// without a value.
+func renderTemplateString(text string, values map[string]string) (string, error) {
+	var missing string
+	rendered := templatePlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templatePlaceholder.FindStringSubmatch(placeholder)[1]
+		value, ok := values[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return value
+	})
+	if missing != "" {
+		return "", fmt.Errorf("no value for placeholder {{%s}}", missing)
+	}
+	return rendered, nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplateValue(t *testing.T) {
+	t.Parallel()
+
+	decoder := json.NewDecoder(strings.NewReader(`{
+		"checkType": "Existential",
+		"filters": {"from": {"location": {"value": "{{src}}"}}, "to": {"value": " {{ dst }}/32"}},
+		"ports": [{"port": "{{port}}"}, 443],
+		"noiseLevel": 0.5
+	}`))
+	decoder.UseNumber()
+	var template any
+	if err := decoder.Decode(&template); err != nil {
+		t.Fatalf("decode template: %v", err)
+	}
+
+	rendered, err := renderTemplateValue(template, map[string]string{"src": `site"a`, "dst": "10.0.0.1", "port": "22"})
+	if err != nil {
+		t.Fatalf("renderTemplateValue: %v", err)
+	}
+	encoded, err := json.Marshal(rendered)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	want := `{"checkType":"Existential","filters":{"from":{"location":{"value":"site\"a"}},"to":{"value":" 10.0.0.1/32"}},"noiseLevel":0.5,"ports":[{"port":"22"},443]}`
+	if string(encoded) != want {
+		t.Fatalf("unexpected definition:\n got %s\nwant %s", encoded, want)
+	}
+
+	if _, err := renderTemplateValue(template, map[string]string{"src": "a", "dst": "b"}); err == nil || !strings.Contains(err.Error(), "{{port}}") {
+		t.Fatalf("expected the missing placeholder to be named, got %v", err)
+	}
+}
+
+func TestRenderTemplateString(t *testing.T) {
+	t.Parallel()
+
+	got, err := renderTemplateString("{{site}}-{{site}}-{x}", map[string]string{"site": "sfo"})
+	if err != nil || got != "sfo-sfo-{x}" {
+		t.Fatalf("unexpected rendering: %q, %v", got, err)
+	}
+	if _, err := renderTemplateString("{{site}}", nil); err == nil {
+		t.Fatal("expected an error for a placeholder without a value")
+	}
+}
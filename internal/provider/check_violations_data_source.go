@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &CheckViolationsDataSource{}
+
+// NewCheckViolationsDataSource instantiates the check violations data source.
+func NewCheckViolationsDataSource() datasource.DataSource {
+	return &CheckViolationsDataSource{}
+}
+
+// CheckViolationsDataSource lists the individual violations of an intent check.
+type CheckViolationsDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type checkViolationsDataSourceModel struct {
+	Profile           types.String         `tfsdk:"profile"`
+	SnapshotID        types.String         `tfsdk:"snapshot_id"`
+	CheckID           types.String         `tfsdk:"check_id"`
+	Status            types.String         `tfsdk:"status"`
+	NumViolations     types.Int64          `tfsdk:"num_violations"`
+	Summary           types.String         `tfsdk:"summary"`
+	DetailsIncomplete types.Bool           `tfsdk:"details_incomplete"`
+	Devices           []types.String       `tfsdk:"devices"`
+	Violations        []checkViolationItem `tfsdk:"violations"`
+}
+
+type checkViolationItem struct {
+	Device    types.String `tfsdk:"device"`
+	Interface types.String `tfsdk:"interface"`
+	Values    types.Map    `tfsdk:"values"`
+	Files     types.List   `tfsdk:"files"`
+	Query     types.String `tfsdk:"query"`
+}
+
+// violationDeviceKeys and violationInterfaceKeys are the reference keys, compared
+// case-insensitively, that identify the device and interface of a violation.
+var (
+	violationDeviceKeys    = []string{"device", "deviceName"}
+	violationInterfaceKeys = []string{"interface", "interfaceName"}
+)
+
+func (d *CheckViolationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_violations"
+}
+
+func (d *CheckViolationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List the individual violations of an intent check on a snapshot, one row per violation with " +
+			"the device, interface, and reported values, so remediation can target exactly the violating devices.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot the check ran on.",
+				Required:            true,
+			},
+			"check_id": schema.StringAttribute{
+				MarkdownDescription: "Intent check identifier.",
+				Required:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Check status, such as PASS or FAIL.",
+				Computed:            true,
+			},
+			"num_violations": schema.Int64Attribute{
+				MarkdownDescription: "Number of violations Forward reports for the check. May exceed the length of `violations` when `details_incomplete` is true.",
+				Computed:            true,
+			},
+			"summary": schema.StringAttribute{
+				MarkdownDescription: "Human-readable summary of the violations.",
+				Computed:            true,
+			},
+			"details_incomplete": schema.BoolAttribute{
+				MarkdownDescription: "True when Forward truncated the violation details.",
+				Computed:            true,
+			},
+			"devices": schema.ListAttribute{
+				MarkdownDescription: "Distinct devices with at least one violation, sorted.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"violations": schema.ListAttribute{
+				MarkdownDescription: "Violations in the order Forward reports them. `values` holds every reference of the " +
+					"violation keyed by name, `files` the device files it points to, and `query` the query that found it.",
+				Computed: true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"device":    types.StringType,
+						"interface": types.StringType,
+						"values":    types.MapType{ElemType: types.StringType},
+						"files":     types.ListType{ElemType: types.StringType},
+						"query":     types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CheckViolationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *CheckViolationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data checkViolationsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	check, err := providerData.Client.GetSnapshotCheck(ctx, data.SnapshotID.ValueString(), data.CheckID.ValueString())
+	if err != nil {
+		if !providerData.tolerateMissing(err, "Check Not Found", &resp.Diagnostics) {
+			addAPIError(&resp.Diagnostics, "Error reading check violations", err)
+			return
+		}
+		check = &fwdclient.CheckResultWithDiagnosis{}
+	}
+
+	data.Status = stringOrNull(check.Status)
+	data.NumViolations = int64PointerOrNull(check.NumViolations)
+	data.Summary = types.StringNull()
+	data.DetailsIncomplete = types.BoolValue(false)
+	data.Violations = []checkViolationItem{}
+	if check.Diagnosis != nil {
+		data.Summary = stringOrNull(check.Diagnosis.Summary)
+		data.DetailsIncomplete = types.BoolValue(check.Diagnosis.DetailsIncomplete != nil && *check.Diagnosis.DetailsIncomplete)
+		for _, detail := range check.Diagnosis.Details {
+			data.Violations = append(data.Violations, checkViolation(detail))
+		}
+	}
+	data.Devices = violationDevices(data.Violations)
+
+	tflog.Trace(ctx, "read forward check violations", map[string]any{"violations": len(data.Violations)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checkViolation flattens one diagnosis detail. References sharing a key are joined
+// with ", " in values.
+func checkViolation(detail fwdclient.DiagnosisDetail) checkViolationItem {
+	joined := map[string]string{}
+	files := map[string]struct{}{}
+	for _, ref := range detail.References {
+		if existing, ok := joined[ref.Key]; ok {
+			joined[ref.Key] = existing + ", " + ref.Value
+		} else {
+			joined[ref.Key] = ref.Value
+		}
+		for file := range ref.Files {
+			files[file] = struct{}{}
+		}
+	}
+	values := make(map[string]attr.Value, len(joined))
+	for key, value := range joined {
+		values[key] = types.StringValue(value)
+	}
+
+	fileNames := make([]string, 0, len(files))
+	for file := range files {
+		fileNames = append(fileNames, file)
+	}
+	sort.Strings(fileNames)
+
+	return checkViolationItem{
+		Device:    stringOrNull(referenceValue(detail.References, violationDeviceKeys)),
+		Interface: stringOrNull(referenceValue(detail.References, violationInterfaceKeys)),
+		Values:    types.MapValueMust(types.StringType, values),
+		Files:     types.ListValueMust(types.StringType, stringSliceToValue(fileNames)),
+		Query:     stringOrNull(detail.Query),
+	}
+}
+
+// referenceValue returns the value of the first reference whose key is one of keys.
+func referenceValue(refs []fwdclient.DiagnosisReference, keys []string) string {
+	for _, ref := range refs {
+		for _, key := range keys {
+			if strings.EqualFold(ref.Key, key) {
+				return ref.Value
+			}
+		}
+	}
+	return ""
+}
+
+func violationDevices(violations []checkViolationItem) []types.String {
+	seen := map[string]struct{}{}
+	for _, violation := range violations {
+		if !violation.Device.IsNull() {
+			seen[violation.Device.ValueString()] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return stringValues(names)
+}
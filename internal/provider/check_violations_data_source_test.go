@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestCheckViolation(t *testing.T) {
+	t.Parallel()
+
+	violation := checkViolation(fwdclient.DiagnosisDetail{
+		Query: "mtu mismatch",
+		References: []fwdclient.DiagnosisReference{
+			{Key: "DeviceName", Value: "leaf-1", Files: map[string][]fwdclient.LineRange{"running-config": nil}},
+			{Key: "interface", Value: "Ethernet1"},
+			{Key: "mtu", Value: "1500"},
+			{Key: "mtu", Value: "9000"},
+		},
+	})
+
+	if violation.Device.ValueString() != "leaf-1" || violation.Interface.ValueString() != "Ethernet1" {
+		t.Fatalf("unexpected device or interface: %#v", violation)
+	}
+	if got := violation.Values.Elements()["mtu"].String(); got != `"1500, 9000"` {
+		t.Fatalf("expected joined mtu values, got %s", got)
+	}
+	if len(violation.Files.Elements()) != 1 {
+		t.Fatalf("expected one file, got %s", violation.Files)
+	}
+}
+
+func TestAccCheckViolationsDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	violations := int64(2)
+	checkID := server.AddCheck("snap-1", fwdclient.CheckResult{Name: "mtu", Status: "FAIL", NumViolations: &violations})
+	server.SetCheckDiagnosis(checkID, fwdclient.CheckDiagnosis{
+		Summary: "2 interfaces have mismatched MTU",
+		Details: []fwdclient.DiagnosisDetail{
+			{References: []fwdclient.DiagnosisReference{{Key: "device", Value: "leaf-2"}, {Key: "interface", Value: "Ethernet2"}}},
+			{References: []fwdclient.DiagnosisReference{{Key: "device", Value: "leaf-1"}, {Key: "interface", Value: "Ethernet1"}}},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_check_violations" "test" {
+  snapshot_id = "snap-1"
+  check_id    = %q
+}
+`, server.URL, checkID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_check_violations.test", "status", "FAIL"),
+					resource.TestCheckResourceAttr("data.forward_check_violations.test", "violations.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_check_violations.test", "violations.0.interface", "Ethernet2"),
+					resource.TestCheckResourceAttr("data.forward_check_violations.test", "devices.0", "leaf-1"),
+					resource.TestCheckResourceAttr("data.forward_check_violations.test", "details_incomplete", "false"),
+				),
+			},
+		},
+	})
+}
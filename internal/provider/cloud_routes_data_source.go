@@ -0,0 +1,298 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &CloudRoutesDataSource{}
+
+// NewCloudRoutesDataSource instantiates the cloud routing data source.
+func NewCloudRoutesDataSource() datasource.DataSource {
+	return &CloudRoutesDataSource{}
+}
+
+// CloudRoutesDataSource exposes the cloud route tables and attachments modeled in a
+// snapshot.
+type CloudRoutesDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type cloudRoutesDataSourceModel struct {
+	ID              types.String          `tfsdk:"id"`
+	Profile         types.String          `tfsdk:"profile"`
+	SnapshotID      types.String          `tfsdk:"snapshot_id"`
+	CloudType       types.String          `tfsdk:"cloud_type"`
+	AccountID       types.String          `tfsdk:"account_id"`
+	VPCID           types.String          `tfsdk:"vpc_id"`
+	EffectiveRoutes types.Bool            `tfsdk:"effective_routes"`
+	RouteTables     []cloudRouteTableItem `tfsdk:"route_tables"`
+	Attachments     []cloudAttachmentItem `tfsdk:"attachments"`
+}
+
+type cloudRouteTableItem struct {
+	ID        types.String     `tfsdk:"id"`
+	Name      types.String     `tfsdk:"name"`
+	CloudType types.String     `tfsdk:"cloud_type"`
+	AccountID types.String     `tfsdk:"account_id"`
+	Region    types.String     `tfsdk:"region"`
+	VPCID     types.String     `tfsdk:"vpc_id"`
+	Subnets   []types.String   `tfsdk:"subnets"`
+	Routes    []cloudRouteItem `tfsdk:"routes"`
+}
+
+type cloudRouteItem struct {
+	Destination types.String `tfsdk:"destination"`
+	TargetType  types.String `tfsdk:"target_type"`
+	TargetID    types.String `tfsdk:"target_id"`
+	Origin      types.String `tfsdk:"origin"`
+	State       types.String `tfsdk:"state"`
+}
+
+type cloudAttachmentItem struct {
+	ID        types.String `tfsdk:"id"`
+	Type      types.String `tfsdk:"type"`
+	CloudType types.String `tfsdk:"cloud_type"`
+	AccountID types.String `tfsdk:"account_id"`
+	Region    types.String `tfsdk:"region"`
+	VPCID     types.String `tfsdk:"vpc_id"`
+	PeerID    types.String `tfsdk:"peer_id"`
+	State     types.String `tfsdk:"state"`
+}
+
+func (d *CloudRoutesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_routes"
+}
+
+func (d *CloudRoutesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Expose the cloud route tables and the transit gateway, peering, and VPN attachments modeled " +
+			"in a snapshot, optionally with each route table's effective routes, so deployed cloud routing can be " +
+			"cross-checked against intent.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot identifier the routing was read from.",
+				Computed:            true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot whose cloud routing is read.",
+				Required:            true,
+			},
+			"cloud_type": schema.StringAttribute{
+				MarkdownDescription: "Only include route tables and attachments of this cloud (AWS, AZURE, GCP).",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("AWS", "AZURE", "GCP"),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "Only include route tables and attachments of this cloud account, subscription, or project.",
+				Optional:            true,
+			},
+			"vpc_id": schema.StringAttribute{
+				MarkdownDescription: "Only include route tables and attachments of this VPC or virtual network.",
+				Optional:            true,
+			},
+			"effective_routes": schema.BoolAttribute{
+				MarkdownDescription: "Return each route table's effective routes, including propagated and default routes, " +
+					"instead of its configured routes. Defaults to `false`.",
+				Optional: true,
+			},
+			"route_tables": schema.ListAttribute{
+				MarkdownDescription: "Route tables sorted by cloud, account, and ID.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"id":         types.StringType,
+						"name":       types.StringType,
+						"cloud_type": types.StringType,
+						"account_id": types.StringType,
+						"region":     types.StringType,
+						"vpc_id":     types.StringType,
+						"subnets":    types.ListType{ElemType: types.StringType},
+						"routes": types.ListType{ElemType: types.ObjectType{
+							AttrTypes: map[string]attr.Type{
+								"destination": types.StringType,
+								"target_type": types.StringType,
+								"target_id":   types.StringType,
+								"origin":      types.StringType,
+								"state":       types.StringType,
+							},
+						}},
+					},
+				},
+			},
+			"attachments": schema.ListAttribute{
+				MarkdownDescription: "Transit gateway, peering, and VPN attachments sorted by cloud, account, and ID.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"id":         types.StringType,
+						"type":       types.StringType,
+						"cloud_type": types.StringType,
+						"account_id": types.StringType,
+						"region":     types.StringType,
+						"vpc_id":     types.StringType,
+						"peer_id":    types.StringType,
+						"state":      types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CloudRoutesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *CloudRoutesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data cloudRoutesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !providerData.requireFeature(ctx, featureCloudRoutes, path.Empty(), &resp.Diagnostics) {
+		return
+	}
+
+	routing, err := providerData.Client.GetCloudRouting(ctx, data.SnapshotID.ValueString(), fwdclient.CloudRoutingOptions{
+		Effective: data.EffectiveRoutes.ValueBool(),
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Cloud Routing", err)
+		return
+	}
+
+	tables, attachments := filterCloudRouting(*routing, cloudScope{
+		CloudType: stringValue(data.CloudType),
+		AccountID: stringValue(data.AccountID),
+		VPCID:     stringValue(data.VPCID),
+	})
+
+	data.RouteTables = make([]cloudRouteTableItem, 0, len(tables))
+	for _, table := range tables {
+		item := cloudRouteTableItem{
+			ID:        stringOrNull(table.ID),
+			Name:      stringOrNull(table.Name),
+			CloudType: stringOrNull(table.CloudType),
+			AccountID: stringOrNull(table.AccountID),
+			Region:    stringOrNull(table.Region),
+			VPCID:     stringOrNull(table.VPCID),
+			Subnets:   stringValues(table.Subnets),
+			Routes:    make([]cloudRouteItem, 0, len(table.Routes)),
+		}
+		for _, route := range table.Routes {
+			item.Routes = append(item.Routes, cloudRouteItem{
+				Destination: stringOrNull(route.Destination),
+				TargetType:  stringOrNull(route.TargetType),
+				TargetID:    stringOrNull(route.TargetID),
+				Origin:      stringOrNull(route.Origin),
+				State:       stringOrNull(route.State),
+			})
+		}
+		data.RouteTables = append(data.RouteTables, item)
+	}
+	data.Attachments = make([]cloudAttachmentItem, 0, len(attachments))
+	for _, attachment := range attachments {
+		data.Attachments = append(data.Attachments, cloudAttachmentItem{
+			ID:        stringOrNull(attachment.ID),
+			Type:      stringOrNull(attachment.Type),
+			CloudType: stringOrNull(attachment.CloudType),
+			AccountID: stringOrNull(attachment.AccountID),
+			Region:    stringOrNull(attachment.Region),
+			VPCID:     stringOrNull(attachment.VPCID),
+			PeerID:    stringOrNull(attachment.PeerID),
+			State:     stringOrNull(attachment.State),
+		})
+	}
+	data.ID = data.SnapshotID
+
+	tflog.Trace(ctx, "retrieved forward cloud routing", map[string]any{"route_tables": len(tables), "attachments": len(attachments)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterCloudRouting keeps the route tables and attachments in scope, sorted by cloud,
+// account, and ID so plans stay stable between reads. Routes keep the API order.
+func filterCloudRouting(routing fwdclient.CloudRouting, scope cloudScope) ([]fwdclient.CloudRouteTable, []fwdclient.CloudAttachment) {
+	tables := make([]fwdclient.CloudRouteTable, 0, len(routing.RouteTables))
+	for _, table := range routing.RouteTables {
+		if scope.matches(table.CloudType, table.AccountID, table.VPCID) {
+			tables = append(tables, table)
+		}
+	}
+	attachments := make([]fwdclient.CloudAttachment, 0, len(routing.Attachments))
+	for _, attachment := range routing.Attachments {
+		if scope.matches(attachment.CloudType, attachment.AccountID, attachment.VPCID) {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	sort.SliceStable(tables, func(i, j int) bool {
+		a, b := tables[i], tables[j]
+		if a.CloudType != b.CloudType {
+			return a.CloudType < b.CloudType
+		}
+		if a.AccountID != b.AccountID {
+			return a.AccountID < b.AccountID
+		}
+		return a.ID < b.ID
+	})
+	sort.SliceStable(attachments, func(i, j int) bool {
+		a, b := attachments[i], attachments[j]
+		if a.CloudType != b.CloudType {
+			return a.CloudType < b.CloudType
+		}
+		if a.AccountID != b.AccountID {
+			return a.AccountID < b.AccountID
+		}
+		return a.ID < b.ID
+	})
+
+	return tables, attachments
+}
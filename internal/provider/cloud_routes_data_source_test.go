@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestFilterCloudRouting(t *testing.T) {
+	t.Parallel()
+
+	tables, attachments := filterCloudRouting(fwdclient.CloudRouting{
+		RouteTables: []fwdclient.CloudRouteTable{
+			{ID: "rtb-2", CloudType: "AWS", VPCID: "vpc-1"},
+			{ID: "rtb-1", CloudType: "AWS", VPCID: "vpc-1"},
+			{ID: "rtb-3", CloudType: "AWS", VPCID: "vpc-2"},
+		},
+		Attachments: []fwdclient.CloudAttachment{
+			{ID: "attach-2", CloudType: "AWS", VPCID: "vpc-2"},
+			{ID: "attach-1", CloudType: "AWS", VPCID: "vpc-1"},
+		},
+	}, cloudScope{VPCID: "vpc-1"})
+
+	if len(tables) != 2 || tables[0].ID != "rtb-1" || tables[1].ID != "rtb-2" {
+		t.Fatalf("unexpected route tables: %#v", tables)
+	}
+	if len(attachments) != 1 || attachments[0].ID != "attach-1" {
+		t.Fatalf("unexpected attachments: %#v", attachments)
+	}
+}
+
+func TestAccCloudRoutesDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.SetCloudRouting("snap-1", fwdclient.CloudRouting{
+		RouteTables: []fwdclient.CloudRouteTable{{
+			ID:        "rtb-1",
+			CloudType: "AWS",
+			VPCID:     "vpc-1",
+			Subnets:   []string{"subnet-1"},
+			Routes:    []fwdclient.CloudRoute{{Destination: "10.0.0.0/8", TargetType: "TRANSIT_GATEWAY", TargetID: "tgw-1", Origin: "STATIC", State: "ACTIVE"}},
+		}},
+		Attachments: []fwdclient.CloudAttachment{{ID: "tgw-attach-1", Type: "TRANSIT_GATEWAY", CloudType: "AWS", VPCID: "vpc-1", PeerID: "tgw-1", State: "AVAILABLE"}},
+	})
+	server.SetEffectiveRoutes("snap-1", "rtb-1", []fwdclient.CloudRoute{
+		{Destination: "172.31.0.0/16", TargetType: "LOCAL", Origin: "DEFAULT", State: "ACTIVE"},
+		{Destination: "10.0.0.0/8", TargetType: "TRANSIT_GATEWAY", TargetID: "tgw-1", Origin: "STATIC", State: "ACTIVE"},
+	})
+
+	config := func(effective bool) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_cloud_routes" "test" {
+  snapshot_id      = "snap-1"
+  vpc_id           = "vpc-1"
+  effective_routes = %t
+}
+`, server.URL, effective)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_cloud_routes.test", "route_tables.0.routes.#", "1"),
+					resource.TestCheckResourceAttr("data.forward_cloud_routes.test", "route_tables.0.subnets.0", "subnet-1"),
+					resource.TestCheckResourceAttr("data.forward_cloud_routes.test", "attachments.0.peer_id", "tgw-1"),
+				),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_cloud_routes.test", "route_tables.0.routes.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_cloud_routes.test", "route_tables.0.routes.0.origin", "DEFAULT"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,255 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &CloudSecurityGroupsDataSource{}
+
+// NewCloudSecurityGroupsDataSource instantiates the cloud security group data source.
+func NewCloudSecurityGroupsDataSource() datasource.DataSource {
+	return &CloudSecurityGroupsDataSource{}
+}
+
+// CloudSecurityGroupsDataSource lists the cloud security groups modeled in a snapshot.
+type CloudSecurityGroupsDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type cloudSecurityGroupsDataSourceModel struct {
+	ID             types.String             `tfsdk:"id"`
+	Profile        types.String             `tfsdk:"profile"`
+	SnapshotID     types.String             `tfsdk:"snapshot_id"`
+	CloudType      types.String             `tfsdk:"cloud_type"`
+	AccountID      types.String             `tfsdk:"account_id"`
+	VPCID          types.String             `tfsdk:"vpc_id"`
+	EffectiveRules types.Bool               `tfsdk:"effective_rules"`
+	SecurityGroups []cloudSecurityGroupItem `tfsdk:"security_groups"`
+}
+
+type cloudSecurityGroupItem struct {
+	ID        types.String            `tfsdk:"id"`
+	Name      types.String            `tfsdk:"name"`
+	CloudType types.String            `tfsdk:"cloud_type"`
+	AccountID types.String            `tfsdk:"account_id"`
+	Region    types.String            `tfsdk:"region"`
+	VPCID     types.String            `tfsdk:"vpc_id"`
+	Rules     []cloudSecurityRuleItem `tfsdk:"rules"`
+}
+
+type cloudSecurityRuleItem struct {
+	Direction types.String `tfsdk:"direction"`
+	Action    types.String `tfsdk:"action"`
+	Protocol  types.String `tfsdk:"protocol"`
+	Ports     types.String `tfsdk:"ports"`
+	Remote    types.String `tfsdk:"remote"`
+	Priority  types.Int64  `tfsdk:"priority"`
+}
+
+func (d *CloudSecurityGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_security_groups"
+}
+
+func (d *CloudSecurityGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List the AWS security groups, Azure network security groups, and GCP firewall policies modeled " +
+			"in a snapshot, normalized into a common rule model, so hybrid reachability policy can be validated alongside " +
+			"on-premises ACLs.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot identifier the security groups were read from.",
+				Computed:            true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot whose security groups are listed.",
+				Required:            true,
+			},
+			"cloud_type": schema.StringAttribute{
+				MarkdownDescription: "Only list security groups of this cloud (AWS, AZURE, GCP).",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("AWS", "AZURE", "GCP"),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "Only list security groups of this cloud account, subscription, or project.",
+				Optional:            true,
+			},
+			"vpc_id": schema.StringAttribute{
+				MarkdownDescription: "Only list security groups of this VPC or virtual network.",
+				Optional:            true,
+			},
+			"effective_rules": schema.BoolAttribute{
+				MarkdownDescription: "Return the rules as evaluated, including implicit default rules and rules inherited " +
+					"from parent policies, in evaluation order, instead of the configured rules. Defaults to `false`.",
+				Optional: true,
+			},
+			"security_groups": schema.ListAttribute{
+				MarkdownDescription: "Security groups sorted by cloud, account, and ID.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"id":         types.StringType,
+						"name":       types.StringType,
+						"cloud_type": types.StringType,
+						"account_id": types.StringType,
+						"region":     types.StringType,
+						"vpc_id":     types.StringType,
+						"rules": types.ListType{ElemType: types.ObjectType{
+							AttrTypes: map[string]attr.Type{
+								"direction": types.StringType,
+								"action":    types.StringType,
+								"protocol":  types.StringType,
+								"ports":     types.StringType,
+								"remote":    types.StringType,
+								"priority":  types.Int64Type,
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CloudSecurityGroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *CloudSecurityGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data cloudSecurityGroupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !providerData.requireFeature(ctx, featureCloudSecurityGroups, path.Empty(), &resp.Diagnostics) {
+		return
+	}
+
+	groups, err := providerData.Client.ListCloudSecurityGroups(ctx, data.SnapshotID.ValueString(), fwdclient.CloudSecurityGroupOptions{
+		Effective: data.EffectiveRules.ValueBool(),
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Cloud Security Groups", err)
+		return
+	}
+
+	groups = filterCloudSecurityGroups(groups, cloudScope{
+		CloudType: stringValue(data.CloudType),
+		AccountID: stringValue(data.AccountID),
+		VPCID:     stringValue(data.VPCID),
+	})
+
+	data.SecurityGroups = make([]cloudSecurityGroupItem, 0, len(groups))
+	for _, group := range groups {
+		item := cloudSecurityGroupItem{
+			ID:        stringOrNull(group.ID),
+			Name:      stringOrNull(group.Name),
+			CloudType: stringOrNull(group.CloudType),
+			AccountID: stringOrNull(group.AccountID),
+			Region:    stringOrNull(group.Region),
+			VPCID:     stringOrNull(group.VPCID),
+			Rules:     make([]cloudSecurityRuleItem, 0, len(group.Rules)),
+		}
+		for _, rule := range group.Rules {
+			item.Rules = append(item.Rules, cloudSecurityRuleItem{
+				Direction: stringOrNull(rule.Direction),
+				Action:    stringOrNull(rule.Action),
+				Protocol:  stringOrNull(rule.Protocol),
+				Ports:     stringOrNull(rule.Ports),
+				Remote:    stringOrNull(rule.Remote),
+				Priority:  int64PointerOrNull(rule.Priority),
+			})
+		}
+		data.SecurityGroups = append(data.SecurityGroups, item)
+	}
+	data.ID = data.SnapshotID
+
+	tflog.Trace(ctx, "retrieved forward cloud security groups", map[string]any{"count": len(groups)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// cloudScope holds the cloud_type, account_id, and vpc_id filters of the cloud data
+// sources. Empty fields match everything.
+type cloudScope struct {
+	CloudType string
+	AccountID string
+	VPCID     string
+}
+
+func (s cloudScope) matches(cloudType, accountID, vpcID string) bool {
+	return (s.CloudType == "" || strings.EqualFold(cloudType, s.CloudType)) &&
+		(s.AccountID == "" || accountID == s.AccountID) &&
+		(s.VPCID == "" || vpcID == s.VPCID)
+}
+
+// filterCloudSecurityGroups keeps the groups in scope, sorted by cloud, account, and ID
+// so plans stay stable between reads. Rules keep the API order, which is the evaluation
+// order for effective rules.
+func filterCloudSecurityGroups(groups []fwdclient.CloudSecurityGroup, scope cloudScope) []fwdclient.CloudSecurityGroup {
+	filtered := make([]fwdclient.CloudSecurityGroup, 0, len(groups))
+	for _, group := range groups {
+		if scope.matches(group.CloudType, group.AccountID, group.VPCID) {
+			filtered = append(filtered, group)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		if a.CloudType != b.CloudType {
+			return a.CloudType < b.CloudType
+		}
+		if a.AccountID != b.AccountID {
+			return a.AccountID < b.AccountID
+		}
+		return a.ID < b.ID
+	})
+	return filtered
+}
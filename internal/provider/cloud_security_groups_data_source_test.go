@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestFilterCloudSecurityGroups(t *testing.T) {
+	t.Parallel()
+
+	groups := filterCloudSecurityGroups([]fwdclient.CloudSecurityGroup{
+		{ID: "sg-2", CloudType: "AWS", AccountID: "111", VPCID: "vpc-1"},
+		{ID: "nsg-1", CloudType: "AZURE", AccountID: "sub-1", VPCID: "vnet-1"},
+		{ID: "sg-1", CloudType: "AWS", AccountID: "111", VPCID: "vpc-1"},
+		{ID: "sg-3", CloudType: "AWS", AccountID: "111", VPCID: "vpc-2"},
+	}, cloudScope{CloudType: "aws", VPCID: "vpc-1"})
+
+	if len(groups) != 2 || groups[0].ID != "sg-1" || groups[1].ID != "sg-2" {
+		t.Fatalf("unexpected security groups: %#v", groups)
+	}
+}
+
+func TestAccCloudSecurityGroupsDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddCloudSecurityGroup("snap-1", fwdclient.CloudSecurityGroup{
+		ID:        "sg-1",
+		Name:      "web",
+		CloudType: "AWS",
+		VPCID:     "vpc-1",
+		Rules:     []fwdclient.CloudSecurityRule{{Direction: "INGRESS", Action: "ALLOW", Protocol: "tcp", Ports: "443", Remote: "0.0.0.0/0"}},
+	})
+	server.SetEffectiveSecurityRules("snap-1", "sg-1", []fwdclient.CloudSecurityRule{
+		{Direction: "INGRESS", Action: "ALLOW", Protocol: "tcp", Ports: "443", Remote: "0.0.0.0/0"},
+		{Direction: "INGRESS", Action: "DENY", Protocol: "any", Remote: "0.0.0.0/0"},
+	})
+
+	config := func(effective bool) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_cloud_security_groups" "test" {
+  snapshot_id     = "snap-1"
+  cloud_type      = "AWS"
+  effective_rules = %t
+}
+`, server.URL, effective)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_cloud_security_groups.test", "security_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.forward_cloud_security_groups.test", "security_groups.0.rules.#", "1"),
+					resource.TestCheckResourceAttr("data.forward_cloud_security_groups.test", "security_groups.0.rules.0.ports", "443"),
+				),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_cloud_security_groups.test", "security_groups.0.rules.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_cloud_security_groups.test", "security_groups.0.rules.1.action", "DENY"),
+				),
+			},
+		},
+	})
+}
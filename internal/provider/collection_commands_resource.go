@@ -0,0 +1,290 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ resource.Resource = &CollectionCommandsResource{}
+var _ resource.ResourceWithImportState = &CollectionCommandsResource{}
+
+// collectionCommandsAPIFields maps custom command fields to the attributes that set them.
+var collectionCommandsAPIFields = map[string]path.Path{
+	"name":       path.Root("name"),
+	"deviceType": path.Root("device_type"),
+	"deviceTags": path.Root("device_tags"),
+	"commands":   path.Root("commands"),
+}
+
+// NewCollectionCommandsResource instantiates the collection commands resource.
+func NewCollectionCommandsResource() resource.Resource {
+	return &CollectionCommandsResource{}
+}
+
+// CollectionCommandsResource manages extra CLI commands collected from a group of devices.
+type CollectionCommandsResource struct {
+	providerData *ForwardProviderData
+}
+
+// CollectionCommandsResourceModel maps Terraform schema data.
+type CollectionCommandsResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Profile    types.String `tfsdk:"profile"`
+	NetworkID  types.String `tfsdk:"network_id"`
+	Name       types.String `tfsdk:"name"`
+	DeviceType types.String `tfsdk:"device_type"`
+	DeviceTags types.List   `tfsdk:"device_tags"`
+	Commands   types.List   `tfsdk:"commands"`
+}
+
+func (r *CollectionCommandsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_commands"
+}
+
+func (r *CollectionCommandsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage extra CLI commands the collector runs on a group of devices, so NQE queries can read " +
+			"their output. Commands apply from the next collection; snapshots already taken are unchanged.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the command set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Network whose collection settings hold the commands.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the command set.",
+			},
+			"device_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Forward device type the commands run on, such as `cisco_ios_ssh`. All types when omitted.",
+			},
+			"device_tags": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only run the commands on devices carrying all of these tags. All devices of `device_type` when omitted.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"commands": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "CLI commands to collect, in the order they run.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *CollectionCommandsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *CollectionCommandsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError("Unconfigured Provider", "The provider client was not configured. Re-run terraform init or review provider configuration.")
+		return
+	}
+
+	var plan CollectionCommandsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, diags := expandCustomCommandSet(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := providerData.Client.CreateCustomCommandSet(ctx, plan.NetworkID.ValueString(), set)
+	if err != nil {
+		addAPIErrorWithPaths(&resp.Diagnostics, "Error creating collection commands", err, collectionCommandsAPIFields)
+		return
+	}
+
+	setCollectionCommandsState(&plan, created)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionCommandsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError("Unconfigured Provider", "The provider client was not configured. Re-run terraform init or review provider configuration.")
+		return
+	}
+
+	var state CollectionCommandsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, err := providerData.Client.GetCustomCommandSet(ctx, state.NetworkID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error reading collection commands", err)
+		return
+	}
+
+	setCollectionCommandsState(&state, set)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CollectionCommandsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError("Unconfigured Provider", "The provider client was not configured. Re-run terraform init or review provider configuration.")
+		return
+	}
+
+	var plan CollectionCommandsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, diags := expandCustomCommandSet(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	set.ID = plan.ID.ValueString()
+
+	updated, err := providerData.Client.UpdateCustomCommandSet(ctx, plan.NetworkID.ValueString(), set)
+	if err != nil {
+		addAPIErrorWithPaths(&resp.Diagnostics, "Error updating collection commands", err, collectionCommandsAPIFields)
+		return
+	}
+
+	setCollectionCommandsState(&plan, updated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionCommandsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError("Unconfigured Provider", "The provider client was not configured. Re-run terraform init or review provider configuration.")
+		return
+	}
+
+	var state CollectionCommandsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := providerData.Client.DeleteCustomCommandSet(ctx, state.NetworkID.ValueString(), state.ID.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		addAPIError(&resp.Diagnostics, "Error deleting collection commands", err)
+	}
+}
+
+func (r *CollectionCommandsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) == 3 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile"), parts[0])...)
+		parts = parts[1:]
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError("Invalid import format", "Use: network_id/id or profile/network_id/id")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+func expandCustomCommandSet(ctx context.Context, model CollectionCommandsResourceModel) (fwdclient.CustomCommandSet, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	set := fwdclient.CustomCommandSet{
+		Name:       model.Name.ValueString(),
+		DeviceType: stringValue(model.DeviceType),
+	}
+
+	diags.Append(model.Commands.ElementsAs(ctx, &set.Commands, false)...)
+	if !model.DeviceTags.IsNull() && !model.DeviceTags.IsUnknown() {
+		diags.Append(model.DeviceTags.ElementsAs(ctx, &set.DeviceTags, false)...)
+	}
+
+	return set, diags
+}
+
+func setCollectionCommandsState(model *CollectionCommandsResourceModel, set *fwdclient.CustomCommandSet) {
+	if set == nil {
+		return
+	}
+
+	model.ID = types.StringValue(set.ID)
+	model.Name = types.StringValue(set.Name)
+	model.DeviceType = stringOrNull(set.DeviceType)
+	model.DeviceTags = stringSliceToList(set.DeviceTags)
+	model.Commands = types.ListValueMust(types.StringType, stringSliceToValue(set.Commands))
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAccCollectionCommandsResource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{})
+
+	config := func(commands string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_collection_commands" "test" {
+  network_id  = "net-1"
+  name        = "bgp-detail"
+  device_type = "cisco_ios_ssh"
+  device_tags = ["edge"]
+  commands    = %s
+}
+`, server.URL, commands)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["show ip bgp summary"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("forward_collection_commands.test", "id"),
+					resource.TestCheckResourceAttr("forward_collection_commands.test", "commands.#", "1"),
+				),
+			},
+			{
+				Config: config(`["show ip bgp summary", "show bgp neighbors"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_collection_commands.test", "commands.#", "2"),
+					resource.TestCheckResourceAttr("forward_collection_commands.test", "commands.1", "show bgp neighbors"),
+				),
+			},
+			{
+				ResourceName:      "forward_collection_commands.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					return "net-1/" + state.RootModule().Resources["forward_collection_commands.test"].Primary.ID, nil
+				},
+			},
+		},
+	})
+}
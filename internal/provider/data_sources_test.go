@@ -1,17 +1,22 @@
 // Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
 
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
-	"net/http"
-	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 const (
@@ -20,77 +25,51 @@ const (
 )
 
 func TestAccDataSourceVersionAndSnapshots(t *testing.T) {
-	versionPayload := `{"build":"ee9b380","release":"21.50.1-03","version":"21.50.1"}`
-	snapshotsPayload := fmt.Sprintf(`{
-        "id": "%s",
-        "name": "Primary",
-        "orgId": "42",
-        "snapshots": [
-            {
-                "id": "%s",
-                "state": "PROCESSED",
-                "processingTrigger": "COLLECTION",
-                "creationDateMillis": 1700000000000,
-                "processedAtMillis": 1700000010000
-            },
-            {
-                "id": "snap-2",
-                "state": "FAILED",
-                "processingTrigger": "COLLECTION",
-                "isDraft": true
-            }
-        ]
-    }`, testNetworkID, testSnapshotID)
-	checksPayload := `[
-        {
-            "id": "check-1",
-            "name": "Critical Reachability",
-            "status": "PASS",
-            "priority": "HIGH",
-            "numViolations": 0,
-            "enabled": true,
-            "creationDateMillis": 1700000000000,
-            "executionDateMillis": 1700000015000
-        },
-        {
-            "id": "check-2",
-            "name": "Intent Regression",
-            "status": "FAIL",
-            "priority": "MEDIUM",
-            "numViolations": 1,
-            "enabled": true,
-            "tags": ["pre-change"],
-            "executionDurationMillis": 1500
-        }
-    ]`
-	nqePayload := fmt.Sprintf(`{
-        "snapshotId": "%s",
-        "items": [
-            {"fields": {"device": "leaf1", "status": "permit"}}
-        ],
-        "totalNumItems": 1
-    }`, testSnapshotID)
-
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch r.URL.Path {
-		case "/api/version":
-			fmt.Fprint(w, versionPayload)
-		case fmt.Sprintf("/api/networks/%s/snapshots", testNetworkID):
-			fmt.Fprint(w, snapshotsPayload)
-		case fmt.Sprintf("/api/snapshots/%s/checks", testSnapshotID):
-			fmt.Fprint(w, checksPayload)
-		case "/api/nqe":
-			if r.Method != http.MethodPost {
-				http.NotFound(w, r)
-				return
-			}
-			w.Header().Set("Content-Type", "application/json")
-			fmt.Fprint(w, nqePayload)
-		default:
-			http.NotFound(w, r)
-		}
-	}))
-	defer server.Close()
+	server := fakeforward.New(t)
+	server.SetVersion(fwdclient.Version{Build: "ee9b380", Release: "21.50.1-03", Version: "21.50.1"})
+
+	creation, processed := int64(1700000000000), int64(1700000010000)
+	draft := true
+	server.AddSnapshot(testNetworkID, fwdclient.Snapshot{ID: "snap-2", State: "FAILED", ProcessingTrigger: "COLLECTION", IsDraft: &draft})
+	server.AddSnapshot(testNetworkID, fwdclient.Snapshot{
+		ID:                 testSnapshotID,
+		State:              "PROCESSED",
+		ProcessingTrigger:  "COLLECTION",
+		CreationDateMillis: &creation,
+		ProcessedAtMillis:  &processed,
+	})
+
+	enabled := true
+	zero, one := int64(0), int64(1)
+	executed, duration := int64(1700000015000), int64(1500)
+	server.AddCheck(testSnapshotID, fwdclient.CheckResult{
+		ID:                  "check-1",
+		Name:                "Critical Reachability",
+		Status:              "PASS",
+		Priority:            "HIGH",
+		Definition:          json.RawMessage(`{"checkType": "Existential", "filters": {}}`),
+		NumViolations:       &zero,
+		Enabled:             &enabled,
+		CreationDateMillis:  &creation,
+		ExecutionDateMillis: &executed,
+	})
+	server.AddCheck(testSnapshotID, fwdclient.CheckResult{
+		ID:                "check-2",
+		Name:              "Intent Regression",
+		Status:            "FAIL",
+		Priority:          "MEDIUM",
+		Creator:           "alice",
+		NumViolations:     &one,
+		Enabled:           &enabled,
+		Tags:              []string{"pre-change"},
+		ExecutionDuration: &duration,
+	})
+
+	total := int64(1)
+	server.SetNQEResult("SELECT device FROM devices", fwdclient.NqeRunResult{
+		Items:         []json.RawMessage{json.RawMessage(`{"fields":{"device":"leaf1","status":"permit"}}`)},
+		TotalNumItems: &total,
+	})
 
 	t.Setenv("FORWARD_API_KEY", "test-token")
 
@@ -130,7 +109,14 @@ data "forward_snapshots" "all" {
 }
 
 data "forward_intent_checks" "snapshot_checks" {
+  snapshot_id         = data.forward_snapshots.all.snapshots[0].id
+  include_definitions = true
+}
+
+data "forward_intent_checks" "filtered" {
   snapshot_id = data.forward_snapshots.all.snapshots[0].id
+  name_regex  = "^Intent"
+  creator     = "alice"
 }
 
 data "forward_nqe_query" "latest_acl" {
@@ -158,7 +144,7 @@ data "forward_nqe_query" "latest_acl" {
 					statecheck.ExpectKnownValue(
 						"data.forward_snapshots.all",
 						tfjsonpath.New("snapshots[0].id"),
-						knownvalue.StringExact("snap-1"),
+						knownvalue.StringExact(testSnapshotID),
 					),
 					statecheck.ExpectKnownValue(
 						"data.forward_snapshots.all",
@@ -175,11 +161,46 @@ data "forward_nqe_query" "latest_acl" {
 						tfjsonpath.New("pass_count"),
 						knownvalue.Int64Exact(1),
 					),
+					statecheck.ExpectKnownValue(
+						"data.forward_intent_checks.snapshot_checks",
+						tfjsonpath.New("statuses").AtMapKey("FAIL"),
+						knownvalue.Int64Exact(1),
+					),
+					statecheck.ExpectKnownValue(
+						"data.forward_intent_checks.snapshot_checks",
+						tfjsonpath.New("summary").AtMapKey("total"),
+						knownvalue.Int64Exact(2),
+					),
+					statecheck.ExpectKnownValue(
+						"data.forward_intent_checks.snapshot_checks",
+						tfjsonpath.New("summary").AtMapKey("by_priority").AtMapKey("MEDIUM"),
+						knownvalue.Int64Exact(1),
+					),
+					statecheck.ExpectKnownValue(
+						"data.forward_intent_checks.snapshot_checks",
+						tfjsonpath.New("score"),
+						knownvalue.Float64Exact(1000.0/15),
+					),
+					statecheck.ExpectKnownValue(
+						"data.forward_intent_checks.snapshot_checks",
+						tfjsonpath.New("checks[0].definition_json"),
+						knownvalue.StringExact(`{"checkType":"Existential","filters":{}}`),
+					),
 					statecheck.ExpectKnownValue(
 						"data.forward_intent_checks.snapshot_checks",
 						tfjsonpath.New("checks[1].status"),
 						knownvalue.StringExact("FAIL"),
 					),
+					statecheck.ExpectKnownValue(
+						"data.forward_intent_checks.filtered",
+						tfjsonpath.New("summary").AtMapKey("total"),
+						knownvalue.Int64Exact(1),
+					),
+					statecheck.ExpectKnownValue(
+						"data.forward_intent_checks.filtered",
+						tfjsonpath.New("checks[0].id"),
+						knownvalue.StringExact("check-2"),
+					),
 					statecheck.ExpectKnownValue(
 						"data.forward_nqe_query.latest_acl",
 						tfjsonpath.New("items_json[0]"),
@@ -195,3 +216,108 @@ data "forward_nqe_query" "latest_acl" {
 		},
 	})
 }
+
+func TestSummarizeChecks(t *testing.T) {
+	t.Parallel()
+
+	summary := summarizeChecks([]fwdclient.CheckResult{
+		{ID: "1", Status: "PASS", Priority: "HIGH"},
+		{ID: "2", Status: "FAIL", Priority: "HIGH"},
+		{ID: "3", Status: "NOT_RUN", Priority: "LOW"},
+		{ID: "4", Status: "TIMEOUT"},
+	})
+
+	if summary.Total.ValueInt64() != 4 || summary.Pass.ValueInt64() != 1 || summary.Fail.ValueInt64() != 1 ||
+		summary.Error.ValueInt64() != 0 || summary.Timeout.ValueInt64() != 1 {
+		t.Fatalf("unexpected counts: %+v", summary)
+	}
+	if len(summary.ByPriority) != 2 || summary.ByPriority["HIGH"].ValueInt64() != 2 || summary.ByPriority["LOW"].ValueInt64() != 1 {
+		t.Fatalf("unexpected priority counts: %v", summary.ByPriority)
+	}
+}
+
+func TestScoreChecks(t *testing.T) {
+	t.Parallel()
+
+	checks := []fwdclient.CheckResult{
+		{ID: "1", Status: "PASS", Priority: "HIGH"},
+		{ID: "2", Status: "FAIL", Priority: "LOW"},
+		{ID: "3", Status: "ERROR"},
+		{ID: "4", Status: "NOT_RUN", Priority: "HIGH"},
+	}
+
+	if score := scoreChecks(checks, defaultPriorityWeights); score.ValueFloat64() != 100*10.0/12 {
+		t.Fatalf("unexpected default score: %v", score)
+	}
+	if score := scoreChecks(checks, map[string]int64{"HIGH": 2, "LOW": 0, "": 2}); score.ValueFloat64() != 50 {
+		t.Fatalf("unexpected weighted score: %v", score)
+	}
+	if score := scoreChecks(checks[3:], defaultPriorityWeights); !score.IsNull() {
+		t.Fatalf("expected a null score without results, got %v", score)
+	}
+}
+
+func TestCountCheckStatuses(t *testing.T) {
+	t.Parallel()
+
+	counts := countCheckStatuses([]fwdclient.CheckResult{
+		{ID: "1", Status: "PASS"},
+		{ID: "2", Status: "NOT_RUN"},
+		{ID: "3", Status: "NOT_RUN"},
+		{ID: "4", Status: "DISABLED"},
+		{ID: "5"},
+	})
+
+	want := map[string]int64{"PASS": 1, "NOT_RUN": 2, "DISABLED": 1}
+	if len(counts) != len(want) {
+		t.Fatalf("unexpected statuses: %v", counts)
+	}
+	for status, count := range want {
+		if counts[status].ValueInt64() != count {
+			t.Fatalf("expected %d %s checks, got %v", count, status, counts[status])
+		}
+	}
+}
+
+func TestMatchesCheckFilters(t *testing.T) {
+	t.Parallel()
+
+	checks := []fwdclient.CheckResult{
+		{ID: "1", Name: "netops-bgp", Creator: "alice", CreatorID: "u-1"},
+		{ID: "2", Name: "netops-ospf", Creator: "bob", CreatorID: "u-2"},
+		{ID: "3", Name: "secops-acl", Creator: "alice", CreatorID: "u-1"},
+	}
+
+	tests := map[string]struct {
+		pattern string
+		creator string
+		want    []string
+	}{
+		"none":          {want: []string{"1", "2", "3"}},
+		"name":          {pattern: "^netops-", want: []string{"1", "2"}},
+		"creator name":  {creator: "alice", want: []string{"1", "3"}},
+		"creator id":    {creator: "u-2", want: []string{"2"}},
+		"name and user": {pattern: "^netops-", creator: "alice", want: []string{"1"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var pattern *regexp.Regexp
+			if tc.pattern != "" {
+				pattern = regexp.MustCompile(tc.pattern)
+			}
+
+			var got []string
+			for _, check := range checks {
+				if matchesCheckFilters(check, pattern, tc.creator) {
+					got = append(got, check.ID)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// deviceDeltaFields are the inventoryFields compared between snapshots: hostname,
+// serial number, and OS version.
+var deviceDeltaFields = []string{"display_name", "serial_number", "os_version"}
+
+var _ datasource.DataSource = &DeviceDeltaDataSource{}
+
+// NewDeviceDeltaDataSource instantiates the device delta data source.
+func NewDeviceDeltaDataSource() datasource.DataSource {
+	return &DeviceDeltaDataSource{}
+}
+
+// DeviceDeltaDataSource compares the device inventories of two snapshots.
+type DeviceDeltaDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type deviceDeltaDataSourceModel struct {
+	Profile        types.String      `tfsdk:"profile"`
+	BaseSnapshotID types.String      `tfsdk:"base_snapshot_id"`
+	SnapshotID     types.String      `tfsdk:"snapshot_id"`
+	AddedDevices   []types.String    `tfsdk:"added_devices"`
+	RemovedDevices []types.String    `tfsdk:"removed_devices"`
+	ChangedDevices []deviceDeltaItem `tfsdk:"changed_devices"`
+	HasChanges     types.Bool        `tfsdk:"has_changes"`
+}
+
+type deviceDeltaItem struct {
+	Name      types.String `tfsdk:"name"`
+	Attribute types.String `tfsdk:"attribute"`
+	Before    types.String `tfsdk:"before"`
+	After     types.String `tfsdk:"after"`
+}
+
+func (d *DeviceDeltaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_delta"
+}
+
+func (d *DeviceDeltaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List the devices added, removed, and changed between two snapshots, comparing hostname " +
+			"(`display_name`), `serial_number`, and `os_version`. A lighter-weight alternative to NQE diffs for " +
+			"inventory drift alerts; pair it with `forward_snapshot_lineage` to compare a snapshot against its parent.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"base_snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Earlier snapshot the inventory is compared against.",
+				Required:            true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Later snapshot whose inventory is compared.",
+				Required:            true,
+			},
+			"added_devices": schema.ListAttribute{
+				MarkdownDescription: "Names of devices in `snapshot_id` but not in `base_snapshot_id`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"removed_devices": schema.ListAttribute{
+				MarkdownDescription: "Names of devices in `base_snapshot_id` but not in `snapshot_id`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"changed_devices": schema.ListAttribute{
+				MarkdownDescription: "Compared attributes that differ between the snapshots, one entry per device attribute, " +
+					"sorted by device name and then attribute.",
+				Computed: true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"name":      types.StringType,
+						"attribute": types.StringType,
+						"before":    types.StringType,
+						"after":     types.StringType,
+					},
+				},
+			},
+			"has_changes": schema.BoolAttribute{
+				MarkdownDescription: "True when any device was added, removed, or changed.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DeviceDeltaDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *DeviceDeltaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data deviceDeltaDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	before, err := providerData.Client.ListSnapshotDevices(ctx, data.BaseSnapshotID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Base Device Inventory", err)
+		return
+	}
+	after, err := providerData.Client.ListSnapshotDevices(ctx, data.SnapshotID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Device Inventory", err)
+		return
+	}
+
+	diff := diffDevices(before, after)
+
+	data.AddedDevices = stringValues(diff.Extra)
+	data.RemovedDevices = stringValues(diff.Missing)
+	data.ChangedDevices = make([]deviceDeltaItem, 0, len(diff.Mismatched))
+	for _, change := range diff.Mismatched {
+		data.ChangedDevices = append(data.ChangedDevices, deviceDeltaItem{
+			Name:      types.StringValue(change.Name),
+			Attribute: types.StringValue(change.Attribute),
+			Before:    types.StringValue(change.Expected),
+			After:     types.StringValue(change.Actual),
+		})
+	}
+	data.HasChanges = types.BoolValue(len(diff.Missing) > 0 || len(diff.Extra) > 0 || len(diff.Mismatched) > 0)
+
+	tflog.Trace(ctx, "compared forward device inventories", map[string]any{
+		"added":   len(diff.Extra),
+		"removed": len(diff.Missing),
+		"changed": len(diff.Mismatched),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// diffDevices compares two inventories by treating the earlier one as the expected
+// inventory: missing devices were removed, extra devices were added, and mismatches
+// hold the before and after values of deviceDeltaFields.
+func diffDevices(before, after []fwdclient.Device) inventoryDiff {
+	expected := make([]map[string]string, 0, len(before))
+	for _, device := range before {
+		values := map[string]string{"name": device.Name}
+		for _, field := range deviceDeltaFields {
+			values[field] = inventoryFields[field](device)
+		}
+		expected = append(expected, values)
+	}
+	return diffInventory(expected, after)
+}
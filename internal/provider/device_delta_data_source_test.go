@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestDiffDevices(t *testing.T) {
+	t.Parallel()
+
+	before := []fwdclient.Device{
+		{Name: "leaf1", DisplayName: "leaf1.dc1", SerialNumber: "SN1", OSVersion: "4.30"},
+		{Name: "leaf2", OSVersion: "4.30", Vendor: "ARISTA"},
+		{Name: "spine1"},
+	}
+	after := []fwdclient.Device{
+		{Name: "leaf1", DisplayName: "leaf1.dc1", SerialNumber: "SN9", OSVersion: "4.31"},
+		{Name: "leaf2", OSVersion: "4.30", Vendor: "CISCO"},
+		{Name: "border1"},
+	}
+
+	got := diffDevices(before, after)
+	want := inventoryDiff{
+		Missing: []string{"spine1"},
+		Extra:   []string{"border1"},
+		Mismatched: []inventoryMismatch{
+			{Name: "leaf1", Attribute: "os_version", Expected: "4.30", Actual: "4.31"},
+			{Name: "leaf1", Attribute: "serial_number", Expected: "SN1", Actual: "SN9"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestAccDeviceDeltaDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-2", ParentSnapshotID: "snap-1"})
+	server.AddDevice("snap-1", fwdclient.Device{Name: "leaf1", OSVersion: "4.30"})
+	server.AddDevice("snap-1", fwdclient.Device{Name: "leaf2"})
+	server.AddDevice("snap-2", fwdclient.Device{Name: "leaf1", OSVersion: "4.31"})
+	server.AddDevice("snap-2", fwdclient.Device{Name: "leaf3"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_device_delta" "test" {
+  base_snapshot_id = "snap-1"
+  snapshot_id      = "snap-2"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_device_delta.test", "has_changes", "true"),
+					resource.TestCheckResourceAttr("data.forward_device_delta.test", "added_devices.0", "leaf3"),
+					resource.TestCheckResourceAttr("data.forward_device_delta.test", "removed_devices.0", "leaf2"),
+					resource.TestCheckResourceAttr("data.forward_device_delta.test", "changed_devices.0.before", "4.30"),
+					resource.TestCheckResourceAttr("data.forward_device_delta.test", "changed_devices.0.after", "4.31"),
+				),
+			},
+		},
+	})
+}
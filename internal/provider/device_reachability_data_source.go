@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &DeviceReachabilityDataSource{}
+
+// NewDeviceReachabilityDataSource instantiates the device reachability data source.
+func NewDeviceReachabilityDataSource() datasource.DataSource {
+	return &DeviceReachabilityDataSource{}
+}
+
+// DeviceReachabilityDataSource asks the collector whether it can reach and log in to a
+// device before the device is added to collection.
+type DeviceReachabilityDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type deviceReachabilityDataSourceModel struct {
+	Profile         types.String `tfsdk:"profile"`
+	NetworkID       types.String `tfsdk:"network_id"`
+	Host            types.String `tfsdk:"host"`
+	Port            types.Int64  `tfsdk:"port"`
+	DeviceType      types.String `tfsdk:"device_type"`
+	CliCredentialID types.String `tfsdk:"cli_credential_id"`
+	Collector       types.String `tfsdk:"collector"`
+	Reachable       types.Bool   `tfsdk:"reachable"`
+	LoginSucceeded  types.Bool   `tfsdk:"login_succeeded"`
+	Success         types.Bool   `tfsdk:"success"`
+	Status          types.String `tfsdk:"status"`
+	Message         types.String `tfsdk:"message"`
+}
+
+func (d *DeviceReachabilityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_reachability"
+}
+
+func (d *DeviceReachabilityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Test whether the collector can reach a device and log in with a credential, without collecting " +
+			"from it, so onboarding pipelines can verify a device before changing device sources. The test runs on every read.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "Network whose collector runs the test. Defaults to the provider `network_id` when omitted.",
+				Optional:            true,
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "Hostname or IP address of the device.",
+				Required:            true,
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "Port to connect to. Defaults to the standard port of the device type.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"device_type": schema.StringAttribute{
+				MarkdownDescription: "Forward device type, such as `cisco_ios_ssh`. Detected by the collector when omitted.",
+				Optional:            true,
+			},
+			"cli_credential_id": schema.StringAttribute{
+				MarkdownDescription: "CLI credential to log in with. Only reachability is tested when omitted.",
+				Optional:            true,
+			},
+			"collector": schema.StringAttribute{
+				MarkdownDescription: "Name of the collector that runs the test, for networks with several collectors.",
+				Optional:            true,
+			},
+			"reachable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the device answered the collector.",
+				Computed:            true,
+			},
+			"login_succeeded": schema.BoolAttribute{
+				MarkdownDescription: "Whether the credential was accepted. Null when no login was attempted.",
+				Computed:            true,
+			},
+			"success": schema.BoolAttribute{
+				MarkdownDescription: "Whether the test passed as a whole, for use in `precondition` blocks.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Test outcome: SUCCESS, UNREACHABLE, AUTH_FAILED, or TIMEOUT.",
+				Computed:            true,
+			},
+			"message": schema.StringAttribute{
+				MarkdownDescription: "Detail reported by the collector, such as the connection or authentication error.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DeviceReachabilityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *DeviceReachabilityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data deviceReachabilityDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networkID := providerData.NetworkID
+	if !data.NetworkID.IsNull() && !data.NetworkID.IsUnknown() {
+		networkID = data.NetworkID.ValueString()
+	}
+
+	if networkID == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("network_id"),
+			"Missing Network ID",
+			"Network ID must be specified either on the provider or data source.",
+		)
+		return
+	}
+
+	result, err := providerData.Client.TestDeviceConnectivity(ctx, networkID, fwdclient.ConnectivityTestRequest{
+		Host:            data.Host.ValueString(),
+		Port:            int(data.Port.ValueInt64()),
+		DeviceType:      stringValue(data.DeviceType),
+		CliCredentialID: stringValue(data.CliCredentialID),
+		CollectorName:   stringValue(data.Collector),
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Test Device Connectivity", err)
+		return
+	}
+
+	data.NetworkID = types.StringValue(networkID)
+	data.Reachable = boolPointerOrNull(result.PingSucceeded)
+	if result.PingSucceeded == nil {
+		// Logging in proves the device is reachable even when no ping was sent.
+		data.Reachable = types.BoolValue(result.LoginSucceeded != nil && *result.LoginSucceeded)
+	}
+	data.LoginSucceeded = boolPointerOrNull(result.LoginSucceeded)
+	data.Success = types.BoolValue(result.Status == fwdclient.ConnectivitySucceeded)
+	data.Status = stringOrNull(result.Status)
+	data.Message = stringOrNull(result.Message)
+
+	tflog.Trace(ctx, "tested forward device connectivity", map[string]any{"host": data.Host.ValueString(), "status": result.Status})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
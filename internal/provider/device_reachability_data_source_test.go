@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAccDeviceReachabilityDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	reachable, rejected := true, false
+	server.SetConnectivityResult("10.0.0.5", fwdclient.ConnectivityTestResult{
+		Status:         "AUTH_FAILED",
+		PingSucceeded:  &reachable,
+		LoginSucceeded: &rejected,
+		Message:        "authentication failed",
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_device_reachability" "known" {
+  host              = "10.0.0.5"
+  cli_credential_id = "cred-1"
+}
+
+data "forward_device_reachability" "unknown" {
+  host = "10.0.0.6"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_device_reachability.known", "reachable", "true"),
+					resource.TestCheckResourceAttr("data.forward_device_reachability.known", "login_succeeded", "false"),
+					resource.TestCheckResourceAttr("data.forward_device_reachability.known", "success", "false"),
+					resource.TestCheckResourceAttr("data.forward_device_reachability.known", "status", "AUTH_FAILED"),
+					resource.TestCheckResourceAttr("data.forward_device_reachability.unknown", "reachable", "false"),
+					resource.TestCheckResourceAttr("data.forward_device_reachability.unknown", "status", "UNREACHABLE"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &DeviceStateDataSource{}
+
+// deviceStateTables maps the table names accepted in tables to API table names, in the
+// order they are returned when tables is not set.
+var deviceStateTables = []struct {
+	Name     string
+	APITable string
+}{
+	{"arp", fwdclient.DeviceStateARP},
+	{"mac", fwdclient.DeviceStateMAC},
+	{"routes", fwdclient.DeviceStateRoutes},
+	{"interface_counters", fwdclient.DeviceStateInterfaceCounters},
+}
+
+// NewDeviceStateDataSource instantiates the device state data source.
+func NewDeviceStateDataSource() datasource.DataSource {
+	return &DeviceStateDataSource{}
+}
+
+// DeviceStateDataSource retrieves parsed state tables of a single device.
+type DeviceStateDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type deviceStateDataSourceModel struct {
+	ID         types.String            `tfsdk:"id"`
+	Profile    types.String            `tfsdk:"profile"`
+	SnapshotID types.String            `tfsdk:"snapshot_id"`
+	Device     types.String            `tfsdk:"device"`
+	Tables     []types.String          `tfsdk:"tables"`
+	StateJSON  map[string]types.String `tfsdk:"state_json"`
+}
+
+func (d *DeviceStateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_state"
+}
+
+func (d *DeviceStateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieve parsed state tables (ARP, MAC, routes, interface counters) of a device in a snapshot as JSON. " +
+			"Decode a table with `jsondecode` to use its rows.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier in the form `snapshot_id/device`.",
+				Computed:            true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot the state is read from.",
+				Required:            true,
+			},
+			"device": schema.StringAttribute{
+				MarkdownDescription: "Device name.",
+				Required:            true,
+			},
+			"tables": schema.ListAttribute{
+				MarkdownDescription: "State tables to retrieve: `arp`, `mac`, `routes`, and `interface_counters`. Defaults to all of them.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(deviceStateTableNames()...)),
+				},
+			},
+			"state_json": schema.MapAttribute{
+				MarkdownDescription: "Rows of each requested table encoded as a JSON array, keyed by table name.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *DeviceStateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *DeviceStateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data deviceStateDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tables := deviceStateTableNames()
+	if data.Tables != nil {
+		tables = make([]string, 0, len(data.Tables))
+		for _, table := range data.Tables {
+			tables = append(tables, table.ValueString())
+		}
+	}
+
+	snapshotID, device := data.SnapshotID.ValueString(), data.Device.ValueString()
+	data.StateJSON = make(map[string]types.String, len(tables))
+	for _, table := range tables {
+		rows, err := providerData.Client.GetDeviceState(ctx, snapshotID, device, deviceStateAPITable(table))
+		if err != nil {
+			addAPIError(&resp.Diagnostics, fmt.Sprintf("Unable to Retrieve %s State of %s", table, device), err)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, rows); err != nil {
+			resp.Diagnostics.AddError("Invalid Device State", fmt.Sprintf("The %s table of %s is not valid JSON: %s", table, device, err))
+			return
+		}
+		data.StateJSON[table] = types.StringValue(buf.String())
+	}
+
+	data.ID = types.StringValue(snapshotID + "/" + device)
+
+	tflog.Trace(ctx, "retrieved forward device state", map[string]any{"device": device, "tables": len(tables)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func deviceStateTableNames() []string {
+	names := make([]string, 0, len(deviceStateTables))
+	for _, table := range deviceStateTables {
+		names = append(names, table.Name)
+	}
+	return names
+}
+
+func deviceStateAPITable(name string) string {
+	for _, table := range deviceStateTables {
+		if table.Name == name {
+			return table.APITable
+		}
+	}
+	return name
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAccDeviceStateDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.SetDeviceState("snap-1", "leaf1", fwdclient.DeviceStateARP, `[ {"ip": "10.0.0.2", "mac": "00:00:5e:00:53:01"} ]`)
+	server.SetDeviceState("snap-1", "leaf1", fwdclient.DeviceStateInterfaceCounters, `[{"interface": "Ethernet1", "inErrors": 0}]`)
+
+	config := func(tables string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_device_state" "test" {
+  snapshot_id = "snap-1"
+  device      = "leaf1"
+  tables      = %s
+}
+`, server.URL, tables)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["arp", "interface_counters"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_device_state.test", "id", "snap-1/leaf1"),
+					resource.TestCheckResourceAttr("data.forward_device_state.test", "state_json.arp", `[{"ip":"10.0.0.2","mac":"00:00:5e:00:53:01"}]`),
+					resource.TestCheckResourceAttr("data.forward_device_state.test", "state_json.%", "2"),
+				),
+			},
+			{
+				Config:      config(`["bgp"]`),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
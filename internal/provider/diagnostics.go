@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// addAPIError appends an error diagnostic for a failed SDK call. Authentication and
+// authorization failures are rewritten into actionable guidance rather than echoing
+// the raw response body.
+func addAPIError(diags *diag.Diagnostics, summary string, err error) {
+	addAPIErrorWithPaths(diags, summary, err, nil)
+}
+
+// addAPIErrorWithPaths behaves like addAPIError, additionally attaching field-level
+// problems reported by the API to attributes. fields maps the top-level API request
+// field (for example "definition") to the Terraform attribute that supplies it.
+func addAPIErrorWithPaths(diags *diag.Diagnostics, summary string, err error, fields map[string]path.Path) {
+	var circuitErr *fwdclient.CircuitOpenError
+	if errors.As(err, &circuitErr) {
+		addCircuitOpenError(diags, summary, circuitErr)
+		return
+	}
+
+	var apiErr *fwdclient.APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	switch {
+	case fwdclient.IsUnauthorized(err):
+		detail := fmt.Sprintf("The Forward API rejected the configured API key while %s (HTTP 401). "+
+			"Verify that `api_key` (or the `FORWARD_API_KEY` environment variable) is correct and has not been revoked.", apiErr.Operation)
+		if apiErr.Message != "" {
+			detail += "\n\nForward reported: " + apiErr.Message
+		}
+		diags.AddError(summary+": Authentication Failed", detail)
+	case fwdclient.IsForbidden(err):
+		detail := fmt.Sprintf("The API key has insufficient permissions for %s (HTTP 403).", apiErr.Operation)
+		if apiErr.Permission != "" {
+			detail = fmt.Sprintf("The API key lacks the %s permission, which is required for %s (HTTP 403).", apiErr.Permission, apiErr.Operation)
+		}
+		detail += " Grant the missing permission to the API key's user or role in Forward Enterprise, or use a different key."
+		if apiErr.Message != "" {
+			detail += "\n\nForward reported: " + apiErr.Message
+		}
+		diags.AddError(summary+": Permission Denied", detail)
+	case apiErr.Message == "" && len(apiErr.Details) == 0:
+		diags.AddError(summary, err.Error())
+	default:
+		var general []string
+		for _, d := range apiErr.Details {
+			if attrPath, ok := fields[apiFieldRoot(d.Field)]; ok && d.Field != "" {
+				diags.AddAttributeError(attrPath, summary, fmt.Sprintf("Forward rejected %s: %s", d.Field, d.Message))
+				continue
+			}
+			if d.Field != "" {
+				general = append(general, fmt.Sprintf("- %s: %s", d.Field, d.Message))
+			} else if d.Message != "" {
+				general = append(general, "- "+d.Message)
+			}
+		}
+
+		detail := fmt.Sprintf("Forward returned HTTP %d while %s", apiErr.StatusCode, apiErr.Operation)
+		if apiErr.Code != "" {
+			detail += fmt.Sprintf(" (code %s)", apiErr.Code)
+		}
+		detail += "."
+		if apiErr.Message != "" {
+			detail += "\n\n" + apiErr.Message
+		}
+		if len(general) > 0 {
+			detail += "\n\n" + strings.Join(general, "\n")
+		}
+		diags.AddError(summary, detail)
+	}
+}
+
+// apiFieldRoot returns the top-level request field from a dotted or indexed field
+// reference such as "definition.queryId" or "tags[0]".
+func apiFieldRoot(field string) string {
+	if i := strings.IndexAny(field, ".["); i >= 0 {
+		return field[:i]
+	}
+	return field
+}
+
+// addCircuitOpenError reports a request the client refused to send because Forward
+// Enterprise kept failing. The outage is described in full once; every other operation
+// cut short by it gets a brief pointer so the plan output is not flooded with repeats.
+func addCircuitOpenError(diags *diag.Diagnostics, summary string, err *fwdclient.CircuitOpenError) {
+	if !err.First {
+		diags.AddError(summary+": Forward Enterprise Unavailable",
+			"Skipped because Forward Enterprise is unavailable; see the first \"Forward Enterprise Unavailable\" error for details.")
+		return
+	}
+
+	detail := fmt.Sprintf("The last %d requests to Forward Enterprise failed, so the provider stopped sending requests "+
+		"rather than retrying every resource against an unavailable appliance.", err.Failures)
+	if err.LastErr != nil {
+		detail += "\n\nLast error: " + err.LastErr.Error()
+	}
+	detail += "\n\nCheck that `base_url` is reachable and the appliance is healthy, then re-run Terraform. " +
+		"Raise `circuit_breaker_threshold`, or set it to 0, to keep retrying instead."
+	diags.AddError(summary+": Forward Enterprise Unavailable", detail)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAddAPIError_CircuitOpen(t *testing.T) {
+	t.Parallel()
+
+	open := &fwdclient.CircuitOpenError{Failures: 10, LastErr: errors.New("received status 503"), RetryAt: time.Now(), First: true}
+
+	var diags diag.Diagnostics
+	addAPIError(&diags, "Error reading network", fmt.Errorf("get network request failed: %w", open))
+	if len(diags) != 1 || diags[0].Summary() != "Error reading network: Forward Enterprise Unavailable" {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, "last 10 requests") || !strings.Contains(detail, "received status 503") {
+		t.Fatalf("expected the first diagnostic to describe the outage, got %q", detail)
+	}
+
+	later := *open
+	later.First = false
+	diags = nil
+	addAPIError(&diags, "Error reading snapshot", &later)
+	if len(diags) != 1 || strings.Contains(diags[0].Detail(), "received status 503") {
+		t.Fatalf("expected a brief pointer to the first diagnostic, got %v", diags)
+	}
+}
+
+func TestAddAPIError_Forbidden(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		permission string
+		want       string
+	}{
+		"reported permission": {"CHECK_EDIT", "lacks the CHECK_EDIT permission, which is required for creating check"},
+		"no permission":       {"", "has insufficient permissions for creating check"},
+	}
+	for name, tc := range cases {
+		var diags diag.Diagnostics
+		addAPIError(&diags, "Error creating intent check", &fwdclient.APIError{
+			StatusCode: 403,
+			Operation:  "creating check",
+			Method:     "POST",
+			Path:       "/api/snapshots/snap-1/checks",
+			Permission: tc.permission,
+		})
+		if len(diags) != 1 || diags[0].Summary() != "Error creating intent check: Permission Denied" {
+			t.Fatalf("%s: unexpected diagnostics: %v", name, diags)
+		}
+		if detail := diags[0].Detail(); !strings.Contains(detail, tc.want) {
+			t.Errorf("%s: expected %q in %q", name, tc.want, detail)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Provider settings resolve in order of precedence: the attribute in the provider
+// block, then the first of its FORWARD_ environment variables that is set, then the
+// default. Profiles override the resolved top-level values.
+
+// stringSetting returns the configured value, or the first non-empty environment variable.
+func stringSetting(value types.String, envs ...string) string {
+	if !value.IsNull() && !value.IsUnknown() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+	for _, env := range envs {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// boolSetting returns the configured value, the environment variable, or fallback.
+func boolSetting(value types.Bool, env string, fallback bool, attr string, diags *diag.Diagnostics) bool {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueBool()
+	}
+	raw := strings.TrimSpace(os.Getenv(env))
+	if raw == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		diags.AddAttributeError(path.Root(attr), "Invalid Environment Variable",
+			fmt.Sprintf("%s must be \"true\" or \"false\", got %q.", env, raw))
+		return fallback
+	}
+	return parsed
+}
+
+// intSetting returns the configured value, the environment variable, or fallback. The
+// schema validates configured values; min applies the same bound to the environment.
+func intSetting(value types.Int64, env string, fallback, min int64, attr string, diags *diag.Diagnostics) int64 {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64()
+	}
+	raw := strings.TrimSpace(os.Getenv(env))
+	if raw == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || parsed < min {
+		diags.AddAttributeError(path.Root(attr), "Invalid Environment Variable",
+			fmt.Sprintf("%s must be an integer of at least %d, got %q.", env, min, raw))
+		return fallback
+	}
+	return parsed
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSettingPrecedence(t *testing.T) {
+	t.Setenv(envProxyURL, "http://env-proxy:3128")
+	t.Setenv(envInsecure, "true")
+	t.Setenv(envMaxRetries, "5")
+	t.Setenv(envAPIKeyPrimary, "")
+	t.Setenv(envAPIKeyLegacy, "legacy")
+
+	var diags diag.Diagnostics
+	if got := stringSetting(types.StringValue("http://config-proxy:3128"), envProxyURL); got != "http://config-proxy:3128" {
+		t.Fatalf("expected configuration to win, got %q", got)
+	}
+	if got := stringSetting(types.StringNull(), envProxyURL); got != "http://env-proxy:3128" {
+		t.Fatalf("expected the environment variable, got %q", got)
+	}
+	if got := stringSetting(types.StringNull(), envAPIKeyPrimary, envAPIKeyLegacy); got != "legacy" {
+		t.Fatalf("expected the first set environment variable, got %q", got)
+	}
+	if got := stringSetting(types.StringNull(), envCACertFile); got != "" {
+		t.Fatalf("expected no value, got %q", got)
+	}
+
+	if got := boolSetting(types.BoolValue(false), envInsecure, false, "insecure", &diags); got {
+		t.Fatal("expected configuration to win over FORWARD_INSECURE")
+	}
+	if got := boolSetting(types.BoolNull(), envInsecure, false, "insecure", &diags); !got {
+		t.Fatal("expected FORWARD_INSECURE to apply")
+	}
+	if got := boolSetting(types.BoolNull(), envValidateCredentials, true, "validate_credentials", &diags); !got {
+		t.Fatal("expected the default when unset")
+	}
+
+	if got := intSetting(types.Int64Value(0), envMaxRetries, 3, 0, "max_retries", &diags); got != 0 {
+		t.Fatalf("expected configuration to win, got %d", got)
+	}
+	if got := intSetting(types.Int64Null(), envMaxRetries, 3, 0, "max_retries", &diags); got != 5 {
+		t.Fatalf("expected FORWARD_MAX_RETRIES, got %d", got)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestSettingInvalidEnvironment(t *testing.T) {
+	t.Setenv(envInsecure, "sometimes")
+	t.Setenv(envMaxConcurrentRequests, "0")
+
+	var diags diag.Diagnostics
+	boolSetting(types.BoolNull(), envInsecure, false, "insecure", &diags)
+	intSetting(types.Int64Null(), envMaxConcurrentRequests, 0, 1, "max_concurrent_requests", &diags)
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected both invalid values to be reported, got %v", diags)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &ExistingChecksDataSource{}
+
+// resourceNameInvalid matches characters that are not allowed in Terraform resource names.
+var resourceNameInvalid = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// NewExistingChecksDataSource instantiates the existing checks data source.
+func NewExistingChecksDataSource() datasource.DataSource {
+	return &ExistingChecksDataSource{}
+}
+
+// ExistingChecksDataSource renders import blocks and configuration for checks that are
+// not yet managed by Terraform.
+type ExistingChecksDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type existingChecksDataSourceModel struct {
+	Profile      types.String        `tfsdk:"profile"`
+	SnapshotID   types.String        `tfsdk:"snapshot_id"`
+	Checks       []existingCheckItem `tfsdk:"checks"`
+	ImportBlocks types.String        `tfsdk:"import_blocks"`
+	Config       types.String        `tfsdk:"config"`
+}
+
+type existingCheckItem struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	ResourceName types.String `tfsdk:"resource_name"`
+	ImportBlock  types.String `tfsdk:"import_block"`
+	Config       types.String `tfsdk:"config"`
+}
+
+func (d *ExistingChecksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_existing_checks"
+}
+
+func (d *ExistingChecksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generate `import` blocks and `forward_intent_check` configuration for every check defined on a " +
+			"snapshot, to bring existing checks under Terraform management. Write `import_blocks` and `config` to a `.tf` " +
+			"file (for example with `local_file` or `terraform console`) and run `terraform plan`.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot whose checks are listed.",
+				Required:            true,
+			},
+			"checks": schema.ListAttribute{
+				MarkdownDescription: "Checks on the snapshot with the generated Terraform for each.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"id":            types.StringType,
+						"name":          types.StringType,
+						"resource_name": types.StringType,
+						"import_block":  types.StringType,
+						"config":        types.StringType,
+					},
+				},
+			},
+			"import_blocks": schema.StringAttribute{
+				MarkdownDescription: "`import` blocks for every check, separated by blank lines.",
+				Computed:            true,
+			},
+			"config": schema.StringAttribute{
+				MarkdownDescription: "`forward_intent_check` resource blocks for every check, separated by blank lines.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ExistingChecksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ExistingChecksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data existingChecksDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshotID := data.SnapshotID.ValueString()
+	checks, err := providerData.Client.ListSnapshotChecks(ctx, snapshotID, fwdclient.CheckListOptions{})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Intent Checks", err)
+		return
+	}
+
+	profile := attrStringValue(data.Profile)
+	used := map[string]bool{}
+	imports := make([]string, 0, len(checks))
+	configs := make([]string, 0, len(checks))
+	data.Checks = make([]existingCheckItem, 0, len(checks))
+	for _, check := range checks {
+		name := uniqueResourceName(checkResourceName(check), used)
+		importBlock := checkImportBlock(name, profile, snapshotID, check.ID)
+		config := checkResourceConfig(name, profile, snapshotID, check)
+
+		imports = append(imports, importBlock)
+		configs = append(configs, config)
+		data.Checks = append(data.Checks, existingCheckItem{
+			ID:           types.StringValue(check.ID),
+			Name:         stringOrNull(check.Name),
+			ResourceName: types.StringValue(name),
+			ImportBlock:  types.StringValue(importBlock),
+			Config:       types.StringValue(config),
+		})
+	}
+
+	data.ImportBlocks = types.StringValue(strings.Join(imports, "\n"))
+	data.Config = types.StringValue(strings.Join(configs, "\n"))
+
+	tflog.Trace(ctx, "generated import blocks for forward checks", map[string]any{"count": len(checks)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checkResourceName derives a Terraform resource name from the check name, falling
+// back to the check ID.
+func checkResourceName(check fwdclient.CheckResult) string {
+	source := check.Name
+	if strings.TrimSpace(source) == "" {
+		source = check.ID
+	}
+
+	name := strings.Trim(resourceNameInvalid.ReplaceAllString(strings.ToLower(source), "_"), "_")
+	switch {
+	case name == "":
+		return "check"
+	case name[0] >= '0' && name[0] <= '9':
+		return "check_" + name
+	default:
+		return name
+	}
+}
+
+// uniqueResourceName suffixes repeated names with a counter so each block has a
+// distinct address.
+func uniqueResourceName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func checkImportBlock(name, profile, snapshotID, checkID string) string {
+	id := snapshotID + "/" + checkID
+	if profile != "" {
+		id = profile + "/" + id
+	}
+	return fmt.Sprintf("import {\n  to = forward_intent_check.%s\n  id = %s\n}\n", name, hclString(id))
+}
+
+// checkResourceConfig renders a forward_intent_check block whose values match what an
+// import of the check stores, so the first plan after import is empty.
+func checkResourceConfig(name, profile, snapshotID string, check fwdclient.CheckResult) string {
+	var attributes [][2]string
+	add := func(key, value string) {
+		attributes = append(attributes, [2]string{key, value})
+	}
+
+	if profile != "" {
+		add("profile", hclString(profile))
+	}
+	add("snapshot_id", hclString(snapshotID))
+	if definition := checkDefinitionString(check.Definition); !definition.IsNull() {
+		add("definition_json", hclString(definition.ValueString()))
+	}
+	if check.Name != "" {
+		add("name", hclString(check.Name))
+	}
+	if check.Description != "" {
+		add("description", hclString(check.Description))
+	}
+	if check.Note != "" {
+		add("note", hclString(check.Note))
+	}
+	if check.Enabled != nil {
+		add("enabled", fmt.Sprintf("%t", *check.Enabled))
+	}
+	if check.PerfMonitoringEnabled != nil {
+		add("perf_monitoring_enabled", fmt.Sprintf("%t", *check.PerfMonitoringEnabled))
+	}
+	if check.Priority != "" {
+		add("priority", hclString(check.Priority))
+	}
+	if checkTags := withoutManagedByTag(check.Tags); len(checkTags) > 0 {
+		tags := make([]string, 0, len(checkTags))
+		for _, tag := range checkTags {
+			tags = append(tags, hclString(tag))
+		}
+		add("tags", "["+strings.Join(tags, ", ")+"]")
+	}
+
+	// Align equals signs the way terraform fmt does.
+	width := 0
+	for _, attribute := range attributes {
+		width = max(width, len(attribute[0]))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "resource \"forward_intent_check\" %q {\n", name)
+	for _, attribute := range attributes {
+		fmt.Fprintf(&b, "  %-*s = %s\n", width, attribute[0], attribute[1])
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// hclString quotes value as an HCL string literal. JSON escapes are valid HCL escapes;
+// template sequences are escaped so values are taken literally.
+func hclString(value string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+
+	quoted := strings.TrimSuffix(buf.String(), "\n")
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestCheckResourceConfig(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	check := fwdclient.CheckResult{
+		ID:         "C-1",
+		Name:       "MTU ${consistency}",
+		Enabled:    &enabled,
+		Priority:   "HIGH",
+		Tags:       []string{"core"},
+		Definition: json.RawMessage(`{ "queryId": "FQ_1", "checkType": "NQE" }`),
+	}
+
+	want := `resource "forward_intent_check" "mtu_consistency" {
+  snapshot_id     = "snap-1"
+  definition_json = "{\"queryId\":\"FQ_1\",\"checkType\":\"NQE\"}"
+  name            = "MTU $${consistency}"
+  enabled         = true
+  priority        = "HIGH"
+  tags            = ["core"]
+}
+`
+	if got := checkResourceConfig("mtu_consistency", "", "snap-1", check); got != want {
+		t.Fatalf("unexpected config:\n%s", got)
+	}
+
+	wantImport := "import {\n  to = forward_intent_check.mtu_consistency\n  id = \"lab/snap-1/C-1\"\n}\n"
+	if got := checkImportBlock("mtu_consistency", "lab", "snap-1", "C-1"); got != wantImport {
+		t.Fatalf("unexpected import block:\n%s", got)
+	}
+}
+
+func TestCheckResourceNames(t *testing.T) {
+	t.Parallel()
+
+	used := map[string]bool{}
+	for _, tc := range []struct {
+		check fwdclient.CheckResult
+		want  string
+	}{
+		{fwdclient.CheckResult{ID: "C-1", Name: "MTU consistency"}, "mtu_consistency"},
+		{fwdclient.CheckResult{ID: "C-2", Name: "MTU  Consistency!"}, "mtu_consistency_2"},
+		{fwdclient.CheckResult{ID: "C-3", Name: "10G links"}, "check_10g_links"},
+		{fwdclient.CheckResult{ID: "C-4"}, "c_4"},
+		{fwdclient.CheckResult{ID: "!!"}, "check"},
+	} {
+		if got := uniqueResourceName(checkResourceName(tc.check), used); got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.check.ID, tc.want, got)
+		}
+	}
+}
+
+func TestAccExistingChecksDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	id := server.AddCheck("snap-1", fwdclient.CheckResult{Name: "Reachability", Definition: json.RawMessage(`{"checkType":"NQE"}`)})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_existing_checks" "test" {
+  snapshot_id = "snap-1"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_existing_checks.test", "checks.#", "1"),
+					resource.TestCheckResourceAttr("data.forward_existing_checks.test", "checks.0.resource_name", "reachability"),
+					resource.TestCheckResourceAttr("data.forward_existing_checks.test", "import_blocks",
+						fmt.Sprintf("import {\n  to = forward_intent_check.reachability\n  id = \"snap-1/%s\"\n}\n", id)),
+				),
+			},
+		},
+	})
+}
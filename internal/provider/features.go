@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// feature is an API capability that older Forward Enterprise releases do not serve.
+type feature struct {
+	// name describes the feature in errors, naming the resource or attribute that uses it.
+	name string
+	// minVersion is the first release that serves the endpoints the feature calls.
+	minVersion string
+}
+
+var (
+	featureL2PathSearch        = feature{name: "L2 path search (forward_l2_path)", minVersion: "23.2"}
+	featureNQECommits          = feature{name: "Committing NQE queries", minVersion: "23.8"}
+	featureTunnels             = feature{name: "Tunnel listing (forward_tunnels)", minVersion: "23.10"}
+	featureCheckOwner          = feature{name: "Intent check ownership transfer (forward_check_owner)", minVersion: "24.4"}
+	featureCheckExecution      = feature{name: "On-demand intent check execution (forward_check_execution)", minVersion: "24.7"}
+	featureOverlays            = feature{name: "SD-WAN overlays (forward_overlays)", minVersion: "24.10"}
+	featureCloudSecurityGroups = feature{name: "Cloud security groups (forward_cloud_security_groups)", minVersion: "25.2"}
+	featureCloudRoutes         = feature{name: "Cloud routing (forward_cloud_routes)", minVersion: "25.2"}
+	featureKubernetes          = feature{name: "Kubernetes inventory (forward_k8s_inventory)", minVersion: "25.4"}
+)
+
+// versionCache remembers the version an appliance reports so feature checks cost at
+// most one request per configured instance. Failed lookups are not cached.
+type versionCache struct {
+	mu      sync.Mutex
+	version string
+	hits    atomic.Int64
+}
+
+func (c *versionCache) get(ctx context.Context, client *fwdclient.Client) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.version != "" {
+		c.hits.Add(1)
+		return c.version, nil
+	}
+	version, err := client.GetVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.version = version.Version
+	if c.version == "" {
+		c.version = version.Release
+	}
+	return c.version, nil
+}
+
+func (c *versionCache) set(version *fwdclient.Version) {
+	if version == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version = version.Version
+	if c.version == "" {
+		c.version = version.Release
+	}
+}
+
+// requireFeature reports whether the appliance is recent enough for f, adding an error
+// attributed to attr (or to the whole configuration when attr is empty) when it is not.
+// An unknown or unparsable version lets the request proceed so the API has the final say.
+func (d *ForwardProviderData) requireFeature(ctx context.Context, f feature, attr path.Path, diags *diag.Diagnostics) bool {
+	if d.versions == nil {
+		return true
+	}
+
+	version, err := d.versions.get(ctx, d.Client)
+	if err != nil || version == "" {
+		tflog.Debug(ctx, "unable to determine forward version for feature check", map[string]any{"feature": f.name, "error": fmt.Sprint(err)})
+		return true
+	}
+	cmp, err := compareVersions(version, f.minVersion)
+	if err != nil || cmp >= 0 {
+		return true
+	}
+
+	summary := "Unsupported Forward Enterprise Version"
+	detail := fmt.Sprintf("%s requires Forward Enterprise %s or later, but the instance reports version %s. "+
+		"Upgrade Forward Enterprise or remove the configuration that uses it.", f.name, f.minVersion, version)
+	if attr.Equal(path.Empty()) {
+		diags.AddError(summary, detail)
+	} else {
+		diags.AddAttributeError(attr, summary, detail)
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestRequireFeature(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.SetVersion(fwdclient.Version{Release: "24.2.0-07", Version: "24.2.0"})
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	data := &ForwardProviderData{Client: client, versions: &versionCache{}}
+
+	var diags diag.Diagnostics
+	if !data.requireFeature(context.Background(), featureL2PathSearch, path.Empty(), &diags) || diags.HasError() {
+		t.Fatalf("expected 24.2 to support L2 path search, got %v", diags)
+	}
+
+	if data.requireFeature(context.Background(), featureCheckOwner, path.Root("owner"), &diags) {
+		t.Fatal("expected 24.2 to lack check ownership transfer")
+	}
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail(), "requires Forward Enterprise 24.4 or later") ||
+		!strings.Contains(diags[0].Detail(), "reports version 24.2.0") {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if got := server.RequestCount(http.MethodGet, "/api/version"); got != 1 {
+		t.Fatalf("expected the version to be fetched once, got %d requests", got)
+	}
+}
+
+func TestRequireFeatureUnknownVersion(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.InjectFault(fakeforward.Fault{PathPrefix: "/api/version", Status: http.StatusForbidden, Times: 1})
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	data := &ForwardProviderData{Client: client, versions: &versionCache{}}
+
+	var diags diag.Diagnostics
+	if !data.requireFeature(context.Background(), featureOverlays, path.Empty(), &diags) || diags.HasError() {
+		t.Fatalf("expected an unknown version to let the request proceed, got %v", diags)
+	}
+
+	// The failed lookup is not cached, so the next check learns the version.
+	server.SetVersion(fwdclient.Version{Version: "24.1"})
+	if data.requireFeature(context.Background(), featureOverlays, path.Empty(), &diags) {
+		t.Fatal("expected 24.1 to lack overlays")
+	}
+}
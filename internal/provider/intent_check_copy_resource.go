@@ -0,0 +1,354 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ resource.Resource = &IntentCheckCopyResource{}
+var _ resource.ResourceWithConfigValidators = &IntentCheckCopyResource{}
+
+// IntentCheckCopyResource copies an existing intent check onto another snapshot.
+type IntentCheckCopyResource struct {
+	providerData *ForwardProviderData
+}
+
+// IntentCheckCopyResourceModel maps Terraform schema data.
+type IntentCheckCopyResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Profile          types.String `tfsdk:"profile"`
+	SourceProfile    types.String `tfsdk:"source_profile"`
+	SourceSnapshotID types.String `tfsdk:"source_snapshot_id"`
+	SourceCheckID    types.String `tfsdk:"source_check_id"`
+	SnapshotID       types.String `tfsdk:"snapshot_id"`
+	Persistent       types.Bool   `tfsdk:"persistent"`
+
+	Name           types.String `tfsdk:"name"`
+	DefinitionJSON types.String `tfsdk:"definition_json"`
+	Priority       types.String `tfsdk:"priority"`
+	Tags           types.List   `tfsdk:"tags"`
+	Status         types.String `tfsdk:"status"`
+	NumViolations  types.Int64  `tfsdk:"num_violations"`
+}
+
+func NewIntentCheckCopyResource() resource.Resource {
+	return &IntentCheckCopyResource{}
+}
+
+func (r *IntentCheckCopyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_intent_check_copy"
+}
+
+func (r *IntentCheckCopyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Copy an existing Forward Enterprise intent check onto another snapshot, preserving its definition and tags. " +
+			"Useful for promoting validated checks from a staging network to production.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier assigned by Forward Enterprise for the copied intent check.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_profile": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Name of the provider `profiles` entry identifying the Forward instance the source check is read " +
+					"from, for copying checks between instances. Defaults to `profile`, which selects the instance the copy is created on.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_snapshot_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Snapshot identifier that owns the check being copied.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_check_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the intent check to copy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Destination snapshot identifier. May belong to a different network than the source snapshot.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"persistent": schema.BoolAttribute{
+				MarkdownDescription: "Whether the copied check should persist to future snapshots of the destination network. " +
+					"Changing it updates the copy in place.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the copied intent check.",
+			},
+			"definition_json": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Definition copied from the source check, encoded as JSON.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"priority": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Priority copied from the source check.",
+			},
+			"tags": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Tags copied from the source check.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Last known Forward Enterprise status for the copied check.",
+			},
+			"num_violations": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of violations detected by the copied check.",
+			},
+		},
+	}
+}
+
+func (r *IntentCheckCopyResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validateSnapshotReferencesOn(func() *ForwardProviderData { return r.providerData }, []string{"source_profile", "profile"}, "source_snapshot_id"),
+		validateSnapshotReferences(func() *ForwardProviderData { return r.providerData }, "snapshot_id"),
+	}
+}
+
+func (r *IntentCheckCopyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *IntentCheckCopyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan IntentCheckCopyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	sourceProfile := plan.SourceProfile
+	if sourceProfile.IsNull() {
+		sourceProfile = plan.Profile
+	}
+	sourceData, diags := r.providerData.forProfile(sourceProfile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source, err := sourceData.Client.GetSnapshotCheck(ctx, plan.SourceSnapshotID.ValueString(), plan.SourceCheckID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading source intent check", err)
+		return
+	}
+
+	reqBody, diags := copyCheckRequest(&source.CheckResult)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	reqBody.Tags = withManagedByTag(reqBody.Tags)
+
+	result, err := providerData.Client.AddSnapshotCheck(ctx, plan.SnapshotID.ValueString(), reqBody, boolPointer(plan.Persistent))
+	providerData.checks.invalidate(plan.SnapshotID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error copying intent check", err)
+		return
+	}
+
+	plan.ID = types.StringValue(result.ID)
+	plan.DefinitionJSON = types.StringValue(string(source.Definition))
+	setCheckCopyState(&plan, result)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *IntentCheckCopyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state IntentCheckCopyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := providerData.readSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error reading copied intent check", err)
+		return
+	}
+
+	setCheckCopyState(&state, result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *IntentCheckCopyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan, state IntentCheckCopyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only persistence can be changed in place; every other attribute forces replacement.
+	result, err := providerData.Client.UpdateSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString(),
+		fwdclient.CheckUpdateRequest{Persistent: boolPointer(plan.Persistent)})
+	providerData.checks.invalidate(state.SnapshotID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating copied intent check", err)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.DefinitionJSON = state.DefinitionJSON
+	setCheckCopyState(&plan, result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *IntentCheckCopyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state IntentCheckCopyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := providerData.Client.DeactivateSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString())
+	providerData.checks.invalidate(state.SnapshotID.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		addAPIError(&resp.Diagnostics, "Error deleting copied intent check", err)
+	}
+}
+
+// copyCheckRequest builds a create payload that reproduces the source check.
+func copyCheckRequest(source *fwdclient.CheckResult) (fwdclient.NewCheckRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var definition fwdclient.CheckDefinition
+	if len(source.Definition) == 0 {
+		diags.AddError("Missing Source Definition", fmt.Sprintf("Intent check %s did not return a definition to copy.", source.ID))
+		return fwdclient.NewCheckRequest{}, diags
+	}
+	if err := json.Unmarshal(source.Definition, &definition); err != nil {
+		diags.AddError("Invalid Source Definition", err.Error())
+		return fwdclient.NewCheckRequest{}, diags
+	}
+
+	return fwdclient.NewCheckRequest{
+		Definition:            definition,
+		Description:           source.Description,
+		Enabled:               source.Enabled,
+		Name:                  source.Name,
+		Note:                  source.Note,
+		PerfMonitoringEnabled: source.PerfMonitoringEnabled,
+		Priority:              source.Priority,
+		Tags:                  source.Tags,
+	}, diags
+}
+
+func setCheckCopyState(model *IntentCheckCopyResourceModel, result *fwdclient.CheckResult) {
+	if result == nil {
+		return
+	}
+
+	model.Name = stringOrNull(result.Name)
+	model.Priority = stringOrNull(result.Priority)
+	model.Tags = stringSliceToList(withoutManagedByTag(result.Tags))
+	model.Status = stringOrNull(result.Status)
+	model.NumViolations = int64PointerOrNull(result.NumViolations)
+	if len(result.Definition) > 0 {
+		model.DefinitionJSON = types.StringValue(string(result.Definition))
+	}
+}
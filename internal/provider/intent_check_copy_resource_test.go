@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestIntentCheckCopyResourceCreate(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-staging", fwdclient.Snapshot{ID: "snap-staging"})
+	server.AddSnapshot("net-prod", fwdclient.Snapshot{ID: "snap-prod"})
+	server.AddCheck("snap-staging", fwdclient.CheckResult{
+		ID:         "check-1",
+		Name:       "Reachability",
+		Priority:   "HIGH",
+		Tags:       []string{"staging"},
+		Definition: json.RawMessage(`{"checkType":"NQE","queryId":"FQ_test"}`),
+	})
+
+	providerFactory := providerserver.NewProtocol6WithError(New("test")())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"forward": providerFactory,
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: intentCheckCopyTestConfig(server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("forward_intent_check_copy.test", "id"),
+					resource.TestCheckResourceAttr("forward_intent_check_copy.test", "name", "Reachability"),
+					resource.TestCheckResourceAttr("forward_intent_check_copy.test", "tags.0", "staging"),
+				),
+			},
+		},
+	})
+}
+
+func TestIntentCheckCopyResourceSourceProfile(t *testing.T) {
+	t.Parallel()
+
+	staging := fakeforward.New(t)
+	staging.AddSnapshot("net-staging", fwdclient.Snapshot{ID: "snap-staging"})
+	staging.AddCheck("snap-staging", fwdclient.CheckResult{
+		ID:         "check-1",
+		Name:       "Reachability",
+		Definition: json.RawMessage(`{"checkType":"NQE","queryId":"FQ_test"}`),
+	})
+	prod := fakeforward.New(t)
+	prod.AddSnapshot("net-prod", fwdclient.Snapshot{ID: "snap-prod"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "prod-token"
+  network_id = "net-prod"
+
+  profiles = {
+    staging = {
+      base_url = %q
+      api_key  = "staging-token"
+    }
+  }
+}
+
+resource "forward_intent_check_copy" "test" {
+  source_profile     = "staging"
+  source_snapshot_id = "snap-staging"
+  source_check_id    = "check-1"
+  snapshot_id        = "snap-prod"
+}
+`, prod.URL, staging.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_intent_check_copy.test", "name", "Reachability"),
+					func(s *terraform.State) error {
+						id := s.RootModule().Resources["forward_intent_check_copy.test"].Primary.ID
+						if _, ok := prod.Check("snap-prod", id); !ok {
+							return fmt.Errorf("copy %s was not created on the destination instance", id)
+						}
+						if _, ok := staging.Check("snap-prod", id); ok {
+							return fmt.Errorf("copy %s was created on the source instance", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func intentCheckCopyTestConfig(host string) string {
+	return fmt.Sprintf(`
+provider "forward" {
+  base_url   = "%s"
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_intent_check_copy" "test" {
+  source_snapshot_id = "snap-staging"
+  source_check_id    = "check-1"
+  snapshot_id        = "snap-prod"
+}
+`, host)
+}
@@ -1,32 +1,41 @@
 // Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
 
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
-
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"regexp"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	"github.com/forwardnetworks/terraform-provider-forward/internal/sdk"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 var _ resource.Resource = &IntentCheckResource{}
 var _ resource.ResourceWithImportState = &IntentCheckResource{}
+var _ resource.ResourceWithConfigValidators = &IntentCheckResource{}
+var _ resource.ResourceWithModifyPlan = &IntentCheckResource{}
 
 // IntentCheckResource manages Forward Enterprise intent checks bound to a snapshot.
 type IntentCheckResource struct {
@@ -36,20 +45,44 @@ type IntentCheckResource struct {
 // IntentCheckResourceModel maps Terraform schema data.
 type IntentCheckResourceModel struct {
 	ID                    types.String `tfsdk:"id"`
+	Profile               types.String `tfsdk:"profile"`
 	SnapshotID            types.String `tfsdk:"snapshot_id"`
 	Persistent            types.Bool   `tfsdk:"persistent"`
 	DefinitionJSON        types.String `tfsdk:"definition_json"`
+	DefinitionIgnorePaths types.List   `tfsdk:"definition_ignore_paths"`
 	Name                  types.String `tfsdk:"name"`
+	Description           types.String `tfsdk:"description"`
 	Note                  types.String `tfsdk:"note"`
 	Enabled               types.Bool   `tfsdk:"enabled"`
 	PerfMonitoringEnabled types.Bool   `tfsdk:"perf_monitoring_enabled"`
 	Priority              types.String `tfsdk:"priority"`
 	Tags                  types.List   `tfsdk:"tags"`
+	OnFailWebhook         types.String `tfsdk:"on_fail_webhook"`
+	IgnoreExecution       types.Bool   `tfsdk:"ignore_execution_fields"`
+	Adopt                 types.Bool   `tfsdk:"adopt"`
 
 	Status            types.String `tfsdk:"status"`
 	NumViolations     types.Int64  `tfsdk:"num_violations"`
 	ExecutionDateMs   types.Int64  `tfsdk:"execution_date_millis"`
 	ExecutionDuration types.Int64  `tfsdk:"execution_duration_millis"`
+	UIURL             types.String `tfsdk:"ui_url"`
+}
+
+// intentCheckAPIFields maps check request fields to the attributes that supply them.
+var intentCheckAPIFields = map[string]path.Path{
+	"definition":            path.Root("definition_json"),
+	"name":                  path.Root("name"),
+	"description":           path.Root("description"),
+	"note":                  path.Root("note"),
+	"enabled":               path.Root("enabled"),
+	"perfMonitoringEnabled": path.Root("perf_monitoring_enabled"),
+	"priority":              path.Root("priority"),
+	"tags":                  path.Root("tags"),
+}
+
+// intentCheckWebhookAPIFields maps check webhook fields to the attribute that sets them.
+var intentCheckWebhookAPIFields = map[string]path.Path{
+	"url": path.Root("on_fail_webhook"),
 }
 
 func NewIntentCheckResource() resource.Resource {
@@ -64,9 +97,13 @@ func (r *IntentCheckResource) Schema(ctx context.Context, req resource.SchemaReq
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manage Forward Enterprise intent checks against a specific snapshot.",
 		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Identifier assigned by Forward Enterprise for the intent check.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"snapshot_id": schema.StringAttribute{
 				Required:            true,
@@ -76,24 +113,45 @@ func (r *IntentCheckResource) Schema(ctx context.Context, req resource.SchemaReq
 				},
 			},
 			"persistent": schema.BoolAttribute{
-				Optional:            true,
-				Computed:            true,
-				MarkdownDescription: "Whether the intent check should persist to future snapshots.",
-				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the intent check should persist to future snapshots. Changing it updates the check " +
+					"in place, so an ad-hoc snapshot check can be promoted to a persistent one.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
 			},
 			"definition_json": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Raw JSON payload describing the Forward intent check definition (as expected by the Forward API).",
+				Required: true,
+				MarkdownDescription: "Raw JSON payload describing the Forward intent check definition (as expected by the Forward API). " +
+					"Refreshed from Forward Enterprise, so edits made outside Terraform replace the check. Key order, formatting, " +
+					"and fields the server adds with default values are not treated as changes. Definitions of the `NQE`, `Existential`, " +
+					"`Isolation`, and `Reachability` check types are validated at plan time.",
+				Validators: []validator.String{
+					checkDefinitionValidator{},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"definition_ignore_paths": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Paths within `definition_json` whose stored values are not compared on refresh, for fields " +
+					"Forward Enterprise rewrites, such as `filters.from.location`. Segments are separated by dots, and `*` matches " +
+					"any key or list index.",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(
+						regexp.MustCompile(`^(\$\.)?[^.\s]+(\.[^.\s]+)*$`), "must be dot-separated keys such as filters.from.location",
+					)),
+				},
+			},
 			"name": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Optional human readable name for the intent check.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				MarkdownDescription: "Optional human readable name for the intent check. Renaming updates the check in place.",
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Description of what the check verifies and why, so its documentation lives with the check " +
+					"in Forward Enterprise. Changing it updates the check in place.",
 			},
 			"note": schema.StringAttribute{
 				Optional:            true,
@@ -101,15 +159,24 @@ func (r *IntentCheckResource) Schema(ctx context.Context, req resource.SchemaReq
 			},
 			"enabled": schema.BoolAttribute{
 				Optional:            true,
-				MarkdownDescription: "Whether the intent check should be enabled when created.",
+				Computed:            true,
+				MarkdownDescription: "Whether the intent check is enabled. Defaults to the Forward Enterprise default. Changing it updates the check in place.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"perf_monitoring_enabled": schema.BoolAttribute{
 				Optional:            true,
-				MarkdownDescription: "Enable performance monitoring (supported for existential checks only).",
+				Computed:            true,
+				MarkdownDescription: "Enable performance monitoring (supported for existential checks only). Changing it updates the check in place.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"priority": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Intent check priority (NOT_SET, LOW, MEDIUM, HIGH).",
+				Computed:            true,
+				MarkdownDescription: "Intent check priority (NOT_SET, LOW, MEDIUM, HIGH). Defaults to the provider `default_check_priority`.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -118,29 +185,98 @@ func (r *IntentCheckResource) Schema(ctx context.Context, req resource.SchemaReq
 				Optional:            true,
 				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Tags assigned to the intent check.",
-				Default:             listdefault.StaticValue(types.ListNull(types.StringType)),
+				MarkdownDescription: "Tags assigned to the intent check. Defaults to the provider `default_check_tags`. The `managed-by:terraform` tag the provider adds on create is not listed.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"on_fail_webhook": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: "URL Forward Enterprise calls whenever the check fails, for example an Ansible Tower job " +
+					"template callback, so failures trigger remediation automatically. Marked sensitive because such URLs " +
+					"often embed a token. The webhook is only refreshed while set, so webhooks of imported checks are not adopted.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "must be an http or https URL"),
+				},
+			},
+			"ignore_execution_fields": schema.BoolAttribute{
+				MarkdownDescription: "Leave `status`, `num_violations`, `execution_date_millis`, and `execution_duration_millis` " +
+					"null instead of recording the latest execution, keeping results that change with every run out of state. " +
+					"Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"adopt": adoptAttribute("the check"),
 			"status": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Last known Forward Enterprise status for the check.",
+				MarkdownDescription: "Last known Forward Enterprise status for the check. Kept from state when planning updates.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"num_violations": schema.Int64Attribute{
 				Computed:            true,
-				MarkdownDescription: "Number of violations detected by the check.",
+				MarkdownDescription: "Number of violations detected by the check. Kept from state when planning updates.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"execution_date_millis": schema.Int64Attribute{
 				Computed:            true,
-				MarkdownDescription: "Execution timestamp (milliseconds since epoch).",
+				MarkdownDescription: "Execution timestamp (milliseconds since epoch). Kept from state when planning updates.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"execution_duration_millis": schema.Int64Attribute{
 				Computed:            true,
-				MarkdownDescription: "Execution duration in milliseconds.",
+				MarkdownDescription: "Execution duration in milliseconds. Kept from state when planning updates.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"ui_url": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Link to the check in the Forward Enterprise UI, built from the provider `base_url` and the " +
+					"network, snapshot, and check identifiers, for deep-linking reviewers from outputs and notifications.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
 }
 
+func (r *IntentCheckResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validateSnapshotReferences(func() *ForwardProviderData { return r.providerData }, "snapshot_id"),
+	}
+}
+
+// ModifyPlan applies the provider check defaults to new checks and plans the execution
+// fields as null when ignore_execution_fields is set.
+func (r *IntentCheckResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planCheckDefaults(ctx, r.providerData, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan IntentCheckResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !plan.IgnoreExecution.ValueBool() {
+		return
+	}
+
+	clearCheckExecution(&plan)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 func (r *IntentCheckResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -173,32 +309,51 @@ func (r *IntentCheckResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	definition, diags := parseCheckDefinition(plan.DefinitionJSON)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	reqBody := sdk.NewCheckRequest{
+	reqBody := fwdclient.NewCheckRequest{
 		Definition:            definition,
+		Description:           stringOrEmpty(plan.Description),
 		Enabled:               boolPointer(plan.Enabled),
 		Name:                  stringOrEmpty(plan.Name),
 		Note:                  stringOrEmpty(plan.Note),
 		PerfMonitoringEnabled: boolPointer(plan.PerfMonitoringEnabled),
 		Priority:              stringOrEmpty(plan.Priority),
-		Tags:                  stringList(plan.Tags),
+		Tags:                  withManagedByTag(stringList(plan.Tags)),
 	}
 
 	persistent := boolPointer(plan.Persistent)
 
-	result, err := r.providerData.Client.AddSnapshotCheck(ctx, plan.SnapshotID.ValueString(), reqBody, persistent)
+	result, err := providerData.Client.AddSnapshotCheck(ctx, plan.SnapshotID.ValueString(), reqBody, persistent)
+	providerData.checks.invalidate(plan.SnapshotID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating intent check", err.Error())
+		addAPIErrorWithPaths(&resp.Diagnostics, "Error creating intent check", err, intentCheckAPIFields)
 		return
 	}
 
 	plan.ID = types.StringValue(result.ID)
-	setCheckState(ctx, &plan, result)
+	setPlannedCheckState(ctx, &plan, result)
+	plan.UIURL = providerData.checkUIURL(ctx, plan.SnapshotID.ValueString(), result.ID)
+
+	if !plan.OnFailWebhook.IsNull() {
+		if err := setCheckWebhook(ctx, providerData.Client, plan.SnapshotID.ValueString(), result.ID, plan.OnFailWebhook); err != nil {
+			// Keep the check in state; the webhook is registered again on the next apply.
+			plan.OnFailWebhook = types.StringNull()
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			addAPIErrorWithPaths(&resp.Diagnostics, "Error registering intent check webhook", err, intentCheckWebhookAPIFields)
+			return
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -218,27 +373,94 @@ func (r *IntentCheckResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	result, err := r.providerData.Client.GetSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString())
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := providerData.readSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString())
 	if err != nil {
 		if isNotFoundError(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading intent check", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading intent check", err)
+		return
+	}
+
+	setCheckState(ctx, &state, result)
+	state.UIURL = providerData.checkUIURL(ctx, state.SnapshotID.ValueString(), state.ID.ValueString())
+
+	state.OnFailWebhook = readCheckWebhook(ctx, providerData.Client, state.SnapshotID.ValueString(), state.ID.ValueString(), state.OnFailWebhook, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	setCheckState(ctx, &state, &result.CheckResult)
+	// Imported checks have no configured definition yet and adopt the one stored in Forward.
+	ignore, diags := definitionIgnorePaths(ctx, state.DefinitionIgnorePaths)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.DefinitionJSON = refreshCheckDefinition(state.DefinitionJSON, result.Definition, ignore)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *IntentCheckResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// All mutable attributes require replacement. Nothing to do here.
-	var plan IntentCheckResourceModel
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan, state IntentCheckResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	copyCheckExecution(&plan, state)
+
+	// Every attribute except the definition and snapshot can be changed in place.
+	update, changed := intentCheckUpdateRequest(plan, state)
+
+	if changed || !plan.OnFailWebhook.Equal(state.OnFailWebhook) {
+		resp.Diagnostics.Append(providerData.requireManagedCheck(ctx, req.Private, state.SnapshotID.ValueString(), state.ID.ValueString(), plan.Adopt, "updated")...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if changed {
+		result, err := providerData.Client.UpdateSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString(), update)
+		providerData.checks.invalidate(state.SnapshotID.ValueString())
+		if err != nil {
+			addAPIErrorWithPaths(&resp.Diagnostics, "Error updating intent check", err, intentCheckAPIFields)
+			return
+		}
+		setPlannedCheckState(ctx, &plan, result)
+		// An update does not execute the check; keep the execution fields as planned.
+		copyCheckExecution(&plan, state)
+	}
+
+	if !plan.OnFailWebhook.Equal(state.OnFailWebhook) {
+		if err := setCheckWebhook(ctx, providerData.Client, state.SnapshotID.ValueString(), state.ID.ValueString(), plan.OnFailWebhook); err != nil {
+			addAPIErrorWithPaths(&resp.Diagnostics, "Error updating intent check webhook", err, intentCheckWebhookAPIFields)
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -257,24 +479,166 @@ func (r *IntentCheckResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := r.providerData.Client.DeactivateSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString())
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.requireManagedCheck(ctx, req.Private, state.SnapshotID.ValueString(), state.ID.ValueString(), state.Adopt, "deactivated")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.OnFailWebhook.IsNull() {
+		err := providerData.Client.DeleteCheckWebhook(ctx, state.SnapshotID.ValueString(), state.ID.ValueString())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error removing intent check webhook", err)
+			return
+		}
+	}
+
+	err := providerData.Client.DeactivateSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString())
+	providerData.checks.invalidate(state.SnapshotID.ValueString())
 	if err != nil && !isNotFoundError(err) {
-		resp.Diagnostics.AddError("Error deleting intent check", err.Error())
+		addAPIError(&resp.Diagnostics, "Error deleting intent check", err)
 	}
 }
 
 func (r *IntentCheckResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	parts := strings.Split(req.ID, "/")
+	if len(parts) == 3 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile"), parts[0])...)
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError("Invalid import format", "Use: snapshot_id/check_id or profile/snapshot_id/check_id")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("snapshot_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("persistent"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ignore_execution_fields"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("adopt"), false)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedCheckPrivateKey, []byte("true"))...)
+}
+
+// checkUpdateRequest returns the update applying the planned name and persistence, and
+// whether either changed.
+func checkUpdateRequest(planName, stateName types.String, planPersistent, statePersistent types.Bool) (fwdclient.CheckUpdateRequest, bool) {
+	var update fwdclient.CheckUpdateRequest
+	if !planName.Equal(stateName) {
+		name := stringOrEmpty(planName)
+		update.Name = &name
+	}
+	if !planPersistent.Equal(statePersistent) {
+		update.Persistent = boolPointer(planPersistent)
+	}
+	return update, update.Name != nil || update.Persistent != nil
+}
+
+// intentCheckUpdateRequest returns the update applying every planned change to the
+// check's mutable fields, and whether there is any.
+func intentCheckUpdateRequest(plan, state IntentCheckResourceModel) (fwdclient.CheckUpdateRequest, bool) {
+	update, changed := checkUpdateRequest(plan.Name, state.Name, plan.Persistent, state.Persistent)
+	if !plan.Description.Equal(state.Description) {
+		description := stringOrEmpty(plan.Description)
+		update.Description = &description
+		changed = true
+	}
+	if !plan.Note.Equal(state.Note) {
+		note := stringOrEmpty(plan.Note)
+		update.Note = &note
+		changed = true
+	}
+	if !plan.Priority.IsUnknown() && !plan.Priority.Equal(state.Priority) {
+		priority := stringOrEmpty(plan.Priority)
+		update.Priority = &priority
+		changed = true
+	}
+	if !plan.Tags.IsUnknown() && !plan.Tags.Equal(state.Tags) {
+		update.Tags = withManagedByTag(stringList(plan.Tags))
+		changed = true
+	}
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() && !plan.Enabled.Equal(state.Enabled) {
+		update.Enabled = boolPointer(plan.Enabled)
+		changed = true
+	}
+	if !plan.PerfMonitoringEnabled.IsNull() && !plan.PerfMonitoringEnabled.IsUnknown() && !plan.PerfMonitoringEnabled.Equal(state.PerfMonitoringEnabled) {
+		update.PerfMonitoringEnabled = boolPointer(plan.PerfMonitoringEnabled)
+		changed = true
+	}
+	return update, changed
+}
+
+// readCheckWebhook returns the failure webhook to store after reading the check. Only
+// checks with a webhook in state are looked up, so refreshing checks without one costs no
+// extra request. A removed webhook is cleared; when the webhook endpoint is forbidden or
+// not supported by the appliance, the prior value is kept with a warning.
+func readCheckWebhook(ctx context.Context, client *fwdclient.Client, snapshotID, checkID string, prior types.String, diags *diag.Diagnostics) types.String {
+	if prior.IsNull() || prior.IsUnknown() {
+		return prior
+	}
+
+	webhook, err := client.GetCheckWebhook(ctx, snapshotID, checkID)
+	switch {
+	case err == nil:
+		return types.StringValue(webhook.URL)
+	case isNotFoundError(err):
+		return types.StringNull()
+	case fwdclient.IsForbidden(err), fwdclient.IsNotSupported(err):
+		diags.AddWarning("Unable to Read Intent Check Webhook",
+			fmt.Sprintf("The webhook of check %s could not be read, so on_fail_webhook keeps its prior value: %s", checkID, err))
+		return prior
+	default:
+		addAPIError(diags, "Error reading intent check webhook", err)
+		return prior
+	}
+}
+
+// setCheckWebhook registers webhook as the check's failure webhook, or removes the
+// webhook when it is null.
+func setCheckWebhook(ctx context.Context, client *fwdclient.Client, snapshotID, checkID string, webhook types.String) error {
+	if webhook.IsNull() || webhook.IsUnknown() {
+		return client.DeleteCheckWebhook(ctx, snapshotID, checkID)
+	}
+	return client.SetCheckWebhook(ctx, snapshotID, checkID, fwdclient.CheckWebhook{URL: webhook.ValueString()})
 }
 
-func parseCheckDefinition(definition types.String) (sdk.CheckDefinition, diag.Diagnostics) {
+// checkUIURL links to a check in the Forward Enterprise UI. The network is looked up from
+// the snapshot and left out of the link when the lookup fails, as the link is only
+// informational.
+func (p *ForwardProviderData) checkUIURL(ctx context.Context, snapshotID, checkID string) types.String {
+	var networkID string
+	if p.snapshotStates != nil {
+		var err error
+		networkID, err = p.snapshotStates.network(ctx, p.Client, snapshotID)
+		if err != nil {
+			tflog.Debug(ctx, "unable to resolve snapshot network for check link", map[string]any{"snapshot_id": snapshotID, "error": err.Error()})
+		}
+	}
+	return types.StringValue(p.Client.CheckUIURL(networkID, snapshotID, checkID))
+}
+
+// checkDefinitionString compacts a definition returned by the API, matching the
+// definition_json emitted by forward_existing_checks.
+func checkDefinitionString(definition json.RawMessage) types.String {
+	var buf bytes.Buffer
+	if len(definition) == 0 || json.Compact(&buf, definition) != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(buf.String())
+}
+
+func parseCheckDefinition(definition types.String) (fwdclient.CheckDefinition, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if definition.IsNull() || definition.IsUnknown() {
 		diags.AddAttributeError(path.Root("definition_json"), "Missing Definition", "definition_json must be provided.")
 		return nil, diags
 	}
 
-	var payload sdk.CheckDefinition
+	var payload fwdclient.CheckDefinition
 	if err := json.Unmarshal([]byte(definition.ValueString()), &payload); err != nil {
 		diags.AddAttributeError(path.Root("definition_json"), "Invalid Definition JSON", err.Error())
 		return nil, diags
@@ -283,13 +647,14 @@ func parseCheckDefinition(definition types.String) (sdk.CheckDefinition, diag.Di
 	return payload, diags
 }
 
-func setCheckState(_ context.Context, model *IntentCheckResourceModel, result *sdk.CheckResult) {
+func setCheckState(_ context.Context, model *IntentCheckResourceModel, result *fwdclient.CheckResult) {
 	if result == nil {
 		return
 	}
 
 	model.Status = stringOrNull(result.Status)
 	model.Name = stringOrNull(result.Name)
+	model.Description = stringOrNull(result.Description)
 	model.Note = stringOrNull(result.Note)
 
 	if result.Enabled != nil {
@@ -304,7 +669,7 @@ func setCheckState(_ context.Context, model *IntentCheckResourceModel, result *s
 	}
 
 	model.Priority = stringOrNull(result.Priority)
-	model.Tags = stringSliceToList(result.Tags)
+	model.Tags = stringSliceToList(withoutManagedByTag(result.Tags))
 
 	if result.NumViolations != nil {
 		model.NumViolations = types.Int64Value(*result.NumViolations)
@@ -321,6 +686,52 @@ func setCheckState(_ context.Context, model *IntentCheckResourceModel, result *s
 	} else {
 		model.ExecutionDuration = types.Int64Null()
 	}
+	if model.IgnoreExecution.ValueBool() {
+		clearCheckExecution(model)
+	}
+}
+
+// setPlannedCheckState records a create or update response without overwriting the
+// planned values of model, which the API may not echo back; only attributes the plan
+// left unknown take the values Forward assigned.
+func setPlannedCheckState(ctx context.Context, model *IntentCheckResourceModel, result *fwdclient.CheckResult) {
+	planned := *model
+	setCheckState(ctx, model, result)
+
+	model.Name = planned.Name
+	model.Description = planned.Description
+	model.Note = planned.Note
+	if !planned.Priority.IsUnknown() {
+		model.Priority = planned.Priority
+	}
+	if !planned.Tags.IsUnknown() {
+		model.Tags = planned.Tags
+	}
+	if !planned.Enabled.IsUnknown() {
+		model.Enabled = planned.Enabled
+	}
+	if !planned.PerfMonitoringEnabled.IsUnknown() {
+		model.PerfMonitoringEnabled = planned.PerfMonitoringEnabled
+	}
+}
+
+// copyCheckExecution copies the fields describing the latest check execution from src,
+// or clears them when model ignores them.
+func copyCheckExecution(model *IntentCheckResourceModel, src IntentCheckResourceModel) {
+	model.Status = src.Status
+	model.NumViolations = src.NumViolations
+	model.ExecutionDateMs = src.ExecutionDateMs
+	model.ExecutionDuration = src.ExecutionDuration
+	if model.IgnoreExecution.ValueBool() {
+		clearCheckExecution(model)
+	}
+}
+
+func clearCheckExecution(model *IntentCheckResourceModel) {
+	model.Status = types.StringNull()
+	model.NumViolations = types.Int64Null()
+	model.ExecutionDateMs = types.Int64Null()
+	model.ExecutionDuration = types.Int64Null()
 }
 
 func boolPointer(value types.Bool) *bool {
@@ -365,9 +776,7 @@ func stringList(list types.List) []string {
 	return values
 }
 
+// isNotFoundError reports whether err represents a 404 returned by the Forward API.
 func isNotFoundError(err error) bool {
-	if err == nil {
-		return false
-	}
-	return errors.Is(err, context.Canceled) || strings.Contains(strings.ToLower(err.Error()), "not found") || strings.Contains(err.Error(), "404")
+	return fwdclient.IsNotFound(err)
 }
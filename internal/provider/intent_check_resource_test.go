@@ -0,0 +1,477 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestIntentCheckResourceRename(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+
+	providerFactory := providerserver.NewProtocol6WithError(New("test")())
+
+	var checkID string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"forward": providerFactory,
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: intentCheckTestConfig(server.URL, "Reachability"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_intent_check.test", "status", "PASS"),
+					resource.TestCheckResourceAttrWith("forward_intent_check.test", "id", func(value string) error {
+						checkID = value
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("forward_intent_check.test", "ui_url", func(value string) error {
+						if want := server.URL + "/?/checks?checkId=" + checkID + "&networkId=net-1&snapshotId=snap-1"; value != want {
+							return fmt.Errorf("expected ui_url %s, got %s", want, value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: intentCheckTestConfig(server.URL, "Reachability (renamed)"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_intent_check.test", "name", "Reachability (renamed)"),
+					resource.TestCheckResourceAttrWith("forward_intent_check.test", "id", func(value string) error {
+						if value != checkID {
+							return fmt.Errorf("expected rename to keep id %s, got %s", checkID, value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "forward_intent_check.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return "snap-1/" + checkID, nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccIntentCheckResourceWebhook(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+
+	config := func(webhook string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_intent_check" "test" {
+  snapshot_id     = "snap-1"
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+  on_fail_webhook = %s
+}
+`, server.URL, webhook)
+	}
+	checkWebhook := func(want string) resource.TestCheckFunc {
+		return func(state *terraform.State) error {
+			id := state.RootModule().Resources["forward_intent_check.test"].Primary.ID
+			webhook, ok := server.CheckWebhook(id)
+			switch {
+			case want == "" && ok:
+				return fmt.Errorf("expected no webhook, got %q", webhook.URL)
+			case want != "" && webhook.URL != want:
+				return fmt.Errorf("expected webhook %q, got %q", want, webhook.URL)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`"https://tower.example/api/v2/job_templates/7/callback/"`),
+				Check:  checkWebhook("https://tower.example/api/v2/job_templates/7/callback/"),
+			},
+			{
+				Config: config(`"https://tower.example/api/v2/job_templates/8/callback/"`),
+				Check:  checkWebhook("https://tower.example/api/v2/job_templates/8/callback/"),
+			},
+			{
+				Config: config("null"),
+				Check:  checkWebhook(""),
+			},
+		},
+	})
+}
+
+func TestReadCheckWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	checkID := server.AddCheck("snap-1", fwdclient.CheckResult{Name: "Webhook"})
+	client, err := fwdclient.NewClient(ctx, fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	webhookPath := "/api/snapshots/snap-1/checks/" + checkID + "/webhook"
+	prior := types.StringValue("https://tower.example/callback/")
+
+	var diags diag.Diagnostics
+	if got := readCheckWebhook(ctx, client, "snap-1", checkID, types.StringNull(), &diags); !got.IsNull() || diags.HasError() {
+		t.Fatalf("expected null without a lookup, got %s: %v", got, diags)
+	}
+	if count := server.RequestCount(http.MethodGet, webhookPath); count != 0 {
+		t.Fatalf("expected no webhook request for a check without one, got %d", count)
+	}
+
+	if got := readCheckWebhook(ctx, client, "snap-1", checkID, prior, &diags); !got.IsNull() || diags.HasError() {
+		t.Fatalf("expected a removed webhook to be cleared, got %s: %v", got, diags)
+	}
+
+	for _, status := range []int{http.StatusForbidden, http.StatusMethodNotAllowed, http.StatusNotImplemented} {
+		server.InjectFault(fakeforward.Fault{PathPrefix: webhookPath, Status: status, Times: 1})
+		var diags diag.Diagnostics
+		got := readCheckWebhook(ctx, client, "snap-1", checkID, prior, &diags)
+		if !got.Equal(prior) || diags.HasError() || diags.WarningsCount() != 1 {
+			t.Errorf("status %d: expected the prior webhook with a warning, got %s: %v", status, got, diags)
+		}
+	}
+
+	server.InjectFault(fakeforward.Fault{PathPrefix: webhookPath, Status: http.StatusBadRequest, Times: 1})
+	diags = nil
+	readCheckWebhook(ctx, client, "snap-1", checkID, prior, &diags)
+	if !diags.HasError() {
+		t.Fatalf("expected other failures to be reported")
+	}
+}
+
+func intentCheckTestConfig(host, name string) string {
+	return fmt.Sprintf(`
+provider "forward" {
+  base_url   = "%s"
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_intent_check" "test" {
+  snapshot_id     = "snap-1"
+  name            = %q
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+}
+`, host, name)
+}
+
+func TestAccIntentCheckResourceExecutionFields(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+
+	config := func(name string, ignore bool) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_intent_check" "test" {
+  snapshot_id             = "snap-1"
+  name                    = %q
+  definition_json         = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+  ignore_execution_fields = %t
+}
+`, server.URL, name, ignore)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Reachability", false),
+				Check:  resource.TestCheckResourceAttr("forward_intent_check.test", "status", "PASS"),
+			},
+			{
+				// Renaming keeps the execution fields known in the plan.
+				Config: config("Reachability (renamed)", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("forward_intent_check.test", tfjsonpath.New("status"), knownvalue.StringExact("PASS")),
+					},
+				},
+			},
+			{
+				Config: config("Reachability (renamed)", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("forward_intent_check.test", "status"),
+					resource.TestCheckNoResourceAttr("forward_intent_check.test", "num_violations"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIntentCheckResourcePersistentUpdate(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+
+	config := func(persistent bool) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_intent_check" "test" {
+  snapshot_id     = "snap-1"
+  name            = "Reachability"
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+  persistent      = %t
+}
+`, server.URL, persistent)
+	}
+
+	var checkID string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.TestCheckResourceAttrWith("forward_intent_check.test", "id", func(value string) error {
+					checkID = value
+					return nil
+				}),
+			},
+			{
+				// Promoting the check to persistent updates it in place.
+				Config: config(true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("forward_intent_check.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(*terraform.State) error {
+					if persistent, ok := server.CheckPersistent(checkID); !ok || !persistent {
+						return fmt.Errorf("expected check %s to be made persistent", checkID)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccIntentCheckResourceDescription(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+
+	config := func(description string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_intent_check" "test" {
+  snapshot_id     = "snap-1"
+  name            = "Reachability"
+  description     = %q
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+}
+`, server.URL, description)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Hosts in the DMZ reach the internet."),
+				Check:  resource.TestCheckResourceAttr("forward_intent_check.test", "description", "Hosts in the DMZ reach the internet."),
+			},
+			{
+				Config: config("DMZ hosts reach the internet through the edge firewalls."),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("forward_intent_check.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("forward_intent_check.test", "description", "DMZ hosts reach the internet through the edge firewalls."),
+			},
+		},
+	})
+}
+
+func TestAccIntentCheckResourceUpdateInPlace(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+
+	config := func(name, attributes string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_intent_check" "test" {
+  snapshot_id     = "snap-1"
+  name            = %q
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+%s
+}
+`, server.URL, name, attributes)
+	}
+	checkServer := func(note, priority string, enabled bool, tags ...string) resource.TestCheckFunc {
+		return func(state *terraform.State) error {
+			id := state.RootModule().Resources["forward_intent_check.test"].Primary.ID
+			check, ok := server.Check("snap-1", id)
+			switch {
+			case !ok:
+				return fmt.Errorf("check %s not found", id)
+			case check.Note != note || check.Priority != priority:
+				return fmt.Errorf("expected note %q and priority %q, got %q and %q", note, priority, check.Note, check.Priority)
+			case check.Enabled == nil || *check.Enabled != enabled:
+				return fmt.Errorf("expected enabled %t, got %v", enabled, check.Enabled)
+			case fmt.Sprint(check.Tags) != fmt.Sprint(tags):
+				return fmt.Errorf("expected tags %v, got %v", tags, check.Tags)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Reachability", `  note = "first"
+  priority = "LOW"
+  tags = ["dmz"]`),
+				Check: checkServer("first", "LOW", true, "dmz", managedByTag),
+			},
+			{
+				// Renaming together with every other mutable field updates in place.
+				Config: config("Reachability (renamed)", `  note = "second"
+  priority = "HIGH"
+  tags = ["dmz", "pci"]
+  enabled = false
+  perf_monitoring_enabled = true`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("forward_intent_check.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					checkServer("second", "HIGH", false, "dmz", "pci", managedByTag),
+					resource.TestCheckResourceAttr("forward_intent_check.test", "note", "second"),
+					resource.TestCheckResourceAttr("forward_intent_check.test", "perf_monitoring_enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIntentCheckResourceDefinitionRefresh(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.SetCheckDefinitionDefaults(map[string]any{"strict": false})
+
+	config := fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_intent_check" "test" {
+  snapshot_id     = "snap-1"
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+}
+`, server.URL)
+
+	var checkID string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The server-added default must not produce a diff after refresh.
+				Config: config,
+				Check: resource.TestCheckResourceAttrWith("forward_intent_check.test", "id", func(value string) error {
+					checkID = value
+					return nil
+				}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				PreConfig: func() {
+					server.SetCheckDefinition("snap-1", checkID, json.RawMessage(`{"checkType":"NQE","queryId":"FQ_edited"}`))
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("forward_intent_check.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestCheckUIURLResolvesNetwork(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	data := &ForwardProviderData{Client: client, snapshotStates: newSnapshotStateCache()}
+
+	for i := 0; i < 3; i++ {
+		got := data.checkUIURL(context.Background(), "snap-1", "C-1").ValueString()
+		if want := server.URL + "/?/checks?checkId=C-1&networkId=net-1&snapshotId=snap-1"; got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	}
+	if n := server.RequestCount(http.MethodGet, "/api/snapshots/snap-1"); n != 1 {
+		t.Fatalf("expected the snapshot to be looked up once, got %d lookups", n)
+	}
+
+	// An unknown snapshot leaves the network out of the link.
+	if got, want := data.checkUIURL(context.Background(), "snap-2", "C-2").ValueString(), server.URL+"/?/checks?checkId=C-2&snapshotId=snap-2"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
@@ -6,20 +6,32 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	"github.com/forwardnetworks/terraform-provider-forward/internal/sdk"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 var _ datasource.DataSource = &IntentChecksDataSource{}
 
+// defaultPriorityWeights weigh checks by priority when computing `score`. Priorities
+// without a weight, including NOT_SET and checks without a priority, weigh 1.
+var defaultPriorityWeights = map[string]int64{
+	"HIGH":   10,
+	"MEDIUM": 5,
+	"LOW":    1,
+}
+
 // NewIntentChecksDataSource wires the Forward Enterprise intent checks data source.
 func NewIntentChecksDataSource() datasource.DataSource {
 	return &IntentChecksDataSource{}
@@ -31,16 +43,36 @@ type IntentChecksDataSource struct {
 }
 
 type intentChecksDataSourceModel struct {
+	Profile    types.String `tfsdk:"profile"`
 	SnapshotID types.String `tfsdk:"snapshot_id"`
 	Statuses   types.List   `tfsdk:"status"`
 	Priorities types.List   `tfsdk:"priority"`
 	Types      types.List   `tfsdk:"type"`
+	NameRegex  types.String `tfsdk:"name_regex"`
+	Creator    types.String `tfsdk:"creator"`
+
+	IncludeDefinitions types.Bool  `tfsdk:"include_definitions"`
+	Limit              types.Int64 `tfsdk:"limit"`
+	Offset             types.Int64 `tfsdk:"offset"`
+	PriorityWeights    types.Map   `tfsdk:"priority_weights"`
+
+	PassCount    types.Int64            `tfsdk:"pass_count"`
+	FailCount    types.Int64            `tfsdk:"fail_count"`
+	ErrorCount   types.Int64            `tfsdk:"error_count"`
+	TimeoutCount types.Int64            `tfsdk:"timeout_count"`
+	StatusCounts map[string]types.Int64 `tfsdk:"statuses"`
+	Summary      intentChecksSummary    `tfsdk:"summary"`
+	Score        types.Float64          `tfsdk:"score"`
+	Checks       []intentCheckItem      `tfsdk:"checks"`
+}
 
-	PassCount    types.Int64       `tfsdk:"pass_count"`
-	FailCount    types.Int64       `tfsdk:"fail_count"`
-	ErrorCount   types.Int64       `tfsdk:"error_count"`
-	TimeoutCount types.Int64       `tfsdk:"timeout_count"`
-	Checks       []intentCheckItem `tfsdk:"checks"`
+type intentChecksSummary struct {
+	Total      types.Int64            `tfsdk:"total"`
+	Pass       types.Int64            `tfsdk:"pass"`
+	Fail       types.Int64            `tfsdk:"fail"`
+	Error      types.Int64            `tfsdk:"error"`
+	Timeout    types.Int64            `tfsdk:"timeout"`
+	ByPriority map[string]types.Int64 `tfsdk:"by_priority"`
 }
 
 type intentCheckItem struct {
@@ -57,6 +89,7 @@ type intentCheckItem struct {
 	ExecutionDateMillis   types.Int64  `tfsdk:"execution_date_millis"`
 	ExecutionDuration     types.Int64  `tfsdk:"execution_duration_millis"`
 	Tags                  types.List   `tfsdk:"tags"`
+	DefinitionJSON        types.String `tfsdk:"definition_json"`
 }
 
 func (d *IntentChecksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -67,6 +100,7 @@ func (d *IntentChecksDataSource) Schema(ctx context.Context, req datasource.Sche
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Retrieve Forward Enterprise intent checks and their result status for a specific snapshot.",
 		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
 			"snapshot_id": schema.StringAttribute{
 				MarkdownDescription: "Snapshot identifier to query.",
 				Required:            true,
@@ -86,6 +120,45 @@ func (d *IntentChecksDataSource) Schema(ctx context.Context, req datasource.Sche
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return checks whose name matches this regular expression (RE2 syntax). " +
+					"Applied by the provider after the checks are retrieved.",
+				Optional: true,
+			},
+			"creator": schema.StringAttribute{
+				MarkdownDescription: "Only return checks created by this user, matched against the creator name or ID. " +
+					"Applied by the provider after the checks are retrieved.",
+				Optional: true,
+			},
+			"include_definitions": schema.BoolAttribute{
+				MarkdownDescription: "Populate `definition_json` on each check. Defaults to `false` to keep state small.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of checks Forward Enterprise returns, for reading snapshots with " +
+					"thousands of checks a page at a time. Applied before `name_regex` and `creator`. All checks are returned when omitted.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"offset": schema.Int64Attribute{
+				MarkdownDescription: "Number of matching checks to skip before the first one returned. Combine with `limit` to page " +
+					"through the checks of a snapshot. Defaults to `0`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"priority_weights": schema.MapAttribute{
+				MarkdownDescription: "Weight of a check of each priority in `score`, keyed by priority. Overrides the " +
+					"defaults of `HIGH = 10`, `MEDIUM = 5`, and `LOW = 1` for the priorities it lists; any other priority weighs 1.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.Map{
+					mapvalidator.ValueInt64sAre(int64validator.AtLeast(0)),
+				},
+			},
 			"pass_count": schema.Int64Attribute{
 				MarkdownDescription: "Number of checks that passed.",
 				Computed:            true,
@@ -102,27 +175,53 @@ func (d *IntentChecksDataSource) Schema(ctx context.Context, req datasource.Sche
 				MarkdownDescription: "Number of checks that timed out.",
 				Computed:            true,
 			},
-			"checks": schema.ListNestedAttribute{
-				MarkdownDescription: "Intent checks returned by the Forward Enterprise API.",
-				Computed:            true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"id":                        schema.StringAttribute{Computed: true},
-						"name":                      schema.StringAttribute{Computed: true},
-						"status":                    schema.StringAttribute{Computed: true},
-						"priority":                  schema.StringAttribute{Computed: true},
-						"description":               schema.StringAttribute{Computed: true},
-						"note":                      schema.StringAttribute{Computed: true},
-						"enabled":                   schema.BoolAttribute{Computed: true},
-						"perf_monitoring_enabled":   schema.BoolAttribute{Computed: true},
-						"num_violations":            schema.Int64Attribute{Computed: true},
-						"creation_date_millis":      schema.Int64Attribute{Computed: true},
-						"execution_date_millis":     schema.Int64Attribute{Computed: true},
-						"execution_duration_millis": schema.Int64Attribute{Computed: true},
-						"tags": schema.ListAttribute{
-							ElementType: types.StringType,
-							Computed:    true,
-						},
+			"statuses": schema.MapAttribute{
+				MarkdownDescription: "Number of checks with each status returned by the API, keyed by status. Unlike the " +
+					"`*_count` attributes this includes every status, such as `NOT_RUN` or `DISABLED`.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"summary": schema.ObjectAttribute{
+				MarkdownDescription: "Counts of the returned checks in one object, for gating expressions such as " +
+					"`summary.fail + summary.error == 0`. `total` counts every check, including statuses other than " +
+					"PASS, FAIL, ERROR, and TIMEOUT; `by_priority` counts checks per priority.",
+				Computed: true,
+				AttributeTypes: map[string]attr.Type{
+					"total":       types.Int64Type,
+					"pass":        types.Int64Type,
+					"fail":        types.Int64Type,
+					"error":       types.Int64Type,
+					"timeout":     types.Int64Type,
+					"by_priority": types.MapType{ElemType: types.Int64Type},
+				},
+			},
+			"score": schema.Float64Attribute{
+				MarkdownDescription: "Quality score from 0 to 100: the share of the weight of the returned checks with a " +
+					"PASS, FAIL, ERROR, or TIMEOUT result that belongs to passing checks, with each check weighted by " +
+					"`priority_weights`. For gating on quality rather than raw fail counts, such as `score >= 95`. Null when no " +
+					"returned check has a result or every such check weighs 0.",
+				Computed: true,
+			},
+			"checks": schema.ListAttribute{
+				MarkdownDescription: "Intent checks returned by the Forward Enterprise API. `definition_json` is the compact " +
+					"JSON check definition, accepted by `forward_intent_check`, and is only set when `include_definitions` is true.",
+				Computed: true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"id":                        types.StringType,
+						"name":                      types.StringType,
+						"status":                    types.StringType,
+						"priority":                  types.StringType,
+						"description":               types.StringType,
+						"note":                      types.StringType,
+						"enabled":                   types.BoolType,
+						"perf_monitoring_enabled":   types.BoolType,
+						"num_violations":            types.Int64Type,
+						"creation_date_millis":      types.Int64Type,
+						"execution_date_millis":     types.Int64Type,
+						"execution_duration_millis": types.Int64Type,
+						"tags":                      types.ListType{ElemType: types.StringType},
+						"definition_json":           types.StringType,
 					},
 				},
 			},
@@ -162,6 +261,12 @@ func (d *IntentChecksDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if data.SnapshotID.IsNull() || data.SnapshotID.ValueString() == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("snapshot_id"),
@@ -177,24 +282,41 @@ func (d *IntentChecksDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	checks, err := d.providerData.Client.ListSnapshotChecks(ctx, data.SnapshotID.ValueString(), options)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Retrieve Intent Checks",
-			err.Error(),
-		)
-		return
+	weights := map[string]int64{}
+	for priority, weight := range defaultPriorityWeights {
+		weights[priority] = weight
+	}
+	if !data.PriorityWeights.IsNull() && !data.PriorityWeights.IsUnknown() {
+		var overrides map[string]int64
+		resp.Diagnostics.Append(data.PriorityWeights.ElementsAs(ctx, &overrides, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for priority, weight := range overrides {
+			weights[priority] = weight
+		}
 	}
 
-	stats := map[string]int64{
-		"PASS":    0,
-		"FAIL":    0,
-		"ERROR":   0,
-		"TIMEOUT": 0,
+	var nameRegex *regexp.Regexp
+	if pattern := data.NameRegex.ValueString(); pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Name Pattern", err.Error())
+			return
+		}
+		nameRegex = compiled
 	}
 
-	items := make([]intentCheckItem, 0, len(checks))
-	for _, check := range checks {
+	// Checks are converted as they are decoded and kept without their definitions, which
+	// make up most of the response, so snapshots with thousands of checks stay cheap.
+	includeDefinitions := data.IncludeDefinitions.ValueBool()
+	items := []intentCheckItem{}
+	var checks []fwdclient.CheckResult
+	err := providerData.Client.ForEachSnapshotCheck(ctx, data.SnapshotID.ValueString(), options, func(check *fwdclient.CheckResult) error {
+		if !matchesCheckFilters(*check, nameRegex, data.Creator.ValueString()) {
+			return nil
+		}
+
 		item := intentCheckItem{
 			ID:                    types.StringValue(check.ID),
 			Name:                  stringOrNull(check.Name),
@@ -209,30 +331,121 @@ func (d *IntentChecksDataSource) Read(ctx context.Context, req datasource.ReadRe
 			ExecutionDateMillis:   int64PointerOrNull(check.ExecutionDateMillis),
 			ExecutionDuration:     int64PointerOrNull(check.ExecutionDuration),
 			Tags:                  listOfStrings(check.Tags),
+			DefinitionJSON:        types.StringNull(),
 		}
-
-		status := check.Status
-		if _, ok := stats[status]; ok {
-			stats[status]++
+		if includeDefinitions {
+			item.DefinitionJSON = checkDefinitionString(check.Definition)
 		}
-
 		items = append(items, item)
+
+		check.Definition = nil
+		checks = append(checks, *check)
+		return nil
+	})
+	if err != nil {
+		if !providerData.tolerateMissing(err, "Snapshot Not Found", &resp.Diagnostics) {
+			addAPIError(&resp.Diagnostics, "Unable to Retrieve Intent Checks", err)
+			return
+		}
+		items = []intentCheckItem{}
+		checks = nil
 	}
 
 	data.Checks = items
-	data.PassCount = types.Int64Value(stats["PASS"])
-	data.FailCount = types.Int64Value(stats["FAIL"])
-	data.ErrorCount = types.Int64Value(stats["ERROR"])
-	data.TimeoutCount = types.Int64Value(stats["TIMEOUT"])
+	data.StatusCounts = countCheckStatuses(checks)
+	data.Summary = summarizeChecks(checks)
+	data.Score = scoreChecks(checks, weights)
+	data.PassCount = data.Summary.Pass
+	data.FailCount = data.Summary.Fail
+	data.ErrorCount = data.Summary.Error
+	data.TimeoutCount = data.Summary.Timeout
 
 	tflog.Trace(ctx, "retrieved forward intent checks", map[string]any{"count": len(items)})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func expandCheckListOptions(ctx context.Context, data intentChecksDataSourceModel) (sdk.CheckListOptions, diag.Diagnostics) {
+// summarizeChecks counts checks by status and priority. Checks without a priority are
+// only included in the total and status counts.
+func summarizeChecks(checks []fwdclient.CheckResult) intentChecksSummary {
+	statuses := map[string]int64{}
+	priorities := map[string]int64{}
+	for _, check := range checks {
+		statuses[check.Status]++
+		if check.Priority != "" {
+			priorities[check.Priority]++
+		}
+	}
+
+	byPriority := make(map[string]types.Int64, len(priorities))
+	for priority, count := range priorities {
+		byPriority[priority] = types.Int64Value(count)
+	}
+
+	return intentChecksSummary{
+		Total:      types.Int64Value(int64(len(checks))),
+		Pass:       types.Int64Value(statuses["PASS"]),
+		Fail:       types.Int64Value(statuses["FAIL"]),
+		Error:      types.Int64Value(statuses["ERROR"]),
+		Timeout:    types.Int64Value(statuses["TIMEOUT"]),
+		ByPriority: byPriority,
+	}
+}
+
+// scoreChecks returns the percentage of the weight of checks with a result that belongs
+// to passing checks. Priorities missing from weights weigh 1.
+func scoreChecks(checks []fwdclient.CheckResult, weights map[string]int64) types.Float64 {
+	var passed, total int64
+	for _, check := range checks {
+		switch check.Status {
+		case "PASS", "FAIL", "ERROR", "TIMEOUT":
+		default:
+			continue
+		}
+		weight, ok := weights[check.Priority]
+		if !ok {
+			weight = 1
+		}
+		total += weight
+		if check.Status == "PASS" {
+			passed += weight
+		}
+	}
+	if total == 0 {
+		return types.Float64Null()
+	}
+	return types.Float64Value(100 * float64(passed) / float64(total))
+}
+
+// matchesCheckFilters applies the filters the checks API does not support. A nil
+// pattern or empty creator matches every check.
+func matchesCheckFilters(check fwdclient.CheckResult, nameRegex *regexp.Regexp, creator string) bool {
+	if nameRegex != nil && !nameRegex.MatchString(check.Name) {
+		return false
+	}
+	return creator == "" || check.Creator == creator || check.CreatorID == creator
+}
+
+// countCheckStatuses counts checks per reported status. Checks without a status are
+// not counted.
+func countCheckStatuses(checks []fwdclient.CheckResult) map[string]types.Int64 {
+	counts := map[string]int64{}
+	for _, check := range checks {
+		if check.Status != "" {
+			counts[check.Status]++
+		}
+	}
+
+	result := make(map[string]types.Int64, len(counts))
+	for status, count := range counts {
+		result[status] = types.Int64Value(count)
+	}
+	return result
+}
+
+func expandCheckListOptions(ctx context.Context, data intentChecksDataSourceModel) (fwdclient.CheckListOptions, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	options := sdk.CheckListOptions{}
+	options := fwdclient.CheckListOptions{}
 
 	if !data.Statuses.IsNull() && !data.Statuses.IsUnknown() {
 		var statuses []string
@@ -261,6 +474,12 @@ func expandCheckListOptions(ctx context.Context, data intentChecksDataSourceMode
 		}
 		options.Types = types
 	}
+	if !data.Limit.IsNull() && !data.Limit.IsUnknown() {
+		options.Limit = int(data.Limit.ValueInt64())
+	}
+	if !data.Offset.IsNull() && !data.Offset.IsUnknown() {
+		options.Offset = int(data.Offset.ValueInt64())
+	}
 
 	return options, diags
 }
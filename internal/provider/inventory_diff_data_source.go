@@ -0,0 +1,334 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &InventoryDiffDataSource{}
+
+// inventoryFields maps the keys accepted in expected_devices to device values. The
+// name key identifies the device and is not compared.
+var inventoryFields = map[string]func(fwdclient.Device) string{
+	"name":          func(d fwdclient.Device) string { return d.Name },
+	"display_name":  func(d fwdclient.Device) string { return d.DisplayName },
+	"type":          func(d fwdclient.Device) string { return d.Type },
+	"vendor":        func(d fwdclient.Device) string { return d.Vendor },
+	"platform":      func(d fwdclient.Device) string { return d.Platform },
+	"model":         func(d fwdclient.Device) string { return d.Model },
+	"os_version":    func(d fwdclient.Device) string { return d.OSVersion },
+	"serial_number": func(d fwdclient.Device) string { return d.SerialNumber },
+	"management_ips": func(d fwdclient.Device) string {
+		ips := append([]string(nil), d.ManagementIPs...)
+		sort.Strings(ips)
+		return strings.Join(ips, ",")
+	},
+}
+
+// NewInventoryDiffDataSource instantiates the inventory diff data source.
+func NewInventoryDiffDataSource() datasource.DataSource {
+	return &InventoryDiffDataSource{}
+}
+
+// InventoryDiffDataSource compares a snapshot's device inventory against an expected
+// inventory.
+type InventoryDiffDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type inventoryDiffDataSourceModel struct {
+	ID                types.String              `tfsdk:"id"`
+	Profile           types.String              `tfsdk:"profile"`
+	SnapshotID        types.String              `tfsdk:"snapshot_id"`
+	ExpectedDevices   []map[string]types.String `tfsdk:"expected_devices"`
+	MissingDevices    []types.String            `tfsdk:"missing_devices"`
+	ExtraDevices      []types.String            `tfsdk:"extra_devices"`
+	MismatchedDevices []inventoryMismatchItem   `tfsdk:"mismatched_devices"`
+	InSync            types.Bool                `tfsdk:"in_sync"`
+}
+
+type inventoryMismatchItem struct {
+	Name      types.String `tfsdk:"name"`
+	Attribute types.String `tfsdk:"attribute"`
+	Expected  types.String `tfsdk:"expected"`
+	Actual    types.String `tfsdk:"actual"`
+}
+
+// inventoryMismatch is a single attribute that differs from the expected inventory.
+type inventoryMismatch struct {
+	Name      string
+	Attribute string
+	Expected  string
+	Actual    string
+}
+
+// inventoryDiff summarizes the differences between an expected and actual inventory.
+type inventoryDiff struct {
+	Missing    []string
+	Extra      []string
+	Mismatched []inventoryMismatch
+}
+
+func (d *InventoryDiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inventory_diff"
+}
+
+func (d *InventoryDiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Compare the device inventory of a Forward Enterprise snapshot against an expected inventory, " +
+			"such as a CMDB or source-of-truth export, and report missing, extra, and mismatched devices.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot identifier the comparison was made against.",
+				Computed:            true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot whose device inventory is compared.",
+				Required:            true,
+			},
+			"expected_devices": schema.ListAttribute{
+				MarkdownDescription: "Expected devices. Each entry must set `name` and may set any of `display_name`, `type`, " +
+					"`vendor`, `platform`, `model`, `os_version`, `serial_number`, and `management_ips` (comma-separated). Only the keys " +
+					"present in an entry are compared.",
+				Required:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+			},
+			"missing_devices": schema.ListAttribute{
+				MarkdownDescription: "Names of expected devices that are absent from the snapshot.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"extra_devices": schema.ListAttribute{
+				MarkdownDescription: "Names of snapshot devices that are not in `expected_devices`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"mismatched_devices": schema.ListAttribute{
+				MarkdownDescription: "Attributes whose snapshot value differs from the expected value, one entry per device attribute.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"name":      types.StringType,
+						"attribute": types.StringType,
+						"expected":  types.StringType,
+						"actual":    types.StringType,
+					},
+				},
+			},
+			"in_sync": schema.BoolAttribute{
+				MarkdownDescription: "True when no devices are missing, extra, or mismatched.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *InventoryDiffDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *InventoryDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data inventoryDiffDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expected, diags := parseExpectedDevices(data.ExpectedDevices)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devices, err := providerData.Client.ListSnapshotDevices(ctx, data.SnapshotID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Device Inventory", err)
+		return
+	}
+
+	diff := diffInventory(expected, devices)
+
+	data.ID = data.SnapshotID
+	data.MissingDevices = stringValues(diff.Missing)
+	data.ExtraDevices = stringValues(diff.Extra)
+	data.MismatchedDevices = make([]inventoryMismatchItem, 0, len(diff.Mismatched))
+	for _, mismatch := range diff.Mismatched {
+		data.MismatchedDevices = append(data.MismatchedDevices, inventoryMismatchItem{
+			Name:      types.StringValue(mismatch.Name),
+			Attribute: types.StringValue(mismatch.Attribute),
+			Expected:  types.StringValue(mismatch.Expected),
+			Actual:    types.StringValue(mismatch.Actual),
+		})
+	}
+	data.InSync = types.BoolValue(len(diff.Missing) == 0 && len(diff.Extra) == 0 && len(diff.Mismatched) == 0)
+
+	tflog.Trace(ctx, "compared forward device inventory", map[string]any{
+		"missing":    len(diff.Missing),
+		"extra":      len(diff.Extra),
+		"mismatched": len(diff.Mismatched),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseExpectedDevices validates expected_devices entries and returns the set keys of
+// each entry.
+func parseExpectedDevices(entries []map[string]types.String) ([]map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	seen := make(map[string]bool, len(entries))
+	expected := make([]map[string]string, 0, len(entries))
+
+	for i, entry := range entries {
+		entryPath := path.Root("expected_devices").AtListIndex(i)
+		values := make(map[string]string, len(entry))
+		for key, value := range entry {
+			if _, ok := inventoryFields[key]; !ok {
+				diags.AddAttributeError(entryPath.AtMapKey(key), "Unknown Inventory Attribute",
+					fmt.Sprintf("Expected devices may only set: %s.", strings.Join(inventoryFieldNames(), ", ")))
+				continue
+			}
+			if value.IsNull() || value.IsUnknown() {
+				continue
+			}
+			values[key] = value.ValueString()
+		}
+
+		name := values["name"]
+		if name == "" {
+			diags.AddAttributeError(entryPath, "Missing Device Name", "Every expected device must set a non-empty `name`.")
+			continue
+		}
+		if seen[name] {
+			diags.AddAttributeError(entryPath, "Duplicate Device Name", fmt.Sprintf("Device %q is listed more than once.", name))
+			continue
+		}
+		seen[name] = true
+		expected = append(expected, values)
+	}
+
+	return expected, diags
+}
+
+// diffInventory compares expected entries against devices. Results are sorted by device
+// name, then attribute, so plans stay stable between reads.
+func diffInventory(expected []map[string]string, devices []fwdclient.Device) inventoryDiff {
+	actual := make(map[string]fwdclient.Device, len(devices))
+	for _, device := range devices {
+		actual[device.Name] = device
+	}
+
+	diff := inventoryDiff{Missing: []string{}, Extra: []string{}, Mismatched: []inventoryMismatch{}}
+	wanted := make(map[string]bool, len(expected))
+	for _, values := range expected {
+		name := values["name"]
+		wanted[name] = true
+
+		device, ok := actual[name]
+		if !ok {
+			diff.Missing = append(diff.Missing, name)
+			continue
+		}
+
+		for key, want := range values {
+			if key == "name" {
+				continue
+			}
+			if key == "management_ips" {
+				want = normalizeIPList(want)
+			}
+			if got := inventoryFields[key](device); got != want {
+				diff.Mismatched = append(diff.Mismatched, inventoryMismatch{Name: name, Attribute: key, Expected: want, Actual: got})
+			}
+		}
+	}
+
+	for _, device := range devices {
+		if !wanted[device.Name] {
+			diff.Extra = append(diff.Extra, device.Name)
+		}
+	}
+
+	sort.Strings(diff.Missing)
+	sort.Strings(diff.Extra)
+	sort.Slice(diff.Mismatched, func(i, j int) bool {
+		if diff.Mismatched[i].Name != diff.Mismatched[j].Name {
+			return diff.Mismatched[i].Name < diff.Mismatched[j].Name
+		}
+		return diff.Mismatched[i].Attribute < diff.Mismatched[j].Attribute
+	})
+
+	return diff
+}
+
+// normalizeIPList sorts a comma-separated address list so ordering differences are not
+// reported as mismatches.
+func normalizeIPList(value string) string {
+	var ips []string
+	for _, ip := range strings.Split(value, ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	sort.Strings(ips)
+	return strings.Join(ips, ",")
+}
+
+func inventoryFieldNames() []string {
+	names := make([]string, 0, len(inventoryFields))
+	for name := range inventoryFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func stringValues(values []string) []types.String {
+	result := make([]types.String, 0, len(values))
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestDiffInventory(t *testing.T) {
+	t.Parallel()
+
+	expected := []map[string]string{
+		{"name": "leaf1", "vendor": "ARISTA", "management_ips": "10.0.0.2, 10.0.0.1"},
+		{"name": "leaf2", "os_version": "4.30"},
+		{"name": "spine1"},
+	}
+	devices := []fwdclient.Device{
+		{Name: "leaf1", Vendor: "CISCO", ManagementIPs: []string{"10.0.0.1", "10.0.0.2"}},
+		{Name: "leaf2", OSVersion: "4.30"},
+		{Name: "border1"},
+	}
+
+	got := diffInventory(expected, devices)
+	want := inventoryDiff{
+		Missing:    []string{"spine1"},
+		Extra:      []string{"border1"},
+		Mismatched: []inventoryMismatch{{Name: "leaf1", Attribute: "vendor", Expected: "ARISTA", Actual: "CISCO"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestParseExpectedDevicesValidation(t *testing.T) {
+	t.Parallel()
+
+	for name, entries := range map[string][]map[string]types.String{
+		"missing name":  {{"vendor": types.StringValue("CISCO")}},
+		"duplicate":     {{"name": types.StringValue("leaf1")}, {"name": types.StringValue("leaf1")}},
+		"unknown field": {{"name": types.StringValue("leaf1"), "serial": types.StringValue("X")}},
+	} {
+		if _, diags := parseExpectedDevices(entries); !diags.HasError() {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestAccInventoryDiffDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddDevice("snap-1", fwdclient.Device{Name: "leaf1", Vendor: "ARISTA"})
+	server.AddDevice("snap-1", fwdclient.Device{Name: "leaf2", Vendor: "CISCO"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_inventory_diff" "test" {
+  snapshot_id = "snap-1"
+  expected_devices = [
+    { name = "leaf1", vendor = "ARISTA" },
+    { name = "leaf3" },
+  ]
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_inventory_diff.test", "in_sync", "false"),
+					resource.TestCheckResourceAttr("data.forward_inventory_diff.test", "missing_devices.0", "leaf3"),
+					resource.TestCheckResourceAttr("data.forward_inventory_diff.test", "extra_devices.0", "leaf2"),
+					resource.TestCheckResourceAttr("data.forward_inventory_diff.test", "mismatched_devices.#", "0"),
+				),
+			},
+		},
+	})
+}
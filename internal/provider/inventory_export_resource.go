@@ -0,0 +1,259 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	schemavalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ resource.Resource = &InventoryExportResource{}
+var _ resource.ResourceWithConfigValidators = &InventoryExportResource{}
+
+// inventoryCSVHeader lists the columns written for the csv format.
+var inventoryCSVHeader = []string{"name", "display_name", "type", "vendor", "platform", "model", "os_version", "management_ips"}
+
+// InventoryExportResource writes a snapshot's device inventory to a local file.
+type InventoryExportResource struct {
+	providerData *ForwardProviderData
+}
+
+// InventoryExportResourceModel maps Terraform schema data.
+type InventoryExportResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Profile     types.String `tfsdk:"profile"`
+	SnapshotID  types.String `tfsdk:"snapshot_id"`
+	Filename    types.String `tfsdk:"filename"`
+	Format      types.String `tfsdk:"format"`
+	DeviceCount types.Int64  `tfsdk:"device_count"`
+}
+
+func NewInventoryExportResource() resource.Resource {
+	return &InventoryExportResource{}
+}
+
+func (r *InventoryExportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inventory_export"
+}
+
+func (r *InventoryExportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Export the device inventory of a Forward Enterprise snapshot to a local CSV or JSON file, for example " +
+			"to feed CMDB reconciliation jobs. The file is rewritten when the snapshot, path, or format changes, or when " +
+			"the file is modified or removed outside Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 checksum of the exported file contents.",
+			},
+			"snapshot_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Snapshot whose device inventory is exported.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filename": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path of the file to write. Missing parent directories are created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"format": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Output format, either `json` or `csv`. Defaults to `json`.",
+				Default:             stringdefault.StaticString("json"),
+				Validators: []schemavalidator.String{
+					stringvalidator.OneOf("json", "csv"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"device_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of devices written to the file.",
+			},
+		},
+	}
+}
+
+func (r *InventoryExportResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validateSnapshotReferences(func() *ForwardProviderData { return r.providerData }, "snapshot_id"),
+	}
+}
+
+func (r *InventoryExportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *InventoryExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan InventoryExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devices, err := providerData.Client.ListSnapshotDevices(ctx, plan.SnapshotID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error retrieving device inventory", err)
+		return
+	}
+
+	content, err := encodeInventory(devices, plan.Format.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding device inventory", err.Error())
+		return
+	}
+
+	filename := plan.Filename.ValueString()
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "Error creating export directory", err.Error())
+		return
+	}
+	if err := os.WriteFile(filename, content, 0o644); err != nil { // #nosec G306 -- inventory exports are meant to be shared with other tools.
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "Error writing device inventory", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(contentChecksum(content))
+	plan.DeviceCount = types.Int64Value(int64(len(devices)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *InventoryExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state InventoryExportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The export is a point-in-time copy; only drift in the local file triggers a rewrite.
+	content, err := os.ReadFile(state.Filename.ValueString())
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && contentChecksum(content) != state.ID.ValueString()) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "Error reading device inventory export", err.Error())
+		return
+	}
+}
+
+func (r *InventoryExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only the profile can change without replacement, and it does not affect the file.
+	var plan InventoryExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *InventoryExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state InventoryExportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := os.Remove(state.Filename.ValueString()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "Error removing device inventory export", err.Error())
+	}
+}
+
+// encodeInventory renders devices sorted by name so unchanged inventories produce
+// identical files.
+func encodeInventory(devices []fwdclient.Device, format string) ([]byte, error) {
+	sorted := append([]fwdclient.Device(nil), devices...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	switch format {
+	case "csv":
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		if err := writer.Write(inventoryCSVHeader); err != nil {
+			return nil, err
+		}
+		for _, d := range sorted {
+			record := []string{d.Name, d.DisplayName, d.Type, d.Vendor, d.Platform, d.Model, d.OSVersion, strings.Join(d.ManagementIPs, ";")}
+			if err := writer.Write(record); err != nil {
+				return nil, err
+			}
+		}
+		writer.Flush()
+		return buf.Bytes(), writer.Error()
+	case "json", "":
+		if sorted == nil {
+			sorted = []fwdclient.Device{}
+		}
+		content, err := json.MarshalIndent(sorted, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(content, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func contentChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestEncodeInventoryCSV(t *testing.T) {
+	t.Parallel()
+
+	content, err := encodeInventory([]fwdclient.Device{
+		{Name: "spine1", Type: "SWITCH", Vendor: "ARISTA"},
+		{Name: "leaf1", Type: "SWITCH", Vendor: "CISCO", ManagementIPs: []string{"10.0.0.1", "10.0.0.2"}},
+	}, "csv")
+	if err != nil {
+		t.Fatalf("encodeInventory: %v", err)
+	}
+
+	want := "name,display_name,type,vendor,platform,model,os_version,management_ips\n" +
+		"leaf1,,SWITCH,CISCO,,,,10.0.0.1;10.0.0.2\n" +
+		"spine1,,SWITCH,ARISTA,,,,\n"
+	if string(content) != want {
+		t.Fatalf("unexpected CSV:\n%s", content)
+	}
+}
+
+func TestAccInventoryExportResource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddDevice("snap-1", fwdclient.Device{Name: "leaf1", Type: "SWITCH"})
+	server.AddDevice("snap-1", fwdclient.Device{Name: "leaf2", Type: "SWITCH"})
+
+	filename := filepath.Join(t.TempDir(), "exports", "inventory.csv")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if _, err := os.Stat(filename); !os.IsNotExist(err) {
+				return fmt.Errorf("expected %s to be removed, got %v", filename, err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_inventory_export" "test" {
+  snapshot_id = "snap-1"
+  filename    = %q
+  format      = "csv"
+}
+`, server.URL, filename),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_inventory_export.test", "device_count", "2"),
+					func(*terraform.State) error {
+						if _, err := os.Stat(filename); err != nil {
+							return fmt.Errorf("expected export file: %w", err)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,306 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &K8sInventoryDataSource{}
+
+// NewK8sInventoryDataSource instantiates the Kubernetes inventory data source.
+func NewK8sInventoryDataSource() datasource.DataSource {
+	return &K8sInventoryDataSource{}
+}
+
+// K8sInventoryDataSource exposes the Kubernetes clusters modeled in a snapshot.
+type K8sInventoryDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type k8sInventoryDataSourceModel struct {
+	ID              types.String       `tfsdk:"id"`
+	Profile         types.String       `tfsdk:"profile"`
+	SnapshotID      types.String       `tfsdk:"snapshot_id"`
+	Cluster         types.String       `tfsdk:"cluster"`
+	Namespace       types.String       `tfsdk:"namespace"`
+	Clusters        []k8sClusterItem   `tfsdk:"clusters"`
+	Nodes           []k8sNodeItem      `tfsdk:"nodes"`
+	Services        []k8sServiceItem   `tfsdk:"services"`
+	NetworkPolicies []k8sNetPolicyItem `tfsdk:"network_policies"`
+}
+
+type k8sClusterItem struct {
+	Name         types.String `tfsdk:"name"`
+	Distribution types.String `tfsdk:"distribution"`
+	Version      types.String `tfsdk:"version"`
+}
+
+type k8sNodeItem struct {
+	Cluster    types.String `tfsdk:"cluster"`
+	Name       types.String `tfsdk:"name"`
+	InternalIP types.String `tfsdk:"internal_ip"`
+	PodCIDR    types.String `tfsdk:"pod_cidr"`
+	Ready      types.Bool   `tfsdk:"ready"`
+}
+
+type k8sServiceItem struct {
+	Cluster   types.String   `tfsdk:"cluster"`
+	Namespace types.String   `tfsdk:"namespace"`
+	Name      types.String   `tfsdk:"name"`
+	Type      types.String   `tfsdk:"type"`
+	ClusterIP types.String   `tfsdk:"cluster_ip"`
+	Ports     []types.String `tfsdk:"ports"`
+}
+
+type k8sNetPolicyItem struct {
+	Cluster     types.String   `tfsdk:"cluster"`
+	Namespace   types.String   `tfsdk:"namespace"`
+	Name        types.String   `tfsdk:"name"`
+	PodSelector types.String   `tfsdk:"pod_selector"`
+	PolicyTypes []types.String `tfsdk:"policy_types"`
+}
+
+func (d *K8sInventoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_inventory"
+}
+
+func (d *K8sInventoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Expose the Kubernetes clusters modeled in a snapshot with their nodes, services, and network " +
+			"policies, so container reachability checks can be parameterized from code. Nodes, services, and policies are " +
+			"flattened into lists that name their cluster. All lists are empty when the network has no Kubernetes " +
+			"collection configured.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot identifier the inventory was read from.",
+				Computed:            true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot whose Kubernetes inventory is read.",
+				Required:            true,
+			},
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Only include this cluster.",
+				Optional:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Only include services and network policies in this namespace.",
+				Optional:            true,
+			},
+			"clusters": schema.ListAttribute{
+				MarkdownDescription: "Clusters sorted by name.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"name":         types.StringType,
+						"distribution": types.StringType,
+						"version":      types.StringType,
+					},
+				},
+			},
+			"nodes": schema.ListAttribute{
+				MarkdownDescription: "Cluster nodes sorted by cluster and name.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"cluster":     types.StringType,
+						"name":        types.StringType,
+						"internal_ip": types.StringType,
+						"pod_cidr":    types.StringType,
+						"ready":       types.BoolType,
+					},
+				},
+			},
+			"services": schema.ListAttribute{
+				MarkdownDescription: "Services sorted by cluster, namespace, and name. Ports read like `443/TCP`.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"cluster":    types.StringType,
+						"namespace":  types.StringType,
+						"name":       types.StringType,
+						"type":       types.StringType,
+						"cluster_ip": types.StringType,
+						"ports":      types.ListType{ElemType: types.StringType},
+					},
+				},
+			},
+			"network_policies": schema.ListAttribute{
+				MarkdownDescription: "Network policies sorted by cluster, namespace, and name.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"cluster":      types.StringType,
+						"namespace":    types.StringType,
+						"name":         types.StringType,
+						"pod_selector": types.StringType,
+						"policy_types": types.ListType{ElemType: types.StringType},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *K8sInventoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *K8sInventoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data k8sInventoryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !providerData.requireFeature(ctx, featureKubernetes, path.Empty(), &resp.Diagnostics) {
+		return
+	}
+
+	inventory, err := providerData.Client.GetKubernetesInventory(ctx, data.SnapshotID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Kubernetes Inventory", err)
+		return
+	}
+
+	clusters := filterK8sInventory(inventory.Clusters, stringValue(data.Cluster), stringValue(data.Namespace))
+
+	data.Clusters = []k8sClusterItem{}
+	data.Nodes = []k8sNodeItem{}
+	data.Services = []k8sServiceItem{}
+	data.NetworkPolicies = []k8sNetPolicyItem{}
+	for _, cluster := range clusters {
+		name := stringOrNull(cluster.Name)
+		data.Clusters = append(data.Clusters, k8sClusterItem{
+			Name:         name,
+			Distribution: stringOrNull(cluster.Distribution),
+			Version:      stringOrNull(cluster.Version),
+		})
+		for _, node := range cluster.Nodes {
+			data.Nodes = append(data.Nodes, k8sNodeItem{
+				Cluster:    name,
+				Name:       stringOrNull(node.Name),
+				InternalIP: stringOrNull(node.InternalIP),
+				PodCIDR:    stringOrNull(node.PodCIDR),
+				Ready:      types.BoolValue(node.Ready),
+			})
+		}
+		for _, service := range cluster.Services {
+			data.Services = append(data.Services, k8sServiceItem{
+				Cluster:   name,
+				Namespace: stringOrNull(service.Namespace),
+				Name:      stringOrNull(service.Name),
+				Type:      stringOrNull(service.Type),
+				ClusterIP: stringOrNull(service.ClusterIP),
+				Ports:     stringValues(service.Ports),
+			})
+		}
+		for _, policy := range cluster.NetworkPolicies {
+			data.NetworkPolicies = append(data.NetworkPolicies, k8sNetPolicyItem{
+				Cluster:     name,
+				Namespace:   stringOrNull(policy.Namespace),
+				Name:        stringOrNull(policy.Name),
+				PodSelector: stringOrNull(policy.PodSelector),
+				PolicyTypes: stringValues(policy.PolicyTypes),
+			})
+		}
+	}
+	data.ID = data.SnapshotID
+
+	tflog.Trace(ctx, "retrieved forward kubernetes inventory", map[string]any{
+		"clusters": len(data.Clusters),
+		"nodes":    len(data.Nodes),
+		"services": len(data.Services),
+		"policies": len(data.NetworkPolicies),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterK8sInventory keeps the named cluster, when set, and the services and network
+// policies of namespace, when set. Clusters and their contents are sorted so plans stay
+// stable between reads.
+func filterK8sInventory(clusters []fwdclient.KubernetesCluster, clusterName, namespace string) []fwdclient.KubernetesCluster {
+	filtered := make([]fwdclient.KubernetesCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		if clusterName != "" && cluster.Name != clusterName {
+			continue
+		}
+
+		nodes := append([]fwdclient.KubernetesNode(nil), cluster.Nodes...)
+		sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+		services := make([]fwdclient.KubernetesService, 0, len(cluster.Services))
+		for _, service := range cluster.Services {
+			if namespace == "" || service.Namespace == namespace {
+				services = append(services, service)
+			}
+		}
+		sort.SliceStable(services, func(i, j int) bool {
+			if services[i].Namespace != services[j].Namespace {
+				return services[i].Namespace < services[j].Namespace
+			}
+			return services[i].Name < services[j].Name
+		})
+
+		policies := make([]fwdclient.KubernetesNetworkPolicy, 0, len(cluster.NetworkPolicies))
+		for _, policy := range cluster.NetworkPolicies {
+			if namespace == "" || policy.Namespace == namespace {
+				policies = append(policies, policy)
+			}
+		}
+		sort.SliceStable(policies, func(i, j int) bool {
+			if policies[i].Namespace != policies[j].Namespace {
+				return policies[i].Namespace < policies[j].Namespace
+			}
+			return policies[i].Name < policies[j].Name
+		})
+
+		cluster.Nodes, cluster.Services, cluster.NetworkPolicies = nodes, services, policies
+		filtered = append(filtered, cluster)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].Name < filtered[j].Name })
+	return filtered
+}
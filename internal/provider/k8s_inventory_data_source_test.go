@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestFilterK8sInventory(t *testing.T) {
+	t.Parallel()
+
+	clusters := filterK8sInventory([]fwdclient.KubernetesCluster{
+		{Name: "staging"},
+		{
+			Name:  "prod",
+			Nodes: []fwdclient.KubernetesNode{{Name: "node-2"}, {Name: "node-1"}},
+			Services: []fwdclient.KubernetesService{
+				{Namespace: "web", Name: "frontend"},
+				{Namespace: "kube-system", Name: "kube-dns"},
+				{Namespace: "web", Name: "api"},
+			},
+			NetworkPolicies: []fwdclient.KubernetesNetworkPolicy{{Namespace: "kube-system", Name: "allow-dns"}},
+		},
+	}, "prod", "web")
+
+	if len(clusters) != 1 {
+		t.Fatalf("unexpected clusters: %#v", clusters)
+	}
+	prod := clusters[0]
+	if len(prod.Nodes) != 2 || prod.Nodes[0].Name != "node-1" {
+		t.Fatalf("unexpected nodes: %#v", prod.Nodes)
+	}
+	if len(prod.Services) != 2 || prod.Services[0].Name != "api" || prod.Services[1].Name != "frontend" {
+		t.Fatalf("unexpected services: %#v", prod.Services)
+	}
+	if len(prod.NetworkPolicies) != 0 {
+		t.Fatalf("unexpected network policies: %#v", prod.NetworkPolicies)
+	}
+}
+
+func TestAccK8sInventoryDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-2"})
+	server.AddKubernetesCluster("snap-1", fwdclient.KubernetesCluster{
+		Name:            "prod",
+		Distribution:    "EKS",
+		Nodes:           []fwdclient.KubernetesNode{{Name: "node-1", InternalIP: "10.0.1.10", Ready: true}},
+		Services:        []fwdclient.KubernetesService{{Namespace: "web", Name: "frontend", Type: "LoadBalancer", Ports: []string{"443/TCP"}}},
+		NetworkPolicies: []fwdclient.KubernetesNetworkPolicy{{Namespace: "web", Name: "deny-all", PolicyTypes: []string{"Ingress"}}},
+	})
+
+	config := func(snapshotID string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_k8s_inventory" "test" {
+  snapshot_id = %q
+}
+`, server.URL, snapshotID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("snap-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_k8s_inventory.test", "clusters.0.distribution", "EKS"),
+					resource.TestCheckResourceAttr("data.forward_k8s_inventory.test", "nodes.0.cluster", "prod"),
+					resource.TestCheckResourceAttr("data.forward_k8s_inventory.test", "nodes.0.ready", "true"),
+					resource.TestCheckResourceAttr("data.forward_k8s_inventory.test", "services.0.ports.0", "443/TCP"),
+					resource.TestCheckResourceAttr("data.forward_k8s_inventory.test", "network_policies.0.policy_types.0", "Ingress"),
+				),
+			},
+			{
+				// Snapshots without Kubernetes modeling return empty lists.
+				Config: config("snap-2"),
+				Check:  resource.TestCheckResourceAttr("data.forward_k8s_inventory.test", "clusters.#", "0"),
+			},
+		},
+	})
+}
@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &L2PathDataSource{}
+
+// NewL2PathDataSource instantiates the L2 path data source.
+func NewL2PathDataSource() datasource.DataSource {
+	return &L2PathDataSource{}
+}
+
+// L2PathDataSource traces MAC-layer paths between two endpoints.
+type L2PathDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type l2PathDataSourceModel struct {
+	Profile         types.String         `tfsdk:"profile"`
+	NetworkID       types.String         `tfsdk:"network_id"`
+	SnapshotID      types.String         `tfsdk:"snapshot_id"`
+	SrcMAC          types.String         `tfsdk:"src_mac"`
+	DstMAC          types.String         `tfsdk:"dst_mac"`
+	VLAN            types.Int64          `tfsdk:"vlan"`
+	MaxResults      types.Int64          `tfsdk:"max_results"`
+	Outcomes        []types.String       `tfsdk:"outcomes"`
+	Hops            []l2PathHopItem      `tfsdk:"hops"`
+	VLANTransitions []vlanTransitionItem `tfsdk:"vlan_transitions"`
+	TimedOut        types.Bool           `tfsdk:"timed_out"`
+	QueryURL        types.String         `tfsdk:"query_url"`
+	UIURL           types.String         `tfsdk:"ui_url"`
+}
+
+type l2PathHopItem struct {
+	PathIndex        types.Int64  `tfsdk:"path_index"`
+	HopIndex         types.Int64  `tfsdk:"hop_index"`
+	DeviceName       types.String `tfsdk:"device_name"`
+	IngressInterface types.String `tfsdk:"ingress_interface"`
+	EgressInterface  types.String `tfsdk:"egress_interface"`
+	IngressVLAN      types.Int64  `tfsdk:"ingress_vlan"`
+	EgressVLAN       types.Int64  `tfsdk:"egress_vlan"`
+}
+
+type vlanTransitionItem struct {
+	PathIndex  types.Int64  `tfsdk:"path_index"`
+	HopIndex   types.Int64  `tfsdk:"hop_index"`
+	DeviceName types.String `tfsdk:"device_name"`
+	FromVLAN   types.Int64  `tfsdk:"from_vlan"`
+	ToVLAN     types.Int64  `tfsdk:"to_vlan"`
+}
+
+func (d *L2PathDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_l2_path"
+}
+
+func (d *L2PathDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Trace MAC-layer (L2) paths between two endpoints, exposing each switching hop and every VLAN " +
+			"transition along the way. Complements `forward_path_analysis`, which traces L3 paths.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "Network identifier. Defaults to the network `snapshot_id` belongs to, or to the provider " +
+					"`network_id` when no snapshot is given.",
+				Optional: true,
+				Computed: true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot to trace in. Defaults to the latest processed snapshot.",
+				Optional:            true,
+			},
+			"src_mac": schema.StringAttribute{
+				MarkdownDescription: "Source MAC address.",
+				Required:            true,
+			},
+			"dst_mac": schema.StringAttribute{
+				MarkdownDescription: "Destination MAC address.",
+				Required:            true,
+			},
+			"vlan": schema.Int64Attribute{
+				MarkdownDescription: "VLAN the frame enters the network in.",
+				Optional:            true,
+			},
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of paths to return.",
+				Optional:            true,
+			},
+			"outcomes": schema.ListAttribute{
+				MarkdownDescription: "Outcome of each path, such as DELIVERED or DROPPED, indexed like `path_index`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"hops": schema.ListAttribute{
+				MarkdownDescription: "Switching hops of every path, in order.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"path_index":        types.Int64Type,
+						"hop_index":         types.Int64Type,
+						"device_name":       types.StringType,
+						"ingress_interface": types.StringType,
+						"egress_interface":  types.StringType,
+						"ingress_vlan":      types.Int64Type,
+						"egress_vlan":       types.Int64Type,
+					},
+				},
+			},
+			"vlan_transitions": schema.ListAttribute{
+				MarkdownDescription: "Hops where the frame leaves in a different VLAN than it arrived in.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"path_index":  types.Int64Type,
+						"hop_index":   types.Int64Type,
+						"device_name": types.StringType,
+						"from_vlan":   types.Int64Type,
+						"to_vlan":     types.Int64Type,
+					},
+				},
+			},
+			"timed_out": schema.BoolAttribute{
+				MarkdownDescription: "True when the search stopped before exploring every path.",
+				Computed:            true,
+			},
+			"query_url": schema.StringAttribute{
+				MarkdownDescription: "Link to the search in the Forward Enterprise UI, as returned by Forward. Marked sensitive because " +
+					"the link may carry an access token; use `ui_url` to share the search.",
+				Computed:  true,
+				Sensitive: true,
+			},
+			"ui_url": uiURLAttribute(),
+		},
+	}
+}
+
+func (d *L2PathDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *L2PathDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data l2PathDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := fwdclient.L2PathSearchParams{
+		SrcMAC:     data.SrcMAC.ValueString(),
+		DstMAC:     data.DstMAC.ValueString(),
+		SnapshotID: stringValue(data.SnapshotID),
+	}
+	if !data.VLAN.IsNull() && !data.VLAN.IsUnknown() {
+		vlan := int(data.VLAN.ValueInt64())
+		params.VLAN = &vlan
+	}
+	if !data.MaxResults.IsNull() && !data.MaxResults.IsUnknown() {
+		maxResults := int(data.MaxResults.ValueInt64())
+		params.MaxResults = &maxResults
+	}
+
+	if !providerData.requireFeature(ctx, featureL2PathSearch, path.Empty(), &resp.Diagnostics) {
+		return
+	}
+
+	networkID := stringValue(data.NetworkID)
+	switch {
+	case networkID != "":
+	case params.SnapshotID != "":
+		resolved, err := snapshotNetworkID(ctx, providerData.Client, params.SnapshotID)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error resolving snapshot network", err)
+			return
+		}
+		networkID = resolved
+	default:
+		networkID = providerData.NetworkID
+	}
+	data.NetworkID = types.StringValue(networkID)
+
+	result, err := providerData.Client.SearchL2Paths(ctx, networkID, params)
+	if err != nil {
+		if !providerData.tolerateMissing(err, "L2 Path Target Not Found", &resp.Diagnostics) {
+			addAPIError(&resp.Diagnostics, "Error executing L2 path search", err)
+			return
+		}
+		result = &fwdclient.L2PathSearchResult{}
+	}
+
+	data.Outcomes = make([]types.String, 0, len(result.Paths))
+	data.Hops = []l2PathHopItem{}
+	for pathIndex, p := range result.Paths {
+		data.Outcomes = append(data.Outcomes, types.StringValue(p.Outcome))
+		for hopIndex, hop := range p.Hops {
+			data.Hops = append(data.Hops, l2PathHopItem{
+				PathIndex:        types.Int64Value(int64(pathIndex)),
+				HopIndex:         types.Int64Value(int64(hopIndex)),
+				DeviceName:       stringOrNull(hop.DeviceName),
+				IngressInterface: stringOrNull(hop.IngressInterface),
+				EgressInterface:  stringOrNull(hop.EgressInterface),
+				IngressVLAN:      int64PointerOrNull(hop.IngressVLAN),
+				EgressVLAN:       int64PointerOrNull(hop.EgressVLAN),
+			})
+		}
+	}
+	data.VLANTransitions = vlanTransitions(result.Paths)
+	data.TimedOut = types.BoolValue(result.TimedOut)
+	data.QueryURL = stringOrNull(result.QueryURL)
+	data.UIURL = shareableUIURL(providerData.Client, result.QueryURL, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "traced forward l2 paths", map[string]any{"paths": len(result.Paths)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// vlanTransitions lists the hops whose egress VLAN differs from the VLAN the frame
+// arrived in. Hops that do not report both VLANs are skipped.
+func vlanTransitions(paths []fwdclient.L2Path) []vlanTransitionItem {
+	transitions := []vlanTransitionItem{}
+	for pathIndex, p := range paths {
+		for hopIndex, hop := range p.Hops {
+			if hop.IngressVLAN == nil || hop.EgressVLAN == nil || *hop.IngressVLAN == *hop.EgressVLAN {
+				continue
+			}
+			transitions = append(transitions, vlanTransitionItem{
+				PathIndex:  types.Int64Value(int64(pathIndex)),
+				HopIndex:   types.Int64Value(int64(hopIndex)),
+				DeviceName: stringOrNull(hop.DeviceName),
+				FromVLAN:   types.Int64Value(*hop.IngressVLAN),
+				ToVLAN:     types.Int64Value(*hop.EgressVLAN),
+			})
+		}
+	}
+	return transitions
+}
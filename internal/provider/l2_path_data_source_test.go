@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestVLANTransitions(t *testing.T) {
+	t.Parallel()
+
+	vlan10, vlan20 := int64(10), int64(20)
+	transitions := vlanTransitions([]fwdclient.L2Path{{Hops: []fwdclient.L2PathHop{
+		{DeviceName: "access-1", IngressVLAN: &vlan10, EgressVLAN: &vlan10},
+		{DeviceName: "dist-1", IngressVLAN: &vlan10, EgressVLAN: &vlan20},
+		{DeviceName: "access-2", IngressVLAN: &vlan20},
+	}}})
+
+	if len(transitions) != 1 {
+		t.Fatalf("expected one transition, got %#v", transitions)
+	}
+	if got := transitions[0]; got.DeviceName.ValueString() != "dist-1" || got.HopIndex.ValueInt64() != 1 || got.ToVLAN.ValueInt64() != 20 {
+		t.Fatalf("unexpected transition: %#v", got)
+	}
+}
+
+func TestAccL2PathDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	vlan10, vlan20 := int64(10), int64(20)
+	server.SetL2PathResult("net-1", fwdclient.L2PathSearchResult{Paths: []fwdclient.L2Path{{
+		Outcome: "DELIVERED",
+		Hops: []fwdclient.L2PathHop{
+			{DeviceName: "access-1", IngressInterface: "gi1/0/1", EgressInterface: "gi1/0/48", IngressVLAN: &vlan10, EgressVLAN: &vlan10},
+			{DeviceName: "dist-1", IngressInterface: "te1/1", EgressInterface: "te1/2", IngressVLAN: &vlan10, EgressVLAN: &vlan20},
+		},
+	}}})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_l2_path" "test" {
+  network_id = "net-1"
+  src_mac    = "00:00:5e:00:53:01"
+  dst_mac    = "00:00:5e:00:53:02"
+  vlan       = 10
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_l2_path.test", "outcomes.0", "DELIVERED"),
+					resource.TestCheckResourceAttr("data.forward_l2_path.test", "hops.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_l2_path.test", "hops.1.egress_vlan", "20"),
+					resource.TestCheckResourceAttr("data.forward_l2_path.test", "vlan_transitions.#", "1"),
+					resource.TestCheckResourceAttr("data.forward_l2_path.test", "vlan_transitions.0.device_name", "dist-1"),
+				),
+			},
+		},
+	})
+}
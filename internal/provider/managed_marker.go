@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// managedByTag marks checks created by the provider. Imported checks without it were
+// created elsewhere, typically in the Forward UI, and are only changed or deactivated
+// once adopted with `adopt = true`.
+const managedByTag = "managed-by:terraform"
+
+// importedCheckPrivateKey marks check state created by import. Only imported checks must
+// carry the managed-by marker; checks created by the provider, including those created
+// before it tagged them, are managed by Terraform already.
+const importedCheckPrivateKey = "imported_check"
+
+// privateStateReader is the private state of a resource request.
+type privateStateReader interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// withManagedByTag returns tags with the managed-by marker appended.
+func withManagedByTag(tags []string) []string {
+	if slices.Contains(tags, managedByTag) {
+		return tags
+	}
+	return append(slices.Clone(tags), managedByTag)
+}
+
+// withoutManagedByTag returns tags without the managed-by marker, so the marker never
+// shows up as drift against the configured tags.
+func withoutManagedByTag(tags []string) []string {
+	return slices.DeleteFunc(slices.Clone(tags), func(tag string) bool { return tag == managedByTag })
+}
+
+// adoptAttribute is the `adopt` override shared by resources that manage existing checks.
+func adoptAttribute(subject string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("Allow Terraform to update and deactivate %s when imported without the `%s` tag "+
+			"the provider adds to the checks it creates. Set it when importing checks created in the Forward UI; "+
+			"otherwise such checks are protected from accidental changes. Defaults to `false`.", subject, managedByTag),
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// requireManagedCheck reports an error when an imported check lacks the managed-by
+// marker and has not been adopted. Checks that no longer exist pass, so deletes stay
+// idempotent.
+func (d *ForwardProviderData) requireManagedCheck(ctx context.Context, private privateStateReader, snapshotID, checkID string, adopt types.Bool, action string) diag.Diagnostics {
+	if adopt.ValueBool() {
+		return nil
+	}
+	imported, diags := private.GetKey(ctx, importedCheckPrivateKey)
+	if diags.HasError() || len(imported) == 0 {
+		return diags
+	}
+
+	check, err := d.readSnapshotCheck(ctx, snapshotID, checkID)
+	if isNotFoundError(err) {
+		return diags
+	}
+	if err != nil {
+		addAPIError(&diags, "Error reading check", err)
+		return diags
+	}
+	if !slices.Contains(check.Tags, managedByTag) {
+		diags.AddAttributeError(path.Root("adopt"), "Check Not Managed by Terraform",
+			fmt.Sprintf("Check %s in snapshot %s has no %q tag, so it was not created by Terraform and is not %s. "+
+				"Set adopt = true to let Terraform manage it.", checkID, snapshotID, managedByTag, action))
+	}
+	return diags
+}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestManagedByTag(t *testing.T) {
+	t.Parallel()
+
+	tags := []string{"iac"}
+	marked := withManagedByTag(tags)
+	if fmt.Sprint(marked) != "[iac managed-by:terraform]" || fmt.Sprint(tags) != "[iac]" {
+		t.Fatalf("unexpected marked tags %v (input %v)", marked, tags)
+	}
+	if again := withManagedByTag(marked); len(again) != 2 {
+		t.Fatalf("expected the marker once, got %v", again)
+	}
+	if unmarked := withoutManagedByTag(marked); fmt.Sprint(unmarked) != "[iac]" {
+		t.Fatalf("unexpected unmarked tags %v", unmarked)
+	}
+}
+
+func TestRequireManagedCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	managed := server.AddCheck("snap-1", fwdclient.CheckResult{Name: "Terraform", Tags: []string{managedByTag}})
+	unmanaged := server.AddCheck("snap-1", fwdclient.CheckResult{Name: "UI", Tags: []string{"team-net"}})
+
+	client, err := fwdclient.NewClient(ctx, fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	providerData := &ForwardProviderData{Client: client, checks: newCheckCache()}
+
+	imported := testPrivateState{importedCheckPrivateKey: []byte("true")}
+	cases := []struct {
+		checkID string
+		private testPrivateState
+		adopt   types.Bool
+		wantErr bool
+	}{
+		{managed, imported, types.BoolValue(false), false},
+		{unmanaged, imported, types.BoolValue(false), true},
+		{unmanaged, imported, types.BoolNull(), true},
+		{unmanaged, imported, types.BoolValue(true), false},
+		{"missing", imported, types.BoolValue(false), false},
+		// Checks created by the provider before it tagged them, with adopt unset.
+		{unmanaged, nil, types.BoolNull(), false},
+		{unmanaged, nil, types.BoolValue(false), false},
+	}
+	for _, tc := range cases {
+		diags := providerData.requireManagedCheck(ctx, tc.private, "snap-1", tc.checkID, tc.adopt, "deactivated")
+		if diags.HasError() != tc.wantErr {
+			t.Errorf("check %s imported %t adopt %s: expected error %t, got %v", tc.checkID, tc.private != nil, tc.adopt, tc.wantErr, diags)
+		}
+	}
+}
+
+// testPrivateState is resource private state keyed by name.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func TestAccIntentCheckResourceUntaggedCreatedCheck(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_intent_check" "test" {
+  snapshot_id     = "snap-1"
+  name            = %q
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+  tags            = ["dmz"]
+}
+`, server.URL, name)
+	}
+
+	var checkID string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Created by Terraform"),
+				Check: func(s *terraform.State) error {
+					checkID = s.RootModule().Resources["forward_intent_check.test"].Primary.ID
+					return nil
+				},
+			},
+			{
+				// Checks created before the provider tagged them stay manageable.
+				PreConfig: func() { server.SetCheckTags("snap-1", checkID, []string{"dmz"}) },
+				Config:    config("Renamed by Terraform"),
+				Check:     resource.TestCheckResourceAttr("forward_intent_check.test", "name", "Renamed by Terraform"),
+			},
+		},
+	})
+}
+
+func TestAccIntentCheckResourceAdoption(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	checkID := server.AddCheck("snap-1", fwdclient.CheckResult{
+		Name:       "Created in the UI",
+		Definition: []byte(`{"checkType":"NQE","queryId":"FQ_test"}`),
+	})
+
+	config := func(name string, adopt bool) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+import {
+  to = forward_intent_check.test
+  id = "snap-1/%s"
+}
+
+resource "forward_intent_check" "test" {
+  snapshot_id     = "snap-1"
+  name            = %q
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+  adopt           = %t
+}
+`, server.URL, checkID, name, adopt)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("Renamed by Terraform", false),
+				ExpectError: regexp.MustCompile(`Check Not Managed by Terraform`),
+			},
+			{
+				Config: config("Renamed by Terraform", true),
+				Check:  resource.TestCheckResourceAttr("forward_intent_check.test", "name", "Renamed by Terraform"),
+			},
+		},
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &NormalizeIPFunction{}
+
+// NormalizeIPFunction returns the canonical text form of an address or prefix.
+type NormalizeIPFunction struct{}
+
+func NewNormalizeIPFunction() function.Function {
+	return &NormalizeIPFunction{}
+}
+
+func (f *NormalizeIPFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_ip"
+}
+
+func (f *NormalizeIPFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Return the canonical form of an IP address or CIDR prefix",
+		MarkdownDescription: "Returns `ip` in canonical form so values from different sources compare equal and can be " +
+			"passed to path analysis or used as alias entries: surrounding whitespace and IPv6 zones are removed, IPv6 " +
+			"is lower-cased and compressed, IPv4-mapped IPv6 addresses become IPv4, and host bits of a CIDR prefix are " +
+			"cleared. For example `\" 2001:DB8:0:0::1 \"` becomes `2001:db8::1` and `10.1.2.3/24` becomes `10.1.2.0/24`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ip",
+				MarkdownDescription: "IP address or CIDR prefix to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeIPFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ip string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ip))
+	if resp.Error != nil {
+		return
+	}
+
+	prefix, err := parseIPOrPrefix(ip)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	normalized := prefix.Addr().String()
+	if strings.Contains(ip, "/") {
+		normalized = prefix.Masked().String()
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalized))
+}
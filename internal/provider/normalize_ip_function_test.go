@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeIPFunction(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		" 10.1.2.3 ":        "10.1.2.3",
+		"2001:DB8:0:0::1":   "2001:db8::1",
+		"::ffff:10.1.2.3":   "10.1.2.3",
+		"fe80::1%eth0":      "fe80::1",
+		"10.1.2.3/24":       "10.1.2.0/24",
+		"10.1.2.3/32":       "10.1.2.3/32",
+		"2001:db8::1:0/112": "2001:db8::1:0/112",
+		"2001:db8::1:5/112": "2001:db8::1:0/112",
+	}
+	for input, want := range cases {
+		got, err := runFunction(NewNormalizeIPFunction(), types.StringUnknown(), input)
+		if err != nil {
+			t.Fatalf("normalize_ip(%q): %v", input, err)
+		}
+		if !got.Equal(types.StringValue(want)) {
+			t.Errorf("normalize_ip(%q) = %s, want %q", input, got, want)
+		}
+	}
+
+	if _, err := runFunction(NewNormalizeIPFunction(), types.StringUnknown(), "router1"); err == nil {
+		t.Fatal("expected an error for a hostname")
+	}
+}
@@ -0,0 +1,561 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ resource.Resource = &NQECheckResource{}
+var _ resource.ResourceWithImportState = &NQECheckResource{}
+var _ resource.ResourceWithConfigValidators = &NQECheckResource{}
+var _ resource.ResourceWithModifyPlan = &NQECheckResource{}
+
+// NQECheckResource manages an NQE intent check built from a query reference and thresholds.
+type NQECheckResource struct {
+	providerData *ForwardProviderData
+}
+
+// NQECheckResourceModel maps Terraform schema data.
+type NQECheckResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Profile         types.String `tfsdk:"profile"`
+	SnapshotID      types.String `tfsdk:"snapshot_id"`
+	Persistent      types.Bool   `tfsdk:"persistent"`
+	QueryPath       types.String `tfsdk:"query_path"`
+	Repository      types.String `tfsdk:"repository"`
+	QueryID         types.String `tfsdk:"query_id"`
+	CommitID        types.String `tfsdk:"commit_id"`
+	ParamsJSON      types.String `tfsdk:"params_json"`
+	ViolationColumn types.String `tfsdk:"violation_column"`
+	MaxViolations   types.Int64  `tfsdk:"max_violations"`
+	Name            types.String `tfsdk:"name"`
+	Note            types.String `tfsdk:"note"`
+	Priority        types.String `tfsdk:"priority"`
+	Tags            types.List   `tfsdk:"tags"`
+
+	DefinitionJSON types.String `tfsdk:"definition_json"`
+	Status         types.String `tfsdk:"status"`
+	NumViolations  types.Int64  `tfsdk:"num_violations"`
+	Adopt          types.Bool   `tfsdk:"adopt"`
+}
+
+// nqeCheckAPIFields maps check request fields to the attributes that supply them.
+var nqeCheckAPIFields = map[string]path.Path{
+	"queryId":  path.Root("query_id"),
+	"commitId": path.Root("commit_id"),
+	"params":   path.Root("params_json"),
+	"name":     path.Root("name"),
+	"note":     path.Root("note"),
+	"priority": path.Root("priority"),
+	"tags":     path.Root("tags"),
+}
+
+func NewNQECheckResource() resource.Resource {
+	return &NQECheckResource{}
+}
+
+func (r *NQECheckResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nqe_check"
+}
+
+func (r *NQECheckResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage an NQE intent check on a snapshot without writing `definition_json` by hand. The check " +
+			"runs a library query, pinned to a commit, and fails when more than `max_violations` rows are violations.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier assigned by Forward Enterprise for the intent check.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"snapshot_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Snapshot identifier the check is evaluated against.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"persistent": schema.BoolAttribute{
+				MarkdownDescription: "Whether the intent check should persist to future snapshots. Changing it updates the check " +
+					"in place, so an ad-hoc snapshot check can be promoted to a persistent one.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"query_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Library path of the query, resolved to `query_id` in `repository`. Conflicts with `query_id`.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("query_path"), path.MatchRoot("query_id")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repository": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Repository `query_path` is resolved in: `ORG` or `FWD`.",
+				Default:             stringdefault.StaticString(nqePackRepository),
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(nqePackRepository, nqeVendorRepository),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"query_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Identifier of the query the check runs. Resolved from `query_path` when that is set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"commit_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Query commit the check is pinned to. Defaults to the query's latest commit when the check is created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"params_json": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "JSON object of query parameter values.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"violation_column": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Boolean result column marking violating rows. By default every returned row is a violation.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_violations": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Number of violating rows tolerated before the check fails. Defaults to 0.",
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Optional human readable name for the intent check. Renaming updates the check in place.",
+			},
+			"note": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Optional descriptive note stored with the check.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"priority": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Intent check priority (NOT_SET, LOW, MEDIUM, HIGH). Defaults to the provider `default_check_priority`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tags": schema.ListAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Tags assigned to the intent check. Defaults to the provider `default_check_tags`. The `managed-by:terraform` tag the provider adds on create is not listed.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"definition_json": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Check definition built from the query reference and thresholds, encoded as JSON.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Last known Forward Enterprise status for the check. Kept from state when planning updates.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"num_violations": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of violations detected by the check. Kept from state when planning updates.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"adopt": adoptAttribute("the check"),
+		},
+	}
+}
+
+func (r *NQECheckResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validateSnapshotReferences(func() *ForwardProviderData { return r.providerData }, "snapshot_id"),
+	}
+}
+
+// ModifyPlan applies the provider check defaults to new checks.
+func (r *NQECheckResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planCheckDefaults(ctx, r.providerData, req, resp)
+}
+
+func (r *NQECheckResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *NQECheckResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan NQECheckResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve the query reference, and the commit to pin when none is configured.
+	if plan.QueryID.IsUnknown() || plan.CommitID.IsUnknown() {
+		var query *fwdclient.NqeQuery
+		if !plan.QueryPath.IsNull() {
+			query, diags = lookupQuery(ctx, providerData, plan.QueryPath.ValueString(), plan.Repository.ValueString())
+		} else {
+			query, diags = lookupQueryByID(ctx, providerData, plan.QueryID.ValueString())
+		}
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if query == nil {
+			resp.Diagnostics.AddError(
+				"NQE query not found",
+				"The query referenced by query_path or query_id does not exist in the Forward library.",
+			)
+			return
+		}
+
+		plan.QueryID = types.StringValue(query.QueryID)
+		if plan.CommitID.IsUnknown() {
+			plan.CommitID = types.StringValue(query.LastCommitID)
+			if query.LastCommitID == "" {
+				resp.Diagnostics.AddAttributeError(path.Root("commit_id"), "Missing Query Commit",
+					fmt.Sprintf("Forward Enterprise did not report a commit for query %s; set commit_id explicitly.", query.QueryID))
+				return
+			}
+		}
+	}
+
+	definition, diags := nqeCheckDefinition(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqBody := fwdclient.NewCheckRequest{
+		Definition: definition,
+		Name:       stringOrEmpty(plan.Name),
+		Note:       stringOrEmpty(plan.Note),
+		Priority:   stringOrEmpty(plan.Priority),
+		Tags:       withManagedByTag(stringList(plan.Tags)),
+	}
+
+	result, err := providerData.Client.AddSnapshotCheck(ctx, plan.SnapshotID.ValueString(), reqBody, boolPointer(plan.Persistent))
+	providerData.checks.invalidate(plan.SnapshotID.ValueString())
+	if err != nil {
+		addAPIErrorWithPaths(&resp.Diagnostics, "Error creating NQE check", err, nqeCheckAPIFields)
+		return
+	}
+
+	plan.ID = types.StringValue(result.ID)
+	setNQECheckState(&plan, result)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NQECheckResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state NQECheckResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := providerData.readSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error reading NQE check", err)
+		return
+	}
+
+	// Imported checks only know their ID; recover the query reference from the definition.
+	if state.QueryID.IsNull() {
+		resp.Diagnostics.Append(setNQECheckDefinitionState(&state, result.Definition)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.Note = stringOrNull(result.Note)
+		state.Priority = stringOrNull(result.Priority)
+		state.Tags = stringSliceToList(withoutManagedByTag(result.Tags))
+	}
+
+	setNQECheckState(&state, result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *NQECheckResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan, state NQECheckResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Status = state.Status
+	plan.NumViolations = state.NumViolations
+
+	// Only the name and persistence can be changed in place; every other change forces
+	// replacement.
+	if update, changed := checkUpdateRequest(plan.Name, state.Name, plan.Persistent, state.Persistent); changed {
+		resp.Diagnostics.Append(providerData.requireManagedCheck(ctx, req.Private, state.SnapshotID.ValueString(), state.ID.ValueString(), plan.Adopt, "updated")...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		result, err := providerData.Client.UpdateSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString(), update)
+		providerData.checks.invalidate(state.SnapshotID.ValueString())
+		if err != nil {
+			addAPIErrorWithPaths(&resp.Diagnostics, "Error updating NQE check", err, nqeCheckAPIFields)
+			return
+		}
+		setNQECheckState(&plan, result)
+		// An update does not execute the check; keep the execution fields as planned.
+		plan.Status = state.Status
+		plan.NumViolations = state.NumViolations
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NQECheckResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state NQECheckResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.requireManagedCheck(ctx, req.Private, state.SnapshotID.ValueString(), state.ID.ValueString(), state.Adopt, "deactivated")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := providerData.Client.DeactivateSnapshotCheck(ctx, state.SnapshotID.ValueString(), state.ID.ValueString())
+	providerData.checks.invalidate(state.SnapshotID.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		addAPIError(&resp.Diagnostics, "Error deleting NQE check", err)
+	}
+}
+
+func (r *NQECheckResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) == 3 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile"), parts[0])...)
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError("Invalid import format", "Use: snapshot_id/check_id or profile/snapshot_id/check_id")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("snapshot_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("persistent"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repository"), nqePackRepository)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("adopt"), false)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedCheckPrivateKey, []byte("true"))...)
+}
+
+// nqeCheckDefinition builds the NQE check definition for the resolved query reference.
+func nqeCheckDefinition(model NQECheckResourceModel) (fwdclient.CheckDefinition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	definition := fwdclient.CheckDefinition{
+		"checkType": "NQE",
+		"queryId":   model.QueryID.ValueString(),
+		"commitId":  model.CommitID.ValueString(),
+	}
+
+	if params := model.ParamsJSON.ValueString(); params != "" {
+		var values map[string]any
+		if err := json.Unmarshal([]byte(params), &values); err != nil {
+			diags.AddAttributeError(path.Root("params_json"), "Invalid Parameters JSON", err.Error())
+			return nil, diags
+		}
+		definition["params"] = values
+	}
+
+	if column := model.ViolationColumn.ValueString(); column != "" {
+		definition["violationPredicate"] = map[string]any{"columnName": column, "value": true}
+	}
+	if maxViolations := model.MaxViolations.ValueInt64(); maxViolations > 0 {
+		definition["maxViolations"] = maxViolations
+	}
+
+	return definition, diags
+}
+
+// setNQECheckDefinitionState fills the query reference and thresholds from a stored
+// definition, the inverse of nqeCheckDefinition.
+func setNQECheckDefinitionState(model *NQECheckResourceModel, raw json.RawMessage) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var definition struct {
+		CheckType          string          `json:"checkType"`
+		QueryID            string          `json:"queryId"`
+		CommitID           string          `json:"commitId"`
+		Params             json.RawMessage `json:"params"`
+		ViolationPredicate *struct {
+			ColumnName string `json:"columnName"`
+		} `json:"violationPredicate"`
+		MaxViolations int64 `json:"maxViolations"`
+	}
+	if err := json.Unmarshal(raw, &definition); err != nil {
+		diags.AddError("Invalid Check Definition", err.Error())
+		return diags
+	}
+	if definition.CheckType != "NQE" || definition.QueryID == "" {
+		diags.AddError("Not an NQE Check",
+			fmt.Sprintf("Intent check %s is not an NQE check; import it as forward_intent_check instead.", model.ID.ValueString()))
+		return diags
+	}
+
+	model.QueryID = types.StringValue(definition.QueryID)
+	model.CommitID = stringOrNull(definition.CommitID)
+	model.ParamsJSON = checkDefinitionString(definition.Params)
+	model.ViolationColumn = types.StringNull()
+	if definition.ViolationPredicate != nil {
+		model.ViolationColumn = stringOrNull(definition.ViolationPredicate.ColumnName)
+	}
+	model.MaxViolations = types.Int64Value(definition.MaxViolations)
+	return diags
+}
+
+func setNQECheckState(model *NQECheckResourceModel, result *fwdclient.CheckResult) {
+	if result == nil {
+		return
+	}
+
+	model.Name = stringOrNull(result.Name)
+	model.Status = stringOrNull(result.Status)
+	model.NumViolations = int64PointerOrNull(result.NumViolations)
+	model.DefinitionJSON = checkDefinitionString(result.Definition)
+}
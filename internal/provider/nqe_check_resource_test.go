@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestNQECheckResource(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddNQEQuery(fwdclient.NqeQuery{QueryID: "FQ_mtu", Repository: "FWD", Path: "/L3/Mtu", LastCommitID: "commit-1"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: nqeCheckTestConfig(server.URL, "MTU consistency"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("forward_nqe_check.test", "id"),
+					resource.TestCheckResourceAttr("forward_nqe_check.test", "query_id", "FQ_mtu"),
+					resource.TestCheckResourceAttr("forward_nqe_check.test", "commit_id", "commit-1"),
+					resource.TestCheckResourceAttr("forward_nqe_check.test", "definition_json",
+						`{"checkType":"NQE","commitId":"commit-1","maxViolations":2,"params":{"mtu":9000},"queryId":"FQ_mtu","violationPredicate":{"columnName":"violation","value":true}}`),
+				),
+			},
+			{
+				// Renaming updates the check in place.
+				Config: nqeCheckTestConfig(server.URL, "Jumbo MTU"),
+				Check:  resource.TestCheckResourceAttr("forward_nqe_check.test", "name", "Jumbo MTU"),
+			},
+			{
+				ResourceName: "forward_nqe_check.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					return "snap-1/" + state.RootModule().Resources["forward_nqe_check.test"].Primary.ID, nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"query_path", "repository"},
+			},
+		},
+	})
+}
+
+func nqeCheckTestConfig(host, name string) string {
+	return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_nqe_check" "test" {
+  snapshot_id      = "snap-1"
+  query_path       = "/L3/Mtu"
+  repository       = "FWD"
+  params_json      = jsonencode({ mtu = 9000 })
+  violation_column = "violation"
+  max_violations   = 2
+  name             = %q
+}
+`, host, name)
+}
+
+func TestNQECheckDefinitionRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	model := NQECheckResourceModel{
+		QueryID:         types.StringValue("FQ_1"),
+		CommitID:        types.StringValue("commit-1"),
+		ParamsJSON:      types.StringValue(`{"site":"nyc"}`),
+		ViolationColumn: types.StringNull(),
+		MaxViolations:   types.Int64Value(0),
+	}
+	definition, diags := nqeCheckDefinition(model)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if _, ok := definition["violationPredicate"]; ok {
+		t.Fatalf("expected no violation predicate without violation_column: %v", definition)
+	}
+	if _, ok := definition["maxViolations"]; ok {
+		t.Fatalf("expected maxViolations to be omitted at zero: %v", definition)
+	}
+
+	raw, err := json.Marshal(definition)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var imported NQECheckResourceModel
+	if diags := setNQECheckDefinitionState(&imported, raw); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !imported.QueryID.Equal(model.QueryID) || !imported.CommitID.Equal(model.CommitID) ||
+		!imported.ParamsJSON.Equal(model.ParamsJSON) || !imported.ViolationColumn.IsNull() ||
+		imported.MaxViolations.ValueInt64() != 0 {
+		t.Fatalf("definition did not round trip: %+v", imported)
+	}
+
+	model.ParamsJSON = types.StringValue(`[1]`)
+	if _, diags := nqeCheckDefinition(model); !diags.HasError() {
+		t.Fatal("expected error for non-object params_json")
+	}
+
+	if diags := setNQECheckDefinitionState(&imported, json.RawMessage(`{"checkType":"Existential"}`)); !diags.HasError() {
+		t.Fatal("expected error importing a non-NQE check")
+	}
+}
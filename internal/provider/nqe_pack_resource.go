@@ -0,0 +1,428 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	schemavalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ resource.Resource = &NQEPackResource{}
+var _ resource.ResourceWithModifyPlan = &NQEPackResource{}
+
+// nqePackRepository is the NQE repository packs are committed to.
+const nqePackRepository = "ORG"
+
+// NQEPackResource installs a local directory of .nqe files into the org repository.
+type NQEPackResource struct {
+	providerData *ForwardProviderData
+}
+
+// NQEPackResourceModel maps Terraform schema data.
+type NQEPackResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Profile       types.String `tfsdk:"profile"`
+	SourceDir     types.String `tfsdk:"source_dir"`
+	TargetDir     types.String `tfsdk:"target_dir"`
+	CommitMessage types.String `tfsdk:"commit_message"`
+	QueryHashes   types.Map    `tfsdk:"query_hashes"`
+	CommitID      types.String `tfsdk:"commit_id"`
+}
+
+func NewNQEPackResource() resource.Resource {
+	return &NQEPackResource{}
+}
+
+func (r *NQEPackResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nqe_pack"
+}
+
+func (r *NQEPackResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Install a directory tree of `.nqe` files into the Forward Enterprise org NQE repository. " +
+			"All changes are applied in a single commit, and only queries whose content changed are recommitted.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Internal Terraform identifier (mirrors target_dir).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_dir": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "Local directory containing the `.nqe` files, for example `\"${path.module}/queries\"`. " +
+					"Subdirectories become subdirectories of `target_dir`.",
+			},
+			"target_dir": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Repository directory the queries are installed under (for example, `/Packs/Compliance`).",
+				Validators: []schemavalidator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must be an absolute repository path starting with /"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"commit_message": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Message recorded on each commit. Defaults to a message naming `target_dir`.",
+			},
+			"query_hashes": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "SHA-256 checksum of each installed query's source, keyed by repository path.",
+			},
+			"commit_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the most recent commit made for this pack.",
+			},
+		},
+	}
+}
+
+func (r *NQEPackResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// ModifyPlan hashes the local query files so edits show up as a planned change to
+// query_hashes.
+func (r *NQEPackResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan NQEPackResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.SourceDir.IsUnknown() || plan.TargetDir.IsUnknown() {
+		return
+	}
+
+	sources, err := loadNQEPack(plan.SourceDir.ValueString(), plan.TargetDir.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source_dir"), "Error Reading NQE Pack", err.Error())
+		return
+	}
+
+	hashes, diags := types.MapValueFrom(ctx, types.StringType, nqePackHashes(sources))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state NQEPackResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Keep the previous commit ID when nothing will be recommitted.
+		if hashes.Equal(state.QueryHashes) {
+			plan.CommitID = state.CommitID
+		}
+	}
+
+	plan.QueryHashes = hashes
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *NQEPackResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan NQEPackResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, map[string]string{}, &resp.Diagnostics, &resp.State)
+}
+
+func (r *NQEPackResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state NQEPackResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	queries, err := providerData.Client.ListNQEQueries(ctx, state.TargetDir.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error listing NQE queries", err)
+		return
+	}
+
+	installed := make(map[string]bool, len(queries))
+	for _, query := range queries {
+		if strings.EqualFold(query.Repository, nqePackRepository) {
+			installed[query.Path] = true
+		}
+	}
+
+	var hashes map[string]string
+	resp.Diagnostics.Append(state.QueryHashes.ElementsAs(ctx, &hashes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Forget queries removed outside Terraform so the next apply reinstalls them.
+	for queryPath := range hashes {
+		if !installed[queryPath] {
+			delete(hashes, queryPath)
+		}
+	}
+
+	state.QueryHashes, diags = types.MapValueFrom(ctx, types.StringType, hashes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *NQEPackResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan, state NQEPackResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var previous map[string]string
+	resp.Diagnostics.Append(state.QueryHashes.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.CommitID.IsUnknown() {
+		plan.CommitID = state.CommitID
+	}
+
+	r.apply(ctx, &plan, previous, &resp.Diagnostics, &resp.State)
+}
+
+func (r *NQEPackResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state NQEPackResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var hashes map[string]string
+	resp.Diagnostics.Append(state.QueryHashes.ElementsAs(ctx, &hashes, false)...)
+	if resp.Diagnostics.HasError() || len(hashes) == 0 {
+		return
+	}
+
+	changes := make([]fwdclient.NqeQueryChange, 0, len(hashes))
+	for _, queryPath := range sortedKeys(hashes) {
+		changes = append(changes, fwdclient.NqeQueryChange{Path: queryPath, Delete: true})
+	}
+
+	request := fwdclient.NqeCommitRequest{Message: nqePackMessage(state, "Remove"), Changes: changes}
+	_, err := providerData.Client.CommitNQEQueries(ctx, nqePackRepository, request)
+	providerData.queries.invalidate()
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error removing NQE pack", err)
+	}
+}
+
+// apply commits the difference between the local pack and the previously installed
+// hashes, then stores the result in state.
+func (r *NQEPackResource) apply(ctx context.Context, plan *NQEPackResourceModel, previous map[string]string, diags *diag.Diagnostics, state *tfsdk.State) {
+	providerData, profileDiags := r.providerData.forProfile(plan.Profile)
+	diags.Append(profileDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	sources, err := loadNQEPack(plan.SourceDir.ValueString(), plan.TargetDir.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("source_dir"), "Error Reading NQE Pack", err.Error())
+		return
+	}
+
+	hashes := nqePackHashes(sources)
+	changes := nqePackChanges(sources, hashes, previous)
+	if len(changes) > 0 {
+		if !providerData.requireFeature(ctx, featureNQECommits, path.Empty(), diags) {
+			return
+		}
+
+		request := fwdclient.NqeCommitRequest{Message: nqePackMessage(*plan, "Update"), Changes: changes}
+		commit, err := providerData.Client.CommitNQEQueries(ctx, nqePackRepository, request)
+		providerData.queries.invalidate()
+		if err != nil {
+			addAPIError(diags, "Error committing NQE pack", err)
+			return
+		}
+		plan.CommitID = types.StringValue(commit.ID)
+	}
+	if plan.CommitID.IsUnknown() {
+		plan.CommitID = types.StringNull()
+	}
+
+	var mapDiags diag.Diagnostics
+	plan.QueryHashes, mapDiags = types.MapValueFrom(ctx, types.StringType, hashes)
+	diags.Append(mapDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	plan.ID = plan.TargetDir
+	diags.Append(state.Set(ctx, plan)...)
+}
+
+// loadNQEPack reads every .nqe file under sourceDir and returns its source keyed by
+// repository path, with the .nqe suffix removed.
+func loadNQEPack(sourceDir, targetDir string) (map[string]string, error) {
+	info, err := os.Stat(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", sourceDir)
+	}
+
+	prefix := strings.TrimSuffix(targetDir, "/")
+	sources := map[string]string{}
+	err = filepath.WalkDir(sourceDir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(name) != ".nqe" {
+			return err
+		}
+
+		rel, err := filepath.Rel(sourceDir, name)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		sources[prefix+"/"+strings.TrimSuffix(filepath.ToSlash(rel), ".nqe")] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+func nqePackHashes(sources map[string]string) map[string]string {
+	hashes := make(map[string]string, len(sources))
+	for queryPath, source := range sources {
+		hashes[queryPath] = contentChecksum([]byte(source))
+	}
+	return hashes
+}
+
+// nqePackChanges returns the upserts for new or edited queries and deletions for
+// queries no longer in the pack, sorted by path.
+func nqePackChanges(sources, hashes, previous map[string]string) []fwdclient.NqeQueryChange {
+	var changes []fwdclient.NqeQueryChange
+	for _, queryPath := range sortedKeys(sources) {
+		if previous[queryPath] != hashes[queryPath] {
+			changes = append(changes, fwdclient.NqeQueryChange{Path: queryPath, Source: sources[queryPath]})
+		}
+	}
+	for _, queryPath := range sortedKeys(previous) {
+		if _, ok := sources[queryPath]; !ok {
+			changes = append(changes, fwdclient.NqeQueryChange{Path: queryPath, Delete: true})
+		}
+	}
+	return changes
+}
+
+func nqePackMessage(model NQEPackResourceModel, verb string) string {
+	if !model.CommitMessage.IsNull() && !model.CommitMessage.IsUnknown() && model.CommitMessage.ValueString() != "" {
+		return model.CommitMessage.ValueString()
+	}
+	return fmt.Sprintf("%s NQE pack %s", verb, model.TargetDir.ValueString())
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestLoadNQEPackAndChanges(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "mtu.nqe"), "select 1")
+	writeTestFile(t, filepath.Join(dir, "l2", "vlans.nqe"), "select 2")
+	writeTestFile(t, filepath.Join(dir, "README.md"), "ignored")
+
+	sources, err := loadNQEPack(dir, "/Packs/Core/")
+	if err != nil {
+		t.Fatalf("loadNQEPack: %v", err)
+	}
+	want := map[string]string{"/Packs/Core/mtu": "select 1", "/Packs/Core/l2/vlans": "select 2"}
+	if !reflect.DeepEqual(sources, want) {
+		t.Fatalf("expected %v, got %v", want, sources)
+	}
+
+	hashes := nqePackHashes(sources)
+	previous := map[string]string{
+		"/Packs/Core/mtu":  hashes["/Packs/Core/mtu"],
+		"/Packs/Core/old":  "stale",
+		"/Packs/Core/l2/x": "stale",
+	}
+	changes := nqePackChanges(sources, hashes, previous)
+	wantChanges := []fwdclient.NqeQueryChange{
+		{Path: "/Packs/Core/l2/vlans", Source: "select 2"},
+		{Path: "/Packs/Core/l2/x", Delete: true},
+		{Path: "/Packs/Core/old", Delete: true},
+	}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Fatalf("expected %v, got %v", wantChanges, changes)
+	}
+}
+
+func TestAccNQEPackResource(t *testing.T) {
+	server := fakeforward.New(t)
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "mtu.nqe"), "select 1")
+	writeTestFile(t, filepath.Join(dir, "vlans.nqe"), "select 2")
+
+	config := fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_nqe_pack" "test" {
+  source_dir = %q
+  target_dir = "/Packs/Core"
+}
+`, server.URL, dir)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if _, ok := server.NQEQuerySource("/Packs/Core/mtu"); ok {
+				return fmt.Errorf("expected pack queries to be removed")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_nqe_pack.test", "query_hashes.%", "2"),
+					resource.TestCheckResourceAttrSet("forward_nqe_pack.test", "commit_id"),
+				),
+			},
+			{
+				PreConfig: func() {
+					writeTestFile(t, filepath.Join(dir, "mtu.nqe"), "select 3")
+					if err := os.Remove(filepath.Join(dir, "vlans.nqe")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_nqe_pack.test", "query_hashes.%", "1"),
+					func(*terraform.State) error {
+						if source, _ := server.NQEQuerySource("/Packs/Core/mtu"); source != "select 3" {
+							return fmt.Errorf("expected updated source, got %q", source)
+						}
+						if _, ok := server.NQEQuerySource("/Packs/Core/vlans"); ok {
+							return fmt.Errorf("expected removed query to be deleted")
+						}
+						if got := server.RequestCount(http.MethodPost, "/api/nqe/repos/org/commits"); got != 2 {
+							return fmt.Errorf("expected one commit per apply, got %d", got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func writeTestFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// nqeQueryCacheTTL bounds how long a library listing is reused. Plans are usually much
+// shorter; the TTL only matters for long applies that also edit the library elsewhere.
+const nqeQueryCacheTTL = 5 * time.Minute
+
+// nqeQueryCache lists each NQE library directory at most once per TTL, so configurations
+// with many forward_nqe_query_definition resources do not download the library once per
+// resource.
+type nqeQueryCache struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	now  func() time.Time
+	dirs map[string]*nqeQueryCacheEntry
+	// hits counts listings served from an earlier request.
+	hits atomic.Int64
+}
+
+type nqeQueryCacheEntry struct {
+	once    sync.Once
+	expires time.Time
+	queries []fwdclient.NqeQuery
+	err     error
+}
+
+func newNqeQueryCache(ttl time.Duration) *nqeQueryCache {
+	return &nqeQueryCache{ttl: ttl, now: time.Now, dirs: map[string]*nqeQueryCacheEntry{}}
+}
+
+// list returns the committed queries under dir, loading the listing on first use or
+// once the cached listing has expired. Failed listings are not cached.
+func (c *nqeQueryCache) list(ctx context.Context, client *fwdclient.Client, dir string) ([]fwdclient.NqeQuery, error) {
+	if c == nil {
+		return client.ListNQEQueries(ctx, dir)
+	}
+
+	c.mu.Lock()
+	entry, ok := c.dirs[dir]
+	if !ok || !c.now().Before(entry.expires) {
+		entry = &nqeQueryCacheEntry{expires: c.now().Add(c.ttl)}
+		c.dirs[dir] = entry
+	}
+	c.mu.Unlock()
+
+	// Concurrent lookups in the same directory wait for a single listing request.
+	loaded := false
+	entry.once.Do(func() {
+		loaded = true
+		entry.queries, entry.err = client.ListNQEQueries(ctx, dir)
+	})
+
+	if entry.err != nil {
+		c.mu.Lock()
+		if c.dirs[dir] == entry {
+			delete(c.dirs, dir)
+		}
+		c.mu.Unlock()
+		return nil, entry.err
+	}
+	if !loaded {
+		c.hits.Add(1)
+	}
+	return entry.queries, nil
+}
+
+// invalidate drops every cached listing after the library changes.
+func (c *nqeQueryCache) invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dirs = map[string]*nqeQueryCacheEntry{}
+}
+
+// nqeQueryDir returns the library directory holding queryPath, used as the server-side
+// filter when looking the query up.
+func nqeQueryDir(queryPath string) string {
+	i := strings.LastIndex(queryPath, "/")
+	if i < 0 {
+		return ""
+	}
+	return queryPath[:i+1]
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestLookupQueryListsOncePerDirectory(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddNQEQuery(fwdclient.NqeQuery{QueryID: "Q_a", Path: "/Team/A", Repository: "ORG"})
+	server.AddNQEQuery(fwdclient.NqeQuery{QueryID: "Q_b", Path: "/Team/B", Repository: "ORG"})
+	server.AddNQEQuery(fwdclient.NqeQuery{QueryID: "Q_c", Path: "/Other/C", Repository: "ORG"})
+
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	now := time.Unix(0, 0)
+	cache := newNqeQueryCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	data := &ForwardProviderData{Client: client, queries: cache}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(queryPath string) {
+			defer wg.Done()
+			query, diags := lookupQuery(context.Background(), data, queryPath, "ORG")
+			if diags.HasError() || query == nil || query.Path != queryPath {
+				t.Errorf("lookup %s: %v %v", queryPath, query, diags)
+			}
+		}([]string{"/Team/A", "/Team/B"}[i%2])
+	}
+	wg.Wait()
+
+	if got := server.RequestCount(http.MethodGet, "/api/nqe/queries"); got != 1 {
+		t.Fatalf("expected a single list request for /Team/, got %d", got)
+	}
+
+	// Other directories are listed separately; the /Team/ listing does not contain them.
+	if query, diags := lookupQuery(context.Background(), data, "/Other/C", "ORG"); diags.HasError() || query == nil {
+		t.Fatalf("lookup /Other/C: %v %v", query, diags)
+	}
+	if got := server.RequestCount(http.MethodGet, "/api/nqe/queries"); got != 2 {
+		t.Fatalf("expected a second list request for /Other/, got %d", got)
+	}
+
+	// Expired listings are reloaded.
+	now = now.Add(2 * time.Minute)
+	if _, diags := lookupQuery(context.Background(), data, "/Team/A", "ORG"); diags.HasError() {
+		t.Fatalf("lookup after expiry: %v", diags)
+	}
+	if got := server.RequestCount(http.MethodGet, "/api/nqe/queries"); got != 3 {
+		t.Fatalf("expected listing to be reloaded after expiry, got %d requests", got)
+	}
+
+	// Commits invalidate the listing so new queries are found.
+	server.AddNQEQuery(fwdclient.NqeQuery{QueryID: "Q_d", Path: "/Team/D", Repository: "ORG"})
+	data.queries.invalidate()
+	if query, diags := lookupQuery(context.Background(), data, "/Team/D", "ORG"); diags.HasError() || query == nil {
+		t.Fatalf("lookup after invalidate: %v %v", query, diags)
+	}
+
+	// Failed listings are retried rather than cached.
+	data.queries.invalidate()
+	server.InjectFault(fakeforward.Fault{PathPrefix: "/api/nqe/queries", Status: http.StatusForbidden, Times: 1})
+	if _, diags := lookupQuery(context.Background(), data, "/Team/A", "ORG"); !diags.HasError() {
+		t.Fatal("expected listing failure")
+	}
+	if query, diags := lookupQuery(context.Background(), data, "/Team/A", "ORG"); diags.HasError() || query == nil {
+		t.Fatalf("lookup after failure: %v %v", query, diags)
+	}
+}
+
+func TestNqeQueryDir(t *testing.T) {
+	t.Parallel()
+
+	for queryPath, want := range map[string]string{
+		"/Team/Devices": "/Team/",
+		"/Devices":      "/",
+		"Devices":       "",
+	} {
+		if got := nqeQueryDir(queryPath); got != want {
+			t.Fatalf("nqeQueryDir(%q) = %q, want %q", queryPath, got, want)
+		}
+	}
+}
@@ -6,21 +6,37 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	"github.com/forwardnetworks/terraform-provider-forward/internal/sdk"
+	"github.com/forwardnetworks/terraform-provider-forward/internal/wait"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 var _ datasource.DataSource = &NqeQueryDataSource{}
 
+// Polling bounds for asynchronous NQE jobs.
+const (
+	nqeJobPollInterval    = time.Second
+	nqeJobMaxPollInterval = 30 * time.Second
+)
+
 // NewNqeQueryDataSource instantiates the NQE query data source.
 func NewNqeQueryDataSource() datasource.DataSource {
 	return &NqeQueryDataSource{}
@@ -32,6 +48,7 @@ type NqeQueryDataSource struct {
 }
 
 type nqeQueryDataSourceModel struct {
+	Profile    types.String `tfsdk:"profile"`
 	SnapshotID types.String `tfsdk:"snapshot_id"`
 	NetworkID  types.String `tfsdk:"network_id"`
 	Query      types.String `tfsdk:"query"`
@@ -41,9 +58,37 @@ type nqeQueryDataSourceModel struct {
 	Limit      types.Int64  `tfsdk:"limit"`
 	Offset     types.Int64  `tfsdk:"offset"`
 
-	ResultSnapshotID types.String `tfsdk:"result_snapshot_id"`
-	TotalItems       types.Int64  `tfsdk:"total_items"`
-	ItemsJSON        types.List   `tfsdk:"items_json"`
+	SnapshotIDs types.List  `tfsdk:"snapshot_ids"`
+	NetworkIDs  types.List  `tfsdk:"network_ids"`
+	MaxParallel types.Int64 `tfsdk:"max_parallel"`
+
+	Async        types.Bool  `tfsdk:"async"`
+	AsyncTimeout types.Int64 `tfsdk:"async_timeout_seconds"`
+
+	ExpectColumns types.List `tfsdk:"expect_columns"`
+	StoreResults  types.Bool `tfsdk:"store_results"`
+
+	ResultSnapshotID    types.String     `tfsdk:"result_snapshot_id"`
+	TotalItems          types.Int64      `tfsdk:"total_items"`
+	ItemsJSON           types.List       `tfsdk:"items_json"`
+	ItemsJSONBySnapshot types.Map        `tfsdk:"items_json_by_snapshot"`
+	NetworkItems        []nqeNetworkItem `tfsdk:"network_items"`
+	ResultHash          types.String     `tfsdk:"result_hash"`
+}
+
+type nqeNetworkItem struct {
+	NetworkID  types.String `tfsdk:"network_id"`
+	SnapshotID types.String `tfsdk:"snapshot_id"`
+	ItemJSON   types.String `tfsdk:"item_json"`
+}
+
+// nqeQueryAPIFields maps NQE request fields to the attributes that supply them.
+var nqeQueryAPIFields = map[string]path.Path{
+	"query":        path.Root("query"),
+	"queryId":      path.Root("query_id"),
+	"commitId":     path.Root("commit_id"),
+	"parameters":   path.Root("parameters"),
+	"queryOptions": path.Root("limit"),
 }
 
 func (d *NqeQueryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -54,6 +99,7 @@ func (d *NqeQueryDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Execute a Forward Enterprise NQE query against a snapshot or network.",
 		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
 			"snapshot_id": schema.StringAttribute{
 				MarkdownDescription: "Snapshot ID to query. If omitted, defaults to the provider snapshot (latest processed) when network_id is supplied.",
 				Optional:            true,
@@ -87,6 +133,58 @@ func (d *NqeQueryDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				MarkdownDescription: "Offset into the result set.",
 				Optional:            true,
 			},
+			"snapshot_ids": schema.ListAttribute{
+				MarkdownDescription: "Snapshots to run the query against, for comparing results across several snapshots. " +
+					"Results are returned in `items_json_by_snapshot`; `items_json`, `total_items`, and `result_snapshot_id` are null.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("snapshot_id")),
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"network_ids": schema.ListAttribute{
+				MarkdownDescription: "Networks whose latest processed snapshot the query runs against, for org-wide sweeps. " +
+					"Results are merged into `network_items`, tagged by network; `items_json`, `total_items`, and " +
+					"`result_snapshot_id` are null.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(
+						path.MatchRoot("network_id"),
+						path.MatchRoot("snapshot_id"),
+						path.MatchRoot("snapshot_ids"),
+					),
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"max_parallel": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of `snapshot_ids` or `network_ids` queried concurrently. Defaults to `4`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"async": schema.BoolAttribute{
+				MarkdownDescription: "Run the query as an asynchronous job that is polled until it completes, instead of a " +
+					"single request. Use for long-running queries that exceed the synchronous request timeout. Defaults to `false`.",
+				Optional: true,
+			},
+			"async_timeout_seconds": schema.Int64Attribute{
+				MarkdownDescription: "How long to wait for an `async` query to complete. Defaults to `1800`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"expect_columns": schema.ListAttribute{
+				MarkdownDescription: "Columns every result row must contain. The read fails with a diagnostic naming the missing " +
+					"columns otherwise, so a changed query is caught before configuration indexes a field that no longer exists.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"store_results": storeResultsAttribute("`items_json`, `items_json_by_snapshot`, and `network_items`"),
+			"result_hash":   resultHashAttribute(),
 			"result_snapshot_id": schema.StringAttribute{
 				MarkdownDescription: "Snapshot ID used for query execution.",
 				Computed:            true,
@@ -100,6 +198,23 @@ func (d *NqeQueryDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				ElementType:         types.StringType,
 				Computed:            true,
 			},
+			"items_json_by_snapshot": schema.MapAttribute{
+				MarkdownDescription: "Query results serialized as JSON strings, keyed by snapshot ID. Only set with `snapshot_ids`.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+			"network_items": schema.ListAttribute{
+				MarkdownDescription: "Query results from every network in `network_ids`, in that order, with the network and " +
+					"snapshot each row came from. Only set with `network_ids`.",
+				Computed: true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"network_id":  types.StringType,
+						"snapshot_id": types.StringType,
+						"item_json":   types.StringType,
+					},
+				},
+			},
 		},
 	}
 }
@@ -136,12 +251,18 @@ func (d *NqeQueryDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	networkID := d.providerData.NetworkID
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networkID := providerData.NetworkID
 	if !data.NetworkID.IsNull() && !data.NetworkID.IsUnknown() {
 		networkID = data.NetworkID.ValueString()
 	}
 
-	if networkID == "" && (data.SnapshotID.IsNull() || data.SnapshotID.ValueString() == "") {
+	if networkID == "" && (data.SnapshotID.IsNull() || data.SnapshotID.ValueString() == "") && data.SnapshotIDs.IsNull() && data.NetworkIDs.IsNull() {
 		resp.Diagnostics.AddError(
 			"Missing Network Or Snapshot",
 			"Provide either network_id or snapshot_id to execute an NQE query.",
@@ -164,26 +285,124 @@ func (d *NqeQueryDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	result, err := d.providerData.Client.RunNQEQuery(ctx, networkID, stringOrEmpty(data.SnapshotID), reqBody)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Execute NQE Query",
-			err.Error(),
-		)
+	var expectColumns []string
+	if !data.ExpectColumns.IsNull() {
+		resp.Diagnostics.Append(data.ExpectColumns.ElementsAs(ctx, &expectColumns, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	run := func(ctx context.Context, networkID, snapshotID string) (*fwdclient.NqeRunResult, error) {
+		if data.Async.ValueBool() {
+			timeout := time.Duration(defaultInt(data.AsyncTimeout, 1800)) * time.Second
+			return runNqeJob(ctx, providerData.Client, networkID, snapshotID, reqBody, timeout)
+		}
+		return providerData.Client.RunNQEQuery(ctx, networkID, snapshotID, reqBody)
+	}
+
+	if !data.SnapshotIDs.IsNull() {
+		var snapshotIDs []string
+		resp.Diagnostics.Append(data.SnapshotIDs.ElementsAs(ctx, &snapshotIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.NetworkID = types.StringValue(networkID)
+		data.ResultSnapshotID = types.StringNull()
+		data.TotalItems = types.Int64Null()
+		data.ItemsJSON = types.ListNull(types.StringType)
+		data.NetworkItems = nil
+		results := runNqeConcurrently(ctx, providerData, "Snapshot", snapshotIDs, data.MaxParallel, func(ctx context.Context, snapshotID string) (*fwdclient.NqeRunResult, error) {
+			return run(ctx, networkID, snapshotID)
+		}, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		bySnapshot := make(map[string]attr.Value, len(results))
+		rows := make(map[string][]json.RawMessage, len(results))
+		for _, snapshotID := range snapshotIDs {
+			result, ok := results[snapshotID]
+			if !ok {
+				continue
+			}
+			checkNqeColumns(expectColumns, result.Items, "snapshot "+snapshotID, &resp.Diagnostics)
+			bySnapshot[snapshotID] = nqeItemsList(result.Items)
+			rows[snapshotID] = result.Items
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.ItemsJSONBySnapshot = types.MapValueMust(types.ListType{ElemType: types.StringType}, bySnapshot)
+		data.ResultHash = resultHash(rows)
+		if !storeResults(data.StoreResults) {
+			data.ItemsJSONBySnapshot = types.MapNull(types.ListType{ElemType: types.StringType})
+		}
+
+		tflog.Trace(ctx, "executed forward nqe query on snapshots", map[string]any{"snapshots": len(snapshotIDs)})
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 		return
 	}
 
-	items := make([]attr.Value, 0, len(result.Items))
-	for _, raw := range result.Items {
-		encoded := json.RawMessage(raw)
-		if len(encoded) == 0 {
-			items = append(items, types.StringValue("{}"))
-			continue
+	if !data.NetworkIDs.IsNull() {
+		var networkIDs []string
+		resp.Diagnostics.Append(data.NetworkIDs.ElementsAs(ctx, &networkIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.NetworkID = types.StringNull()
+		data.ResultSnapshotID = types.StringNull()
+		data.TotalItems = types.Int64Null()
+		data.ItemsJSON = types.ListNull(types.StringType)
+		data.ItemsJSONBySnapshot = types.MapNull(types.ListType{ElemType: types.StringType})
+		results := runNqeConcurrently(ctx, providerData, "Network", networkIDs, data.MaxParallel, func(ctx context.Context, networkID string) (*fwdclient.NqeRunResult, error) {
+			return run(ctx, networkID, "")
+		}, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, networkID := range networkIDs {
+			if result, ok := results[networkID]; ok {
+				checkNqeColumns(expectColumns, result.Items, "network "+networkID, &resp.Diagnostics)
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		rows := make(map[string][]json.RawMessage, len(results))
+		for networkID, result := range results {
+			rows[networkID] = result.Items
+		}
+		data.ResultHash = resultHash(rows)
+		if storeResults(data.StoreResults) {
+			data.NetworkItems = nqeNetworkItems(networkIDs, results)
+		}
+
+		tflog.Trace(ctx, "executed forward nqe query on networks", map[string]any{"networks": len(networkIDs), "items": len(data.NetworkItems)})
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	result, err := run(ctx, networkID, stringOrEmpty(data.SnapshotID))
+	if err != nil {
+		if !providerData.tolerateMissing(err, "NQE Query Target Not Found", &resp.Diagnostics) {
+			addAPIErrorWithPaths(&resp.Diagnostics, "Unable to Execute NQE Query", err, nqeQueryAPIFields)
+			return
 		}
-		items = append(items, types.StringValue(string(encoded)))
+		result = &fwdclient.NqeRunResult{}
+	}
+
+	checkNqeColumns(expectColumns, result.Items, "", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	state := nqeQueryDataSourceModel{
+		Profile:          data.Profile,
 		SnapshotID:       data.SnapshotID,
 		NetworkID:        types.StringValue(networkID),
 		Query:            data.Query,
@@ -192,15 +411,23 @@ func (d *NqeQueryDataSource) Read(ctx context.Context, req datasource.ReadReques
 		Parameters:       data.Parameters,
 		Limit:            data.Limit,
 		Offset:           data.Offset,
+		SnapshotIDs:      data.SnapshotIDs,
+		NetworkIDs:       data.NetworkIDs,
+		MaxParallel:      data.MaxParallel,
+		Async:            data.Async,
+		AsyncTimeout:     data.AsyncTimeout,
+		ExpectColumns:    data.ExpectColumns,
+		StoreResults:     data.StoreResults,
 		ResultSnapshotID: types.StringValue(result.SnapshotID),
 		ItemsJSON:        types.ListNull(types.StringType),
 		TotalItems:       types.Int64Null(),
+		ResultHash:       resultHash(result.Items),
+
+		ItemsJSONBySnapshot: types.MapNull(types.ListType{ElemType: types.StringType}),
 	}
 
-	if len(items) > 0 {
-		state.ItemsJSON = types.ListValueMust(types.StringType, items)
-	} else {
-		state.ItemsJSON = types.ListValueMust(types.StringType, []attr.Value{})
+	if storeResults(data.StoreResults) {
+		state.ItemsJSON = nqeItemsList(result.Items)
 	}
 
 	if result.TotalNumItems != nil {
@@ -218,9 +445,165 @@ func (d *NqeQueryDataSource) Read(ctx context.Context, req datasource.ReadReques
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func expandNqeRequest(ctx context.Context, data nqeQueryDataSourceModel) (sdk.NqeQueryRequest, diag.Diagnostics) {
+// runNqeJob submits the query as an asynchronous job, polls it with backoff until it
+// finishes or timeout elapses, and returns its rows.
+func runNqeJob(ctx context.Context, client *fwdclient.Client, networkID, snapshotID string, reqBody fwdclient.NqeQueryRequest, timeout time.Duration) (*fwdclient.NqeRunResult, error) {
+	job, err := client.SubmitNQEQuery(ctx, networkID, snapshotID, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, "submitted forward nqe job", map[string]any{"job_id": job.ID})
+
+	done, err := nqeJobFinished(job)
+	if err != nil {
+		return nil, err
+	}
+	if !done {
+		poller := wait.Poller{
+			Backoff:    wait.NewBackoff(nqeJobPollInterval, nqeJobMaxPollInterval),
+			Timeout:    timeout,
+			DelayFirst: true,
+		}
+		err := poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+			refreshed, err := client.GetNQEJob(ctx, job.ID)
+			if err != nil {
+				return false, err
+			}
+			job = refreshed
+			return nqeJobFinished(job)
+		})
+		if errors.Is(err, wait.ErrTimeout) {
+			return nil, fmt.Errorf("NQE job %s did not complete within %s (last state %s)", job.ID, timeout, job.State)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return client.GetNQEJobResult(ctx, job.ID)
+}
+
+// nqeJobFinished reports whether job completed, and fails when it ended any other way.
+func nqeJobFinished(job *fwdclient.NqeJob) (bool, error) {
+	switch job.State {
+	case fwdclient.NqeJobCompleted:
+		return true, nil
+	case fwdclient.NqeJobFailed, fwdclient.NqeJobCanceled:
+		message := fmt.Sprintf("NQE job %s finished in state %s", job.ID, job.State)
+		if job.ErrorMessage != "" {
+			message += ": " + job.ErrorMessage
+		}
+		return false, errors.New(message)
+	default:
+		return false, nil
+	}
+}
+
+// runNqeConcurrently executes the query once per target, a snapshot or network ID
+// passed to run, and returns the results keyed by target. Targets tolerated as missing
+// are left out; other failures are added to diags.
+func runNqeConcurrently(ctx context.Context, providerData *ForwardProviderData, kind string, targets []string, parallel types.Int64, run func(context.Context, string) (*fwdclient.NqeRunResult, error), diags *diag.Diagnostics) map[string]*fwdclient.NqeRunResult {
+	var mu sync.Mutex
+	results := make(map[string]*fwdclient.NqeRunResult, len(targets))
+	errs := forEachID(ctx, targets, parallel, func(ctx context.Context, target string) error {
+		result, err := run(ctx, target)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		results[target] = result
+		mu.Unlock()
+		return nil
+	})
+
+	for _, target := range sortedErrorKeys(errs) {
+		err := errs[target]
+		if providerData.tolerateMissing(err, fmt.Sprintf("NQE Query %s %s Not Found", kind, target), diags) {
+			continue
+		}
+		addAPIErrorWithPaths(diags, fmt.Sprintf("Unable to Execute NQE Query on %s %s", kind, target), err, nqeQueryAPIFields)
+	}
+
+	return results
+}
+
+// nqeNetworkItems merges per-network results into rows tagged with their network,
+// following the order of networkIDs.
+func nqeNetworkItems(networkIDs []string, results map[string]*fwdclient.NqeRunResult) []nqeNetworkItem {
+	items := []nqeNetworkItem{}
+	for _, networkID := range networkIDs {
+		result, ok := results[networkID]
+		if !ok {
+			continue
+		}
+		for _, item := range nqeItemsList(result.Items).Elements() {
+			items = append(items, nqeNetworkItem{
+				NetworkID:  types.StringValue(networkID),
+				SnapshotID: stringOrNull(result.SnapshotID),
+				ItemJSON:   item.(types.String),
+			})
+		}
+	}
+	return items
+}
+
+// checkNqeColumns reports the first result row that lacks any expected column. source
+// names the snapshot or network the rows came from, or is empty for a single query.
+func checkNqeColumns(expected []string, rows []json.RawMessage, source string, diags *diag.Diagnostics) {
+	if len(expected) == 0 {
+		return
+	}
+
+	for i, raw := range rows {
+		var row map[string]json.RawMessage
+		_ = json.Unmarshal(raw, &row)
+
+		var missing []string
+		for _, column := range expected {
+			if _, ok := row[column]; !ok {
+				missing = append(missing, column)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		columns := make([]string, 0, len(row))
+		for column := range row {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		if len(columns) == 0 {
+			columns = []string{"no columns"}
+		}
+
+		location := fmt.Sprintf("Row %d", i)
+		if source != "" {
+			location += " from " + source
+		}
+		diags.AddAttributeError(path.Root("expect_columns"), "Missing NQE Result Columns",
+			fmt.Sprintf("%s of the query result has no %s column(s). The row has: %s.",
+				location, strings.Join(missing, ", "), strings.Join(columns, ", ")))
+		return
+	}
+}
+
+// nqeItemsList encodes result rows as JSON strings, substituting {} for empty rows.
+func nqeItemsList(rows []json.RawMessage) types.List {
+	items := make([]attr.Value, 0, len(rows))
+	for _, raw := range rows {
+		if len(raw) == 0 {
+			items = append(items, types.StringValue("{}"))
+			continue
+		}
+		items = append(items, types.StringValue(string(raw)))
+	}
+	return types.ListValueMust(types.StringType, items)
+}
+
+func expandNqeRequest(ctx context.Context, data nqeQueryDataSourceModel) (fwdclient.NqeQueryRequest, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	req := sdk.NqeQueryRequest{}
+	req := fwdclient.NqeQueryRequest{}
 
 	if !data.Query.IsNull() && !data.Query.IsUnknown() {
 		query := data.Query.ValueString()
@@ -286,7 +669,7 @@ func expandNqeRequest(ctx context.Context, data nqeQueryDataSourceModel) (sdk.Nq
 	}
 
 	if limitPtr != nil || offsetPtr != nil {
-		req.QueryOptions = &sdk.NqeQueryOptions{Limit: limitPtr, Offset: offsetPtr}
+		req.QueryOptions = &fwdclient.NqeQueryOptions{Limit: limitPtr, Offset: offsetPtr}
 	}
 
 	return req, diags
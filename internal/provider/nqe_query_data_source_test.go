@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestNqeQueryDataSourceSnapshotIDs(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.SetNQEResult("SELECT device FROM devices", fwdclient.NqeRunResult{
+		Items: []json.RawMessage{json.RawMessage(`{"device":"leaf1"}`), json.RawMessage(`{"device":"leaf2"}`)},
+	})
+
+	providerFactory := providerserver.NewProtocol6WithError(New("test")())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"forward": providerFactory,
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: nqeQueryTestConfig(server.URL, `
+  snapshot_ids = ["snap-1", "snap-2", "snap-3"]
+  max_parallel = 2`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_nqe_query.test", "items_json_by_snapshot.%", "3"),
+					resource.TestCheckResourceAttr("data.forward_nqe_query.test", "items_json_by_snapshot.snap-2.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_nqe_query.test", "items_json_by_snapshot.snap-3.1", `{"device":"leaf2"}`),
+					resource.TestCheckNoResourceAttr("data.forward_nqe_query.test", "items_json"),
+				),
+			},
+			{
+				Config: nqeQueryTestConfig(server.URL, `
+  snapshot_ids   = ["snap-1"]
+  expect_columns = ["device", "os"]`),
+				ExpectError: regexp.MustCompile(`Row 0 from snapshot snap-1 of the query result has no os column`),
+			},
+		},
+	})
+}
+
+func TestNqeQueryDataSourceNetworkIDs(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-a", fwdclient.Snapshot{ID: "snap-a", State: "PROCESSED"})
+	server.AddSnapshot("net-b", fwdclient.Snapshot{ID: "snap-b", State: "PROCESSED"})
+	server.SetNQEResult("SELECT device FROM devices", fwdclient.NqeRunResult{
+		Items: []json.RawMessage{json.RawMessage(`{"device":"leaf1"}`)},
+	})
+
+	providerFactory := providerserver.NewProtocol6WithError(New("test")())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"forward": providerFactory,
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: nqeQueryTestConfig(server.URL, `
+  network_ids = ["net-b", "net-a"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_nqe_query.test", "network_items.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_nqe_query.test", "network_items.0.network_id", "net-b"),
+					resource.TestCheckResourceAttr("data.forward_nqe_query.test", "network_items.0.snapshot_id", "snap-b"),
+					resource.TestCheckResourceAttr("data.forward_nqe_query.test", "network_items.1.item_json", `{"device":"leaf1"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestNqeNetworkItems(t *testing.T) {
+	t.Parallel()
+
+	items := nqeNetworkItems([]string{"net-2", "net-missing", "net-1"}, map[string]*fwdclient.NqeRunResult{
+		"net-1": {SnapshotID: "snap-1", Items: []json.RawMessage{json.RawMessage(`{"a":1}`), nil}},
+		"net-2": {Items: []json.RawMessage{json.RawMessage(`{"b":2}`)}},
+	})
+
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+	if items[0].NetworkID.ValueString() != "net-2" || !items[0].SnapshotID.IsNull() || items[0].ItemJSON.ValueString() != `{"b":2}` {
+		t.Fatalf("unexpected first item: %+v", items[0])
+	}
+	if items[2].NetworkID.ValueString() != "net-1" || items[2].SnapshotID.ValueString() != "snap-1" || items[2].ItemJSON.ValueString() != "{}" {
+		t.Fatalf("unexpected last item: %+v", items[2])
+	}
+}
+
+func TestNqeQueryDataSourceAsync(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.SetNQEResult("SELECT device FROM devices", fwdclient.NqeRunResult{
+		SnapshotID: "snap-1",
+		Items:      []json.RawMessage{json.RawMessage(`{"device":"leaf1"}`)},
+	})
+
+	providerFactory := providerserver.NewProtocol6WithError(New("test")())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"forward": providerFactory,
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: nqeQueryTestConfig(server.URL, `
+  async                 = true
+  async_timeout_seconds = 60`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_nqe_query.test", "result_snapshot_id", "snap-1"),
+					resource.TestCheckResourceAttr("data.forward_nqe_query.test", "items_json.0", `{"device":"leaf1"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestRunNqeJob(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.SetNQEResult("SELECT device FROM devices", fwdclient.NqeRunResult{Items: []json.RawMessage{json.RawMessage(`{}`)}})
+
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	query := "SELECT device FROM devices"
+	result, err := runNqeJob(context.Background(), client, "net-1", "", fwdclient.NqeQueryRequest{Query: &query}, time.Minute)
+	if err != nil {
+		t.Fatalf("runNqeJob returned error: %v", err)
+	}
+	if len(result.Items) != 1 {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+
+	unknown := "SELECT nothing"
+	_, err = runNqeJob(context.Background(), client, "net-1", "", fwdclient.NqeQueryRequest{Query: &unknown}, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "FAILED") {
+		t.Fatalf("expected failed job error, got %v", err)
+	}
+}
+
+func TestCheckNqeColumns(t *testing.T) {
+	t.Parallel()
+
+	rows := []json.RawMessage{
+		json.RawMessage(`{"device":"leaf1","vendor":"ARISTA"}`),
+		json.RawMessage(`{"device":"leaf2"}`),
+	}
+
+	tests := map[string]struct {
+		expected []string
+		source   string
+		want     string
+	}{
+		"present":   {expected: []string{"device"}},
+		"none":      {},
+		"missing":   {expected: []string{"device", "vendor", "os"}, want: "Row 0 of the query result has no os column(s). The row has: device, vendor."},
+		"later row": {expected: []string{"vendor"}, source: "network net-1", want: "Row 1 from network net-1 of the query result has no vendor column(s). The row has: device."},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			checkNqeColumns(tc.expected, rows, tc.source, &diags)
+			if tc.want == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Detail() != tc.want {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+		})
+	}
+}
+
+func nqeQueryTestConfig(host, extra string) string {
+	return fmt.Sprintf(`
+provider "forward" {
+  base_url   = "%s"
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+data "forward_nqe_query" "test" {
+  query = "SELECT device FROM devices"%s
+}
+`, host, extra)
+}
@@ -1,4 +1,5 @@
 // Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
 
 package provider
 
@@ -7,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -14,14 +16,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
-	"github.com/forwardnetworks/terraform-provider-forward/internal/sdk"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 var _ resource.Resource = &NQEQueryResource{}
 var _ resource.ResourceWithImportState = &NQEQueryResource{}
 
+// nqeVendorRepository is the read-only repository of Forward-provided queries.
+const nqeVendorRepository = "FWD"
+
 // NQEQueryResource models a Forward NQE library entry reference.
 type NQEQueryResource struct {
 	providerData *ForwardProviderData
@@ -30,10 +36,13 @@ type NQEQueryResource struct {
 // NQEQueryResourceModel maps Terraform schema data.
 type NQEQueryResourceModel struct {
 	ID         types.String `tfsdk:"id"`
+	Profile    types.String `tfsdk:"profile"`
 	Path       types.String `tfsdk:"path"`
 	Repository types.String `tfsdk:"repository"`
 	Intent     types.String `tfsdk:"intent"`
 	QueryID    types.String `tfsdk:"query_id"`
+	Source     types.String `tfsdk:"source"`
+	CommitID   types.String `tfsdk:"commit_id"`
 }
 
 func NewNQEQueryResource() resource.Resource {
@@ -46,11 +55,16 @@ func (r *NQEQueryResource) Metadata(ctx context.Context, req resource.MetadataRe
 
 func (r *NQEQueryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Reference a Forward Enterprise NQE library entry by path and repository.",
+		MarkdownDescription: "Reference a Forward Enterprise NQE library entry by path and repository, optionally creating it " +
+			"from `source` when it does not exist.",
 		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Internal Terraform identifier (mirrors query_id).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"path": schema.StringAttribute{
 				Required:            true,
@@ -60,18 +74,46 @@ func (r *NQEQueryResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"repository": schema.StringAttribute{
-				Optional:            true,
-				Computed:            true,
-				MarkdownDescription: "Source repository for the query (e.g. ORG or FWD).",
-				Default:             stringdefault.StaticString("ORG"),
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Repository holding the query: `ORG` for the organization's own queries or `FWD` for " +
+					"queries provided by Forward Networks.",
+				Default: stringdefault.StaticString(nqePackRepository),
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(nqePackRepository, nqeVendorRepository),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"intent": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Intent string associated with the query.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"query_id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Forward Enterprise query identifier.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"commit_id": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Latest commit of the query in its repository. Reference it from check definitions to pin " +
+					"the exact query version they were validated against; it changes when the query is edited, including when " +
+					"Forward Networks updates a `FWD` query.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "NQE source used to create and commit the query in the `ORG` repository when `path` does " +
+					"not exist. An existing query is never modified, and the query is left in place on destroy; use " +
+					"`forward_nqe_pack` to manage query source over time.",
 			},
 		},
 	}
@@ -109,25 +151,35 @@ func (r *NQEQueryResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	query, diags := r.lookupQuery(ctx, plan.Path.ValueString(), plan.Repository.ValueString())
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, diags := lookupQuery(ctx, providerData, plan.Path.ValueString(), plan.Repository.ValueString())
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if query == nil && !plan.Source.IsNull() {
+		query, diags = createQuery(ctx, providerData, plan.Path.ValueString(), plan.Repository.ValueString(), plan.Source.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if query == nil {
 		resp.Diagnostics.AddError(
 			"NQE query not found",
-			"The specified NQE query does not exist in the Forward library. New query creation is not currently supported via API; create the query in Forward Enterprise and re-run Terraform.",
+			"The specified NQE query does not exist in the Forward library. Set `source` to create it, or create the query in Forward Enterprise and re-run Terraform.",
 		)
 		return
 	}
 
-	plan.QueryID = types.StringValue(query.QueryID)
-	plan.Intent = stringOrNull(query.Intent)
-	plan.Repository = types.StringValue(query.Repository)
-	plan.ID = plan.QueryID
-
+	setNQEQueryState(&plan, query)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -146,7 +198,19 @@ func (r *NQEQueryResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	query, diags := r.lookupQuery(ctx, state.Path.ValueString(), state.Repository.ValueString())
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported entries only know their query ID until the first read.
+	var query *fwdclient.NqeQuery
+	if state.Path.IsNull() {
+		query, diags = lookupQueryByID(ctx, providerData, state.QueryID.ValueString())
+	} else {
+		query, diags = lookupQuery(ctx, providerData, state.Path.ValueString(), state.Repository.ValueString())
+	}
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -157,16 +221,12 @@ func (r *NQEQueryResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	state.QueryID = types.StringValue(query.QueryID)
-	state.Intent = stringOrNull(query.Intent)
-	state.Repository = types.StringValue(query.Repository)
-	state.ID = state.QueryID
-
+	setNQEQueryState(&state, query)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *NQEQueryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// All fields require replacement; nothing to do.
+	// Identifying fields require replacement and computed values carry over from state.
 	var plan NQEQueryResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -180,10 +240,35 @@ func (r *NQEQueryResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r *NQEQueryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("query_id"), req, resp)
+	parts := strings.Split(req.ID, "/")
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile"), parts[0])...)
+		parts = parts[1:]
+	}
+	if len(parts) != 1 || parts[0] == "" {
+		resp.Diagnostics.AddError("Invalid import format", "Use: query_id or profile/query_id")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("query_id"), parts[0])...)
+}
+
+// setNQEQueryState records the looked-up query. The repository keeps its configured
+// spelling when it only differs in case from the reported one.
+func setNQEQueryState(model *NQEQueryResourceModel, query *fwdclient.NqeQuery) {
+	model.Path = types.StringValue(query.Path)
+	model.QueryID = types.StringValue(query.QueryID)
+	model.Intent = stringOrNull(query.Intent)
+	model.CommitID = stringOrNull(query.LastCommitID)
+	if !strings.EqualFold(model.Repository.ValueString(), query.Repository) {
+		model.Repository = types.StringValue(query.Repository)
+	}
+	model.ID = model.QueryID
 }
 
-func (r *NQEQueryResource) lookupQuery(ctx context.Context, queryPath, repository string) (*sdk.NqeQuery, diag.Diagnostics) {
+// lookupQuery finds the query at queryPath in repository, listing only the directory
+// that holds it.
+func lookupQuery(ctx context.Context, providerData *ForwardProviderData, queryPath, repository string) (*fwdclient.NqeQuery, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if strings.TrimSpace(queryPath) == "" {
@@ -191,9 +276,9 @@ func (r *NQEQueryResource) lookupQuery(ctx context.Context, queryPath, repositor
 		return nil, diags
 	}
 
-	queries, err := r.providerData.Client.ListNQEQueries(ctx, "")
+	queries, err := providerData.queries.list(ctx, providerData.Client, nqeQueryDir(queryPath))
 	if err != nil {
-		diags.AddError("Error listing NQE queries", err.Error())
+		addAPIError(&diags, "Error listing NQE queries", err)
 		return nil, diags
 	}
 
@@ -205,3 +290,50 @@ func (r *NQEQueryResource) lookupQuery(ctx context.Context, queryPath, repositor
 
 	return nil, diags
 }
+
+// createQuery commits source as a new query at queryPath and returns the created query.
+// Only the org repository accepts commits.
+func createQuery(ctx context.Context, providerData *ForwardProviderData, queryPath, repository, source string) (*fwdclient.NqeQuery, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !strings.EqualFold(repository, nqePackRepository) {
+		diags.AddAttributeError(path.Root("source"), "Cannot Create NQE Query",
+			fmt.Sprintf("Queries can only be created in the %s repository, not %s.", nqePackRepository, repository))
+		return nil, diags
+	}
+
+	if !providerData.requireFeature(ctx, featureNQECommits, path.Root("source"), &diags) {
+		return nil, diags
+	}
+
+	request := fwdclient.NqeCommitRequest{
+		Message: fmt.Sprintf("Create NQE query %s", queryPath),
+		Changes: []fwdclient.NqeQueryChange{{Path: queryPath, Source: source}},
+	}
+	_, err := providerData.Client.CommitNQEQueries(ctx, nqePackRepository, request)
+	providerData.queries.invalidate()
+	if err != nil {
+		addAPIError(&diags, "Error creating NQE query", err)
+		return nil, diags
+	}
+
+	return lookupQuery(ctx, providerData, queryPath, repository)
+}
+
+func lookupQueryByID(ctx context.Context, providerData *ForwardProviderData, queryID string) (*fwdclient.NqeQuery, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	queries, err := providerData.queries.list(ctx, providerData.Client, "")
+	if err != nil {
+		addAPIError(&diags, "Error listing NQE queries", err)
+		return nil, diags
+	}
+
+	for _, q := range queries {
+		if q.QueryID == queryID {
+			return &q, diags
+		}
+	}
+
+	return nil, diags
+}
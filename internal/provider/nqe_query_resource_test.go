@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAccNQEQueryResourceImport(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddNQEQuery(fwdclient.NqeQuery{QueryID: "FQ_mtu", Repository: "ORG", Path: "/L3/Mtu", Intent: "Validate MTU"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_nqe_query_definition" "test" {
+  path = "/L3/Mtu"
+}
+`, server.URL),
+				Check: resource.TestCheckResourceAttr("forward_nqe_query_definition.test", "query_id", "FQ_mtu"),
+			},
+			{
+				// Importing by query ID alone resolves the path and repository.
+				ResourceName:      "forward_nqe_query_definition.test",
+				ImportState:       true,
+				ImportStateId:     "FQ_mtu",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNQEQueryResourceVendorRepository(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddNQEQuery(fwdclient.NqeQuery{QueryID: "FQ_org", Repository: "ORG", Path: "/L3/Mtu", LastCommitID: "commit-org"})
+	server.AddNQEQuery(fwdclient.NqeQuery{QueryID: "FQ_fwd", Repository: "FWD", Path: "/L3/Mtu", LastCommitID: "commit-fwd"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_nqe_query_definition" "test" {
+  path       = "/L3/Mtu"
+  repository = "fwd"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_nqe_query_definition.test", "query_id", "FQ_fwd"),
+					resource.TestCheckResourceAttr("forward_nqe_query_definition.test", "repository", "fwd"),
+					resource.TestCheckResourceAttr("forward_nqe_query_definition.test", "commit_id", "commit-fwd"),
+				),
+			},
+		},
+	})
+}
+
+func TestSetNQEQueryState(t *testing.T) {
+	t.Parallel()
+
+	model := NQEQueryResourceModel{Repository: types.StringValue("fwd")}
+	setNQEQueryState(&model, &fwdclient.NqeQuery{QueryID: "FQ_1", Repository: "FWD", Path: "/L3/Mtu", LastCommitID: "commit-1"})
+	if model.Repository.ValueString() != "fwd" {
+		t.Fatalf("expected configured repository spelling to be kept, got %s", model.Repository)
+	}
+	if model.ID.ValueString() != "FQ_1" || model.CommitID.ValueString() != "commit-1" || !model.Intent.IsNull() {
+		t.Fatalf("unexpected state: %+v", model)
+	}
+
+	// Imported entries take the reported repository.
+	model = NQEQueryResourceModel{Repository: types.StringNull()}
+	setNQEQueryState(&model, &fwdclient.NqeQuery{QueryID: "FQ_1", Repository: "ORG", Path: "/L3/Mtu"})
+	if model.Repository.ValueString() != "ORG" || !model.CommitID.IsNull() {
+		t.Fatalf("unexpected state: %+v", model)
+	}
+}
+
+func TestAccNQEQueryResourceCreatesFromSource(t *testing.T) {
+	server := fakeforward.New(t)
+	source := "foreach d in network.devices select {name: d.name}"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_nqe_query_definition" "test" {
+  path   = "/Team/Devices"
+  source = %q
+}
+`, server.URL, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("forward_nqe_query_definition.test", "query_id"),
+					resource.TestCheckResourceAttr("forward_nqe_query_definition.test", "repository", "ORG"),
+					resource.TestCheckResourceAttrSet("forward_nqe_query_definition.test", "commit_id"),
+					func(*terraform.State) error {
+						if got, ok := server.NQEQuerySource("/Team/Devices"); !ok || got != source {
+							return fmt.Errorf("expected committed source %q, got %q", source, got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,566 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	schemavalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ resource.Resource = &OrgCheckResource{}
+var _ resource.ResourceWithModifyPlan = &OrgCheckResource{}
+
+// OrgCheckResource fans a single intent check out to many networks.
+type OrgCheckResource struct {
+	providerData *ForwardProviderData
+}
+
+// OrgCheckResourceModel maps Terraform schema data.
+type OrgCheckResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Profile        types.String `tfsdk:"profile"`
+	DefinitionJSON types.String `tfsdk:"definition_json"`
+	Name           types.String `tfsdk:"name"`
+	Note           types.String `tfsdk:"note"`
+	Priority       types.String `tfsdk:"priority"`
+	NetworkIDs     types.Set    `tfsdk:"network_ids"`
+	MaxParallel    types.Int64  `tfsdk:"max_parallel"`
+	Checks         types.Map    `tfsdk:"checks"`
+	Statuses       types.Map    `tfsdk:"statuses"`
+}
+
+// orgCheckPlacement locates the check created on one network.
+type orgCheckPlacement struct {
+	SnapshotID string
+	CheckID    string
+	Status     string
+}
+
+func NewOrgCheckResource() resource.Resource {
+	return &OrgCheckResource{}
+}
+
+func (r *OrgCheckResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_check"
+}
+
+func (r *OrgCheckResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Roll an intent check out to every network in the organization, or to a chosen set of networks. " +
+			"The check is added as a persistent check to each network's latest processed snapshot, networks are processed " +
+			"concurrently, and the plan lists every network that gains or loses the check. Aliases are not fanned out, " +
+			"since this provider does not manage them yet.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 checksum of `definition_json`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"definition_json": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "Raw JSON payload describing the Forward intent check definition (as expected by the Forward API). " +
+					"Definitions of the `NQE`, `Existential`, `Isolation`, and `Reachability` check types are validated at plan time.",
+				Validators: []schemavalidator.String{
+					checkDefinitionValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name given to the check on every network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"note": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Note stored with the check on every network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"priority": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Intent check priority (NOT_SET, LOW, MEDIUM, HIGH).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"network_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Networks to roll the check out to. Defaults to every network visible to the API key; networks added to the organization later are picked up on the next apply.",
+			},
+			"max_parallel": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Maximum number of networks processed concurrently. Defaults to `4`.",
+				Default:             int64default.StaticInt64(4),
+				Validators: []schemavalidator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"checks": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Check location on each network as `snapshot_id/check_id`, keyed by network ID.",
+			},
+			"statuses": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Last known check status on each network, keyed by network ID.",
+			},
+		},
+	}
+}
+
+func (r *OrgCheckResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// ModifyPlan resolves the target networks so the plan shows which networks gain the
+// check (unknown entries) and which lose it (removed entries).
+func (r *OrgCheckResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var plan OrgCheckResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.NetworkIDs.IsUnknown() || plan.Profile.IsUnknown() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	targets, diags := orgCheckTargets(ctx, providerData.Client, plan.NetworkIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var placements map[string]orgCheckPlacement
+	if !req.State.Raw.IsNull() {
+		var state OrgCheckResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Replacement recreates the check everywhere, so only in-place updates keep placements.
+		if state.DefinitionJSON.Equal(plan.DefinitionJSON) && state.Name.Equal(plan.Name) && state.Note.Equal(plan.Note) &&
+			state.Priority.Equal(plan.Priority) && state.Profile.Equal(plan.Profile) {
+			placements, diags = orgCheckPlacements(ctx, state)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	checks := make(map[string]attr.Value, len(targets))
+	statuses := make(map[string]attr.Value, len(targets))
+	for _, networkID := range targets {
+		if placement, ok := placements[networkID]; ok {
+			checks[networkID] = types.StringValue(placement.SnapshotID + "/" + placement.CheckID)
+			statuses[networkID] = stringOrNull(placement.Status)
+		} else {
+			checks[networkID] = types.StringUnknown()
+			statuses[networkID] = types.StringUnknown()
+		}
+	}
+
+	plan.Checks, diags = types.MapValue(types.StringType, checks)
+	resp.Diagnostics.Append(diags...)
+	plan.Statuses, diags = types.MapValue(types.StringType, statuses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *OrgCheckResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan OrgCheckResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcile(ctx, &plan, map[string]orgCheckPlacement{}, &resp.Diagnostics, &resp.State)
+}
+
+func (r *OrgCheckResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state OrgCheckResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	placements, diags := orgCheckPlacements(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var mu sync.Mutex
+	errs := forEachID(ctx, sortedPlacementKeys(placements), state.MaxParallel, func(ctx context.Context, networkID string) error {
+		mu.Lock()
+		placement := placements[networkID]
+		mu.Unlock()
+
+		check, err := providerData.readSnapshotCheck(ctx, placement.SnapshotID, placement.CheckID)
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case isNotFoundError(err):
+			// Deactivated outside Terraform; the next apply recreates it.
+			delete(placements, networkID)
+			return nil
+		case err != nil:
+			return err
+		}
+		placement.Status = check.Status
+		placements[networkID] = placement
+		return nil
+	})
+	for _, networkID := range sortedErrorKeys(errs) {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Error reading check on network %s", networkID), errs[networkID])
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setOrgCheckPlacements(ctx, &state, placements)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *OrgCheckResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan, state OrgCheckResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	placements, diags := orgCheckPlacements(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcile(ctx, &plan, placements, &resp.Diagnostics, &resp.State)
+}
+
+func (r *OrgCheckResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state OrgCheckResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	placements, diags := orgCheckPlacements(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errs := forEachID(ctx, sortedPlacementKeys(placements), state.MaxParallel, func(ctx context.Context, networkID string) error {
+		placement := placements[networkID]
+		err := providerData.Client.DeactivateSnapshotCheck(ctx, placement.SnapshotID, placement.CheckID)
+		providerData.checks.invalidate(placement.SnapshotID)
+		if err != nil && !isNotFoundError(err) {
+			return err
+		}
+		return nil
+	})
+	for _, networkID := range sortedErrorKeys(errs) {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Error deleting check on network %s", networkID), errs[networkID])
+	}
+}
+
+// reconcile removes the check from networks no longer targeted and adds it to new
+// ones. Placements that succeeded are saved even when other networks fail.
+func (r *OrgCheckResource) reconcile(ctx context.Context, plan *OrgCheckResourceModel, placements map[string]orgCheckPlacement, diags *diag.Diagnostics, state *tfsdk.State) {
+	providerData, profileDiags := r.providerData.forProfile(plan.Profile)
+	diags.Append(profileDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	definition, definitionDiags := parseCheckDefinition(plan.DefinitionJSON)
+	diags.Append(definitionDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	targets, targetDiags := orgCheckTargets(ctx, providerData.Client, plan.NetworkIDs)
+	diags.Append(targetDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	wanted := make(map[string]bool, len(targets))
+	var added []string
+	for _, networkID := range targets {
+		wanted[networkID] = true
+		if _, ok := placements[networkID]; !ok {
+			added = append(added, networkID)
+		}
+	}
+	var removed []string
+	for _, networkID := range sortedPlacementKeys(placements) {
+		if !wanted[networkID] {
+			removed = append(removed, networkID)
+		}
+	}
+
+	var mu sync.Mutex
+	removeErrs := forEachID(ctx, removed, plan.MaxParallel, func(ctx context.Context, networkID string) error {
+		mu.Lock()
+		placement := placements[networkID]
+		mu.Unlock()
+
+		err := providerData.Client.DeactivateSnapshotCheck(ctx, placement.SnapshotID, placement.CheckID)
+		providerData.checks.invalidate(placement.SnapshotID)
+		if err != nil && !isNotFoundError(err) {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		delete(placements, networkID)
+		return nil
+	})
+
+	request := fwdclient.NewCheckRequest{
+		Definition: definition,
+		Name:       stringOrEmpty(plan.Name),
+		Note:       stringOrEmpty(plan.Note),
+		Priority:   stringOrEmpty(plan.Priority),
+		Tags:       withManagedByTag(nil),
+	}
+	persistent := true
+	addErrs := forEachID(ctx, added, plan.MaxParallel, func(ctx context.Context, networkID string) error {
+		snapshot, err := providerData.Client.GetLatestProcessedSnapshot(ctx, networkID)
+		if err != nil {
+			return err
+		}
+
+		result, err := providerData.Client.AddSnapshotCheck(ctx, snapshot.ID, request, &persistent)
+		providerData.checks.invalidate(snapshot.ID)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		placements[networkID] = orgCheckPlacement{SnapshotID: snapshot.ID, CheckID: result.ID, Status: result.Status}
+		return nil
+	})
+
+	for _, networkID := range sortedErrorKeys(removeErrs) {
+		addAPIError(diags, fmt.Sprintf("Error removing check from network %s", networkID), removeErrs[networkID])
+	}
+	for _, networkID := range sortedErrorKeys(addErrs) {
+		addAPIError(diags, fmt.Sprintf("Error adding check to network %s", networkID), addErrs[networkID])
+	}
+
+	plan.ID = types.StringValue(contentChecksum([]byte(plan.DefinitionJSON.ValueString())))
+	diags.Append(setOrgCheckPlacements(ctx, plan, placements)...)
+	diags.Append(state.Set(ctx, plan)...)
+}
+
+// orgCheckTargets returns the configured networks, or every network when none are
+// configured, sorted by ID.
+func orgCheckTargets(ctx context.Context, client *fwdclient.Client, networkIDs types.Set) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var targets []string
+
+	if !networkIDs.IsNull() {
+		diags.Append(networkIDs.ElementsAs(ctx, &targets, false)...)
+		sort.Strings(targets)
+		return targets, diags
+	}
+
+	networks, err := client.ListNetworks(ctx)
+	if err != nil {
+		addAPIError(&diags, "Unable to Retrieve Networks", err)
+		return nil, diags
+	}
+	for _, network := range networks {
+		targets = append(targets, network.ID)
+	}
+	sort.Strings(targets)
+	return targets, diags
+}
+
+// forEachID runs fn for each ID, such as a network or snapshot, with at most parallel
+// calls in flight and returns the errors keyed by ID.
+func forEachID(ctx context.Context, ids []string, parallel types.Int64, fn func(context.Context, string) error) map[string]error {
+	limit := int(defaultInt(parallel, 4))
+	if limit < 1 {
+		limit = 1
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = map[string]error{}
+		sem  = make(chan struct{}, limit)
+	)
+	for _, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx, id); err != nil {
+				mu.Lock()
+				errs[id] = err
+				mu.Unlock()
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func orgCheckPlacements(ctx context.Context, model OrgCheckResourceModel) (map[string]orgCheckPlacement, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var checks, statuses map[string]string
+	diags.Append(model.Checks.ElementsAs(ctx, &checks, false)...)
+	diags.Append(model.Statuses.ElementsAs(ctx, &statuses, false)...)
+
+	placements := make(map[string]orgCheckPlacement, len(checks))
+	for networkID, location := range checks {
+		snapshotID, checkID, ok := strings.Cut(location, "/")
+		if !ok {
+			diags.AddAttributeError(path.Root("checks").AtMapKey(networkID), "Invalid Check Location",
+				fmt.Sprintf("Expected snapshot_id/check_id, got %q.", location))
+			continue
+		}
+		placements[networkID] = orgCheckPlacement{SnapshotID: snapshotID, CheckID: checkID, Status: statuses[networkID]}
+	}
+	return placements, diags
+}
+
+func setOrgCheckPlacements(ctx context.Context, model *OrgCheckResourceModel, placements map[string]orgCheckPlacement) diag.Diagnostics {
+	checks := make(map[string]attr.Value, len(placements))
+	statuses := make(map[string]attr.Value, len(placements))
+	for networkID, placement := range placements {
+		checks[networkID] = types.StringValue(placement.SnapshotID + "/" + placement.CheckID)
+		statuses[networkID] = stringOrNull(placement.Status)
+	}
+
+	var diags, mapDiags diag.Diagnostics
+	model.Checks, mapDiags = types.MapValue(types.StringType, checks)
+	diags.Append(mapDiags...)
+	model.Statuses, mapDiags = types.MapValue(types.StringType, statuses)
+	diags.Append(mapDiags...)
+	return diags
+}
+
+func sortedPlacementKeys(placements map[string]orgCheckPlacement) []string {
+	keys := make([]string, 0, len(placements))
+	for key := range placements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedErrorKeys(errs map[string]error) []string {
+	keys := make([]string, 0, len(errs))
+	for key := range errs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
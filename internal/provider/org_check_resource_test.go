@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestForEachID(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		peak     int
+	)
+	networks := []string{"net-1", "net-2", "net-3", "net-4", "net-5"}
+	errs := forEachID(context.Background(), networks, types.Int64Value(2), func(ctx context.Context, networkID string) error {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		if networkID == "net-3" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent calls, got %d", peak)
+	}
+	if len(errs) != 1 || errs["net-3"] == nil {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestAccOrgCheckResource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1", State: "PROCESSED"})
+	server.AddSnapshot("net-2", fwdclient.Snapshot{ID: "snap-2", State: "PROCESSED"})
+
+	config := func(networks string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_org_check" "test" {
+  name            = "MTU"
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_1" })
+  %s
+}
+`, server.URL, networks)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_org_check.test", "checks.%", "2"),
+					resource.TestCheckResourceAttrWith("forward_org_check.test", "checks.net-2", func(value string) error {
+						checkID, ok := strings.CutPrefix(value, "snap-2/")
+						if !ok {
+							return fmt.Errorf("expected check on snap-2, got %q", value)
+						}
+						if check, found := server.Check("snap-2", checkID); !found || check.Name != "MTU" {
+							return fmt.Errorf("expected check %s on snap-2, got %+v", checkID, check)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: config(`network_ids = ["net-1"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_org_check.test", "checks.%", "1"),
+					resource.TestCheckNoResourceAttr("forward_org_check.test", "checks.net-2"),
+				),
+			},
+		},
+	})
+}
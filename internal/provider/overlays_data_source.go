@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &OverlaysDataSource{}
+
+// NewOverlaysDataSource instantiates the SD-WAN overlay data source.
+func NewOverlaysDataSource() datasource.DataSource {
+	return &OverlaysDataSource{}
+}
+
+// OverlaysDataSource exposes the SD-WAN overlay edges and paths modeled in a snapshot.
+type OverlaysDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type overlaysDataSourceModel struct {
+	ID         types.String      `tfsdk:"id"`
+	Profile    types.String      `tfsdk:"profile"`
+	SnapshotID types.String      `tfsdk:"snapshot_id"`
+	Vendor     types.String      `tfsdk:"vendor"`
+	Edges      []overlayEdgeItem `tfsdk:"edges"`
+	Paths      []overlayPathItem `tfsdk:"paths"`
+}
+
+type overlayEdgeItem struct {
+	DeviceName types.String `tfsdk:"device_name"`
+	Vendor     types.String `tfsdk:"vendor"`
+	SiteID     types.String `tfsdk:"site_id"`
+	SystemIP   types.String `tfsdk:"system_ip"`
+	Status     types.String `tfsdk:"status"`
+}
+
+type overlayPathItem struct {
+	SrcDevice    types.String `tfsdk:"src_device"`
+	DstDevice    types.String `tfsdk:"dst_device"`
+	SrcTransport types.String `tfsdk:"src_transport"`
+	DstTransport types.String `tfsdk:"dst_transport"`
+	Status       types.String `tfsdk:"status"`
+}
+
+func (d *OverlaysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_overlays"
+}
+
+func (d *OverlaysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Expose the SD-WAN overlay (for example Cisco Viptela or VMware VeloCloud) modeled in a snapshot: " +
+			"the participating edges and the overlay paths between them, so overlay policy can be verified in Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot identifier the overlay was read from.",
+				Computed:            true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot whose overlay is read.",
+				Required:            true,
+			},
+			"vendor": schema.StringAttribute{
+				MarkdownDescription: "Only include edges of this vendor, and paths between them.",
+				Optional:            true,
+			},
+			"edges": schema.ListAttribute{
+				MarkdownDescription: "Overlay edges sorted by device name.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"device_name": types.StringType,
+						"vendor":      types.StringType,
+						"site_id":     types.StringType,
+						"system_ip":   types.StringType,
+						"status":      types.StringType,
+					},
+				},
+			},
+			"paths": schema.ListAttribute{
+				MarkdownDescription: "Overlay paths between edges sorted by source, destination, and transport.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"src_device":    types.StringType,
+						"dst_device":    types.StringType,
+						"src_transport": types.StringType,
+						"dst_transport": types.StringType,
+						"status":        types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OverlaysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *OverlaysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data overlaysDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !providerData.requireFeature(ctx, featureOverlays, path.Empty(), &resp.Diagnostics) {
+		return
+	}
+
+	overlay, err := providerData.Client.GetOverlay(ctx, data.SnapshotID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Overlay", err)
+		return
+	}
+
+	edges, paths := filterOverlay(*overlay, stringValue(data.Vendor))
+
+	data.Edges = make([]overlayEdgeItem, 0, len(edges))
+	for _, edge := range edges {
+		data.Edges = append(data.Edges, overlayEdgeItem{
+			DeviceName: stringOrNull(edge.DeviceName),
+			Vendor:     stringOrNull(edge.Vendor),
+			SiteID:     stringOrNull(edge.SiteID),
+			SystemIP:   stringOrNull(edge.SystemIP),
+			Status:     stringOrNull(edge.Status),
+		})
+	}
+	data.Paths = make([]overlayPathItem, 0, len(paths))
+	for _, p := range paths {
+		data.Paths = append(data.Paths, overlayPathItem{
+			SrcDevice:    stringOrNull(p.SrcDevice),
+			DstDevice:    stringOrNull(p.DstDevice),
+			SrcTransport: stringOrNull(p.SrcTransport),
+			DstTransport: stringOrNull(p.DstTransport),
+			Status:       stringOrNull(p.Status),
+		})
+	}
+	data.ID = data.SnapshotID
+
+	tflog.Trace(ctx, "retrieved forward overlay", map[string]any{"edges": len(edges), "paths": len(paths)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterOverlay keeps the edges of vendor, when set, and the paths whose ends are both
+// kept edges. Results are sorted so plans stay stable between reads.
+func filterOverlay(overlay fwdclient.Overlay, vendor string) ([]fwdclient.OverlayEdge, []fwdclient.OverlayPath) {
+	kept := make(map[string]bool, len(overlay.Edges))
+	edges := make([]fwdclient.OverlayEdge, 0, len(overlay.Edges))
+	for _, edge := range overlay.Edges {
+		if vendor != "" && !strings.EqualFold(edge.Vendor, vendor) {
+			continue
+		}
+		kept[edge.DeviceName] = true
+		edges = append(edges, edge)
+	}
+
+	paths := make([]fwdclient.OverlayPath, 0, len(overlay.Paths))
+	for _, p := range overlay.Paths {
+		if vendor != "" && (!kept[p.SrcDevice] || !kept[p.DstDevice]) {
+			continue
+		}
+		paths = append(paths, p)
+	}
+
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].DeviceName < edges[j].DeviceName })
+	sort.SliceStable(paths, func(i, j int) bool {
+		a, b := paths[i], paths[j]
+		if a.SrcDevice != b.SrcDevice {
+			return a.SrcDevice < b.SrcDevice
+		}
+		if a.DstDevice != b.DstDevice {
+			return a.DstDevice < b.DstDevice
+		}
+		return a.SrcTransport+"/"+a.DstTransport < b.SrcTransport+"/"+b.DstTransport
+	})
+
+	return edges, paths
+}
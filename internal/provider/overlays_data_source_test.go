@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestFilterOverlay(t *testing.T) {
+	t.Parallel()
+
+	edges, paths := filterOverlay(fwdclient.Overlay{
+		Edges: []fwdclient.OverlayEdge{
+			{DeviceName: "edge-2", Vendor: "VIPTELA"},
+			{DeviceName: "velo-1", Vendor: "VELOCLOUD"},
+			{DeviceName: "edge-1", Vendor: "VIPTELA"},
+		},
+		Paths: []fwdclient.OverlayPath{
+			{SrcDevice: "edge-2", DstDevice: "edge-1", SrcTransport: "mpls"},
+			{SrcDevice: "edge-1", DstDevice: "velo-1"},
+			{SrcDevice: "edge-1", DstDevice: "edge-2", SrcTransport: "internet"},
+		},
+	}, "viptela")
+
+	if len(edges) != 2 || edges[0].DeviceName != "edge-1" {
+		t.Fatalf("unexpected edges: %#v", edges)
+	}
+	if len(paths) != 2 || paths[0].SrcDevice != "edge-1" || paths[1].SrcDevice != "edge-2" {
+		t.Fatalf("unexpected paths: %#v", paths)
+	}
+}
+
+func TestAccOverlaysDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.SetOverlay("snap-1", fwdclient.Overlay{
+		Edges: []fwdclient.OverlayEdge{{DeviceName: "edge-1", Vendor: "VIPTELA", SiteID: "100"}, {DeviceName: "edge-2", Vendor: "VIPTELA", SiteID: "200"}},
+		Paths: []fwdclient.OverlayPath{{SrcDevice: "edge-1", DstDevice: "edge-2", SrcTransport: "mpls", DstTransport: "mpls", Status: "UP"}},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_overlays" "test" {
+  snapshot_id = "snap-1"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_overlays.test", "edges.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_overlays.test", "edges.1.site_id", "200"),
+					resource.TestCheckResourceAttr("data.forward_overlays.test", "paths.0.status", "UP"),
+				),
+			},
+		},
+	})
+}
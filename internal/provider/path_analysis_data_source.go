@@ -9,18 +9,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	diag "github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
-	"github.com/forwardnetworks/terraform-provider-forward/internal/sdk"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 var _ datasource.DataSource = &PathAnalysisDataSource{}
 
+// pathIntents are the intent values accepted by the path search API.
+var pathIntents = []string{"PREFER_DELIVERED", "PREFER_VIOLATIONS", "VIOLATIONS_ONLY"}
+
 // PathAnalysisDataSource executes path analysis queries.
 type PathAnalysisDataSource struct {
 	providerData *ForwardProviderData
@@ -28,6 +36,7 @@ type PathAnalysisDataSource struct {
 
 // PathAnalysisModel represents Terraform state.
 type PathAnalysisModel struct {
+	Profile                 types.String `tfsdk:"profile"`
 	NetworkID               types.String `tfsdk:"network_id"`
 	From                    types.String `tfsdk:"from"`
 	SrcIP                   types.String `tfsdk:"src_ip"`
@@ -54,14 +63,39 @@ type PathAnalysisModel struct {
 	MaxResults              types.Int64  `tfsdk:"max_results"`
 	MaxReturnResults        types.Int64  `tfsdk:"max_return_path_results"`
 	MaxSeconds              types.Int64  `tfsdk:"max_seconds"`
+	MaxPaths                types.Int64  `tfsdk:"max_paths"`
+	StoreResults            types.Bool   `tfsdk:"store_results"`
+	FailOnUnrecognized      types.Bool   `tfsdk:"fail_on_unrecognized"`
+
+	SrcIPLocationType types.String  `tfsdk:"src_ip_location_type"`
+	DstIPLocationType types.String  `tfsdk:"dst_ip_location_type"`
+	TimedOut          types.Bool    `tfsdk:"timed_out"`
+	QueryURL          types.String  `tfsdk:"query_url"`
+	UIURL             types.String  `tfsdk:"ui_url"`
+	PathsJSON         types.List    `tfsdk:"paths_json"`
+	ReturnPathsJSON   types.List    `tfsdk:"return_paths_json"`
+	Unrecognized      types.Map     `tfsdk:"unrecognized_values"`
+	Hops              []pathHopItem `tfsdk:"hops"`
+	DiagramMermaid    types.String  `tfsdk:"diagram_mermaid"`
+	ResultHash        types.String  `tfsdk:"result_hash"`
+}
+
+// pathHopItem flattens one hop of a forward path, with its ACL decisions and zones.
+type pathHopItem struct {
+	PathIndex           types.Int64   `tfsdk:"path_index"`
+	HopIndex            types.Int64   `tfsdk:"hop_index"`
+	DeviceName          types.String  `tfsdk:"device_name"`
+	IngressInterface    types.String  `tfsdk:"ingress_interface"`
+	EgressInterface     types.String  `tfsdk:"egress_interface"`
+	IngressSecurityZone types.String  `tfsdk:"ingress_security_zone"`
+	EgressSecurityZone  types.String  `tfsdk:"egress_security_zone"`
+	ACLs                []pathACLItem `tfsdk:"acls"`
+}
 
-	SrcIPLocationType types.String `tfsdk:"src_ip_location_type"`
-	DstIPLocationType types.String `tfsdk:"dst_ip_location_type"`
-	TimedOut          types.Bool   `tfsdk:"timed_out"`
-	QueryURL          types.String `tfsdk:"query_url"`
-	PathsJSON         types.List   `tfsdk:"paths_json"`
-	ReturnPathsJSON   types.List   `tfsdk:"return_paths_json"`
-	Unrecognized      types.Map    `tfsdk:"unrecognized_values"`
+type pathACLItem struct {
+	Name    types.String `tfsdk:"name"`
+	Context types.String `tfsdk:"context"`
+	Action  types.String `tfsdk:"action"`
 }
 
 func NewPathAnalysisDataSource() datasource.DataSource {
@@ -76,11 +110,20 @@ func (d *PathAnalysisDataSource) Schema(ctx context.Context, req datasource.Sche
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Execute a path analysis query using the Forward Networks API.",
 		Attributes: map[string]schema.Attribute{
-			"network_id":                schema.StringAttribute{Required: true, MarkdownDescription: "Network identifier."},
-			"from":                      schema.StringAttribute{Optional: true, MarkdownDescription: "Source device name."},
-			"src_ip":                    schema.StringAttribute{Optional: true, MarkdownDescription: "Source IP address."},
-			"dst_ip":                    schema.StringAttribute{Required: true, MarkdownDescription: "Destination IP address."},
-			"intent":                    schema.StringAttribute{Optional: true, MarkdownDescription: "Path analysis intent."},
+			"profile":    dataSourceProfileAttribute(),
+			"network_id": schema.StringAttribute{Required: true, MarkdownDescription: "Network identifier."},
+			"from":       schema.StringAttribute{Optional: true, MarkdownDescription: "Source device name."},
+			"src_ip":     schema.StringAttribute{Optional: true, MarkdownDescription: "Source IP address."},
+			"dst_ip":     schema.StringAttribute{Required: true, MarkdownDescription: "Destination IP address."},
+			"intent": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Which paths the search favors when more candidates exist than results are returned. " +
+					"`PREFER_DELIVERED` (the Forward default) returns delivered paths first; `PREFER_VIOLATIONS` returns paths that " +
+					"are dropped, black-holed, or otherwise not delivered first; `VIOLATIONS_ONLY` returns only such paths.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(pathIntents...),
+				},
+			},
 			"snapshot_id":               schema.StringAttribute{Optional: true},
 			"ip_proto":                  schema.Int64Attribute{Optional: true},
 			"src_port":                  schema.StringAttribute{Optional: true},
@@ -101,12 +144,38 @@ func (d *PathAnalysisDataSource) Schema(ctx context.Context, req datasource.Sche
 			"max_candidates":            schema.Int64Attribute{Optional: true},
 			"max_results":               schema.Int64Attribute{Optional: true},
 			"max_return_path_results":   schema.Int64Attribute{Optional: true},
-			"max_seconds":               schema.Int64Attribute{Optional: true},
+			"max_seconds": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Time limit of the search on the server, in seconds. The provider waits that long, plus a margin for " +
+					"the response, even when it exceeds the 60-second request timeout.",
+			},
+			"max_paths": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Maximum number of forward paths, and separately of return paths, kept in state. Further paths are " +
+					"discarded as the response is read and reported in a warning, bounding state size for searches with large " +
+					"`max_results`. All paths are kept when omitted.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"fail_on_unrecognized": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Fail with an error when Forward does not recognize the `app_id`, `user_id`, or `user_group_id` " +
+					"of the search, instead of returning results with `unrecognized_values` set. Defaults to `false`.",
+			},
+			"store_results": storeResultsAttribute("`paths_json`, `return_paths_json`, `hops`, and `diagram_mermaid`"),
 
+			"result_hash":          resultHashAttribute(),
 			"src_ip_location_type": schema.StringAttribute{Computed: true},
 			"dst_ip_location_type": schema.StringAttribute{Computed: true},
 			"timed_out":            schema.BoolAttribute{Computed: true},
-			"query_url":            schema.StringAttribute{Computed: true},
+			"query_url": schema.StringAttribute{
+				MarkdownDescription: "Link to the search in the Forward Enterprise UI, as returned by Forward. Marked sensitive because " +
+					"the link may carry an access token; use `ui_url` to share the search.",
+				Computed:  true,
+				Sensitive: true,
+			},
+			"ui_url": uiURLAttribute(),
 			"paths_json": schema.ListAttribute{
 				Computed:            true,
 				ElementType:         types.StringType,
@@ -118,8 +187,37 @@ func (d *PathAnalysisDataSource) Schema(ctx context.Context, req datasource.Sche
 				MarkdownDescription: "Return path results encoded as JSON strings.",
 			},
 			"unrecognized_values": schema.MapAttribute{
-				Computed:    true,
-				ElementType: types.ListType{ElemType: types.StringType},
+				Computed:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "Values of `app_id`, `user_id`, and `user_group_id` that Forward did not recognize, keyed by attribute.",
+			},
+			"diagram_mermaid": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Hop graph of the forward paths as a Mermaid flowchart, for embedding in change requests. " +
+					"Hops shared by several paths appear once; the last hop of each path links to the destination, labelled with the forwarding outcome.",
+			},
+			"hops": schema.ListAttribute{
+				Computed: true,
+				MarkdownDescription: "Hops of every forward path, in order, with the ACL decisions and security zones reported for each. " +
+					"ACLs and zones are only returned when `include_network_functions` is true.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"path_index":            types.Int64Type,
+						"hop_index":             types.Int64Type,
+						"device_name":           types.StringType,
+						"ingress_interface":     types.StringType,
+						"egress_interface":      types.StringType,
+						"ingress_security_zone": types.StringType,
+						"egress_security_zone":  types.StringType,
+						"acls": types.ListType{ElemType: types.ObjectType{
+							AttrTypes: map[string]attr.Type{
+								"name":    types.StringType,
+								"context": types.StringType,
+								"action":  types.StringType,
+							},
+						}},
+					},
+				},
 			},
 		},
 	}
@@ -151,22 +249,44 @@ func (d *PathAnalysisDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if data.From.IsNull() && data.SrcIP.IsNull() {
 		resp.Diagnostics.AddAttributeError(path.Root("from"), "Invalid configuration", "Either from or src_ip must be supplied.")
 		return
 	}
 
 	params := buildPathParams(data)
-	result, err := d.providerData.Client.SearchPaths(ctx, data.NetworkID.ValueString(), params)
+	collected := &boundedPaths{max: int(data.MaxPaths.ValueInt64())}
+	result, err := providerData.Client.StreamPathSearch(ctx, data.NetworkID.ValueString(), params, collected.add)
 	if err != nil {
-		resp.Diagnostics.AddError("Error executing path analysis", err.Error())
-		return
+		if !providerData.tolerateMissing(err, "Path Analysis Target Not Found", &resp.Diagnostics) {
+			addAPIError(&resp.Diagnostics, "Error executing path analysis", err)
+			return
+		}
+		result = &fwdclient.PathSearchResult{}
+		collected = &boundedPaths{}
+	}
+	result.Info.Paths = collected.paths
+	result.ReturnPathInfo.Paths = collected.returnPaths
+	if collected.dropped > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("max_paths"),
+			"Path Results Truncated",
+			fmt.Sprintf("The path search returned %d more paths than max_paths (%d) allows; they were left out of state. "+
+				"Lower max_results or raise max_paths to keep every path.", collected.dropped, collected.max),
+		)
 	}
 
 	data.SrcIPLocationType = types.StringValue(result.SrcIPLocationType)
 	data.DstIPLocationType = types.StringValue(result.DstIPLocationType)
 	data.TimedOut = types.BoolValue(result.TimedOut)
 	data.QueryURL = types.StringValue(result.QueryURL)
+	data.UIURL = shareableUIURL(providerData.Client, result.QueryURL, &resp.Diagnostics)
 
 	pathsJSON, diag := marshalPaths(ctx, result.Info.Paths)
 	resp.Diagnostics.Append(diag...)
@@ -188,12 +308,28 @@ func (d *PathAnalysisDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 	data.Unrecognized = unrec
+	if data.FailOnUnrecognized.ValueBool() {
+		resp.Diagnostics.Append(unrecognizedValueErrors(result.Unrecognized)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	data.Hops = flattenPathHops(result.Info.Paths)
+	data.DiagramMermaid = renderPathMermaid(result.Info.Paths, data.DstIP.ValueString())
+	data.ResultHash = resultHash([][]fwdclient.Path{result.Info.Paths, result.ReturnPathInfo.Paths})
+
+	if !storeResults(data.StoreResults) {
+		data.PathsJSON = types.ListNull(types.StringType)
+		data.ReturnPathsJSON = types.ListNull(types.StringType)
+		data.Hops = nil
+		data.DiagramMermaid = types.StringNull()
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func buildPathParams(model PathAnalysisModel) sdk.PathSearchParams {
-	params := sdk.PathSearchParams{
+func buildPathParams(model PathAnalysisModel) fwdclient.PathSearchParams {
+	params := fwdclient.PathSearchParams{
 		From:        stringValue(model.From),
 		SrcIP:       stringValue(model.SrcIP),
 		DstIP:       model.DstIP.ValueString(),
@@ -239,7 +375,29 @@ func buildPathParams(model PathAnalysisModel) sdk.PathSearchParams {
 	return params
 }
 
-func marshalPaths(ctx context.Context, paths []sdk.Path) (types.List, diag.Diagnostics) {
+// boundedPaths collects streamed path search results, keeping at most max forward and
+// max return paths and counting the rest. A zero max keeps every path.
+type boundedPaths struct {
+	max         int
+	paths       []fwdclient.Path
+	returnPaths []fwdclient.Path
+	dropped     int
+}
+
+func (b *boundedPaths) add(returnPath bool, p *fwdclient.Path) error {
+	target := &b.paths
+	if returnPath {
+		target = &b.returnPaths
+	}
+	if b.max > 0 && len(*target) >= b.max {
+		b.dropped++
+		return nil
+	}
+	*target = append(*target, *p)
+	return nil
+}
+
+func marshalPaths(ctx context.Context, paths []fwdclient.Path) (types.List, diag.Diagnostics) {
 	if len(paths) == 0 {
 		return types.ListNull(types.StringType), nil
 	}
@@ -257,7 +415,94 @@ func marshalPaths(ctx context.Context, paths []sdk.Path) (types.List, diag.Diagn
 	return list, d
 }
 
-func marshalUnrecognized(ctx context.Context, values sdk.PathUnrecognizedValue) (types.Map, diag.Diagnostics) {
+func flattenPathHops(paths []fwdclient.Path) []pathHopItem {
+	hops := []pathHopItem{}
+	for pathIndex, p := range paths {
+		for hopIndex, hop := range p.Hops {
+			item := pathHopItem{
+				PathIndex:           types.Int64Value(int64(pathIndex)),
+				HopIndex:            types.Int64Value(int64(hopIndex)),
+				DeviceName:          stringOrNull(hop.DeviceName),
+				IngressInterface:    stringOrNull(hop.IngressInterface),
+				EgressInterface:     stringOrNull(hop.EgressInterface),
+				IngressSecurityZone: types.StringNull(),
+				EgressSecurityZone:  types.StringNull(),
+				ACLs:                []pathACLItem{},
+			}
+			if functions := hop.NetworkFunctions; functions != nil {
+				item.IngressSecurityZone = stringOrNull(functions.Ingress.SecurityZone)
+				item.EgressSecurityZone = stringOrNull(functions.Egress.SecurityZone)
+				for _, acl := range functions.ACL {
+					item.ACLs = append(item.ACLs, pathACLItem{
+						Name:    stringOrNull(acl.Name),
+						Context: stringOrNull(acl.Context),
+						Action:  stringOrNull(acl.Action),
+					})
+				}
+			}
+			hops = append(hops, item)
+		}
+	}
+	return hops
+}
+
+// renderPathMermaid draws the devices of every path as a left-to-right Mermaid
+// flowchart. Edges are labelled with the egress and ingress interfaces they connect.
+func renderPathMermaid(paths []fwdclient.Path, destination string) types.String {
+	if len(paths) == 0 {
+		return types.StringNull()
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	nodes := map[string]string{}
+	node := func(name, shape string) string {
+		if id, ok := nodes[name]; ok {
+			return id
+		}
+		id := fmt.Sprintf("n%d", len(nodes))
+		nodes[name] = id
+		fmt.Fprintf(&b, "  %s%s\n", id, fmt.Sprintf(shape, mermaidLabel(name)))
+		return id
+	}
+
+	edges := map[string]bool{}
+	edge := func(from, to, label string) {
+		line := fmt.Sprintf("  %s --> %s\n", from, to)
+		if label != "" {
+			line = fmt.Sprintf("  %s -->|%s| %s\n", from, mermaidLabel(label), to)
+		}
+		if !edges[line] {
+			edges[line] = true
+			b.WriteString(line)
+		}
+	}
+
+	for _, p := range paths {
+		previous := ""
+		var previousHop fwdclient.PathHop
+		for _, hop := range p.Hops {
+			current := node(hop.DeviceName, "[%s]")
+			if previous != "" {
+				edge(previous, current, strings.Trim(previousHop.EgressInterface+" → "+hop.IngressInterface, " →"))
+			}
+			previous, previousHop = current, hop
+		}
+		if previous != "" {
+			edge(previous, node(destination, "([%s])"), p.ForwardingOutcome)
+		}
+	}
+
+	return types.StringValue(b.String())
+}
+
+// mermaidLabel quotes text for use as a Mermaid node or edge label.
+func mermaidLabel(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, "#quot;") + `"`
+}
+
+func marshalUnrecognized(ctx context.Context, values fwdclient.PathUnrecognizedValue) (types.Map, diag.Diagnostics) {
 	data := map[string][]string{
 		"app_id":        values.AppID,
 		"user_id":       values.UserID,
@@ -266,9 +511,54 @@ func marshalUnrecognized(ctx context.Context, values sdk.PathUnrecognizedValue)
 	return types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, data)
 }
 
+// unrecognizedValueErrors reports each value Forward did not recognize as an error on
+// the attribute that supplied it.
+func unrecognizedValueErrors(values fwdclient.PathUnrecognizedValue) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, field := range []struct {
+		attribute string
+		values    []string
+	}{
+		{"app_id", values.AppID},
+		{"user_id", values.UserID},
+		{"user_group_id", values.UserGroupID},
+	} {
+		if len(field.values) == 0 {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root(field.attribute),
+			"Unrecognized Path Search Value",
+			fmt.Sprintf("Forward did not recognize %s %s, so the search ignored it. Correct the value or unset fail_on_unrecognized.",
+				field.attribute, strings.Join(field.values, ", ")),
+		)
+	}
+	return diags
+}
+
 func stringValue(value types.String) string {
 	if value.IsNull() || value.IsUnknown() {
 		return ""
 	}
 	return value.ValueString()
 }
+
+// uiURLAttribute is the `ui_url` attribute of path search data sources.
+func uiURLAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Absolute link to the search in the Forward Enterprise UI, built from `query_url` and the provider " +
+			"`base_url`, with user information and any token or other credential removed, so it can be posted into change tickets.",
+		Computed: true,
+	}
+}
+
+// shareableUIURL returns the credential-free absolute form of a UI link returned by the
+// API, or null with a warning when the link cannot be parsed.
+func shareableUIURL(client *fwdclient.Client, link string, diags *diag.Diagnostics) types.String {
+	shareable, err := client.ShareableUIURL(link)
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("ui_url"), "Unable to Build UI Link", err.Error())
+		return types.StringNull()
+	}
+	return stringOrNull(shareable)
+}
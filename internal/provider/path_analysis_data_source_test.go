@@ -7,25 +7,43 @@ package provider
 
 import (
 	"fmt"
-	"net/http"
-	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 func TestPathAnalysisDataSource(t *testing.T) {
 	t.Parallel()
 
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/api/networks/net-1/paths" {
-			t.Fatalf("unexpected path: %s", r.URL.Path)
-		}
-		_, _ = w.Write([]byte(`{"srcIpLocationType":"INTERFACE","dstIpLocationType":"INTERFACE","info":{"paths":[{"forwardingOutcome":"DELIVERED","securityOutcome":"PERMITTED","hops":[]}]} }`))
-	}))
-	defer server.Close()
+	server := fakeforward.New(t)
+	server.SetPathResult("net-1", fwdclient.PathSearchResult{
+		SrcIPLocationType: "INTERFACE",
+		DstIPLocationType: "INTERFACE",
+		QueryURL:          "/?/search?networkId=net-1&token=secret&q=10.0.0.1",
+		Info: fwdclient.PathCollection{Paths: []fwdclient.Path{{
+			ForwardingOutcome: "DELIVERED",
+			SecurityOutcome:   "PERMITTED",
+			Hops: []fwdclient.PathHop{
+				{DeviceName: "edge-1", EgressInterface: "ge-0/0/1"},
+				{
+					DeviceName:       "fw-1",
+					IngressInterface: "eth1",
+					NetworkFunctions: &fwdclient.PathNetworkFunction{
+						ACL:     []fwdclient.PathACL{{Name: "OUTSIDE-IN", Context: "ingress", Action: "PERMIT"}},
+						Ingress: fwdclient.PathInterfaceDetail{SecurityZone: "untrust"},
+						Egress:  fwdclient.PathInterfaceDetail{SecurityZone: "trust"},
+					},
+				},
+			},
+		}}},
+	})
 
 	providerFactory := providerserver.NewProtocol6WithError(New("test")())
 
@@ -37,13 +55,86 @@ func TestPathAnalysisDataSource(t *testing.T) {
 			{
 				Config: pathAnalysisTestConfig(server.URL),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("data.forward_path_analysis.test", "paths.#", "1"),
+					resource.TestCheckResourceAttr("data.forward_path_analysis.test", "paths_json.#", "1"),
+					resource.TestCheckResourceAttr("data.forward_path_analysis.test", "hops.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_path_analysis.test", "hops.1.device_name", "fw-1"),
+					resource.TestCheckResourceAttr("data.forward_path_analysis.test", "hops.1.acls.0.name", "OUTSIDE-IN"),
+					resource.TestCheckResourceAttr("data.forward_path_analysis.test", "hops.1.acls.0.action", "PERMIT"),
+					resource.TestCheckResourceAttr("data.forward_path_analysis.test", "hops.1.ingress_security_zone", "untrust"),
+					resource.TestMatchResourceAttr("data.forward_path_analysis.test", "diagram_mermaid", regexp.MustCompile(`^flowchart LR\n`)),
+					resource.TestCheckResourceAttr("data.forward_path_analysis.test", "ui_url", server.URL+"/?/search?networkId=net-1&q=10.0.0.1"),
 				),
 			},
+			{
+				// Unsupported intents are rejected during plan, before any API call.
+				Config: strings.Replace(pathAnalysisTestConfig(server.URL), `dst_ip     = "10.0.0.1"`, `dst_ip     = "10.0.0.1"
+  intent     = "PREFER_FASTEST"`, 1),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
 		},
 	})
 }
 
+func TestRenderPathMermaid(t *testing.T) {
+	t.Parallel()
+
+	paths := []fwdclient.Path{
+		{
+			ForwardingOutcome: "DELIVERED",
+			Hops: []fwdclient.PathHop{
+				{DeviceName: "edge-1", EgressInterface: "ge-0/0/1"},
+				{DeviceName: "fw-1", IngressInterface: "eth1"},
+			},
+		},
+		{
+			ForwardingOutcome: "DROPPED",
+			Hops: []fwdclient.PathHop{
+				{DeviceName: "edge-1", EgressInterface: "ge-0/0/1"},
+				{DeviceName: "core \"a\""},
+			},
+		},
+	}
+
+	want := `flowchart LR
+  n0["edge-1"]
+  n1["fw-1"]
+  n0 -->|"ge-0/0/1 → eth1"| n1
+  n2(["10.0.0.1"])
+  n1 -->|"DELIVERED"| n2
+  n3["core #quot;a#quot;"]
+  n0 -->|"ge-0/0/1"| n3
+  n3 -->|"DROPPED"| n2
+`
+	if got := renderPathMermaid(paths, "10.0.0.1").ValueString(); got != want {
+		t.Fatalf("unexpected diagram:\n%s", got)
+	}
+	if !renderPathMermaid(nil, "10.0.0.1").IsNull() {
+		t.Fatal("expected null diagram without paths")
+	}
+}
+
+func TestBoundedPaths(t *testing.T) {
+	t.Parallel()
+
+	collected := &boundedPaths{max: 2}
+	for _, returnPath := range []bool{false, false, false, true, false} {
+		if err := collected.add(returnPath, &fwdclient.Path{ForwardingOutcome: "DELIVERED"}); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	if len(collected.paths) != 2 || len(collected.returnPaths) != 1 || collected.dropped != 2 {
+		t.Fatalf("unexpected collection: %d paths, %d return paths, %d dropped", len(collected.paths), len(collected.returnPaths), collected.dropped)
+	}
+
+	unbounded := &boundedPaths{}
+	for i := 0; i < 5; i++ {
+		_ = unbounded.add(false, &fwdclient.Path{})
+	}
+	if len(unbounded.paths) != 5 || unbounded.dropped != 0 {
+		t.Fatalf("expected every path to be kept without a limit, got %d", len(unbounded.paths))
+	}
+}
+
 func pathAnalysisTestConfig(host string) string {
 	return fmt.Sprintf(`
 provider "forward" {
@@ -59,3 +150,19 @@ data "forward_path_analysis" "test" {
 }
 `, host)
 }
+
+func TestUnrecognizedValueErrors(t *testing.T) {
+	t.Parallel()
+
+	if diags := unrecognizedValueErrors(fwdclient.PathUnrecognizedValue{}); diags.HasError() {
+		t.Fatalf("expected no errors, got %v", diags)
+	}
+
+	diags := unrecognizedValueErrors(fwdclient.PathUnrecognizedValue{AppID: []string{"payroll"}, UserGroupID: []string{"hr", "it"}})
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected one error per attribute, got %v", diags)
+	}
+	if detail := diags[1].Detail(); !strings.Contains(detail, "user_group_id hr, it") {
+		t.Fatalf("unexpected detail: %s", detail)
+	}
+}
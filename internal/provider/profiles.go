@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const profileAttributeDescription = "Name of the provider `profiles` entry identifying the Forward instance to use. " +
+	"Defaults to the provider's top-level connection settings."
+
+// profileKeys lists the settings a profile may override; omitted settings inherit the
+// provider's top-level values.
+var profileKeys = []string{"base_url", "api_key", "api_key_ref", "network_id", "insecure"}
+
+// connectionSettings identifies one Forward instance and the default network within it.
+type connectionSettings struct {
+	BaseURL string
+	APIKey  string
+	// APIKeyRef is a profile's `api_key_ref`, resolved into APIKey once profiles are parsed.
+	APIKeyRef string
+	NetworkID string
+	Insecure  bool
+}
+
+// parseProfiles resolves the provider `profiles` map into connection settings, filling
+// unset values from defaults.
+func parseProfiles(ctx context.Context, profiles types.Map, defaults connectionSettings) (map[string]connectionSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if profiles.IsNull() || profiles.IsUnknown() {
+		return nil, diags
+	}
+
+	var raw map[string]map[string]types.String
+	diags.Append(profiles.ElementsAs(ctx, &raw, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	resolved := make(map[string]connectionSettings, len(raw))
+	for name, values := range raw {
+		settings := defaults
+		if !values["api_key"].IsNull() && !values["api_key_ref"].IsNull() {
+			diags.AddAttributeError(path.Root("profiles").AtMapKey(name), "Conflicting Profile Settings",
+				fmt.Sprintf("Profile %q sets both api_key and api_key_ref; set only one.", name))
+			continue
+		}
+		for key, value := range values {
+			if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+				continue
+			}
+			keyPath := path.Root("profiles").AtMapKey(name).AtMapKey(key)
+			switch key {
+			case "base_url":
+				settings.BaseURL = value.ValueString()
+			case "api_key":
+				settings.APIKey = value.ValueString()
+			case "api_key_ref":
+				settings.APIKey, settings.APIKeyRef = "", value.ValueString()
+			case "network_id":
+				settings.NetworkID = value.ValueString()
+			case "insecure":
+				insecure, err := strconv.ParseBool(value.ValueString())
+				if err != nil {
+					diags.AddAttributeError(keyPath, "Invalid Profile Setting", fmt.Sprintf("insecure must be \"true\" or \"false\", got %q.", value.ValueString()))
+					continue
+				}
+				settings.Insecure = insecure
+			default:
+				diags.AddAttributeError(keyPath, "Unknown Profile Setting",
+					fmt.Sprintf("Profile %q sets unsupported key %q. Supported keys are: %s.", name, key, strings.Join(profileKeys, ", ")))
+			}
+		}
+		resolved[name] = settings
+	}
+
+	return resolved, diags
+}
+
+// forProfile returns the provider data for the named profile, or d itself when no
+// profile is selected.
+func (d *ForwardProviderData) forProfile(profile types.String) (*ForwardProviderData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if profile.IsNull() || profile.IsUnknown() || profile.ValueString() == "" {
+		return d, diags
+	}
+
+	if data, ok := d.profiles[profile.ValueString()]; ok {
+		return data, diags
+	}
+
+	names := make([]string, 0, len(d.profiles))
+	for name := range d.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	detail := fmt.Sprintf("Profile %q is not defined in the provider `profiles` map.", profile.ValueString())
+	if len(names) > 0 {
+		detail += fmt.Sprintf(" Defined profiles: %s.", strings.Join(names, ", "))
+	}
+	diags.AddAttributeError(path.Root("profile"), "Unknown Profile", detail)
+	return nil, diags
+}
+
+// resourceProfileAttribute is the `profile` attribute shared by all resources. Moving
+// an object between instances requires recreating it.
+func resourceProfileAttribute() resourceschema.StringAttribute {
+	return resourceschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: profileAttributeDescription,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// dataSourceProfileAttribute is the `profile` attribute shared by all data sources.
+func dataSourceProfileAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: profileAttributeDescription,
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestParseProfilesInheritsDefaults(t *testing.T) {
+	t.Parallel()
+
+	profiles := types.MapValueMust(types.MapType{ElemType: types.StringType}, map[string]attr.Value{
+		"lab": types.MapValueMust(types.StringType, map[string]attr.Value{
+			"base_url": types.StringValue("https://lab.example"),
+			"insecure": types.StringValue("true"),
+		}),
+	})
+	defaults := connectionSettings{BaseURL: "https://prod.example", APIKey: "key", NetworkID: "net-1"}
+
+	resolved, diags := parseProfiles(context.Background(), profiles, defaults)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	want := connectionSettings{BaseURL: "https://lab.example", APIKey: "key", NetworkID: "net-1", Insecure: true}
+	if got := resolved["lab"]; got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestParseProfilesRejectsUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	profiles := types.MapValueMust(types.MapType{ElemType: types.StringType}, map[string]attr.Value{
+		"lab": types.MapValueMust(types.StringType, map[string]attr.Value{
+			"token": types.StringValue("secret"),
+		}),
+	})
+
+	if _, diags := parseProfiles(context.Background(), profiles, connectionSettings{}); !diags.HasError() {
+		t.Fatal("expected an error for an unsupported profile key")
+	}
+}
+
+func TestParseProfilesAPIKeyRef(t *testing.T) {
+	t.Parallel()
+
+	profiles := types.MapValueMust(types.MapType{ElemType: types.StringType}, map[string]attr.Value{
+		"lab": types.MapValueMust(types.StringType, map[string]attr.Value{
+			"api_key_ref": types.StringValue("vault:forward/lab"),
+		}),
+	})
+	resolved, diags := parseProfiles(context.Background(), profiles, connectionSettings{APIKey: "key"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := resolved["lab"]; got.APIKey != "" || got.APIKeyRef != "vault:forward/lab" {
+		t.Fatalf("expected the reference to replace the inherited key, got %+v", got)
+	}
+
+	conflicting := types.MapValueMust(types.MapType{ElemType: types.StringType}, map[string]attr.Value{
+		"lab": types.MapValueMust(types.StringType, map[string]attr.Value{
+			"api_key":     types.StringValue("key"),
+			"api_key_ref": types.StringValue("env:LAB_KEY"),
+		}),
+	})
+	if _, diags := parseProfiles(context.Background(), conflicting, connectionSettings{}); !diags.HasError() {
+		t.Fatal("expected an error for a profile setting both api_key and api_key_ref")
+	}
+}
+
+func TestForProfileUnknownName(t *testing.T) {
+	t.Parallel()
+
+	data := &ForwardProviderData{profiles: map[string]*ForwardProviderData{"lab": {}}}
+
+	if got, diags := data.forProfile(types.StringNull()); diags.HasError() || got != data {
+		t.Fatalf("expected default provider data for null profile, got %v %v", got, diags)
+	}
+	if _, diags := data.forProfile(types.StringValue("staging")); !diags.HasError() {
+		t.Fatal("expected an error for an undefined profile")
+	}
+}
+
+func TestAccProfileSelectsInstance(t *testing.T) {
+	prod := fakeforward.New(t)
+	prod.SetVersion(fwdclient.Version{Version: "24.1.0"})
+	lab := fakeforward.New(t)
+	lab.SetVersion(fwdclient.Version{Version: "25.2.0"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "prod-token"
+  network_id = "net-1"
+
+  profiles = {
+    lab = {
+      base_url = %q
+      api_key  = "lab-token"
+    }
+  }
+}
+
+data "forward_version" "prod" {}
+
+data "forward_version" "lab" {
+  profile = "lab"
+}
+`, prod.URL, lab.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_version.prod", "version", "24.1.0"),
+					resource.TestCheckResourceAttr("data.forward_version.lab", "version", "25.2.0"),
+				),
+			},
+		},
+	})
+}
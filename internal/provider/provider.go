@@ -5,11 +5,19 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -17,23 +25,55 @@ import (
 	schemavalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
-	"github.com/forwardnetworks/terraform-provider-forward/internal/sdk"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 const (
 	envAPIKeyPrimary = "FORWARD_API_KEY"
 	envAPIKeyLegacy  = "FORWARD_API_TOKEN"
+	envAPIKeyRef     = "FORWARD_API_KEY_REF"
 	envNetworkID     = "FORWARD_NETWORK_ID"
 	envBaseURL       = "FORWARD_BASE_URL"
+
+	envInsecure                = "FORWARD_INSECURE"
+	envFailOnMissing           = "FORWARD_FAIL_ON_MISSING"
+	envMaxRetries              = "FORWARD_MAX_RETRIES"
+	envMaxConcurrentRequests   = "FORWARD_MAX_CONCURRENT_REQUESTS"
+	envNQENotReadyTimeout      = "FORWARD_NQE_NOT_READY_TIMEOUT_SECONDS"
+	envCircuitBreakerThreshold = "FORWARD_CIRCUIT_BREAKER_THRESHOLD"
+	envValidateCredentials     = "FORWARD_VALIDATE_CREDENTIALS"
+	envProxyURL                = "FORWARD_PROXY_URL"
+	envCACertFile              = "FORWARD_CA_CERT_FILE"
 )
 
+// testRecorder, when set by the acceptance test harness, returns the recorder that
+// records or replays the API traffic of the given connections. Release builds never set
+// it, so their traffic cannot be recorded.
+var testRecorder func(connections []connectionSettings) (*fwdclient.Recorder, error)
+
 var _ provider.Provider = &ForwardProvider{}
+var _ provider.ProviderWithFunctions = &ForwardProvider{}
 
 // ForwardProviderData houses the configured client and contextual values
 // that resources and data sources will require.
 type ForwardProviderData struct {
-	Client    *sdk.Client
+	Client    *fwdclient.Client
 	NetworkID string
+	// FailOnMissing controls whether data sources error when the referenced
+	// object does not exist, or return an empty result with a warning.
+	FailOnMissing bool
+
+	// validateReferences enables plan-time lookups of referenced snapshots.
+	validateReferences bool
+	// checkDefaults are applied to checks created without a priority or tags.
+	checkDefaults checkDefaults
+
+	checks         *checkCache
+	queries        *nqeQueryCache
+	versions       *versionCache
+	snapshotStates *snapshotStateCache
+	// profiles holds the provider data for each named entry of the `profiles` map.
+	profiles map[string]*ForwardProviderData
 }
 
 // ForwardProvider defines the provider implementation.
@@ -46,10 +86,24 @@ type ForwardProvider struct {
 
 // ForwardProviderModel describes the provider data model.
 type ForwardProviderModel struct {
-	BaseURL   types.String `tfsdk:"base_url"`
-	APIKey    types.String `tfsdk:"api_key"`
-	Insecure  types.Bool   `tfsdk:"insecure"`
-	NetworkID types.String `tfsdk:"network_id"`
+	BaseURL                 types.String `tfsdk:"base_url"`
+	APIKey                  types.String `tfsdk:"api_key"`
+	APIKeyRef               types.String `tfsdk:"api_key_ref"`
+	Insecure                types.Bool   `tfsdk:"insecure"`
+	NetworkID               types.String `tfsdk:"network_id"`
+	FailOnMissing           types.Bool   `tfsdk:"fail_on_missing"`
+	MaxConcurrentRequests   types.Int64  `tfsdk:"max_concurrent_requests"`
+	NQENotReadyTimeout      types.Int64  `tfsdk:"nqe_not_ready_timeout_seconds"`
+	MaxRetries              types.Int64  `tfsdk:"max_retries"`
+	ProxyURL                types.String `tfsdk:"proxy_url"`
+	CACertFile              types.String `tfsdk:"ca_cert_file"`
+	CircuitBreakerThreshold types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	ValidateCredentials     types.Bool   `tfsdk:"validate_credentials"`
+	ValidateReferences      types.Bool   `tfsdk:"validate_references"`
+	SecretCommand           types.List   `tfsdk:"secret_command"`
+	DefaultCheckPriority    types.String `tfsdk:"default_check_priority"`
+	DefaultCheckTags        types.List   `tfsdk:"default_check_tags"`
+	Profiles                types.Map    `tfsdk:"profiles"`
 }
 
 func (p *ForwardProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -59,34 +113,159 @@ func (p *ForwardProvider) Metadata(ctx context.Context, req provider.MetadataReq
 
 func (p *ForwardProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Use the Forward Enterprise provider to interact with the Forward Networks platform APIs.",
+		MarkdownDescription: "Use the Forward Enterprise provider to interact with the Forward Networks platform APIs.\n\n" +
+			"Every setting except `profiles` can also be supplied with a `FORWARD_` environment variable named after it, " +
+			"for example `FORWARD_BASE_URL` or `FORWARD_MAX_RETRIES`. A value in the provider block takes precedence over " +
+			"the environment variable, which takes precedence over the default; `profiles` entries override both.",
 		Attributes: map[string]schema.Attribute{
 			"base_url": schema.StringAttribute{
-				MarkdownDescription: "Base URL for the Forward Networks API, for example `https://fwd.app`.",
-				Required:            true,
+				MarkdownDescription: "Base URL for the Forward Networks API, for example `https://fwd.app`. May include a port and a path prefix " +
+					"when Forward is served behind a reverse proxy, for example `https://tools.corp:8443/forward`. Required unless " +
+					"`FORWARD_BASE_URL` is set.",
+				Optional: true,
 				Validators: []schemavalidator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "API key used to authenticate requests. Marked sensitive and typically sourced from the `FORWARD_API_KEY` environment variable.",
-				Required:            true,
-				Sensitive:           true,
+				MarkdownDescription: "API key used to authenticate requests. Marked sensitive and typically sourced from the `FORWARD_API_KEY` " +
+					"(or legacy `FORWARD_API_TOKEN`) environment variable. Required unless one of them or `api_key_ref` is set.",
+				Optional:  true,
+				Sensitive: true,
 				Validators: []schemavalidator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"api_key_ref": schema.StringAttribute{
+				MarkdownDescription: "Where to read the API key from, in place of `api_key`: `env:NAME` (an environment variable), " +
+					"`file:PATH` (a file, without its trailing newline), or `vault:PATH` (the output of `secret_command`). " +
+					"The reference is resolved when the provider is configured, so the key itself never appears in " +
+					"configuration or state.",
+				Optional: true,
+				Validators: []schemavalidator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key")),
+				},
+			},
 			"insecure": schema.BoolAttribute{
-				MarkdownDescription: "Disable TLS certificate verification (not recommended). Useful for testing against development appliances.",
-				Optional:            true,
+				MarkdownDescription: "Disable TLS certificate verification (not recommended). Useful for testing against development appliances. " +
+					"Prefer `ca_cert_file` for appliances with certificates from a private CA.",
+				Optional: true,
 			},
 			"network_id": schema.StringAttribute{
-				MarkdownDescription: "Default Forward Enterprise Network ID used by resources and data sources when an explicit network is not provided.",
-				Required:            true,
+				MarkdownDescription: "Default Forward Enterprise Network ID used by resources and data sources when an explicit network is not provided. " +
+					"Required unless `FORWARD_NETWORK_ID` is set.",
+				Optional: true,
+				Validators: []schemavalidator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"fail_on_missing": schema.BoolAttribute{
+				MarkdownDescription: "Whether data sources fail when the referenced network, snapshot, or object does not exist. " +
+					"When `false`, data sources return empty results and emit a warning instead. Defaults to `true`.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests the provider sends to Forward Enterprise at once, shared by all " +
+					"resources and data sources using this provider configuration. Lower it for on-premises appliances that " +
+					"cannot serve Terraform's default parallelism. Unlimited when omitted.",
+				Optional: true,
+				Validators: []schemavalidator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"nqe_not_ready_timeout_seconds": schema.Int64Attribute{
+				MarkdownDescription: "How long NQE queries are retried, with exponential backoff, while Forward Enterprise reports " +
+					"the snapshot is not ready (HTTP 409 or 423), for example when a data source reads a snapshot that is " +
+					"still processing. `0` disables the retries. Defaults to `120`.",
+				Optional: true,
+				Validators: []schemavalidator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "How many times a request is retried, with exponential backoff, after a network error, " +
+					"HTTP 429, or HTTP 5xx response. `0` disables retries. Defaults to `3`.",
+				Optional: true,
+				Validators: []schemavalidator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "HTTP(S) proxy used for every request, for example `http://proxy.corp:3128`. When omitted, " +
+					"the standard `HTTPS_PROXY` and `NO_PROXY` environment variables apply.",
+				Optional: true,
 				Validators: []schemavalidator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM bundle of certificate authorities trusted in addition to the system roots, " +
+					"for appliances with certificates issued by a private CA.",
+				Optional: true,
+				Validators: []schemavalidator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				MarkdownDescription: "Number of consecutive failed requests (network errors or HTTP 5xx, counting retries) after " +
+					"which the provider stops contacting an unavailable Forward Enterprise instance for 30 seconds and fails " +
+					"the remaining operations with a single aggregated error instead of retrying each one. Counted separately " +
+					"for each profile. `0` disables the breaker. Defaults to `10`.",
+				Optional: true,
+				Validators: []schemavalidator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"validate_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Verify during provider configuration that `base_url` is reachable, the API key is accepted, and " +
+					"`network_id` is accessible, failing fast instead of every resource failing separately. Defaults to `false`.",
+				Optional: true,
+			},
+			"validate_references": schema.BoolAttribute{
+				MarkdownDescription: "Look up the snapshots referenced by `snapshot_id` attributes during plan and fail when one " +
+					"does not exist or is not PROCESSED, catching stale IDs before an apply partially executes. Costs one API " +
+					"call per distinct snapshot. Defaults to `false`.",
+				Optional: true,
+			},
+			"secret_command": schema.ListAttribute{
+				MarkdownDescription: "Program and leading arguments that resolve `vault:PATH` references in `api_key_ref`, for " +
+					"example `[\"vault\", \"kv\", \"get\", \"-field=api_key\"]`. It runs without a shell, with the path appended " +
+					"as its last argument, and must print the secret on standard output; it is given 30 seconds. May also be " +
+					"set as a JSON array in the `FORWARD_SECRET_COMMAND` environment variable.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []schemavalidator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"default_check_priority": schema.StringAttribute{
+				MarkdownDescription: "Priority given to `forward_intent_check` and `forward_nqe_check` checks created without a " +
+					"`priority`, so governance conventions are enforced centrally. One of `NOT_SET`, `LOW`, `MEDIUM`, or `HIGH`. " +
+					"Only applies when a check is created; existing checks keep their priority when the default changes.",
+				Optional: true,
+				Validators: []schemavalidator.String{
+					stringvalidator.OneOf(checkPriorities...),
+				},
+			},
+			"default_check_tags": schema.ListAttribute{
+				MarkdownDescription: "Tags given to `forward_intent_check` and `forward_nqe_check` checks created without `tags`, " +
+					"for example `[\"iac\"]` to mark Terraform-managed checks. Only applies when a check is created. The " +
+					"`FORWARD_DEFAULT_CHECK_TAGS` environment variable takes a comma-separated list.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"profiles": schema.MapAttribute{
+				MarkdownDescription: "Additional Forward instances keyed by profile name, selected with the `profile` attribute on " +
+					"resources and data sources. Each profile may set `base_url`, `api_key` or `api_key_ref`, `network_id`, and " +
+					"`insecure` (as `\"true\"` or `\"false\"`); omitted settings inherit the provider's top-level values.",
+				Optional:  true,
+				Sensitive: true,
+				ElementType: types.MapType{
+					ElemType: types.StringType,
+				},
+			},
 		},
 	}
 }
@@ -100,35 +279,49 @@ func (p *ForwardProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	baseURL := ""
-	if !data.BaseURL.IsNull() {
-		baseURL = data.BaseURL.ValueString()
-	}
-	if baseURL == "" {
-		baseURL = os.Getenv(envBaseURL)
-	}
-	apiKey := ""
-	if !data.APIKey.IsNull() {
-		apiKey = data.APIKey.ValueString()
-	}
-	if apiKey == "" {
-		apiKey = os.Getenv(envAPIKeyPrimary)
+	baseURL := stringSetting(data.BaseURL, envBaseURL)
+	apiKey := stringSetting(data.APIKey, envAPIKeyPrimary, envAPIKeyLegacy)
+	networkID := stringSetting(data.NetworkID, envNetworkID)
+	insecure := boolSetting(data.Insecure, envInsecure, false, "insecure", &resp.Diagnostics)
+	failOnMissing := boolSetting(data.FailOnMissing, envFailOnMissing, true, "fail_on_missing", &resp.Diagnostics)
+	validate := boolSetting(data.ValidateCredentials, envValidateCredentials, false, "validate_credentials", &resp.Diagnostics)
+	validateReferences := boolSetting(data.ValidateReferences, envValidateReferences, false, "validate_references", &resp.Diagnostics)
+	maxRetries := intSetting(data.MaxRetries, envMaxRetries, 3, 0, "max_retries", &resp.Diagnostics)
+	maxConcurrentRequests := intSetting(data.MaxConcurrentRequests, envMaxConcurrentRequests, 0, 1, "max_concurrent_requests", &resp.Diagnostics)
+	nqeNotReadyTimeout := intSetting(data.NQENotReadyTimeout, envNQENotReadyTimeout, 120, 0, "nqe_not_ready_timeout_seconds", &resp.Diagnostics)
+	circuitBreakerThreshold := intSetting(data.CircuitBreakerThreshold, envCircuitBreakerThreshold, 10, 0, "circuit_breaker_threshold", &resp.Diagnostics)
+	proxyURL := stringSetting(data.ProxyURL, envProxyURL)
+	// A configured api_key takes precedence over a reference from the environment.
+	var apiKeyRef string
+	if data.APIKey.IsNull() {
+		apiKeyRef = stringSetting(data.APIKeyRef, envAPIKeyRef)
 	}
-	if apiKey == "" {
-		apiKey = os.Getenv(envAPIKeyLegacy)
+	secrets := newSecretResolver(secretCommandSetting(ctx, data.SecretCommand, &resp.Diagnostics))
+	checkDefaults, diags := checkDefaultsSetting(ctx, data.DefaultCheckPriority, data.DefaultCheckTags)
+	resp.Diagnostics.Append(diags...)
+	if checkDefaults.Priority != "" && !slices.Contains(checkPriorities, checkDefaults.Priority) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_check_priority"),
+			"Invalid Default Check Priority",
+			fmt.Sprintf("Default check priority %q must be one of %s.", checkDefaults.Priority, strings.Join(checkPriorities, ", ")),
+		)
 	}
-
-	insecure := false
-	if !data.Insecure.IsNull() {
-		insecure = data.Insecure.ValueBool()
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	networkID := ""
-	if !data.NetworkID.IsNull() {
-		networkID = data.NetworkID.ValueString()
-	}
-	if networkID == "" {
-		networkID = os.Getenv(envNetworkID)
+	var caCertPEM []byte
+	if caCertFile := stringSetting(data.CACertFile, envCACertFile); caCertFile != "" {
+		bundle, err := os.ReadFile(caCertFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to Read CA Certificate File",
+				fmt.Sprintf("The provider could not read the CA bundle at %q: %s", caCertFile, err),
+			)
+			return
+		}
+		caCertPEM = bundle
 	}
 
 	if baseURL == "" {
@@ -141,12 +334,12 @@ func (p *ForwardProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	if apiKey == "" {
+	if apiKey == "" && apiKeyRef == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing API Key",
 			"The provider cannot create the Forward Networks client because the `api_key` attribute is empty. "+
-				"Set the `api_key` attribute or the `FORWARD_API_KEY` environment variable.",
+				"Set the `api_key` or `api_key_ref` attribute, or the `FORWARD_API_KEY` environment variable.",
 		)
 		return
 	}
@@ -161,26 +354,110 @@ func (p *ForwardProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	client, err := sdk.NewClient(ctx, sdk.Config{
-		BaseURL:  baseURL,
-		APIKey:   apiKey,
-		Insecure: insecure,
-		UserAgent: fmt.Sprintf(
-			"terraform-provider-forward/%s",
-			p.version,
-		),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Configure Forward Networks Client",
-			err.Error(),
-		)
+	defaults := connectionSettings{
+		BaseURL:   baseURL,
+		APIKey:    apiKey,
+		NetworkID: networkID,
+		Insecure:  insecure,
+	}
+
+	if apiKeyRef != "" {
+		defaults.APIKey = resolveAPIKey(ctx, secrets, apiKeyRef, path.Root("api_key_ref"), &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	providerData := &ForwardProviderData{
-		Client:    client,
-		NetworkID: networkID,
+	profiles, diags := parseProfiles(ctx, data.Profiles, defaults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, settings := range profiles {
+		if settings.APIKeyRef == "" {
+			continue
+		}
+		settings.APIKey = resolveAPIKey(ctx, secrets, settings.APIKeyRef, path.Root("profiles").AtMapKey(name).AtMapKey("api_key_ref"), &resp.Diagnostics)
+		profiles[name] = settings
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var recorder *fwdclient.Recorder
+	if testRecorder != nil {
+		connections := []connectionSettings{defaults}
+		for _, settings := range profiles {
+			connections = append(connections, settings)
+		}
+		var err error
+		if recorder, err = testRecorder(connections); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Configure Forward Networks Recorder",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	newProviderData := func(settings connectionSettings, attrPath func(string) path.Path) *ForwardProviderData {
+		client, err := fwdclient.NewClient(ctx, fwdclient.Config{
+			BaseURL:  settings.BaseURL,
+			APIKey:   settings.APIKey,
+			Insecure: settings.Insecure,
+			ProxyURL: proxyURL,
+			UserAgent: fmt.Sprintf(
+				"terraform-provider-forward/%s",
+				p.version,
+			),
+			Recorder:              recorder,
+			CACertPEM:             caCertPEM,
+			MaxRetries:            sdkMaxRetries(maxRetries),
+			MaxConcurrentRequests: int(maxConcurrentRequests),
+			NQENotReadyTimeout:    time.Duration(nqeNotReadyTimeout) * time.Second,
+
+			CircuitBreakerThreshold: int(circuitBreakerThreshold),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Configure Forward Networks Client",
+				err.Error(),
+			)
+			return nil
+		}
+
+		versions := &versionCache{}
+		if validate {
+			validateCredentials(ctx, client, settings.NetworkID, versions, attrPath, &resp.Diagnostics)
+		}
+
+		return &ForwardProviderData{
+			Client:             client,
+			NetworkID:          settings.NetworkID,
+			FailOnMissing:      failOnMissing,
+			validateReferences: validateReferences,
+			checkDefaults:      checkDefaults,
+			checks:             newCheckCache(),
+			queries:            newNqeQueryCache(nqeQueryCacheTTL),
+			versions:           versions,
+			snapshotStates:     newSnapshotStateCache(),
+		}
+	}
+
+	providerData := newProviderData(defaults, path.Root)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData.profiles = make(map[string]*ForwardProviderData, len(profiles))
+	for name, settings := range profiles {
+		providerData.profiles[name] = newProviderData(settings, func(attr string) path.Path {
+			return path.Root("profiles").AtMapKey(name).AtMapKey(attr)
+		})
+	}
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.DataSourceData = providerData
@@ -189,19 +466,113 @@ func (p *ForwardProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 func (p *ForwardProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewAPIObjectResource,
+		NewCheckExecutionResource,
+		NewCheckLibraryResource,
+		NewCheckOwnerResource,
+		NewCollectionCommandsResource,
 		NewIntentCheckResource,
+		NewIntentCheckCopyResource,
+		NewInventoryExportResource,
+		NewNQECheckResource,
+		NewNQEPackResource,
 		NewNQEQueryResource,
+		NewOrgCheckResource,
 		NewSnapshotResource,
+		NewSnapshotRetentionResource,
+		NewZeroTrustPolicyResource,
 	}
 }
 
 func (p *ForwardProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewVersionDataSource,
+		NewCapabilitiesDataSource,
 		NewSnapshotsDataSource,
 		NewIntentChecksDataSource,
 		NewNqeQueryDataSource,
 		NewPathAnalysisDataSource,
+		NewL2PathDataSource,
+		NewInventoryDiffDataSource,
+		NewDeviceStateDataSource,
+		NewVIPsDataSource,
+		NewTunnelsDataSource,
+		NewOverlaysDataSource,
+		NewExistingChecksDataSource,
+		NewCheckTemplateDataSource,
+		NewDeviceReachabilityDataSource,
+		NewCheckViolationsDataSource,
+		NewCheckResultDiffDataSource,
+		NewProviderStatsDataSource,
+		NewCheckBundleDataSource,
+		NewSnapshotInfoDataSource,
+		NewSnapshotLineageDataSource,
+		NewDeviceDeltaDataSource,
+		NewCloudSecurityGroupsDataSource,
+		NewCloudRoutesDataSource,
+		NewK8sInventoryDataSource,
+		NewAPIRequestDataSource,
+	}
+}
+
+func (p *ForwardProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewNormalizeIPFunction,
+		NewSubnetOfFunction,
+	}
+}
+
+// sdkMaxRetries converts max_retries to fwdclient.Config.MaxRetries, which treats zero as the
+// default and a negative value as no retries.
+func sdkMaxRetries(maxRetries int64) int {
+	if maxRetries == 0 {
+		return -1
+	}
+	return int(maxRetries)
+}
+
+// tolerateMissing downgrades a not-found error to a warning when fail_on_missing is
+// disabled. It returns true when the caller should continue with an empty result.
+func (d *ForwardProviderData) tolerateMissing(err error, summary string, diags *diag.Diagnostics) bool {
+	if d.FailOnMissing || !isNotFoundError(err) {
+		return false
+	}
+	diags.AddWarning(summary, err.Error())
+	return true
+}
+
+// validateCredentials checks that the appliance answers, the API key is accepted, and the
+// default network is readable, attributing each failure to the attribute most likely wrong.
+// The reported version is remembered in versions for later feature checks. attrPath maps
+// a setting name such as "base_url" to the attribute that configured it.
+func validateCredentials(ctx context.Context, client *fwdclient.Client, networkID string, versions *versionCache, attrPath func(string) path.Path, diags *diag.Diagnostics) {
+	version, err := client.GetVersion(ctx)
+	if err != nil {
+		var apiErr *fwdclient.APIError
+		if !errors.As(err, &apiErr) {
+			diags.AddAttributeError(
+				attrPath("base_url"),
+				"Unable to Reach Forward Enterprise",
+				fmt.Sprintf("The provider could not contact the Forward API at the configured `base_url`: %s", err),
+			)
+			return
+		}
+		addAPIError(diags, "Unable to Validate Forward Credentials", err)
+		return
+	}
+	versions.set(version)
+
+	limit := 1
+	if _, err := client.ListSnapshots(ctx, networkID, fwdclient.SnapshotListOptions{Limit: &limit}); err != nil {
+		if isNotFoundError(err) {
+			diags.AddAttributeError(
+				attrPath("network_id"),
+				"Network Not Found",
+				fmt.Sprintf("Network %q does not exist or is not visible to the configured API key.", networkID),
+			)
+			return
+		}
+		addAPIError(diags, "Unable to Validate Network Access", err)
 	}
 }
 
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ProviderStatsDataSource{}
+
+// NewProviderStatsDataSource instantiates the provider stats data source.
+func NewProviderStatsDataSource() datasource.DataSource {
+	return &ProviderStatsDataSource{}
+}
+
+// ProviderStatsDataSource exposes the provider's API request and cache counters, for
+// runs where provider logs are hard to reach, such as Terraform Cloud.
+type ProviderStatsDataSource struct {
+	providerData *ForwardProviderData
+}
+
+// providerStatsDataSourceModel represents the Terraform state.
+type providerStatsDataSourceModel struct {
+	Profile     types.String `tfsdk:"profile"`
+	Requests    types.Int64  `tfsdk:"requests"`
+	Retries     types.Int64  `tfsdk:"retries"`
+	RateLimited types.Int64  `tfsdk:"rate_limited"`
+	CacheHits   types.Int64  `tfsdk:"cache_hits"`
+}
+
+func (d *ProviderStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_stats"
+}
+
+func (d *ProviderStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Report the API requests, retries, and cache hits of this provider configuration so far in the " +
+			"current plan or apply, for surfacing provider behavior as outputs in Terraform Cloud and Terraform Enterprise, " +
+			"where provider logs are hard to access. Counters are read when the data source is, so use `depends_on` to read " +
+			"it after the resources and data sources of interest.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"requests": schema.Int64Attribute{
+				MarkdownDescription: "API requests made to Forward Enterprise, each counted once however often it was retried.",
+				Computed:            true,
+			},
+			"retries": schema.Int64Attribute{
+				MarkdownDescription: "Requests retried after a network error, HTTP 429, or HTTP 5xx response.",
+				Computed:            true,
+			},
+			"rate_limited": schema.Int64Attribute{
+				MarkdownDescription: "Retries caused by HTTP 429 responses. Consider lowering the provider `max_concurrent_requests` when non-zero.",
+				Computed:            true,
+			},
+			"cache_hits": schema.Int64Attribute{
+				MarkdownDescription: "Lookups of checks, NQE queries, snapshots, and the Forward Enterprise version answered " +
+					"from the provider's caches without an API request.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *ProviderStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ProviderStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var config providerStatsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(config.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	usage := providerData.Client.Usage()
+	state := providerStatsDataSourceModel{
+		Profile:     config.Profile,
+		Requests:    types.Int64Value(int64(usage.Requests)),
+		Retries:     types.Int64Value(int64(usage.Retries)),
+		RateLimited: types.Int64Value(int64(usage.RateLimited)),
+		CacheHits:   types.Int64Value(providerData.cacheHits()),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// cacheHits returns how many lookups the provider data's caches have answered without
+// an API request.
+func (d *ForwardProviderData) cacheHits() int64 {
+	var hits int64
+	if d.checks != nil {
+		hits += d.checks.hits.Load()
+	}
+	if d.queries != nil {
+		hits += d.queries.hits.Load()
+	}
+	if d.snapshotStates != nil {
+		hits += d.snapshotStates.hits.Load()
+	}
+	if d.versions != nil {
+		hits += d.versions.hits.Load()
+	}
+	return hits
+}
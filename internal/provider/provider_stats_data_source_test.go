@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAccProviderStatsDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.SetVersion(fwdclient.Version{Release: "25.4.0-12", Version: "25.4.0"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = %q
+}
+
+data "forward_version" "current" {}
+
+data "forward_provider_stats" "run" {
+  depends_on = [data.forward_version.current]
+}
+`, server.URL, testNetworkID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.forward_provider_stats.run", "requests", func(value string) error {
+						if value == "0" {
+							return fmt.Errorf("expected the version request to be counted")
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr("data.forward_provider_stats.run", "retries", "0"),
+					resource.TestCheckResourceAttr("data.forward_provider_stats.run", "rate_limited", "0"),
+					resource.TestCheckResourceAttrSet("data.forward_provider_stats.run", "cache_hits"),
+				),
+			},
+		},
+	})
+}
@@ -4,11 +4,24 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 // testAccProtoV6ProviderFactories is used to instantiate a provider during acceptance testing.
@@ -33,3 +46,109 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+const (
+	// envRecorderMode and envRecorderCassette enable record/replay of API traffic for
+	// acceptance tests. Only the test harness reads them.
+	envRecorderMode     = "FORWARD_VCR_MODE"
+	envRecorderCassette = "FORWARD_VCR_CASSETTE"
+)
+
+// recorders shares one recorder per cassette across provider instances in the same
+// process, so every test step appends to (or replays from) the same cassette.
+var (
+	recordersMu sync.Mutex
+	recorders   = map[string]*fwdclient.Recorder{}
+)
+
+func init() {
+	testRecorder = recorderFromEnv
+}
+
+// recorderFromEnv returns the shared recorder selected by FORWARD_VCR_MODE and
+// FORWARD_VCR_CASSETTE, or nil when recording is disabled. The API keys and appliance
+// hosts of every connection are scrubbed from recorded traffic.
+func recorderFromEnv(connections []connectionSettings) (*fwdclient.Recorder, error) {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv(envRecorderMode)))
+	if mode == "" {
+		return nil, nil
+	}
+
+	cassette := os.Getenv(envRecorderCassette)
+	if strings.TrimSpace(cassette) == "" {
+		return nil, fmt.Errorf("%s is set but %s does not name a cassette file", envRecorderMode, envRecorderCassette)
+	}
+
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+
+	if recorder, ok := recorders[cassette]; ok {
+		return recorder, nil
+	}
+
+	var secrets []string
+	for _, conn := range connections {
+		secrets = append(secrets, conn.APIKey)
+		if parsed, err := url.Parse(conn.BaseURL); err == nil && parsed.Host != "" {
+			secrets = append(secrets, parsed.Host)
+		}
+	}
+
+	recorder, err := fwdclient.NewRecorder(fwdclient.RecorderMode(mode), cassette, secrets...)
+	if err != nil {
+		return nil, err
+	}
+	recorders[cassette] = recorder
+	return recorder, nil
+}
+
+// TestProviderProtocol5Schema guards the protocol5 build: nested attributes are not
+// representable in plugin protocol 5, so every schema must avoid them.
+func TestProviderProtocol5Schema(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProtocol5WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("create protocol 5 server: %v", err)
+	}
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Errorf("unexpected schema error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{})
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	var diags diag.Diagnostics
+	validateCredentials(context.Background(), client, "net-1", &versionCache{}, path.Root, &diags)
+	if diags.HasError() {
+		t.Fatalf("expected valid configuration, got %v", diags)
+	}
+
+	diags = nil
+	validateCredentials(context.Background(), client, "net-missing", &versionCache{}, path.Root, &diags)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Network Not Found" {
+		t.Fatalf("expected network not found error, got %v", diags)
+	}
+
+	server.InjectFault(fakeforward.Fault{PathPrefix: "/api/version", Status: http.StatusUnauthorized, Times: 1})
+	diags = nil
+	validateCredentials(context.Background(), client, "net-1", &versionCache{}, path.Root, &diags)
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Summary(), "Authentication Failed") {
+		t.Fatalf("expected authentication error, got %v", diags)
+	}
+}
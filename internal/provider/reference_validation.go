@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+const envValidateReferences = "FORWARD_VALIDATE_REFERENCES"
+
+var _ resource.ConfigValidator = snapshotReferencesValidator{}
+
+// snapshotReferencesValidator checks the snapshot IDs a resource references. IDs must
+// be non-empty and free of whitespace and slashes. When the provider sets
+// `validate_references`, each snapshot must also exist and be PROCESSED, so stale IDs
+// fail the plan instead of part way through an apply.
+type snapshotReferencesValidator struct {
+	// providerData returns the resource's provider data, which is nil until the
+	// provider is configured; existence is only checked once it is.
+	providerData func() *ForwardProviderData
+	// profiles are the attributes selecting the instance the snapshots live on; the
+	// first one that is set is used.
+	profiles   []string
+	attributes []string
+}
+
+// validateSnapshotReferences returns a validator for the snapshot ID attributes of a resource.
+func validateSnapshotReferences(providerData func() *ForwardProviderData, attributes ...string) resource.ConfigValidator {
+	return snapshotReferencesValidator{providerData: providerData, profiles: []string{"profile"}, attributes: attributes}
+}
+
+// validateSnapshotReferencesOn is validateSnapshotReferences for snapshots on the
+// instance selected by the first of profiles that is set, such as a copy source.
+func validateSnapshotReferencesOn(providerData func() *ForwardProviderData, profiles []string, attributes ...string) resource.ConfigValidator {
+	return snapshotReferencesValidator{providerData: providerData, profiles: profiles, attributes: attributes}
+}
+
+func (v snapshotReferencesValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("%s must reference existing, processed snapshots", strings.Join(v.attributes, ", "))
+}
+
+func (v snapshotReferencesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v snapshotReferencesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var providerData *ForwardProviderData
+	if data := v.providerData(); data != nil && data.validateReferences {
+		var profile types.String
+		for _, attribute := range v.profiles {
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &profile)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			if !profile.IsNull() {
+				break
+			}
+		}
+		// An unknown profile is reported when the resource is applied.
+		if selected, diags := data.forProfile(profile); !diags.HasError() {
+			providerData = selected
+		}
+	}
+
+	for _, attribute := range v.attributes {
+		attrPath := path.Root(attribute)
+		var snapshotID types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attrPath, &snapshotID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if snapshotID.IsNull() || snapshotID.IsUnknown() {
+			continue
+		}
+
+		id := snapshotID.ValueString()
+		if id == "" || strings.ContainsAny(id, " \t\r\n/") {
+			resp.Diagnostics.AddAttributeError(attrPath, "Invalid Snapshot ID",
+				fmt.Sprintf("Snapshot ID %q must be non-empty and must not contain whitespace or slashes.", id))
+			continue
+		}
+		if providerData == nil {
+			continue
+		}
+
+		state, err := providerData.snapshotStates.get(ctx, providerData.Client, id)
+		switch {
+		case isNotFoundError(err):
+			resp.Diagnostics.AddAttributeError(attrPath, "Snapshot Not Found",
+				fmt.Sprintf("Snapshot %s does not exist or is not visible to the configured API key.", id))
+		case err != nil:
+			resp.Diagnostics.AddAttributeWarning(attrPath, "Unable to Validate Snapshot",
+				fmt.Sprintf("Snapshot %s could not be looked up, so it is not validated until apply: %s", id, err))
+		case !strings.EqualFold(state, "PROCESSED"):
+			resp.Diagnostics.AddAttributeError(attrPath, "Snapshot Not Processed",
+				fmt.Sprintf("Snapshot %s is %s; only PROCESSED snapshots can be referenced.", id, state))
+		}
+	}
+}
+
+// snapshotStateCache remembers snapshots looked up during validation and for UI links,
+// so a plan referencing one snapshot from many resources looks it up once.
+type snapshotStateCache struct {
+	mu        sync.Mutex
+	snapshots map[string]*fwdclient.SnapshotDetails
+	hits      atomic.Int64
+}
+
+func newSnapshotStateCache() *snapshotStateCache {
+	return &snapshotStateCache{snapshots: map[string]*fwdclient.SnapshotDetails{}}
+}
+
+func (c *snapshotStateCache) get(ctx context.Context, client *fwdclient.Client, snapshotID string) (string, error) {
+	snapshot, err := c.lookup(ctx, client, snapshotID)
+	if err != nil {
+		return "", err
+	}
+	return snapshot.State, nil
+}
+
+// network returns the network a snapshot belongs to, which may be empty when the
+// snapshot does not report it.
+func (c *snapshotStateCache) network(ctx context.Context, client *fwdclient.Client, snapshotID string) (string, error) {
+	snapshot, err := c.lookup(ctx, client, snapshotID)
+	if err != nil {
+		return "", err
+	}
+	return snapshot.NetworkID, nil
+}
+
+func (c *snapshotStateCache) lookup(ctx context.Context, client *fwdclient.Client, snapshotID string) (*fwdclient.SnapshotDetails, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if snapshot, ok := c.snapshots[snapshotID]; ok {
+		c.hits.Add(1)
+		return snapshot, nil
+	}
+	snapshot, err := client.GetSnapshotByID(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	c.snapshots[snapshotID] = snapshot
+	return snapshot, nil
+}
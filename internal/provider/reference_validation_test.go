@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	testresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestSnapshotReferencesValidator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1", State: "PROCESSED"})
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-2", State: "PROCESSING"})
+
+	client, err := fwdclient.NewClient(ctx, fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	providerData := &ForwardProviderData{Client: client, validateReferences: true, snapshotStates: newSnapshotStateCache()}
+
+	owner := &CheckOwnerResource{}
+	var schemaResp resource.SchemaResponse
+	owner.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	validate := func(data *ForwardProviderData, snapshotID string) string {
+		objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+		values := map[string]tftypes.Value{}
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values["snapshot_id"] = tftypes.NewValue(tftypes.String, snapshotID)
+
+		var resp resource.ValidateConfigResponse
+		validator := validateSnapshotReferences(func() *ForwardProviderData { return data }, "snapshot_id")
+		validator.ValidateResource(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}, &resp)
+		if !resp.Diagnostics.HasError() {
+			return ""
+		}
+		return resp.Diagnostics.Errors()[0].Summary()
+	}
+
+	cases := []struct {
+		data       *ForwardProviderData
+		snapshotID string
+		want       string
+	}{
+		{providerData, "snap-1", ""},
+		{providerData, "snap-2", "Snapshot Not Processed"},
+		{providerData, "snap-missing", "Snapshot Not Found"},
+		{providerData, "net-1/snap-1", "Invalid Snapshot ID"},
+		// Existence is only checked once the provider is configured with validate_references.
+		{nil, "snap-missing", ""},
+		{&ForwardProviderData{Client: client, snapshotStates: newSnapshotStateCache()}, "snap-missing", ""},
+	}
+	for _, tc := range cases {
+		if got := validate(tc.data, tc.snapshotID); got != tc.want {
+			t.Errorf("snapshot %q: expected %q, got %q", tc.snapshotID, tc.want, got)
+		}
+	}
+}
+
+func TestSnapshotReferencesValidatorSourceProfile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newData := func(snapshotID string) *ForwardProviderData {
+		server := fakeforward.New(t)
+		server.AddSnapshot("net-1", fwdclient.Snapshot{ID: snapshotID, State: "PROCESSED"})
+		client, err := fwdclient.NewClient(ctx, fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+		if err != nil {
+			t.Fatalf("new client: %v", err)
+		}
+		return &ForwardProviderData{Client: client, validateReferences: true, snapshotStates: newSnapshotStateCache()}
+	}
+	providerData := newData("snap-prod")
+	providerData.profiles = map[string]*ForwardProviderData{"staging": newData("snap-staging")}
+
+	copyResource := &IntentCheckCopyResource{providerData: providerData}
+	var schemaResp resource.SchemaResponse
+	copyResource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	validate := func(sourceProfile any) string {
+		objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+		values := map[string]tftypes.Value{}
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values["source_profile"] = tftypes.NewValue(tftypes.String, sourceProfile)
+		values["source_snapshot_id"] = tftypes.NewValue(tftypes.String, "snap-staging")
+		values["snapshot_id"] = tftypes.NewValue(tftypes.String, "snap-prod")
+
+		var resp resource.ValidateConfigResponse
+		for _, validator := range copyResource.ConfigValidators(ctx) {
+			validator.ValidateResource(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}, &resp)
+		}
+		if !resp.Diagnostics.HasError() {
+			return ""
+		}
+		return resp.Diagnostics.Errors()[0].Summary()
+	}
+
+	// The source snapshot is looked up on the source instance and the destination on
+	// the resource's own.
+	if got := validate("staging"); got != "" {
+		t.Errorf("expected the source snapshot on the staging profile, got %q", got)
+	}
+	if got := validate(nil); got != "Snapshot Not Found" {
+		t.Errorf("expected the source snapshot to be missing without source_profile, got %q", got)
+	}
+}
+
+func TestAccIntentCheckResourceValidateReferences(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1", State: "PROCESSED"})
+
+	testresource.Test(t, testresource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []testresource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url            = %q
+  network_id          = "net-1"
+  api_key             = "token"
+  validate_references = true
+}
+
+resource "forward_intent_check" "test" {
+  snapshot_id     = "snap-stale"
+  name            = "Reachability"
+  definition_json = jsonencode({ checkType = "NQE", queryId = "FQ_test" })
+}
+`, server.URL),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Snapshot Not Found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// storeResultsAttribute is the `store_results` attribute of data sources whose results
+// can be left out of state. cleared lists the attributes it nulls.
+func storeResultsAttribute(cleared string) datasourceschema.BoolAttribute {
+	return datasourceschema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: "Whether to keep the results in state. Set to `false` when only `result_hash` or the counts are " +
+			"needed, so megabytes of JSON do not bloat plans and state; " + cleared + " are then null. Defaults to `true`.",
+	}
+}
+
+// resultHashAttribute is the computed `result_hash` attribute paired with `store_results`.
+func resultHashAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		Computed: true,
+		MarkdownDescription: "SHA-256 hash of the results, set whether or not they are stored. It changes exactly when the " +
+			"results do, so pipelines can detect changes without keeping the results in state.",
+	}
+}
+
+// storeResults reports whether a `store_results` value asks for results to be kept.
+func storeResults(value types.Bool) bool {
+	return value.IsNull() || value.IsUnknown() || value.ValueBool()
+}
+
+// resultHash hashes the JSON encoding of results. Maps are encoded with sorted keys, so
+// equal results always hash the same.
+func resultHash(results any) types.String {
+	content, err := json.Marshal(results)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(contentChecksum(content))
+}
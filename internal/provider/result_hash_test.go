@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResultHash(t *testing.T) {
+	t.Parallel()
+
+	rows := func(items ...string) []json.RawMessage {
+		out := make([]json.RawMessage, 0, len(items))
+		for _, item := range items {
+			out = append(out, json.RawMessage(item))
+		}
+		return out
+	}
+
+	a := resultHash(map[string][]json.RawMessage{"snap-1": rows(`{"a":1}`), "snap-2": rows(`{"b":2}`)})
+	b := resultHash(map[string][]json.RawMessage{"snap-2": rows(`{"b": 2}`), "snap-1": rows(`{"a":1}`)})
+	if a.IsNull() || a.ValueString() != b.ValueString() {
+		t.Fatalf("expected equal results to hash the same, got %s and %s", a, b)
+	}
+	if len(a.ValueString()) != 64 {
+		t.Fatalf("expected a hex SHA-256 digest, got %q", a.ValueString())
+	}
+
+	if c := resultHash(rows(`{"a":1}`, `{"a":2}`)); c.ValueString() == resultHash(rows(`{"a":2}`, `{"a":1}`)).ValueString() {
+		t.Fatal("expected reordered rows to hash differently")
+	}
+}
+
+func TestStoreResults(t *testing.T) {
+	t.Parallel()
+
+	if !storeResults(types.BoolNull()) || !storeResults(types.BoolValue(true)) {
+		t.Fatal("expected results to be stored by default")
+	}
+	if storeResults(types.BoolValue(false)) {
+		t.Fatal("expected store_results = false to drop results")
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const envSecretCommand = "FORWARD_SECRET_COMMAND"
+
+// The `api_key_ref` settings name where the API key is kept, so the key itself never
+// appears in configuration or state:
+//
+//	env:NAME    the value of environment variable NAME
+//	file:PATH   the contents of the file at PATH, without the trailing newline
+//	vault:PATH  the output of the provider's `secret_command` run with PATH appended
+const (
+	secretSchemeEnv   = "env:"
+	secretSchemeFile  = "file:"
+	secretSchemeVault = "vault:"
+)
+
+// secretCommandTimeout bounds each run of the `secret_command` resolver.
+const secretCommandTimeout = 30 * time.Second
+
+// secretResolver turns secret references into their values when the provider is
+// configured.
+type secretResolver struct {
+	// command is the `secret_command` program followed by its leading arguments. Empty
+	// when no command is configured.
+	command []string
+}
+
+func newSecretResolver(command []string) *secretResolver {
+	return &secretResolver{command: command}
+}
+
+// secretCommandSetting returns the configured `secret_command`, or the command in the
+// FORWARD_SECRET_COMMAND environment variable, written as a JSON array of strings.
+func secretCommandSetting(ctx context.Context, value types.List, diags *diag.Diagnostics) []string {
+	var command []string
+	if !value.IsNull() && !value.IsUnknown() {
+		diags.Append(value.ElementsAs(ctx, &command, false)...)
+		return command
+	}
+
+	raw := strings.TrimSpace(os.Getenv(envSecretCommand))
+	if raw == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(raw), &command); err != nil || len(command) == 0 || command[0] == "" {
+		diags.AddAttributeError(path.Root("secret_command"), "Invalid Secret Command",
+			fmt.Sprintf("%s must be a JSON array naming the program and its arguments, for example "+
+				`["vault", "kv", "get", "-field=password"].`, envSecretCommand))
+		return nil
+	}
+	return command
+}
+
+// resolve returns the secret a reference points to. Values without a supported scheme
+// are rejected rather than used as the secret, so a mistyped reference never ends up
+// sent as a credential.
+func (r *secretResolver) resolve(ctx context.Context, value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, secretSchemeEnv):
+		name := strings.TrimPrefix(value, secretSchemeEnv)
+		secret, ok := os.LookupEnv(name)
+		if !ok || secret == "" {
+			return "", fmt.Errorf("environment variable %q is not set", name)
+		}
+		return secret, nil
+	case strings.HasPrefix(value, secretSchemeFile):
+		name := strings.TrimPrefix(value, secretSchemeFile)
+		contents, err := os.ReadFile(name)
+		if err != nil {
+			return "", fmt.Errorf("read secret file: %w", err)
+		}
+		return nonEmptySecret(string(contents), value)
+	case strings.HasPrefix(value, secretSchemeVault):
+		return r.runCommand(ctx, strings.TrimPrefix(value, secretSchemeVault))
+	default:
+		return "", fmt.Errorf("%q is not a secret reference; use env:NAME, file:PATH, or vault:PATH", value)
+	}
+}
+
+// runCommand runs the configured `secret_command` with secretPath appended and returns
+// its standard output.
+func (r *secretResolver) runCommand(ctx context.Context, secretPath string) (string, error) {
+	if r == nil || len(r.command) == 0 {
+		return "", errors.New("vault references require the provider `secret_command` setting or the " +
+			envSecretCommand + " environment variable")
+	}
+	if secretPath == "" {
+		return "", errors.New("vault reference has an empty path")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, secretCommandTimeout)
+	defer cancel()
+
+	args := append(append([]string{}, r.command[1:]...), secretPath)
+	cmd := exec.CommandContext(ctx, r.command[0], args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("secret command failed for %q: %w: %s", secretPath, err, msg)
+		}
+		return "", fmt.Errorf("secret command failed for %q: %w", secretPath, err)
+	}
+	return nonEmptySecret(stdout.String(), secretSchemeVault+secretPath)
+}
+
+// nonEmptySecret strips the trailing newline files and commands usually end with.
+func nonEmptySecret(raw, reference string) (string, error) {
+	secret := strings.TrimRight(raw, "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("secret %q is empty", reference)
+	}
+	return secret, nil
+}
+
+// resolveAPIKey resolves a provider or profile `api_key_ref` to the API key.
+func resolveAPIKey(ctx context.Context, secrets *secretResolver, reference string, attrPath path.Path, diags *diag.Diagnostics) string {
+	resolved, err := secrets.resolve(ctx, reference)
+	if err != nil {
+		diags.AddAttributeError(attrPath, "Unable to Resolve API Key", err.Error())
+		return ""
+	}
+	return resolved
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSecretResolverSchemes(t *testing.T) {
+	t.Setenv("FORWARD_TEST_SECRET", "from-env")
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("write secret file: %v", err)
+	}
+
+	resolver := newSecretResolver([]string{"echo", "from-vault"})
+	ctx := context.Background()
+
+	cases := map[string]string{
+		"env:FORWARD_TEST_SECRET":  "from-env",
+		"file:" + secretFile:       "from-file",
+		"vault:secret/forward/key": "from-vault secret/forward/key",
+	}
+	for value, want := range cases {
+		got, err := resolver.resolve(ctx, value)
+		if err != nil {
+			t.Fatalf("resolve %q: %v", value, err)
+		}
+		if got != want {
+			t.Fatalf("resolve %q: expected %q, got %q", value, want, got)
+		}
+	}
+}
+
+func TestSecretResolverErrors(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := newSecretResolver(nil).resolve(ctx, "vault:secret/forward/key"); err == nil || !strings.Contains(err.Error(), "secret_command") {
+		t.Fatalf("expected a missing secret_command error, got %v", err)
+	}
+	if _, err := newSecretResolver(nil).resolve(ctx, "env:FORWARD_TEST_SECRET_UNSET"); err == nil {
+		t.Fatal("expected an error for an unset environment variable")
+	}
+	if _, err := newSecretResolver(nil).resolve(ctx, "plain"); err == nil || !strings.Contains(err.Error(), "not a secret reference") {
+		t.Fatalf("expected a plain value to be rejected, got %v", err)
+	}
+	if _, err := newSecretResolver([]string{"false"}).resolve(ctx, "vault:secret/forward/key"); err == nil {
+		t.Fatal("expected an error when the secret command fails")
+	}
+}
+
+func TestSecretCommandSetting(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	listValue := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("vault"), types.StringValue("kv get")})
+	if got := secretCommandSetting(ctx, listValue, &diags); diags.HasError() || strings.Join(got, "|") != "vault|kv get" {
+		t.Fatalf("expected the configured command, got %q (%v)", got, diags)
+	}
+
+	t.Setenv(envSecretCommand, `["vault", "kv", "get", "-field=api_key"]`)
+	if got := secretCommandSetting(ctx, types.ListNull(types.StringType), &diags); diags.HasError() || len(got) != 4 || got[3] != "-field=api_key" {
+		t.Fatalf("expected the command from the environment, got %q (%v)", got, diags)
+	}
+
+	t.Setenv(envSecretCommand, "vault kv get")
+	secretCommandSetting(ctx, types.ListNull(types.StringType), &diags)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a command that is not a JSON array")
+	}
+	if errDiag, ok := diags.Errors()[0].(diag.DiagnosticWithPath); !ok || !errDiag.Path().Equal(path.Root("secret_command")) {
+		t.Fatalf("expected the error on secret_command, got %v", diags)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ datasource.DataSource = &SnapshotInfoDataSource{}
+
+// NewSnapshotInfoDataSource instantiates the snapshot info data source.
+func NewSnapshotInfoDataSource() datasource.DataSource {
+	return &SnapshotInfoDataSource{}
+}
+
+// SnapshotInfoDataSource retrieves the details of a single snapshot.
+type SnapshotInfoDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type snapshotInfoDataSourceModel struct {
+	Profile           types.String `tfsdk:"profile"`
+	NetworkID         types.String `tfsdk:"network_id"`
+	SnapshotID        types.String `tfsdk:"snapshot_id"`
+	State             types.String `tfsdk:"state"`
+	ProcessingTrigger types.String `tfsdk:"processing_trigger"`
+	ParentSnapshotID  types.String `tfsdk:"parent_snapshot_id"`
+	Note              types.String `tfsdk:"note"`
+	IsDraft           types.Bool   `tfsdk:"is_draft"`
+	CreationMillis    types.Int64  `tfsdk:"creation_date_millis"`
+	ProcessedMillis   types.Int64  `tfsdk:"processed_at_millis"`
+	RestoredMillis    types.Int64  `tfsdk:"restored_at_millis"`
+	FavoritedBy       types.String `tfsdk:"favorited_by"`
+	FavoritedByUserID types.String `tfsdk:"favorited_by_user_id"`
+	FavoritedMillis   types.Int64  `tfsdk:"favorited_at_millis"`
+}
+
+func (d *SnapshotInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_info"
+}
+
+func (d *SnapshotInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieve the details of a single snapshot, for configurations that receive a snapshot ID from " +
+			"outside Terraform, such as a CI variable, to validate it (for example with a `postcondition` on `state`) and " +
+			"look up its network, parent, and timestamps.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "Network the snapshot must belong to. Defaults to the provider `network_id`; when neither is " +
+					"set, the snapshot is looked up by ID alone and this reports its network.",
+				Optional: true,
+				Computed: true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot to look up.",
+				Required:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Processing state of the snapshot, for example `PROCESSED`.",
+				Computed:            true,
+			},
+			"processing_trigger": schema.StringAttribute{
+				MarkdownDescription: "What triggered processing of the snapshot.",
+				Computed:            true,
+			},
+			"parent_snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot this snapshot was derived from.",
+				Computed:            true,
+			},
+			"note": schema.StringAttribute{
+				MarkdownDescription: "Note attached to the snapshot.",
+				Computed:            true,
+			},
+			"is_draft": schema.BoolAttribute{
+				MarkdownDescription: "Whether the snapshot is a draft.",
+				Computed:            true,
+			},
+			"creation_date_millis": schema.Int64Attribute{
+				MarkdownDescription: "When the snapshot was created, in milliseconds since the epoch.",
+				Computed:            true,
+			},
+			"processed_at_millis": schema.Int64Attribute{
+				MarkdownDescription: "When the snapshot finished processing, in milliseconds since the epoch.",
+				Computed:            true,
+			},
+			"restored_at_millis": schema.Int64Attribute{
+				MarkdownDescription: "When the snapshot was restored from an archive, in milliseconds since the epoch.",
+				Computed:            true,
+			},
+			"favorited_by": schema.StringAttribute{
+				MarkdownDescription: "User who favorited the snapshot.",
+				Computed:            true,
+			},
+			"favorited_by_user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user who favorited the snapshot.",
+				Computed:            true,
+			},
+			"favorited_at_millis": schema.Int64Attribute{
+				MarkdownDescription: "When the snapshot was favorited, in milliseconds since the epoch.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SnapshotInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *SnapshotInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data snapshotInfoDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networkID := providerData.NetworkID
+	if !data.NetworkID.IsNull() && !data.NetworkID.IsUnknown() {
+		networkID = data.NetworkID.ValueString()
+	}
+
+	var snapshot *fwdclient.SnapshotDetails
+	var err error
+	if networkID != "" {
+		snapshot, err = providerData.Client.GetSnapshot(ctx, networkID, data.SnapshotID.ValueString())
+	} else {
+		snapshot, err = providerData.Client.GetSnapshotByID(ctx, data.SnapshotID.ValueString())
+	}
+	if err != nil {
+		if !providerData.tolerateMissing(err, "Snapshot Not Found", &resp.Diagnostics) {
+			addAPIErrorWithPaths(&resp.Diagnostics, "Unable to Retrieve Snapshot", err, map[string]path.Path{"snapshotId": path.Root("snapshot_id")})
+			return
+		}
+		snapshot = &fwdclient.SnapshotDetails{Snapshot: fwdclient.Snapshot{ID: data.SnapshotID.ValueString()}}
+	}
+
+	if snapshot.NetworkID != "" {
+		networkID = snapshot.NetworkID
+	}
+	data.NetworkID = stringOrNull(networkID)
+
+	item := newSnapshotItem(snapshot.Snapshot)
+	data.State = item.State
+	data.ProcessingTrigger = item.ProcessingTrigger
+	data.ParentSnapshotID = item.ParentSnapshotID
+	data.Note = item.Note
+	data.IsDraft = item.IsDraft
+	data.CreationMillis = item.CreationMillis
+	data.ProcessedMillis = item.ProcessedMillis
+	data.RestoredMillis = item.RestoredMillis
+	data.FavoritedBy = item.FavoritedBy
+	data.FavoritedByUserID = item.FavoritedByUserID
+	data.FavoritedMillis = item.FavoritedMillis
+
+	tflog.Trace(ctx, "retrieved forward snapshot", map[string]any{"snapshot_id": snapshot.ID, "state": snapshot.State})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
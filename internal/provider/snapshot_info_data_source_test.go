@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAccSnapshotInfoDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	processed := int64(1700000000000)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddSnapshot("net-1", fwdclient.Snapshot{
+		ID:                "snap-2",
+		ParentSnapshotID:  "snap-1",
+		ProcessingTrigger: "COLLECTION",
+		ProcessedAtMillis: &processed,
+	})
+
+	config := func(networkID, snapshotID string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = %q
+  api_key    = "token"
+}
+
+data "forward_snapshot_info" "test" {
+  snapshot_id = %q
+}
+`, server.URL, networkID, snapshotID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("net-1", "snap-2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_snapshot_info.test", "network_id", "net-1"),
+					resource.TestCheckResourceAttr("data.forward_snapshot_info.test", "state", "PROCESSED"),
+					resource.TestCheckResourceAttr("data.forward_snapshot_info.test", "parent_snapshot_id", "snap-1"),
+					resource.TestCheckResourceAttr("data.forward_snapshot_info.test", "processing_trigger", "COLLECTION"),
+					resource.TestCheckResourceAttr("data.forward_snapshot_info.test", "processed_at_millis", "1700000000000"),
+				),
+			},
+			{
+				// Without a network the snapshot is looked up by ID and reports its network.
+				Config: config("", "snap-2"),
+				Check:  resource.TestCheckResourceAttr("data.forward_snapshot_info.test", "network_id", "net-1"),
+			},
+			{
+				Config:      config("net-1", "snap-missing"),
+				ExpectError: regexp.MustCompile(`Unable to Retrieve Snapshot`),
+			},
+		},
+	})
+}
@@ -0,0 +1,213 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// defaultLineageDepth bounds how many ancestors forward_snapshot_lineage fetches when
+// max_depth is not set.
+const defaultLineageDepth = 20
+
+var _ datasource.DataSource = &SnapshotLineageDataSource{}
+
+// NewSnapshotLineageDataSource instantiates the snapshot lineage data source.
+func NewSnapshotLineageDataSource() datasource.DataSource {
+	return &SnapshotLineageDataSource{}
+}
+
+// SnapshotLineageDataSource walks a snapshot's parent links back to its ancestors.
+type SnapshotLineageDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type snapshotLineageDataSourceModel struct {
+	Profile          types.String   `tfsdk:"profile"`
+	NetworkID        types.String   `tfsdk:"network_id"`
+	SnapshotID       types.String   `tfsdk:"snapshot_id"`
+	MaxDepth         types.Int64    `tfsdk:"max_depth"`
+	ParentSnapshotID types.String   `tfsdk:"parent_snapshot_id"`
+	AncestorIDs      []types.String `tfsdk:"ancestor_ids"`
+	Ancestors        []snapshotItem `tfsdk:"ancestors"`
+	Complete         types.Bool     `tfsdk:"complete"`
+}
+
+func (d *SnapshotLineageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_lineage"
+}
+
+func (d *SnapshotLineageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Walk a snapshot's `parentSnapshotId` links and return its ancestry chain, nearest first. Use " +
+			"`parent_snapshot_id` to select the snapshot immediately before this one for before/after comparisons.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "Network the snapshots belong to. Defaults to the provider `network_id`; when neither is " +
+					"set, snapshots are looked up by ID alone and this reports their network.",
+				Optional: true,
+				Computed: true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot whose ancestors are returned.",
+				Required:            true,
+			},
+			"max_depth": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of ancestors to return. Each ancestor costs one API request. "+
+					"Defaults to `%d`.", defaultLineageDepth),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"parent_snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot immediately before `snapshot_id`. Null when the snapshot has no parent.",
+				Computed:            true,
+			},
+			"ancestor_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the ancestors, starting with the parent.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"ancestors": schema.ListAttribute{
+				MarkdownDescription: "Ancestors with the same fields as `forward_snapshots`, starting with the parent.",
+				Computed:            true,
+				ElementType:         snapshotItemType,
+			},
+			"complete": schema.BoolAttribute{
+				MarkdownDescription: "Whether the chain reaches a snapshot without a parent. False when it was cut short by " +
+					"`max_depth` or by an ancestor that no longer exists.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *SnapshotLineageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *SnapshotLineageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data snapshotLineageDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networkID := providerData.NetworkID
+	if !data.NetworkID.IsNull() && !data.NetworkID.IsUnknown() {
+		networkID = data.NetworkID.ValueString()
+	}
+	getSnapshot := func(ctx context.Context, snapshotID string) (*fwdclient.SnapshotDetails, error) {
+		if networkID != "" {
+			return providerData.Client.GetSnapshot(ctx, networkID, snapshotID)
+		}
+		return providerData.Client.GetSnapshotByID(ctx, snapshotID)
+	}
+
+	data.AncestorIDs = []types.String{}
+	data.Ancestors = []snapshotItem{}
+	data.ParentSnapshotID = types.StringNull()
+	data.Complete = types.BoolValue(false)
+
+	snapshot, err := getSnapshot(ctx, data.SnapshotID.ValueString())
+	if err != nil {
+		if !providerData.tolerateMissing(err, "Snapshot Not Found", &resp.Diagnostics) {
+			addAPIErrorWithPaths(&resp.Diagnostics, "Unable to Retrieve Snapshot", err, map[string]path.Path{"snapshotId": path.Root("snapshot_id")})
+			return
+		}
+		data.NetworkID = stringOrNull(networkID)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	if snapshot.NetworkID != "" {
+		networkID = snapshot.NetworkID
+	}
+	data.NetworkID = stringOrNull(networkID)
+	data.ParentSnapshotID = stringOrNull(snapshot.ParentSnapshotID)
+
+	maxDepth := defaultLineageDepth
+	if !data.MaxDepth.IsNull() {
+		maxDepth = int(data.MaxDepth.ValueInt64())
+	}
+	ancestors, complete, err := snapshotLineage(ctx, snapshot.Snapshot, maxDepth, getSnapshot)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Snapshot Ancestor", err)
+		return
+	}
+	for _, ancestor := range ancestors {
+		data.AncestorIDs = append(data.AncestorIDs, types.StringValue(ancestor.ID))
+		data.Ancestors = append(data.Ancestors, newSnapshotItem(ancestor))
+	}
+	data.Complete = types.BoolValue(complete)
+
+	tflog.Trace(ctx, "retrieved forward snapshot lineage", map[string]any{"snapshot_id": snapshot.ID, "ancestors": len(ancestors), "complete": complete})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// snapshotLineage follows parent links from start and returns up to maxDepth ancestors,
+// nearest first. It reports whether the walk ended at a snapshot without a parent; a
+// deleted ancestor, a parent cycle, or reaching maxDepth ends it early instead.
+func snapshotLineage(ctx context.Context, start fwdclient.Snapshot, maxDepth int, get func(context.Context, string) (*fwdclient.SnapshotDetails, error)) ([]fwdclient.Snapshot, bool, error) {
+	var ancestors []fwdclient.Snapshot
+	seen := map[string]bool{start.ID: true}
+	parentID := start.ParentSnapshotID
+	for parentID != "" {
+		if len(ancestors) >= maxDepth || seen[parentID] {
+			return ancestors, false, nil
+		}
+		seen[parentID] = true
+
+		parent, err := get(ctx, parentID)
+		if isNotFoundError(err) {
+			return ancestors, false, nil
+		}
+		if err != nil {
+			return ancestors, false, err
+		}
+		ancestors = append(ancestors, parent.Snapshot)
+		parentID = parent.ParentSnapshotID
+	}
+	return ancestors, true, nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestSnapshotLineage(t *testing.T) {
+	t.Parallel()
+
+	parents := map[string]string{"snap-4": "snap-3", "snap-3": "snap-2", "snap-2": "snap-1", "loop-a": "loop-b", "loop-b": "loop-a", "orphan": "deleted"}
+	get := func(ctx context.Context, id string) (*fwdclient.SnapshotDetails, error) {
+		if id == "deleted" {
+			return nil, &fwdclient.APIError{StatusCode: 404}
+		}
+		return &fwdclient.SnapshotDetails{Snapshot: fwdclient.Snapshot{ID: id, ParentSnapshotID: parents[id]}}, nil
+	}
+
+	cases := []struct {
+		start        string
+		maxDepth     int
+		wantIDs      string
+		wantComplete bool
+	}{
+		{"snap-4", 10, "[snap-3 snap-2 snap-1]", true},
+		{"snap-4", 1, "[snap-3]", false},
+		{"snap-1", 10, "[]", true},
+		{"loop-a", 10, "[loop-b]", false},
+		{"orphan", 10, "[]", false},
+	}
+	for _, tc := range cases {
+		ancestors, complete, err := snapshotLineage(context.Background(), fwdclient.Snapshot{ID: tc.start, ParentSnapshotID: parents[tc.start]}, tc.maxDepth, get)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", tc.start, err)
+		}
+		ids := []string{}
+		for _, ancestor := range ancestors {
+			ids = append(ids, ancestor.ID)
+		}
+		if fmt.Sprint(ids) != tc.wantIDs || complete != tc.wantComplete {
+			t.Errorf("%s depth %d: expected %s complete %t, got %v complete %t", tc.start, tc.maxDepth, tc.wantIDs, tc.wantComplete, ids, complete)
+		}
+	}
+}
+
+func TestAccSnapshotLineageDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-2", ParentSnapshotID: "snap-1"})
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-3", ParentSnapshotID: "snap-2"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+data "forward_snapshot_lineage" "test" {
+  snapshot_id = "snap-3"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_snapshot_lineage.test", "parent_snapshot_id", "snap-2"),
+					resource.TestCheckResourceAttr("data.forward_snapshot_lineage.test", "ancestor_ids.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_snapshot_lineage.test", "ancestor_ids.1", "snap-1"),
+					resource.TestCheckResourceAttr("data.forward_snapshot_lineage.test", "ancestors.0.id", "snap-2"),
+					resource.TestCheckResourceAttr("data.forward_snapshot_lineage.test", "complete", "true"),
+				),
+			},
+		},
+	})
+}
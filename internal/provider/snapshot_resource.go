@@ -9,24 +9,35 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	"github.com/forwardnetworks/terraform-provider-forward/internal/sdk"
+	"github.com/forwardnetworks/terraform-provider-forward/internal/wait"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 var _ resource.Resource = &SnapshotResource{}
 var _ resource.ResourceWithImportState = &SnapshotResource{}
 
+// importedPrivateKey marks resource state created by import until its first read.
+const importedPrivateKey = "imported"
+
 // SnapshotResource manages Forward snapshot lifecycle.
 type SnapshotResource struct {
 	providerData *ForwardProviderData
@@ -34,17 +45,26 @@ type SnapshotResource struct {
 
 // SnapshotResourceModel stores Terraform state.
 type SnapshotResourceModel struct {
-	ID                  types.String `tfsdk:"id"`
-	NetworkID           types.String `tfsdk:"network_id"`
-	Note                types.String `tfsdk:"note"`
-	WaitForProcessed    types.Bool   `tfsdk:"wait_for_processed"`
-	PollIntervalSeconds types.Int64  `tfsdk:"poll_interval_seconds"`
-	TimeoutSeconds      types.Int64  `tfsdk:"timeout_seconds"`
+	ID                   types.String `tfsdk:"id"`
+	Profile              types.String `tfsdk:"profile"`
+	NetworkID            types.String `tfsdk:"network_id"`
+	Note                 types.String `tfsdk:"note"`
+	WaitForProcessed     types.Bool   `tfsdk:"wait_for_processed"`
+	PollIntervalSeconds  types.Int64  `tfsdk:"poll_interval_seconds"`
+	MaxPollInterval      types.Int64  `tfsdk:"max_poll_interval_seconds"`
+	TimeoutSeconds       types.Int64  `tfsdk:"timeout_seconds"`
+	WaitForChecks        types.Bool   `tfsdk:"wait_for_checks"`
+	PreventDestroyStates types.List   `tfsdk:"prevent_destroy_states"`
 
 	State              types.String `tfsdk:"state"`
 	CreationDateMillis types.Int64  `tfsdk:"creation_date_millis"`
 	ProcessedAtMillis  types.Int64  `tfsdk:"processed_at_millis"`
 	RestoredAtMillis   types.Int64  `tfsdk:"restored_at_millis"`
+	CheckPassCount     types.Int64  `tfsdk:"check_pass_count"`
+	CheckFailCount     types.Int64  `tfsdk:"check_fail_count"`
+	CheckErrorCount    types.Int64  `tfsdk:"check_error_count"`
+	CheckTimeoutCount  types.Int64  `tfsdk:"check_timeout_count"`
+	UIURL              types.String `tfsdk:"ui_url"`
 }
 
 func NewSnapshotResource() resource.Resource {
@@ -59,6 +79,7 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manage Forward Enterprise snapshots (capture, poll, and archive).",
 		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Snapshot identifier assigned by Forward Enterprise.",
@@ -86,15 +107,38 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 			"poll_interval_seconds": schema.Int64Attribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Interval in seconds between polling attempts when wait_for_processed is true.",
-				Default:             int64default.StaticInt64(10),
+				MarkdownDescription: "Initial interval in seconds between polling attempts when wait_for_processed is true. The interval doubles after each poll up to max_poll_interval_seconds; an interval suggested by Forward takes precedence.",
+				Default:             int64default.StaticInt64(5),
+			},
+			"max_poll_interval_seconds": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Upper bound in seconds for the polling interval.",
+				Default:             int64default.StaticInt64(60),
 			},
 			"timeout_seconds": schema.Int64Attribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Maximum seconds to wait for the snapshot to reach PROCESSED.",
+				MarkdownDescription: "Maximum seconds to wait for the snapshot to reach PROCESSED, and for its checks when wait_for_checks is true.",
 				Default:             int64default.StaticInt64(600),
 			},
+			"wait_for_checks": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Also wait, after processing, until every persistent check has executed on the new snapshot and record the result counts. Disabled checks and checks reported as `NOT_RUN` are not waited for. Implies wait_for_processed.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"prevent_destroy_states": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Refuse to delete the snapshot while it is in any of these states, such as `PROCESSED`. The " +
+					"entry `FAVORITED` protects the snapshot while it is favorited in Forward Enterprise, so favoriting a " +
+					"compliance baseline in the UI keeps Terraform from destroying it. Checked against the live snapshot on destroy.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
 			"state": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Current snapshot state.",
@@ -111,6 +155,41 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				MarkdownDescription: "Snapshot restored timestamp (milliseconds).",
 			},
+			"check_pass_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of checks that passed on the snapshot when creation completed. Set only when wait_for_checks is true.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"check_fail_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of checks that failed on the snapshot when creation completed. Set only when wait_for_checks is true.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"check_error_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of checks that errored on the snapshot when creation completed. Set only when wait_for_checks is true.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"check_timeout_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of checks that timed out on the snapshot when creation completed. Set only when wait_for_checks is true.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"ui_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Link to the snapshot in the Forward Enterprise UI, built from the provider `base_url` and the network and snapshot identifiers.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -144,28 +223,59 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	request := sdk.SnapshotCreateRequest{}
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := fwdclient.SnapshotCreateRequest{}
 	if !plan.Note.IsNull() && !plan.Note.IsUnknown() {
 		request.Note = plan.Note.ValueString()
 	}
 
-	snapshot, err := r.providerData.Client.CreateSnapshot(ctx, plan.NetworkID.ValueString(), request)
+	snapshot, err := providerData.Client.CreateSnapshot(ctx, plan.NetworkID.ValueString(), request)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating snapshot", err.Error())
+		addAPIErrorWithPaths(&resp.Diagnostics, "Error creating snapshot", err, map[string]path.Path{"note": path.Root("note")})
 		return
 	}
 
 	plan.ID = types.StringValue(snapshot.ID)
+	plan.UIURL = types.StringValue(providerData.Client.SnapshotUIURL(plan.NetworkID.ValueString(), snapshot.ID))
 	updateSnapshotState(&plan, snapshot)
 
-	wait := !plan.WaitForProcessed.IsNull() && plan.WaitForProcessed.ValueBool()
-	if wait {
-		pollInterval := defaultInt(plan.PollIntervalSeconds, 10)
-		timeout := defaultInt(plan.TimeoutSeconds, 600)
-		if pollErr := r.waitForProcessed(ctx, plan.NetworkID.ValueString(), snapshot.ID, time.Duration(pollInterval)*time.Second, time.Duration(timeout)*time.Second, &plan); pollErr != nil {
-			resp.Diagnostics.AddError("Error waiting for snapshot", pollErr.Error())
+	plan.CheckPassCount = types.Int64Null()
+	plan.CheckFailCount = types.Int64Null()
+	plan.CheckErrorCount = types.Int64Null()
+	plan.CheckTimeoutCount = types.Int64Null()
+
+	waitChecks := !plan.WaitForChecks.IsNull() && plan.WaitForChecks.ValueBool()
+	waitProcessed := waitChecks || (!plan.WaitForProcessed.IsNull() && plan.WaitForProcessed.ValueBool())
+	if waitProcessed {
+		backoff := wait.NewBackoff(
+			time.Duration(defaultInt(plan.PollIntervalSeconds, 5))*time.Second,
+			time.Duration(defaultInt(plan.MaxPollInterval, 60))*time.Second,
+		)
+		deadline := time.Now().Add(time.Duration(defaultInt(plan.TimeoutSeconds, 600)) * time.Second)
+		poller := wait.Poller{Backoff: backoff, Timeout: time.Until(deadline)}
+		if pollErr := waitForProcessed(ctx, providerData.Client, plan.NetworkID.ValueString(), snapshot.ID, poller, &plan); pollErr != nil {
+			addAPIError(&resp.Diagnostics, "Error waiting for snapshot", pollErr)
+			if errors.Is(pollErr, errSnapshotWaitTimeout) || ctx.Err() != nil {
+				cancelSnapshotProcessing(ctx, providerData.Client, snapshot.ID, &resp.Diagnostics)
+			}
+			// The snapshot exists, so it is saved and Terraform taints it rather than
+			// losing track of it.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 			return
 		}
+		if waitChecks {
+			poller := wait.Poller{Backoff: backoff.Restart(), Timeout: time.Until(deadline)}
+			if pollErr := waitForChecks(ctx, providerData.Client, snapshot.ID, poller, &plan); pollErr != nil {
+				addAPIError(&resp.Diagnostics, "Error waiting for snapshot checks", pollErr)
+				resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+				return
+			}
+		}
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -183,17 +293,48 @@ func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	snapshot, err := r.providerData.Client.GetSnapshot(ctx, state.NetworkID.ValueString(), state.ID.ValueString())
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imports by bare snapshot ID leave the network to be looked up.
+	if state.NetworkID.IsNull() || state.NetworkID.ValueString() == "" {
+		networkID, err := snapshotNetworkID(ctx, providerData.Client, state.ID.ValueString())
+		if err != nil {
+			if isNotFoundError(err) {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+			addAPIError(&resp.Diagnostics, "Error resolving snapshot network", err)
+			return
+		}
+		state.NetworkID = types.StringValue(networkID)
+	}
+
+	snapshot, err := providerData.Client.GetSnapshot(ctx, state.NetworkID.ValueString(), state.ID.ValueString())
 	if err != nil {
-		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+		if isNotFoundError(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading snapshot", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading snapshot", err)
 		return
 	}
 
 	updateSnapshotState(&state, snapshot)
+	state.UIURL = types.StringValue(providerData.Client.SnapshotUIURL(state.NetworkID.ValueString(), state.ID.ValueString()))
+
+	// The note can only be set at creation, so it is adopted from Forward once, on the
+	// first read after import, to let generated configuration include it.
+	imported, diags := req.Private.GetKey(ctx, importedPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if len(imported) > 0 {
+		state.Note = stringOrNullValue(snapshot.Note)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -219,55 +360,348 @@ func (r *SnapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	if err := r.providerData.Client.DeleteSnapshot(ctx, state.ID.ValueString()); err != nil && !isNotFoundError(err) {
-		resp.Diagnostics.AddError("Error deleting snapshot", err.Error())
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var protectedStates []string
+	if !state.PreventDestroyStates.IsNull() && !state.PreventDestroyStates.IsUnknown() {
+		resp.Diagnostics.Append(state.PreventDestroyStates.ElementsAs(ctx, &protectedStates, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Processing continues on the appliance after a snapshot is deleted, so stop it first.
+	snapshot, err := providerData.Client.GetSnapshot(ctx, state.NetworkID.ValueString(), state.ID.ValueString())
+	if len(protectedStates) > 0 {
+		if err != nil && !isNotFoundError(err) {
+			addAPIError(&resp.Diagnostics, "Error checking snapshot protection", err)
+			return
+		}
+		if err == nil {
+			if matched := snapshotProtection(protectedStates, snapshot); matched != "" {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("prevent_destroy_states"),
+					"Snapshot Protected From Deletion",
+					fmt.Sprintf("Snapshot %s is %s, which prevent_destroy_states protects. Remove %q from "+
+						"prevent_destroy_states, or stop managing the snapshot with terraform state rm, to proceed.",
+						snapshot.ID, matched, matched),
+				)
+				return
+			}
+		}
+	}
+	if err == nil && snapshotInProgress(snapshot.State) {
+		cancelSnapshotProcessing(ctx, providerData.Client, snapshot.ID, &resp.Diagnostics)
+	}
+
+	if err := providerData.Client.DeleteSnapshot(ctx, state.ID.ValueString()); err != nil && !isNotFoundError(err) {
+		addAPIError(&resp.Diagnostics, "Error deleting snapshot", err)
 	}
 }
 
 func (r *SnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	parts := strings.Split(req.ID, "/")
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("Invalid import format", "Use: network_id/snapshot_id")
+	if len(parts) == 3 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile"), parts[0])...)
+		parts = parts[1:]
+	}
+	switch len(parts) {
+	case 1:
+		// The network is looked up from the snapshot on the first read.
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[0])...)
+	case 2:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	default:
+		resp.Diagnostics.AddError("Invalid import format", "Use: snapshot_id, network_id/snapshot_id, or profile/network_id/snapshot_id")
 		return
 	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_processed"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("poll_interval_seconds"), int64(5))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("max_poll_interval_seconds"), int64(60))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("timeout_seconds"), int64(600))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_checks"), false)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, []byte("true"))...)
+}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+// snapshotNetworkID returns the network a snapshot belongs to, for callers that only
+// know the snapshot ID.
+func snapshotNetworkID(ctx context.Context, client *fwdclient.Client, snapshotID string) (string, error) {
+	snapshot, err := client.GetSnapshotByID(ctx, snapshotID)
+	if err != nil {
+		return "", err
+	}
+	if snapshot.NetworkID == "" {
+		return "", fmt.Errorf("snapshot %s does not report its network; specify network_id", snapshotID)
+	}
+	return snapshot.NetworkID, nil
 }
 
-func (r *SnapshotResource) waitForProcessed(ctx context.Context, networkID, snapshotID string, interval, timeout time.Duration, state *SnapshotResourceModel) error {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
-	timeoutChan := time.After(timeout)
-
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-timeoutChan:
-			return errors.New("snapshot processing timed out")
-		case <-ticker.C:
-			snapshot, err := r.providerData.Client.GetSnapshot(ctx, networkID, snapshotID)
-			if err != nil {
-				if strings.Contains(strings.ToLower(err.Error()), "not found") {
-					return err
+// errSnapshotWaitTimeout is returned when a snapshot does not finish processing in time.
+var errSnapshotWaitTimeout = errors.New("snapshot processing timed out")
+
+// processingStallWarning is how long processing progress may stay unchanged before
+// waits log it as stalled rather than slow.
+const processingStallWarning = 5 * time.Minute
+
+// waitForProcessed polls the snapshot until it is processed, logging processing progress
+// between polls. Listing failures other than a missing snapshot are retried.
+func waitForProcessed(ctx context.Context, client *fwdclient.Client, networkID, snapshotID string, poller wait.Poller, state *SnapshotResourceModel) error {
+	started := poller.Now()
+	progress := processingProgress{changed: started}
+	progressSupported := true
+
+	if poller.Backoff == nil {
+		poller.Backoff = wait.NewBackoff(0, 0)
+	}
+	poller.DelayFirst = true
+	err := poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		snapshot, err := client.GetSnapshot(ctx, networkID, snapshotID)
+		if err != nil {
+			if isNotFoundError(err) {
+				return false, err
+			}
+			return false, nil
+		}
+
+		updateSnapshotState(state, snapshot)
+		if strings.EqualFold(snapshot.State, "PROCESSED") {
+			return true, nil
+		}
+		if strings.EqualFold(snapshot.State, "FAILED") {
+			return false, fmt.Errorf("snapshot %s failed", snapshotID)
+		}
+
+		now := poller.Now()
+		fields := map[string]any{
+			"snapshot_id":     snapshotID,
+			"state":           snapshot.State,
+			"elapsed_seconds": int64(now.Sub(started).Seconds()),
+		}
+		var stalled time.Duration
+		if progressSupported {
+			status, err := client.GetSnapshotProcessingStatus(ctx, snapshotID)
+			switch {
+			case isNotFoundError(err):
+				// Older appliances only report the snapshot state.
+				progressSupported = false
+			case err == nil:
+				if status.PollIntervalSeconds != nil {
+					poller.Backoff.Suggest(time.Duration(*status.PollIntervalSeconds) * time.Second)
 				}
+				stalled = progress.observe(status, now)
+				fields["progress"] = describeProcessingStatus(status)
+				fields["stalled_seconds"] = int64(stalled.Seconds())
+			}
+		}
+
+		fields["next_poll_seconds"] = int64(poller.Backoff.Peek().Seconds())
+		if stalled >= processingStallWarning {
+			tflog.Warn(ctx, "forward snapshot processing has not progressed", fields)
+		} else {
+			tflog.Info(ctx, "waiting for forward snapshot processing", fields)
+		}
+		return false, nil
+	})
+	if errors.Is(err, wait.ErrTimeout) {
+		if progress.status != nil {
+			return fmt.Errorf("%w (last progress: %s)", errSnapshotWaitTimeout, describeProcessingStatus(progress.status))
+		}
+		return errSnapshotWaitTimeout
+	}
+	return err
+}
+
+// cancelSnapshotProcessing stops processing of an abandoned snapshot. It runs even when
+// ctx has been cancelled, and failures are reported as warnings.
+func cancelSnapshotProcessing(ctx context.Context, client *fwdclient.Client, snapshotID string, diags *diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+
+	if err := client.CancelSnapshotProcessing(ctx, snapshotID); err != nil {
+		diags.AddWarning("Unable to Cancel Snapshot Processing",
+			fmt.Sprintf("Snapshot %s may still be processing on the appliance: %s", snapshotID, err))
+		return
+	}
+	tflog.Info(ctx, "cancelled forward snapshot processing", map[string]any{"snapshot_id": snapshotID})
+}
+
+// snapshotInProgress reports whether a snapshot in state is still being processed.
+func snapshotInProgress(state string) bool {
+	switch strings.ToUpper(state) {
+	case "", "PROCESSED", "FAILED", "CANCELED", "ARCHIVED":
+		return false
+	default:
+		return true
+	}
+}
+
+// processingProgress remembers the last processing status and when it last changed.
+type processingProgress struct {
+	status  *fwdclient.SnapshotProcessingStatus
+	changed time.Time
+}
+
+// observe records status and returns how long progress has been unchanged.
+func (p *processingProgress) observe(status *fwdclient.SnapshotProcessingStatus, now time.Time) time.Duration {
+	if p.status == nil || describeProcessingStatus(p.status) != describeProcessingStatus(status) {
+		p.changed = now
+	}
+	p.status = status
+	return now.Sub(p.changed)
+}
+
+// describeProcessingStatus renders status for logs and errors, for example
+// "PARSING, 12/40 devices, 30% complete".
+func describeProcessingStatus(status *fwdclient.SnapshotProcessingStatus) string {
+	var parts []string
+	if status.Phase != "" {
+		parts = append(parts, status.Phase)
+	} else if status.State != "" {
+		parts = append(parts, status.State)
+	}
+	if status.DevicesParsed != nil {
+		devices := fmt.Sprintf("%d", *status.DevicesParsed)
+		if status.DevicesTotal != nil {
+			devices += fmt.Sprintf("/%d", *status.DevicesTotal)
+		}
+		parts = append(parts, devices+" devices")
+	}
+	if status.PercentComplete != nil {
+		parts = append(parts, fmt.Sprintf("%.0f%% complete", *status.PercentComplete))
+	}
+	if len(parts) == 0 {
+		return "unknown"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// terminalCheckStatuses are the statuses of checks that have finished executing.
+var terminalCheckStatuses = []string{"PASS", "FAIL", "ERROR", "TIMEOUT"}
+
+// settledCheckStatuses are the statuses of checks that are not going to execute: the
+// terminal statuses, disabled checks, and checks Forward did not run.
+var settledCheckStatuses = append([]string{"DISABLED", "NOT_RUN"}, terminalCheckStatuses...)
+
+// waitForChecks polls the snapshot's checks until each has a terminal status, then
+// records the result counts on state.
+func waitForChecks(ctx context.Context, client *fwdclient.Client, snapshotID string, poller wait.Poller, state *SnapshotResourceModel) error {
+	statuses, err := waitForCheckStatuses(ctx, client, snapshotID, nil, nil, poller)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int64, len(terminalCheckStatuses))
+	for _, status := range statuses {
+		counts[status]++
+	}
+	state.CheckPassCount = types.Int64Value(counts["PASS"])
+	state.CheckFailCount = types.Int64Value(counts["FAIL"])
+	state.CheckErrorCount = types.Int64Value(counts["ERROR"])
+	state.CheckTimeoutCount = types.Int64Value(counts["TIMEOUT"])
+	return nil
+}
+
+// waitForCheckStatuses polls the snapshot's checks until every check in ids, or every
+// check when ids is empty, has a settled status, and returns the upper-cased statuses
+// keyed by check ID. Disabled checks are reported as DISABLED whatever their last
+// status. When previous is non-nil, as recorded by checkExecutionDates before checks
+// are executed, an enabled check is only settled once its execution date is later than
+// its previous one, so the result of an earlier execution is not mistaken for the new
+// one. Listing failures other than a missing snapshot are retried.
+func waitForCheckStatuses(ctx context.Context, client *fwdclient.Client, snapshotID string, ids []string, previous map[string]int64, poller wait.Poller) (map[string]string, error) {
+	var statuses map[string]string
+	err := poller.Poll(ctx, func(ctx context.Context) (bool, error) {
+		checks, err := client.ListSnapshotChecks(ctx, snapshotID, fwdclient.CheckListOptions{})
+		if err != nil {
+			if isNotFoundError(err) {
+				return false, err
+			}
+			return false, nil
+		}
+
+		all := make(map[string]string, len(checks))
+		executed := true
+		for _, check := range checks {
+			all[check.ID] = strings.ToUpper(check.Status)
+			if check.Enabled != nil && !*check.Enabled {
+				all[check.ID] = "DISABLED"
 				continue
 			}
+			if previous != nil && (len(ids) == 0 || slices.Contains(ids, check.ID)) &&
+				(check.ExecutionDateMillis == nil || *check.ExecutionDateMillis <= previous[check.ID]) {
+				executed = false
+			}
+		}
 
-			updateSnapshotState(state, snapshot)
-			if strings.EqualFold(snapshot.State, "PROCESSED") {
-				return nil
+		statuses = all
+		if len(ids) > 0 {
+			statuses = make(map[string]string, len(ids))
+			for _, id := range ids {
+				status, ok := all[id]
+				if !ok {
+					return false, fmt.Errorf("check %s is not on snapshot %s", id, snapshotID)
+				}
+				statuses[id] = status
 			}
-			if strings.EqualFold(snapshot.State, "FAILED") {
-				return fmt.Errorf("snapshot %s failed", snapshotID)
+		}
+
+		for _, status := range statuses {
+			if !slices.Contains(settledCheckStatuses, status) {
+				return false, nil
 			}
 		}
+		return executed, nil
+	})
+	if errors.Is(err, wait.ErrTimeout) {
+		return nil, errors.New("timed out waiting for snapshot checks to execute")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// checkExecutionDates returns the last execution date of each check on the snapshot,
+// or zero for checks that have not executed, for waitForCheckStatuses to compare with.
+func checkExecutionDates(ctx context.Context, client *fwdclient.Client, snapshotID string) (map[string]int64, error) {
+	checks, err := client.ListSnapshotChecks(ctx, snapshotID, fwdclient.CheckListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	dates := make(map[string]int64, len(checks))
+	for _, check := range checks {
+		dates[check.ID] = 0
+		if check.ExecutionDateMillis != nil {
+			dates[check.ID] = *check.ExecutionDateMillis
+		}
+	}
+	return dates, nil
+}
+
+// snapshotFavorited is the prevent_destroy_states entry matching favorited snapshots.
+const snapshotFavorited = "FAVORITED"
+
+// snapshotProtection returns the entry of protectedStates that the snapshot matches,
+// or "" when it may be deleted. Entries match the snapshot state case-insensitively.
+func snapshotProtection(protectedStates []string, snapshot *fwdclient.SnapshotDetails) string {
+	favorited := snapshot.FavoritedBy != "" || snapshot.FavoritedByUserID != "" || snapshot.FavoritedAtMillis != nil
+	for _, protected := range protectedStates {
+		if strings.EqualFold(protected, snapshotFavorited) && favorited {
+			return snapshotFavorited
+		}
+		if snapshot.State != "" && strings.EqualFold(protected, snapshot.State) {
+			return snapshot.State
+		}
 	}
+	return ""
 }
 
-func updateSnapshotState(model *SnapshotResourceModel, snapshot *sdk.SnapshotDetails) {
+func updateSnapshotState(model *SnapshotResourceModel, snapshot *fwdclient.SnapshotDetails) {
 	model.State = stringOrNullValue(snapshot.State)
 	if snapshot.CreationDateMillis != nil {
 		model.CreationDateMillis = types.Int64Value(*snapshot.CreationDateMillis)
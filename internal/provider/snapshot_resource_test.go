@@ -6,32 +6,32 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/internal/wait"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 func TestSnapshotResourceCreate(t *testing.T) {
 	t.Parallel()
 
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodPost:
-			_, _ = w.Write([]byte(`{"id":"snap-1","state":"PROCESSING"}`))
-		case http.MethodGet:
-			_, _ = w.Write([]byte(`{"id":"snap-1","state":"PROCESSED"}`))
-		case http.MethodDelete:
-			w.WriteHeader(http.StatusNoContent)
-		default:
-			t.Fatalf("unexpected method: %s", r.Method)
-		}
-	}))
-	defer server.Close()
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{})
 
 	providerFactory := providerserver.NewProtocol6WithError(New("test")())
 
@@ -42,11 +42,254 @@ func TestSnapshotResourceCreate(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: snapshotTestConfig(server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_snapshot.test", "state", "PROCESSED"),
+					resource.TestCheckResourceAttr("forward_snapshot.test", "note", "test"),
+					resource.TestCheckResourceAttrWith("forward_snapshot.test", "ui_url", func(value string) error {
+						if !strings.HasPrefix(value, server.URL+"/?/search?networkId=net-1&snapshotId=") {
+							return fmt.Errorf("unexpected ui_url %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// Import adopts the note and polling defaults so generated configuration is complete.
+				ResourceName:            "forward_snapshot.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_processed"},
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					return "net-1/" + state.RootModule().Resources["forward_snapshot.test"].Primary.ID, nil
+				},
+			},
+			{
+				// A bare snapshot ID resolves the network from the snapshot.
+				ResourceName:            "forward_snapshot.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_processed"},
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					return state.RootModule().Resources["forward_snapshot.test"].Primary.ID, nil
+				},
+			},
+		},
+	})
+}
+
+func TestSnapshotProtection(t *testing.T) {
+	t.Parallel()
+
+	favorited := int64(1700000000000)
+	processed := &fwdclient.SnapshotDetails{Snapshot: fwdclient.Snapshot{ID: "snap-1", State: "PROCESSED"}}
+	golden := &fwdclient.SnapshotDetails{Snapshot: fwdclient.Snapshot{ID: "snap-2", State: "PROCESSED", FavoritedAtMillis: &favorited}}
+
+	cases := []struct {
+		name      string
+		protected []string
+		snapshot  *fwdclient.SnapshotDetails
+		want      string
+	}{
+		{name: "favorited", protected: []string{"favorited"}, snapshot: golden, want: "FAVORITED"},
+		{name: "not favorited", protected: []string{"FAVORITED"}, snapshot: processed, want: ""},
+		{name: "state", protected: []string{"FAVORITED", "processed"}, snapshot: processed, want: "PROCESSED"},
+		{name: "other state", protected: []string{"ARCHIVED"}, snapshot: processed, want: ""},
+	}
+	for _, tc := range cases {
+		if got := snapshotProtection(tc.protected, tc.snapshot); got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestSnapshotNetworkID(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	snapshotID := server.AddSnapshot("net-7", fwdclient.Snapshot{})
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	networkID, err := snapshotNetworkID(context.Background(), client, snapshotID)
+	if err != nil || networkID != "net-7" {
+		t.Fatalf("expected net-7, got %q (%v)", networkID, err)
+	}
+	if _, err := snapshotNetworkID(context.Background(), client, "snap-missing"); !isNotFoundError(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestSnapshotResourceWaitForChecks(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{})
+	server.AddPersistentCheck("net-1", fwdclient.CheckResult{Name: "MTU"})
+	server.AddPersistentCheck("net-1", fwdclient.CheckResult{Name: "VLAN", Status: "FAIL"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_snapshot" "test" {
+  network_id            = "net-1"
+  wait_for_checks       = true
+  poll_interval_seconds = 1
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_snapshot.test", "state", "PROCESSED"),
+					resource.TestCheckResourceAttr("forward_snapshot.test", "check_pass_count", "1"),
+					resource.TestCheckResourceAttr("forward_snapshot.test", "check_fail_count", "1"),
+					resource.TestCheckResourceAttr("forward_snapshot.test", "check_error_count", "0"),
+				),
 			},
 		},
 	})
 }
 
+func TestSnapshotResourceWaitForChecksErrorSavesState(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{})
+	server.AddPersistentCheck("net-1", fwdclient.CheckResult{Name: "MTU"})
+	// The created snapshot is processed on the first poll, so the fault hits the check poll.
+	server.InjectFault(fakeforward.Fault{Method: http.MethodGet, PathPrefix: "/api/snapshots/", Status: http.StatusNotFound, Times: 1})
+
+	config := fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_snapshot" "test" {
+  network_id            = "net-1"
+  wait_for_checks       = true
+  poll_interval_seconds = 1
+}
+`, server.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("Error waiting for snapshot checks"),
+			},
+			{
+				// The failed snapshot was kept in state and tainted, so it is replaced.
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("forward_snapshot.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("forward_snapshot.test", "check_pass_count", "1"),
+			},
+		},
+	})
+}
+
+func TestProcessingProgress(t *testing.T) {
+	t.Parallel()
+
+	parsed, total, percent := int64(12), int64(40), float64(30)
+	status := &fwdclient.SnapshotProcessingStatus{Phase: "PARSING", DevicesParsed: &parsed, DevicesTotal: &total, PercentComplete: &percent}
+	if got := describeProcessingStatus(status); got != "PARSING, 12/40 devices, 30% complete" {
+		t.Fatalf("unexpected description: %q", got)
+	}
+
+	start := time.Unix(0, 0)
+	progress := processingProgress{changed: start}
+	if stalled := progress.observe(status, start.Add(time.Minute)); stalled != 0 {
+		t.Fatalf("expected first observation to reset the stall clock, got %s", stalled)
+	}
+	if stalled := progress.observe(status, start.Add(3*time.Minute)); stalled != 2*time.Minute {
+		t.Fatalf("expected unchanged progress to report a stall, got %s", stalled)
+	}
+
+	more := int64(13)
+	if stalled := progress.observe(&fwdclient.SnapshotProcessingStatus{Phase: "PARSING", DevicesParsed: &more, DevicesTotal: &total, PercentComplete: &percent}, start.Add(4*time.Minute)); stalled != 0 {
+		t.Fatalf("expected new progress to reset the stall clock, got %s", stalled)
+	}
+}
+
+func TestWaitForProcessedTimeoutAndCancel(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1", State: "PROCESSING"})
+
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	var state SnapshotResourceModel
+	poller := wait.Poller{
+		Backoff: wait.NewBackoff(10*time.Second, 20*time.Second),
+		Timeout: 100 * time.Second,
+		Clock:   wait.NewFakeClock(time.Unix(1700000000, 0)),
+	}
+	err = waitForProcessed(context.Background(), client, "net-1", "snap-1", poller, &state)
+	if !errors.Is(err, errSnapshotWaitTimeout) || !strings.Contains(err.Error(), "last progress: PROCESSING") {
+		t.Fatalf("expected timeout with progress, got %v", err)
+	}
+
+	// Abandoned processing is cancelled even after the caller's context ends.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var diags diag.Diagnostics
+	cancelSnapshotProcessing(ctx, client, "snap-1", &diags)
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if snapshot, _ := server.Snapshot("snap-1"); snapshot.State != "CANCELED" {
+		t.Fatalf("expected cancelled snapshot, got %q", snapshot.State)
+	}
+}
+
+func TestWaitForChecksSkipsDisabledChecks(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	disabled := false
+	server.AddCheck("snap-1", fwdclient.CheckResult{Name: "MTU"})
+	server.AddCheck("snap-1", fwdclient.CheckResult{Name: "VLAN", Status: "PROCESSING", Enabled: &disabled})
+	server.AddCheck("snap-1", fwdclient.CheckResult{Name: "BGP", Status: "DISABLED", Enabled: &disabled})
+	server.AddCheck("snap-1", fwdclient.CheckResult{Name: "OSPF", Status: "NOT_RUN"})
+
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	var state SnapshotResourceModel
+	poller := wait.Poller{
+		Backoff: wait.NewBackoff(10*time.Second, 20*time.Second),
+		Timeout: 100 * time.Second,
+		Clock:   wait.NewFakeClock(time.Unix(1700000000, 0)),
+	}
+	if err := waitForChecks(context.Background(), client, "snap-1", poller, &state); err != nil {
+		t.Fatalf("expected disabled and unrun checks not to block the wait, got %v", err)
+	}
+	if state.CheckPassCount.ValueInt64() != 1 || state.CheckFailCount.ValueInt64() != 0 {
+		t.Fatalf("unexpected counts: pass %v, fail %v", state.CheckPassCount, state.CheckFailCount)
+	}
+}
+
 func snapshotTestConfig(host string) string {
 	return fmt.Sprintf(`
 provider "forward" {
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+var _ resource.Resource = &SnapshotRetentionResource{}
+var _ resource.ResourceWithImportState = &SnapshotRetentionResource{}
+
+// snapshotRetentionAPIFields maps retention policy fields to the attributes that set them.
+var snapshotRetentionAPIFields = map[string]path.Path{
+	"maxAgeDays":    path.Root("max_age_days"),
+	"maxCount":      path.Root("max_count"),
+	"action":        path.Root("action"),
+	"keepFavorites": path.Root("keep_favorites"),
+}
+
+// NewSnapshotRetentionResource instantiates the snapshot retention resource.
+func NewSnapshotRetentionResource() resource.Resource {
+	return &SnapshotRetentionResource{}
+}
+
+// SnapshotRetentionResource manages the automatic purging of old snapshots of a network.
+type SnapshotRetentionResource struct {
+	providerData *ForwardProviderData
+}
+
+// SnapshotRetentionResourceModel maps Terraform schema data.
+type SnapshotRetentionResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Profile       types.String `tfsdk:"profile"`
+	NetworkID     types.String `tfsdk:"network_id"`
+	MaxAgeDays    types.Int64  `tfsdk:"max_age_days"`
+	MaxCount      types.Int64  `tfsdk:"max_count"`
+	Action        types.String `tfsdk:"action"`
+	KeepFavorites types.Bool   `tfsdk:"keep_favorites"`
+}
+
+func (r *SnapshotRetentionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_retention"
+}
+
+func (r *SnapshotRetentionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Configure automatic archiving or deletion of old snapshots of a network, keeping appliance " +
+			"storage under control. A snapshot is purged once it is older than `max_age_days` or falls outside the newest " +
+			"`max_count` snapshots. A network has a single policy; destroying this resource disables it.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the policy, equal to `network_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Network whose snapshots the policy purges.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_age_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Purge snapshots older than this many days. At least one of `max_age_days` and `max_count` must be set.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AtLeastOneOf(path.MatchRoot("max_count")),
+				},
+			},
+			"max_count": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Keep at most this many of the newest snapshots and purge the rest.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"action": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(fwdclient.SnapshotRetentionArchive),
+				MarkdownDescription: "What happens to purged snapshots: `ARCHIVE` (default) or `DELETE`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(fwdclient.SnapshotRetentionArchive, fwdclient.SnapshotRetentionDelete),
+				},
+			},
+			"keep_favorites": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Exempt favorited snapshots from the policy. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func (r *SnapshotRetentionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *SnapshotRetentionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError("Unconfigured Provider", "The provider client was not configured. Re-run terraform init or review provider configuration.")
+		return
+	}
+
+	var plan SnapshotRetentionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := providerData.Client.SetSnapshotRetentionPolicy(ctx, plan.NetworkID.ValueString(), expandSnapshotRetentionPolicy(plan))
+	if err != nil {
+		addAPIErrorWithPaths(&resp.Diagnostics, "Error creating snapshot retention policy", err, snapshotRetentionAPIFields)
+		return
+	}
+
+	setSnapshotRetentionState(&plan, policy)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SnapshotRetentionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError("Unconfigured Provider", "The provider client was not configured. Re-run terraform init or review provider configuration.")
+		return
+	}
+
+	var state SnapshotRetentionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := providerData.Client.GetSnapshotRetentionPolicy(ctx, state.NetworkID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error reading snapshot retention policy", err)
+		return
+	}
+	// A policy disabled outside Terraform no longer exists as far as Terraform is concerned.
+	if !policy.Enabled {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setSnapshotRetentionState(&state, policy)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *SnapshotRetentionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError("Unconfigured Provider", "The provider client was not configured. Re-run terraform init or review provider configuration.")
+		return
+	}
+
+	var plan SnapshotRetentionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(plan.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := providerData.Client.SetSnapshotRetentionPolicy(ctx, plan.NetworkID.ValueString(), expandSnapshotRetentionPolicy(plan))
+	if err != nil {
+		addAPIErrorWithPaths(&resp.Diagnostics, "Error updating snapshot retention policy", err, snapshotRetentionAPIFields)
+		return
+	}
+
+	setSnapshotRetentionState(&plan, policy)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SnapshotRetentionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError("Unconfigured Provider", "The provider client was not configured. Re-run terraform init or review provider configuration.")
+		return
+	}
+
+	var state SnapshotRetentionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := providerData.Client.SetSnapshotRetentionPolicy(ctx, state.NetworkID.ValueString(), fwdclient.SnapshotRetentionPolicy{Enabled: false})
+	if err != nil && !isNotFoundError(err) {
+		addAPIError(&resp.Diagnostics, "Error disabling snapshot retention policy", err)
+	}
+}
+
+func (r *SnapshotRetentionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile"), parts[0])...)
+		parts = parts[1:]
+	}
+	if len(parts) != 1 || parts[0] == "" {
+		resp.Diagnostics.AddError("Invalid import format", "Use: network_id or profile/network_id")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[0])...)
+}
+
+func expandSnapshotRetentionPolicy(model SnapshotRetentionResourceModel) fwdclient.SnapshotRetentionPolicy {
+	return fwdclient.SnapshotRetentionPolicy{
+		Enabled:       true,
+		MaxAgeDays:    model.MaxAgeDays.ValueInt64Pointer(),
+		MaxCount:      model.MaxCount.ValueInt64Pointer(),
+		Action:        model.Action.ValueString(),
+		KeepFavorites: model.KeepFavorites.ValueBool(),
+	}
+}
+
+func setSnapshotRetentionState(model *SnapshotRetentionResourceModel, policy *fwdclient.SnapshotRetentionPolicy) {
+	model.ID = model.NetworkID
+	model.MaxAgeDays = int64PointerOrNull(policy.MaxAgeDays)
+	model.MaxCount = int64PointerOrNull(policy.MaxCount)
+	model.Action = types.StringValue(policy.Action)
+	model.KeepFavorites = types.BoolValue(policy.KeepFavorites)
+}
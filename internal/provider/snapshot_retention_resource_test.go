@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAccSnapshotRetentionResource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{})
+
+	config := func(limits string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  network_id = "net-1"
+  api_key    = "token"
+}
+
+resource "forward_snapshot_retention" "test" {
+  network_id = "net-1"
+  %s
+}
+`, server.URL, limits)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`max_age_days = 30`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_snapshot_retention.test", "id", "net-1"),
+					resource.TestCheckResourceAttr("forward_snapshot_retention.test", "action", "ARCHIVE"),
+					resource.TestCheckResourceAttr("forward_snapshot_retention.test", "keep_favorites", "true"),
+				),
+			},
+			{
+				Config: config("max_count = 50\n  action    = \"DELETE\""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("forward_snapshot_retention.test", "max_age_days"),
+					resource.TestCheckResourceAttr("forward_snapshot_retention.test", "max_count", "50"),
+					resource.TestCheckResourceAttr("forward_snapshot_retention.test", "action", "DELETE"),
+				),
+			},
+			{
+				ResourceName:      "forward_snapshot_retention.test",
+				ImportState:       true,
+				ImportStateId:     "net-1",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
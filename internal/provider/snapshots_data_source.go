@@ -7,13 +7,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	"github.com/forwardnetworks/terraform-provider-forward/internal/sdk"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
 )
 
 var _ datasource.DataSource = &SnapshotsDataSource{}
@@ -29,12 +30,31 @@ type SnapshotsDataSource struct {
 }
 
 type snapshotsDataSourceModel struct {
+	Profile         types.String   `tfsdk:"profile"`
 	NetworkID       types.String   `tfsdk:"network_id"`
 	Limit           types.Int64    `tfsdk:"limit"`
 	IncludeArchived types.Bool     `tfsdk:"include_archived"`
 	Snapshots       []snapshotItem `tfsdk:"snapshots"`
 }
 
+// snapshotItemType is the object type of snapshotItem.
+var snapshotItemType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":                   types.StringType,
+		"state":                types.StringType,
+		"processing_trigger":   types.StringType,
+		"parent_snapshot_id":   types.StringType,
+		"note":                 types.StringType,
+		"is_draft":             types.BoolType,
+		"creation_date_millis": types.Int64Type,
+		"processed_at_millis":  types.Int64Type,
+		"restored_at_millis":   types.Int64Type,
+		"favorited_by":         types.StringType,
+		"favorited_by_user_id": types.StringType,
+		"favorited_at_millis":  types.Int64Type,
+	},
+}
+
 type snapshotItem struct {
 	ID                types.String `tfsdk:"id"`
 	State             types.String `tfsdk:"state"`
@@ -58,6 +78,7 @@ func (d *SnapshotsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Retrieve Forward Enterprise snapshots for a network.",
 		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
 			"network_id": schema.StringAttribute{
 				MarkdownDescription: "Network ID to query. Defaults to the provider `network_id` when omitted.",
 				Optional:            true,
@@ -70,25 +91,10 @@ func (d *SnapshotsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				MarkdownDescription: "Include archived snapshots in the result set.",
 				Optional:            true,
 			},
-			"snapshots": schema.ListNestedAttribute{
+			"snapshots": schema.ListAttribute{
 				MarkdownDescription: "Snapshots returned by the Forward Enterprise API.",
 				Computed:            true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"id":                   schema.StringAttribute{Computed: true},
-						"state":                schema.StringAttribute{Computed: true},
-						"processing_trigger":   schema.StringAttribute{Computed: true},
-						"parent_snapshot_id":   schema.StringAttribute{Computed: true},
-						"note":                 schema.StringAttribute{Computed: true},
-						"is_draft":             schema.BoolAttribute{Computed: true},
-						"creation_date_millis": schema.Int64Attribute{Computed: true},
-						"processed_at_millis":  schema.Int64Attribute{Computed: true},
-						"restored_at_millis":   schema.Int64Attribute{Computed: true},
-						"favorited_by":         schema.StringAttribute{Computed: true},
-						"favorited_by_user_id": schema.StringAttribute{Computed: true},
-						"favorited_at_millis":  schema.Int64Attribute{Computed: true},
-					},
-				},
+				ElementType:         snapshotItemType,
 			},
 		},
 	}
@@ -126,7 +132,13 @@ func (d *SnapshotsDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	networkID := d.providerData.NetworkID
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networkID := providerData.NetworkID
 	if !data.NetworkID.IsNull() && !data.NetworkID.IsUnknown() {
 		networkID = data.NetworkID.ValueString()
 	}
@@ -140,7 +152,7 @@ func (d *SnapshotsDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	options := sdk.SnapshotListOptions{}
+	options := fwdclient.SnapshotListOptions{}
 	if !data.Limit.IsNull() && !data.Limit.IsUnknown() {
 		limit := int(data.Limit.ValueInt64())
 		if limit < 0 {
@@ -159,67 +171,15 @@ func (d *SnapshotsDataSource) Read(ctx context.Context, req datasource.ReadReque
 		options.IncludeArchived = &value
 	}
 
-	snapshots, err := d.providerData.Client.ListSnapshots(ctx, networkID, options)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Retrieve Snapshots",
-			err.Error(),
-		)
+	snapshots, err := providerData.Client.ListSnapshots(ctx, networkID, options)
+	if err != nil && !providerData.tolerateMissing(err, "Network Not Found", &resp.Diagnostics) {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Snapshots", err)
 		return
 	}
 
 	items := make([]snapshotItem, 0, len(snapshots))
 	for _, snapshot := range snapshots {
-		item := snapshotItem{
-			ID:                types.StringValue(snapshot.ID),
-			State:             types.StringNull(),
-			ProcessingTrigger: types.StringNull(),
-			ParentSnapshotID:  types.StringNull(),
-			Note:              types.StringNull(),
-			IsDraft:           types.BoolNull(),
-			CreationMillis:    types.Int64Null(),
-			ProcessedMillis:   types.Int64Null(),
-			RestoredMillis:    types.Int64Null(),
-			FavoritedBy:       types.StringNull(),
-			FavoritedByUserID: types.StringNull(),
-			FavoritedMillis:   types.Int64Null(),
-		}
-
-		if snapshot.State != "" {
-			item.State = types.StringValue(snapshot.State)
-		}
-		if snapshot.ProcessingTrigger != "" {
-			item.ProcessingTrigger = types.StringValue(snapshot.ProcessingTrigger)
-		}
-		if snapshot.ParentSnapshotID != "" {
-			item.ParentSnapshotID = types.StringValue(snapshot.ParentSnapshotID)
-		}
-		if snapshot.Note != "" {
-			item.Note = types.StringValue(snapshot.Note)
-		}
-		if snapshot.IsDraft != nil {
-			item.IsDraft = types.BoolValue(*snapshot.IsDraft)
-		}
-		if snapshot.CreationDateMillis != nil {
-			item.CreationMillis = types.Int64Value(*snapshot.CreationDateMillis)
-		}
-		if snapshot.ProcessedAtMillis != nil {
-			item.ProcessedMillis = types.Int64Value(*snapshot.ProcessedAtMillis)
-		}
-		if snapshot.RestoredAtMillis != nil {
-			item.RestoredMillis = types.Int64Value(*snapshot.RestoredAtMillis)
-		}
-		if snapshot.FavoritedBy != "" {
-			item.FavoritedBy = types.StringValue(snapshot.FavoritedBy)
-		}
-		if snapshot.FavoritedByUserID != "" {
-			item.FavoritedByUserID = types.StringValue(snapshot.FavoritedByUserID)
-		}
-		if snapshot.FavoritedAtMillis != nil {
-			item.FavoritedMillis = types.Int64Value(*snapshot.FavoritedAtMillis)
-		}
-
-		items = append(items, item)
+		items = append(items, newSnapshotItem(snapshot))
 	}
 
 	data.Snapshots = items
@@ -228,3 +188,57 @@ func (d *SnapshotsDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// newSnapshotItem converts a snapshot returned by the API, leaving absent fields null.
+func newSnapshotItem(snapshot fwdclient.Snapshot) snapshotItem {
+	item := snapshotItem{
+		ID:                types.StringValue(snapshot.ID),
+		State:             types.StringNull(),
+		ProcessingTrigger: types.StringNull(),
+		ParentSnapshotID:  types.StringNull(),
+		Note:              types.StringNull(),
+		IsDraft:           types.BoolNull(),
+		CreationMillis:    types.Int64Null(),
+		ProcessedMillis:   types.Int64Null(),
+		RestoredMillis:    types.Int64Null(),
+		FavoritedBy:       types.StringNull(),
+		FavoritedByUserID: types.StringNull(),
+		FavoritedMillis:   types.Int64Null(),
+	}
+
+	if snapshot.State != "" {
+		item.State = types.StringValue(snapshot.State)
+	}
+	if snapshot.ProcessingTrigger != "" {
+		item.ProcessingTrigger = types.StringValue(snapshot.ProcessingTrigger)
+	}
+	if snapshot.ParentSnapshotID != "" {
+		item.ParentSnapshotID = types.StringValue(snapshot.ParentSnapshotID)
+	}
+	if snapshot.Note != "" {
+		item.Note = types.StringValue(snapshot.Note)
+	}
+	if snapshot.IsDraft != nil {
+		item.IsDraft = types.BoolValue(*snapshot.IsDraft)
+	}
+	if snapshot.CreationDateMillis != nil {
+		item.CreationMillis = types.Int64Value(*snapshot.CreationDateMillis)
+	}
+	if snapshot.ProcessedAtMillis != nil {
+		item.ProcessedMillis = types.Int64Value(*snapshot.ProcessedAtMillis)
+	}
+	if snapshot.RestoredAtMillis != nil {
+		item.RestoredMillis = types.Int64Value(*snapshot.RestoredAtMillis)
+	}
+	if snapshot.FavoritedBy != "" {
+		item.FavoritedBy = types.StringValue(snapshot.FavoritedBy)
+	}
+	if snapshot.FavoritedByUserID != "" {
+		item.FavoritedByUserID = types.StringValue(snapshot.FavoritedByUserID)
+	}
+	if snapshot.FavoritedAtMillis != nil {
+		item.FavoritedMillis = types.Int64Value(*snapshot.FavoritedAtMillis)
+	}
+
+	return item
+}
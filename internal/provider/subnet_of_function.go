@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &SubnetOfFunction{}
+
+// SubnetOfFunction reports whether an address or prefix lies within a CIDR block.
+type SubnetOfFunction struct{}
+
+func NewSubnetOfFunction() function.Function {
+	return &SubnetOfFunction{}
+}
+
+func (f *SubnetOfFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "subnet_of"
+}
+
+func (f *SubnetOfFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check whether an IP address or prefix lies within a CIDR block",
+		MarkdownDescription: "Returns `true` when `ip`, an address such as `10.1.2.3` or a prefix such as `10.1.2.0/24`, lies " +
+			"entirely within `cidr`, for example to pick the path analysis sources that belong to a site. Addresses of " +
+			"different families never match, and IPv4-mapped IPv6 addresses are compared as IPv4.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ip",
+				MarkdownDescription: "IP address or CIDR prefix to test.",
+			},
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "CIDR block that must contain `ip`, such as `10.0.0.0/8`.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *SubnetOfFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ip, cidr string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ip, &cidr))
+	if resp.Error != nil {
+		return
+	}
+
+	inner, err := parseIPOrPrefix(ip)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	outer, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%q is not a CIDR block such as 10.0.0.0/8", cidr))
+		return
+	}
+	outer = unmapPrefix(outer).Masked()
+
+	contained := inner.Addr().Is4() == outer.Addr().Is4() && inner.Bits() >= outer.Bits() && outer.Contains(inner.Addr())
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, contained))
+}
+
+// parseIPOrPrefix parses an address as a single-address prefix, or a CIDR prefix, with
+// IPv4-mapped IPv6 values converted to IPv4.
+func parseIPOrPrefix(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("%q is not an IP address or CIDR prefix", value)
+		}
+		return unmapPrefix(prefix), nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%q is not an IP address or CIDR prefix", value)
+	}
+	addr = addr.Unmap().WithZone("")
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// unmapPrefix converts an IPv4-mapped IPv6 prefix such as ::ffff:10.0.0.0/104 to IPv4.
+func unmapPrefix(prefix netip.Prefix) netip.Prefix {
+	addr := prefix.Addr()
+	if !addr.Is4In6() {
+		return prefix
+	}
+	bits := prefix.Bits() - 96
+	if bits < 0 {
+		bits = 0
+	}
+	return netip.PrefixFrom(addr.Unmap(), bits)
+}
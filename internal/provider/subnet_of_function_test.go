@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction calls fn with string arguments and returns its result and error.
+func runFunction(fn function.Function, result attr.Value, args ...string) (attr.Value, *function.FuncError) {
+	values := make([]attr.Value, len(args))
+	for i, arg := range args {
+		values[i] = types.StringValue(arg)
+	}
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	fn.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(values)}, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestSubnetOfFunction(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		ip, cidr string
+		want     bool
+	}{
+		{"10.1.2.3", "10.0.0.0/8", true},
+		{" 10.1.2.3 ", "10.1.2.0/24", true},
+		{"10.1.3.1", "10.1.2.0/24", false},
+		{"10.1.2.0/25", "10.1.2.0/24", true},
+		{"10.1.0.0/16", "10.1.2.0/24", false},
+		{"10.1.2.3", "10.1.2.77/24", true},
+		{"::ffff:10.1.2.3", "10.1.2.0/24", true},
+		{"10.1.2.3", "::/0", false},
+		{"2001:db8::1", "2001:DB8::/32", true},
+		{"fe80::1%eth0", "fe80::/10", true},
+	}
+	for _, tc := range cases {
+		got, err := runFunction(NewSubnetOfFunction(), types.BoolUnknown(), tc.ip, tc.cidr)
+		if err != nil {
+			t.Fatalf("subnet_of(%q, %q): %v", tc.ip, tc.cidr, err)
+		}
+		if !got.Equal(types.BoolValue(tc.want)) {
+			t.Errorf("subnet_of(%q, %q) = %s, want %t", tc.ip, tc.cidr, got, tc.want)
+		}
+	}
+
+	if _, err := runFunction(NewSubnetOfFunction(), types.BoolUnknown(), "10.1.2", "10.0.0.0/8"); err == nil || *err.FunctionArgument != 0 {
+		t.Fatalf("expected an error for the ip argument, got %v", err)
+	}
+	if _, err := runFunction(NewSubnetOfFunction(), types.BoolUnknown(), "10.1.2.3", "10.0.0.0"); err == nil || *err.FunctionArgument != 1 {
+		t.Fatalf("expected an error for the cidr argument, got %v", err)
+	}
+}
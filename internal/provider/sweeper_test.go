@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// testAccSweepPrefix marks artifacts created by acceptance tests against live instances.
+// Sweepers only remove snapshots whose note, and checks whose name, start with it.
+// Device sources and API tokens have no sweepers: the provider and its client do not
+// manage them, so acceptance tests never create them.
+const testAccSweepPrefix = "tf-acc-"
+
+// TestMain runs the sweepers instead of the tests when invoked with -sweep, for example
+//
+//	go test ./internal/provider -v -sweep=all
+//
+// The region flag is ignored; sweepers use the FORWARD_BASE_URL, FORWARD_API_KEY, and
+// FORWARD_NETWORK_ID environment variables.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("forward_intent_check", &resource.Sweeper{
+		Name: "forward_intent_check",
+		F: func(region string) error {
+			client, networkID, err := sharedSweepClient()
+			if err != nil {
+				return err
+			}
+			return sweepIntentChecks(context.Background(), client, networkID)
+		},
+	})
+	resource.AddTestSweepers("forward_snapshot", &resource.Sweeper{
+		Name:         "forward_snapshot",
+		Dependencies: []string{"forward_intent_check"},
+		F: func(region string) error {
+			client, networkID, err := sharedSweepClient()
+			if err != nil {
+				return err
+			}
+			return sweepSnapshots(context.Background(), client, networkID)
+		},
+	})
+}
+
+// sharedSweepClient builds a client for the instance acceptance tests ran against.
+func sharedSweepClient() (*fwdclient.Client, string, error) {
+	baseURL := os.Getenv(envBaseURL)
+	apiKey := os.Getenv(envAPIKeyPrimary)
+	if apiKey == "" {
+		apiKey = os.Getenv(envAPIKeyLegacy)
+	}
+	networkID := os.Getenv(envNetworkID)
+	insecure, _ := strconv.ParseBool(os.Getenv(envInsecure))
+	if baseURL == "" || apiKey == "" || networkID == "" {
+		return nil, "", fmt.Errorf("%s, %s, and %s must be set to run sweepers", envBaseURL, envAPIKeyPrimary, envNetworkID)
+	}
+
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{
+		BaseURL:   baseURL,
+		APIKey:    apiKey,
+		Insecure:  insecure,
+		UserAgent: "terraform-provider-forward/sweeper",
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("create sweeper client: %w", err)
+	}
+	return client, networkID, nil
+}
+
+// sweepIntentChecks deactivates the acceptance test checks on every snapshot of the network.
+func sweepIntentChecks(ctx context.Context, client *fwdclient.Client, networkID string) error {
+	snapshots, err := listSweepSnapshots(ctx, client, networkID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, snapshot := range snapshots {
+		checks, err := client.ListSnapshotChecks(ctx, snapshot.ID, fwdclient.CheckListOptions{})
+		if err != nil {
+			if !isNotFoundError(err) {
+				errs = append(errs, fmt.Errorf("list checks of snapshot %s: %w", snapshot.ID, err))
+			}
+			continue
+		}
+		for _, check := range checks {
+			if !strings.HasPrefix(check.Name, testAccSweepPrefix) {
+				continue
+			}
+			if err := client.DeactivateSnapshotCheck(ctx, snapshot.ID, check.ID); err != nil && !isNotFoundError(err) {
+				errs = append(errs, fmt.Errorf("deactivate check %s: %w", check.ID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sweepSnapshots deletes the acceptance test snapshots of the network. Favorited
+// snapshots are kept, since someone chose to preserve them.
+func sweepSnapshots(ctx context.Context, client *fwdclient.Client, networkID string) error {
+	snapshots, err := listSweepSnapshots(ctx, client, networkID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, snapshot := range snapshots {
+		if !strings.HasPrefix(snapshot.Note, testAccSweepPrefix) || snapshot.FavoritedAtMillis != nil {
+			continue
+		}
+		if err := client.DeleteSnapshot(ctx, snapshot.ID); err != nil && !isNotFoundError(err) {
+			errs = append(errs, fmt.Errorf("delete snapshot %s: %w", snapshot.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func listSweepSnapshots(ctx context.Context, client *fwdclient.Client, networkID string) ([]fwdclient.Snapshot, error) {
+	includeArchived := true
+	snapshots, err := client.ListSnapshots(ctx, networkID, fwdclient.SnapshotListOptions{IncludeArchived: &includeArchived})
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots of network %s: %w", networkID, err)
+	}
+	return snapshots, nil
+}
+
+func TestSweepers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1", Note: "baseline"})
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-2", Note: testAccSweepPrefix + "snapshot"})
+	favorited := int64(1700000000000)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-3", Note: testAccSweepPrefix + "kept", FavoritedAtMillis: &favorited})
+	keep := server.AddCheck("snap-1", fwdclient.CheckResult{Name: "production"})
+	sweep := server.AddCheck("snap-1", fwdclient.CheckResult{Name: testAccSweepPrefix + "check"})
+
+	client, err := fwdclient.NewClient(ctx, fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	if err := sweepIntentChecks(ctx, client, "net-1"); err != nil {
+		t.Fatalf("sweep checks: %v", err)
+	}
+	if _, ok := server.Check("snap-1", keep); !ok {
+		t.Fatal("expected unprefixed check to be kept")
+	}
+	if _, ok := server.Check("snap-1", sweep); ok {
+		t.Fatal("expected acceptance test check to be swept")
+	}
+
+	if err := sweepSnapshots(ctx, client, "net-1"); err != nil {
+		t.Fatalf("sweep snapshots: %v", err)
+	}
+	for id, want := range map[string]bool{"snap-1": true, "snap-2": false, "snap-3": true} {
+		if _, ok := server.Snapshot(id); ok != want {
+			t.Fatalf("snapshot %s exists = %t, want %t", id, ok, want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &TunnelsDataSource{}
+
+// NewTunnelsDataSource instantiates the tunnel inventory data source.
+func NewTunnelsDataSource() datasource.DataSource {
+	return &TunnelsDataSource{}
+}
+
+// TunnelsDataSource lists IPsec, GRE, and VXLAN tunnels modeled in a snapshot.
+type TunnelsDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type tunnelsDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Profile    types.String `tfsdk:"profile"`
+	SnapshotID types.String `tfsdk:"snapshot_id"`
+	Type       types.String `tfsdk:"type"`
+	DeviceName types.String `tfsdk:"device_name"`
+	Tunnels    []tunnelItem `tfsdk:"tunnels"`
+	UpCount    types.Int64  `tfsdk:"up_count"`
+	DownCount  types.Int64  `tfsdk:"down_count"`
+}
+
+type tunnelItem struct {
+	DeviceName    types.String `tfsdk:"device_name"`
+	Name          types.String `tfsdk:"name"`
+	Type          types.String `tfsdk:"type"`
+	LocalAddress  types.String `tfsdk:"local_address"`
+	RemoteAddress types.String `tfsdk:"remote_address"`
+	RemoteDevice  types.String `tfsdk:"remote_device"`
+	VRF           types.String `tfsdk:"vrf"`
+	Status        types.String `tfsdk:"status"`
+}
+
+func (d *TunnelsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tunnels"
+}
+
+func (d *TunnelsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List IPsec, GRE, and VXLAN tunnels modeled in a snapshot with their endpoints and status, " +
+			"for example to assert that every new site has its expected tunnels up.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot identifier the tunnels were read from.",
+				Computed:            true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot whose tunnels are listed.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list tunnels of this type (IPSEC, GRE, VXLAN).",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("IPSEC", "GRE", "VXLAN"),
+				},
+			},
+			"device_name": schema.StringAttribute{
+				MarkdownDescription: "Only list tunnels terminating on this device.",
+				Optional:            true,
+			},
+			"tunnels": schema.ListAttribute{
+				MarkdownDescription: "Tunnels sorted by device and name.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"device_name":    types.StringType,
+						"name":           types.StringType,
+						"type":           types.StringType,
+						"local_address":  types.StringType,
+						"remote_address": types.StringType,
+						"remote_device":  types.StringType,
+						"vrf":            types.StringType,
+						"status":         types.StringType,
+					},
+				},
+			},
+			"up_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of listed tunnels with status UP.",
+				Computed:            true,
+			},
+			"down_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of listed tunnels with any other status.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *TunnelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *TunnelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data tunnelsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !providerData.requireFeature(ctx, featureTunnels, path.Empty(), &resp.Diagnostics) {
+		return
+	}
+
+	tunnels, err := providerData.Client.ListTunnels(ctx, data.SnapshotID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Tunnels", err)
+		return
+	}
+
+	sort.SliceStable(tunnels, func(i, j int) bool {
+		if tunnels[i].DeviceName != tunnels[j].DeviceName {
+			return tunnels[i].DeviceName < tunnels[j].DeviceName
+		}
+		return tunnels[i].Name < tunnels[j].Name
+	})
+
+	tunnelType, device := stringValue(data.Type), stringValue(data.DeviceName)
+	var up, down int64
+	data.Tunnels = make([]tunnelItem, 0, len(tunnels))
+	for _, tunnel := range tunnels {
+		if (tunnelType != "" && !strings.EqualFold(tunnel.Type, tunnelType)) || (device != "" && tunnel.DeviceName != device) {
+			continue
+		}
+
+		if strings.EqualFold(tunnel.Status, "UP") {
+			up++
+		} else {
+			down++
+		}
+		data.Tunnels = append(data.Tunnels, tunnelItem{
+			DeviceName:    stringOrNull(tunnel.DeviceName),
+			Name:          stringOrNull(tunnel.Name),
+			Type:          stringOrNull(tunnel.Type),
+			LocalAddress:  stringOrNull(tunnel.LocalAddress),
+			RemoteAddress: stringOrNull(tunnel.RemoteAddress),
+			RemoteDevice:  stringOrNull(tunnel.RemoteDevice),
+			VRF:           stringOrNull(tunnel.VRF),
+			Status:        stringOrNull(tunnel.Status),
+		})
+	}
+	data.UpCount = types.Int64Value(up)
+	data.DownCount = types.Int64Value(down)
+	data.ID = data.SnapshotID
+
+	tflog.Trace(ctx, "retrieved forward tunnels", map[string]any{"count": len(data.Tunnels)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAccTunnelsDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddTunnel("snap-1", fwdclient.Tunnel{DeviceName: "branch-1", Name: "Tunnel0", Type: "IPSEC", RemoteAddress: "203.0.113.1", Status: "UP"})
+	server.AddTunnel("snap-1", fwdclient.Tunnel{DeviceName: "branch-1", Name: "Tunnel1", Type: "IPSEC", RemoteAddress: "203.0.113.2", Status: "DOWN"})
+	server.AddTunnel("snap-1", fwdclient.Tunnel{DeviceName: "leaf-1", Name: "Vxlan1", Type: "VXLAN", Status: "UP"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_tunnels" "ipsec" {
+  snapshot_id = "snap-1"
+  type        = "IPSEC"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_tunnels.ipsec", "tunnels.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_tunnels.ipsec", "tunnels.1.remote_address", "203.0.113.2"),
+					resource.TestCheckResourceAttr("data.forward_tunnels.ipsec", "up_count", "1"),
+					resource.TestCheckResourceAttr("data.forward_tunnels.ipsec", "down_count", "1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// usageSummaryEndpoints is how many of the slowest endpoints the usage summary lists.
+const usageSummaryEndpoints = 5
+
+// UsageSummary adds up the Forward API requests made by every resource and data source
+// operation a provider process serves, so one summary can be logged when Terraform
+// stops the process. It helps tune max_concurrent_requests and find expensive data
+// sources.
+type UsageSummary struct {
+	mu    sync.Mutex
+	total fwdclient.Usage
+}
+
+func (s *UsageSummary) add(usage fwdclient.Usage) {
+	if usage.Requests == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.total = s.total.Merge(usage)
+}
+
+// Log writes the summary, unless no requests were made. It is meant to be called once
+// the provider server has stopped: go-plugin reads the provider's standard error until
+// the process exits, so the summary is written through a logger configured like the
+// one Terraform's plugin server gives each request.
+func (s *UsageSummary) Log(name string) {
+	s.mu.Lock()
+	total := s.total
+	s.mu.Unlock()
+	if total.Requests == 0 {
+		return
+	}
+
+	ctx := tfsdklog.NewRootProviderLogger(context.Background(),
+		tfsdklog.WithStderrFromInit(),
+		tfsdklog.WithLogName(name),
+		tflog.WithLevelFromEnv("TF_LOG_PROVIDER", name),
+	)
+	fields := usageSummaryFields(total)
+	tflog.Info(ctx, "Forward API usage summary", fields)
+	if total.RateLimited > 0 {
+		tflog.Warn(ctx, "Forward Enterprise rate limited API requests; consider lowering max_concurrent_requests", fields)
+	}
+}
+
+// track returns a context recording the requests of one operation, and a function that
+// adds them to the summary once the operation returns.
+func (s *UsageSummary) track(ctx context.Context) (context.Context, func()) {
+	ctx, usage := fwdclient.WithUsage(ctx)
+	return ctx, func() { s.add(usage()) }
+}
+
+// NewProtocol6Server returns a factory for the provider's protocol 6 server that adds
+// the API usage of each operation to usage.
+func NewProtocol6Server(version string, usage *UsageSummary) func() tfprotov6.ProviderServer {
+	return func() tfprotov6.ProviderServer {
+		return protocol6UsageServer{ProviderServer: providerserver.NewProtocol6(New(version)())(), usage: usage}
+	}
+}
+
+// protocol6UsageServer wraps the framework's protocol 6 server to attribute API requests to the
+// operation that made them.
+type protocol6UsageServer struct {
+	tfprotov6.ProviderServer
+	usage *UsageSummary
+}
+
+func (s protocol6UsageServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.ReadDataSource(ctx, req)
+}
+
+func (s protocol6UsageServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.ReadResource(ctx, req)
+}
+
+func (s protocol6UsageServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.PlanResourceChange(ctx, req)
+}
+
+func (s protocol6UsageServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.ApplyResourceChange(ctx, req)
+}
+
+func (s protocol6UsageServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.ImportResourceState(ctx, req)
+}
+
+func (s protocol6UsageServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.ConfigureProvider(ctx, req)
+}
+
+// NewProtocol5Server returns a factory for the provider's protocol 5 server that adds
+// the API usage of each operation to usage.
+func NewProtocol5Server(version string, usage *UsageSummary) func() tfprotov5.ProviderServer {
+	return func() tfprotov5.ProviderServer {
+		return protocol5UsageServer{ProviderServer: providerserver.NewProtocol5(New(version)())(), usage: usage}
+	}
+}
+
+// protocol5UsageServer wraps the framework's protocol 5 server to attribute API requests to the
+// operation that made them.
+type protocol5UsageServer struct {
+	tfprotov5.ProviderServer
+	usage *UsageSummary
+}
+
+func (s protocol5UsageServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.ReadDataSource(ctx, req)
+}
+
+func (s protocol5UsageServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.ReadResource(ctx, req)
+}
+
+func (s protocol5UsageServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.PlanResourceChange(ctx, req)
+}
+
+func (s protocol5UsageServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.ApplyResourceChange(ctx, req)
+}
+
+func (s protocol5UsageServer) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.ImportResourceState(ctx, req)
+}
+
+func (s protocol5UsageServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	ctx, done := s.usage.track(ctx)
+	defer done()
+	return s.ProviderServer.ConfigureProvider(ctx, req)
+}
+
+func usageSummaryFields(usage fwdclient.Usage) map[string]any {
+	endpoints := usage.Endpoints
+	if len(endpoints) > usageSummaryEndpoints {
+		endpoints = endpoints[:usageSummaryEndpoints]
+	}
+	slowest := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		average := endpoint.Total / time.Duration(endpoint.Requests)
+		slowest = append(slowest, fmt.Sprintf("%s %s: %d requests, max %s, avg %s",
+			endpoint.Method, endpoint.Path, endpoint.Requests,
+			endpoint.Max.Round(time.Millisecond), average.Round(time.Millisecond)))
+	}
+
+	fields := map[string]any{
+		"requests":          usage.Requests,
+		"retries":           usage.Retries,
+		"rate_limited":      usage.RateLimited,
+		"slowest_endpoints": slowest,
+	}
+	return fields
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestUsageSummaryFields(t *testing.T) {
+	t.Parallel()
+
+	usage := fwdclient.Usage{Requests: 9, Retries: 2, RateLimited: 1}
+	for i := 0; i < usageSummaryEndpoints+2; i++ {
+		usage.Endpoints = append(usage.Endpoints, fwdclient.EndpointUsage{
+			Method:   "GET",
+			Path:     "/api/snapshots/{id}/checks",
+			Requests: 2,
+			Total:    3 * time.Second,
+			Max:      2 * time.Second,
+		})
+	}
+
+	fields := usageSummaryFields(usage)
+	if fields["requests"] != 9 || fields["retries"] != 2 || fields["rate_limited"] != 1 {
+		t.Fatalf("unexpected fields: %v", fields)
+	}
+	slowest, ok := fields["slowest_endpoints"].([]string)
+	if !ok || len(slowest) != usageSummaryEndpoints {
+		t.Fatalf("expected the %d slowest endpoints, got %v", usageSummaryEndpoints, fields["slowest_endpoints"])
+	}
+	if want := "GET /api/snapshots/{id}/checks: 2 requests, max 2s, avg 1.5s"; slowest[0] != want {
+		t.Fatalf("expected %q, got %q", want, slowest[0])
+	}
+}
+
+// versionReadingServer reads the appliance version on every data source read.
+type versionReadingServer struct {
+	tfprotov5.ProviderServer
+	client *fwdclient.Client
+}
+
+func (s versionReadingServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	_, err := s.client.GetVersion(ctx)
+	return &tfprotov5.ReadDataSourceResponse{}, err
+}
+
+func TestUsageTrackingServer(t *testing.T) {
+	t.Parallel()
+
+	server := fakeforward.New(t)
+	client, err := fwdclient.NewClient(context.Background(), fwdclient.Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	summary := &UsageSummary{}
+	tracking := protocol5UsageServer{ProviderServer: versionReadingServer{client: client}, usage: summary}
+	for i := 0; i < 3; i++ {
+		if _, err := tracking.ReadDataSource(context.Background(), &tfprotov5.ReadDataSourceRequest{TypeName: "forward_version"}); err != nil {
+			t.Fatalf("read: %v", err)
+		}
+	}
+	// Requests made outside an operation are not attributed to the summary.
+	if _, err := client.GetVersion(context.Background()); err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+
+	if summary.total.Requests != 3 || len(summary.total.Endpoints) != 1 || summary.total.Endpoints[0].Requests != 3 {
+		t.Fatalf("expected the three operations to be summed, got %+v", summary.total)
+	}
+}
@@ -6,13 +6,22 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// versionPattern matches Forward Enterprise versions such as 25.4, 25.4.0, or 25.4.0-12.
+var versionPattern = regexp.MustCompile(`^v?\d+([.\-]\d+)*$`)
+
 var _ datasource.DataSource = &VersionDataSource{}
 
 // NewVersionDataSource instantiates the version data source.
@@ -27,9 +36,11 @@ type VersionDataSource struct {
 
 // versionDataSourceModel represents the Terraform state.
 type versionDataSourceModel struct {
-	Build   types.String `tfsdk:"build"`
-	Release types.String `tfsdk:"release"`
-	Version types.String `tfsdk:"version"`
+	Profile        types.String `tfsdk:"profile"`
+	MinimumVersion types.String `tfsdk:"minimum_version"`
+	Build          types.String `tfsdk:"build"`
+	Release        types.String `tfsdk:"release"`
+	Version        types.String `tfsdk:"version"`
 }
 
 func (d *VersionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -40,6 +51,16 @@ func (d *VersionDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Retrieve Forward Enterprise API version information.",
 		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"minimum_version": schema.StringAttribute{
+				MarkdownDescription: "Fail the plan when the deployment's version is older than this, for example `25.4` or " +
+					"`25.4.0-12`. Versions are compared component by component numerically, so `25.4` is satisfied by `25.4.0-12`. " +
+					"Use it in modules that depend on newer API features to fail early with a clear message.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(versionPattern, "must be a version such as 25.4 or 25.4.0-12"),
+				},
+			},
 			"build": schema.StringAttribute{
 				MarkdownDescription: "Build hash of the Forward Enterprise deployment.",
 				Computed:            true,
@@ -82,19 +103,49 @@ func (d *VersionDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	version, err := d.providerData.Client.GetVersion(ctx)
+	var config versionDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(config.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version, err := providerData.Client.GetVersion(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Retrieve Version",
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Version", err)
 		return
 	}
 
+	if minimum := config.MinimumVersion.ValueString(); minimum != "" {
+		current := version.Version
+		if current == "" {
+			current = version.Release
+		}
+		cmp, err := compareVersions(current, minimum)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("minimum_version"), "Unable to Compare Versions",
+				fmt.Sprintf("Forward Enterprise reported version %q, which cannot be compared with minimum_version: %s", current, err))
+			return
+		}
+		if cmp < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("minimum_version"), "Forward Enterprise Version Too Old",
+				fmt.Sprintf("This configuration requires Forward Enterprise %s or later, but the instance reports version %s. "+
+					"Upgrade Forward Enterprise or use a version of the configuration that supports it.", minimum, current))
+			return
+		}
+	}
+
 	state := versionDataSourceModel{
-		Build:   types.StringNull(),
-		Release: types.StringNull(),
-		Version: types.StringNull(),
+		Profile:        config.Profile,
+		MinimumVersion: config.MinimumVersion,
+		Build:          types.StringNull(),
+		Release:        types.StringNull(),
+		Version:        types.StringNull(),
 	}
 
 	if version.Build != "" {
@@ -111,3 +162,52 @@ func (d *VersionDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
+
+// compareVersions returns -1, 0, or 1 as a is older than, equal to, or newer than b.
+// Components separated by dots or dashes are compared numerically and missing
+// components count as zero, so 25.4 equals 25.4.0 and precedes 25.4.0-12.
+func compareVersions(a, b string) (int, error) {
+	left, err := versionComponents(a)
+	if err != nil {
+		return 0, err
+	}
+	right, err := versionComponents(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(left) || i < len(right); i++ {
+		var l, r int
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		switch {
+		case l < r:
+			return -1, nil
+		case l > r:
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func versionComponents(version string) ([]int, error) {
+	version = strings.TrimSpace(version)
+	if !versionPattern.MatchString(version) {
+		return nil, fmt.Errorf("%q is not a version such as 25.4 or 25.4.0-12", version)
+	}
+
+	fields := strings.FieldsFunc(strings.TrimPrefix(version, "v"), func(r rune) bool { return r == '.' || r == '-' })
+	components := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a version such as 25.4 or 25.4.0-12", version)
+		}
+		components[i] = n
+	}
+	return components, nil
+}
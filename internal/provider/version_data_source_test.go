@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAccVersionDataSourceMinimumVersion(t *testing.T) {
+	server := fakeforward.New(t)
+	server.SetVersion(fwdclient.Version{Build: "ee9b380", Release: "25.4.0-12", Version: "25.4.0"})
+
+	config := func(minimum string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "test-token"
+  network_id = %q
+}
+
+data "forward_version" "current" {
+  minimum_version = %q
+}
+`, server.URL, testNetworkID, minimum)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("25.4"),
+				Check:  resource.TestCheckResourceAttr("data.forward_version.current", "version", "25.4.0"),
+			},
+			{
+				Config:      config("25.10"),
+				ExpectError: regexp.MustCompile(`requires Forward Enterprise 25.10 or later`),
+			},
+			{
+				Config:      config("latest"),
+				ExpectError: regexp.MustCompile(`must be a version such as 25.4`),
+			},
+		},
+	})
+}
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"25.4.0", "25.4", 0},
+		{"25.4.0-12", "25.4", 1},
+		{"25.4", "25.4.0-12", -1},
+		{"25.10.1", "25.4.3", 1},
+		{"24.12", "25.1", -1},
+		{"v25.4.1", "25.4.1", 0},
+	}
+	for _, tc := range cases {
+		got, err := compareVersions(tc.a, tc.b)
+		if err != nil {
+			t.Fatalf("compareVersions(%q, %q): %v", tc.a, tc.b, err)
+		}
+		if got != tc.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+
+	if _, err := compareVersions("fake", "25.4"); err == nil {
+		t.Fatal("expected a non-numeric version to be rejected")
+	}
+}
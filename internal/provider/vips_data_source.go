@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &VIPsDataSource{}
+
+// NewVIPsDataSource instantiates the load balancer VIP data source.
+func NewVIPsDataSource() datasource.DataSource {
+	return &VIPsDataSource{}
+}
+
+// VIPsDataSource lists load balancer virtual servers modeled in a snapshot.
+type VIPsDataSource struct {
+	providerData *ForwardProviderData
+}
+
+type vipsDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Profile    types.String `tfsdk:"profile"`
+	SnapshotID types.String `tfsdk:"snapshot_id"`
+	DeviceName types.String `tfsdk:"device_name"`
+	VIPs       []vipItem    `tfsdk:"vips"`
+}
+
+type vipItem struct {
+	DeviceName types.String     `tfsdk:"device_name"`
+	Name       types.String     `tfsdk:"name"`
+	Address    types.String     `tfsdk:"address"`
+	Port       types.Int64      `tfsdk:"port"`
+	Protocol   types.String     `tfsdk:"protocol"`
+	State      types.String     `tfsdk:"state"`
+	Pool       types.String     `tfsdk:"pool"`
+	Members    []poolMemberItem `tfsdk:"members"`
+}
+
+type poolMemberItem struct {
+	Address types.String `tfsdk:"address"`
+	Port    types.Int64  `tfsdk:"port"`
+	State   types.String `tfsdk:"state"`
+}
+
+func (d *VIPsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vips"
+}
+
+func (d *VIPsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List load balancer virtual servers (VIPs) discovered in a snapshot with their pools and members, " +
+			"for example to generate a reachability check per VIP with `for_each`.",
+		Attributes: map[string]schema.Attribute{
+			"profile": dataSourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot identifier the VIPs were read from.",
+				Computed:            true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot whose load balancers are listed.",
+				Required:            true,
+			},
+			"device_name": schema.StringAttribute{
+				MarkdownDescription: "Only list virtual servers on this load balancer.",
+				Optional:            true,
+			},
+			"vips": schema.ListAttribute{
+				MarkdownDescription: "Virtual servers sorted by device and name.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"device_name": types.StringType,
+						"name":        types.StringType,
+						"address":     types.StringType,
+						"port":        types.Int64Type,
+						"protocol":    types.StringType,
+						"state":       types.StringType,
+						"pool":        types.StringType,
+						"members": types.ListType{ElemType: types.ObjectType{
+							AttrTypes: map[string]attr.Type{
+								"address": types.StringType,
+								"port":    types.Int64Type,
+								"state":   types.StringType,
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *VIPsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *VIPsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The provider client was not configured. Ensure the provider block is present before using this data source.",
+		)
+		return
+	}
+
+	var data vipsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := d.providerData.forProfile(data.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	servers, err := providerData.Client.ListVirtualServers(ctx, data.SnapshotID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Unable to Retrieve Virtual Servers", err)
+		return
+	}
+
+	sort.SliceStable(servers, func(i, j int) bool {
+		if servers[i].DeviceName != servers[j].DeviceName {
+			return servers[i].DeviceName < servers[j].DeviceName
+		}
+		return servers[i].Name < servers[j].Name
+	})
+
+	device := stringValue(data.DeviceName)
+	data.VIPs = make([]vipItem, 0, len(servers))
+	for _, server := range servers {
+		if device != "" && server.DeviceName != device {
+			continue
+		}
+
+		members := make([]poolMemberItem, 0, len(server.Members))
+		for _, member := range server.Members {
+			members = append(members, poolMemberItem{
+				Address: stringOrNull(member.Address),
+				Port:    int64PointerOrNull(member.Port),
+				State:   stringOrNull(member.State),
+			})
+		}
+		data.VIPs = append(data.VIPs, vipItem{
+			DeviceName: stringOrNull(server.DeviceName),
+			Name:       stringOrNull(server.Name),
+			Address:    stringOrNull(server.Address),
+			Port:       int64PointerOrNull(server.Port),
+			Protocol:   stringOrNull(server.Protocol),
+			State:      stringOrNull(server.State),
+			Pool:       stringOrNull(server.Pool),
+			Members:    members,
+		})
+	}
+	data.ID = data.SnapshotID
+
+	tflog.Trace(ctx, "retrieved forward load balancer vips", map[string]any{"count": len(data.VIPs)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
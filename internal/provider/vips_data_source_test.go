@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestAccVIPsDataSource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	port, memberPort := int64(443), int64(8443)
+	server.AddVirtualServer("snap-1", fwdclient.VirtualServer{DeviceName: "lb2", Name: "api", Address: "192.0.2.20"})
+	server.AddVirtualServer("snap-1", fwdclient.VirtualServer{
+		DeviceName: "lb1",
+		Name:       "web",
+		Address:    "192.0.2.10",
+		Port:       &port,
+		Pool:       "web-pool",
+		Members:    []fwdclient.PoolMember{{Address: "10.0.0.11", Port: &memberPort, State: "UP"}},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+data "forward_vips" "all" {
+  snapshot_id = "snap-1"
+}
+
+data "forward_vips" "lb1" {
+  snapshot_id = "snap-1"
+  device_name = "lb1"
+}
+`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.forward_vips.all", "vips.#", "2"),
+					resource.TestCheckResourceAttr("data.forward_vips.all", "vips.0.name", "web"),
+					resource.TestCheckResourceAttr("data.forward_vips.lb1", "vips.#", "1"),
+					resource.TestCheckResourceAttr("data.forward_vips.lb1", "vips.0.port", "443"),
+					resource.TestCheckResourceAttr("data.forward_vips.lb1", "vips.0.members.0.address", "10.0.0.11"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,554 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+// zeroTrustTag is added to every check a zero-trust policy creates.
+const zeroTrustTag = "zero-trust"
+
+// zeroTrustRuleKeys lists the keys accepted in a rules entry.
+var zeroTrustRuleKeys = []string{"action", "application", "ports", "user_group"}
+
+var _ resource.Resource = &ZeroTrustPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &ZeroTrustPolicyResource{}
+var _ resource.ResourceWithConfigValidators = &ZeroTrustPolicyResource{}
+
+// ZeroTrustPolicyResource turns identity-based rules into intent checks on a snapshot.
+type ZeroTrustPolicyResource struct {
+	providerData *ForwardProviderData
+}
+
+// ZeroTrustPolicyResourceModel maps Terraform schema data.
+type ZeroTrustPolicyResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Profile     types.String `tfsdk:"profile"`
+	SnapshotID  types.String `tfsdk:"snapshot_id"`
+	NamePrefix  types.String `tfsdk:"name_prefix"`
+	Persistent  types.Bool   `tfsdk:"persistent"`
+	Rules       types.Map    `tfsdk:"rules"`
+	CheckHashes types.Map    `tfsdk:"check_hashes"`
+	CheckIDs    types.Map    `tfsdk:"check_ids"`
+}
+
+// zeroTrustRule is a validated rules entry: members of UserGroup may (ALLOW) or must
+// not (DENY) reach Application, optionally only over Ports.
+type zeroTrustRule struct {
+	UserGroup   string   `json:"userGroup"`
+	Application string   `json:"application"`
+	Ports       []string `json:"ports,omitempty"`
+	Action      string   `json:"action"`
+}
+
+// zeroTrustIdentity is an application or user group a rule was resolved to.
+type zeroTrustIdentity struct {
+	ID   string
+	Name string
+}
+
+func NewZeroTrustPolicyResource() resource.Resource {
+	return &ZeroTrustPolicyResource{}
+}
+
+func (r *ZeroTrustPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zero_trust_policy"
+}
+
+func (r *ZeroTrustPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Verify a zero-trust policy written as identity-based rules, such as \"HR may reach Payroll over " +
+			"443\". Each rule becomes an intent check on the snapshot, with its user group and application resolved to " +
+			"Forward Enterprise IDs; the plan fails when a user group or application is not recognized. Checks are created, " +
+			"replaced, or deactivated as rules change, like `forward_check_library`.",
+		Attributes: map[string]schema.Attribute{
+			"profile": resourceProfileAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Internal Terraform identifier (mirrors snapshot_id).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"snapshot_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Snapshot identifier the checks are created against.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Prefix of the check names, which read `<name_prefix>: <rule key>`. Defaults to `Zero trust`.",
+				Default:             stringdefault.StaticString("Zero trust"),
+			},
+			"persistent": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the checks should persist to future snapshots.",
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"rules": schema.MapAttribute{
+				Required: true,
+				MarkdownDescription: "Rules keyed by a stable name. Each rule sets `user_group` and `application`, each a name " +
+					"or ID known to the snapshot's network, and may set `ports` (comma-separated destination ports) and " +
+					"`action`: `ALLOW` (the default) checks that the group can reach the application, `DENY` that it cannot.",
+				ElementType: types.MapType{ElemType: types.StringType},
+			},
+			"check_hashes": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "SHA-256 checksum of each rule, keyed by rule key.",
+			},
+			"check_ids": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Forward Enterprise check identifier of each rule, keyed by rule key.",
+			},
+		},
+	}
+}
+
+func (r *ZeroTrustPolicyResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		validateSnapshotReferences(func() *ForwardProviderData { return r.providerData }, "snapshot_id"),
+	}
+}
+
+func (r *ZeroTrustPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ForwardProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ForwardProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// ModifyPlan validates the rules, resolves their identities so unrecognized ones fail
+// the plan, and marks the checks of changed rules for recreation.
+func (r *ZeroTrustPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ZeroTrustPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.NamePrefix.IsUnknown() {
+		return
+	}
+
+	rules, diags := parseZeroTrustRules(ctx, plan.Rules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || rules == nil {
+		return
+	}
+
+	if r.providerData != nil && !plan.SnapshotID.IsUnknown() && !plan.Profile.IsUnknown() {
+		providerData, diags := r.providerData.forProfile(plan.Profile)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		_, _, diags = providerData.resolveZeroTrustRules(ctx, plan.SnapshotID.ValueString(), rules)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	hashes, err := zeroTrustHashes(plan.NamePrefix.ValueString(), rules)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("rules"), "Error Encoding Zero-Trust Rules", err.Error())
+		return
+	}
+
+	planCheckSet(ctx, req, resp, hashes)
+}
+
+func (r *ZeroTrustPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan ZeroTrustPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcile(ctx, &plan, checkSet{hashes: map[string]string{}, ids: map[string]string{}}, &resp.Diagnostics, &resp.State)
+}
+
+func (r *ZeroTrustPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state ZeroTrustPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, diags := checkSetFromMaps(ctx, state.CheckHashes, state.CheckIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.refreshCheckSet(ctx, state.SnapshotID.ValueString(), set)...)
+	resp.Diagnostics.Append(set.toMaps(ctx, &state.CheckHashes, &state.CheckIDs)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ZeroTrustPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var plan, state ZeroTrustPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, diags := checkSetFromMaps(ctx, state.CheckHashes, state.CheckIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcile(ctx, &plan, set, &resp.Diagnostics, &resp.State)
+}
+
+func (r *ZeroTrustPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider client was not configured. Re-run terraform init or review provider configuration.",
+		)
+		return
+	}
+
+	var state ZeroTrustPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := r.providerData.forProfile(state.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, diags := checkSetFromMaps(ctx, state.CheckHashes, state.CheckIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.deactivateCheckSet(ctx, state.SnapshotID.ValueString(), set)...)
+}
+
+// reconcile brings the checks on the snapshot in line with the rules, saving state even
+// when it fails part way.
+func (r *ZeroTrustPolicyResource) reconcile(ctx context.Context, plan *ZeroTrustPolicyResourceModel, set checkSet, diags *diag.Diagnostics, state *tfsdk.State) {
+	providerData, profileDiags := r.providerData.forProfile(plan.Profile)
+	diags.Append(profileDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	rules, ruleDiags := parseZeroTrustRules(ctx, plan.Rules)
+	diags.Append(ruleDiags...)
+	if diags.HasError() {
+		return
+	}
+	desired, err := zeroTrustHashes(plan.NamePrefix.ValueString(), rules)
+	if err != nil {
+		diags.AddAttributeError(path.Root("rules"), "Error Encoding Zero-Trust Rules", err.Error())
+		return
+	}
+
+	snapshotID := plan.SnapshotID.ValueString()
+	groups, applications, resolveDiags := providerData.resolveZeroTrustRules(ctx, snapshotID, rules)
+	diags.Append(resolveDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags.Append(providerData.reconcileCheckSet(ctx, snapshotID, boolPointer(plan.Persistent), set, desired,
+		func(key string) fwdclient.NewCheckRequest {
+			return zeroTrustCheckRequest(plan.NamePrefix.ValueString(), key, rules[key], groups[key], applications[key])
+		})...)
+
+	plan.ID = plan.SnapshotID
+	diags.Append(set.toMaps(ctx, &plan.CheckHashes, &plan.CheckIDs)...)
+	diags.Append(state.Set(ctx, plan)...)
+}
+
+// parseZeroTrustRules validates rules entries. It returns nil rules without errors when
+// the rules or any entry is not yet known.
+func parseZeroTrustRules(ctx context.Context, value types.Map) (map[string]zeroTrustRule, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+
+	var entries map[string]types.Map
+	diags := value.ElementsAs(ctx, &entries, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	rules := make(map[string]zeroTrustRule, len(entries))
+	unknown := false
+
+	for key, entryValue := range entries {
+		if entryValue.IsUnknown() {
+			unknown = true
+			continue
+		}
+		var entry map[string]types.String
+		diags.Append(entryValue.ElementsAs(ctx, &entry, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		entryPath := path.Root("rules").AtMapKey(key)
+		values := make(map[string]string, len(entry))
+		for name, value := range entry {
+			if !slices.Contains(zeroTrustRuleKeys, name) {
+				diags.AddAttributeError(entryPath.AtMapKey(name), "Unknown Zero-Trust Rule Attribute",
+					fmt.Sprintf("Rules may only set: %s.", strings.Join(zeroTrustRuleKeys, ", ")))
+				continue
+			}
+			if value.IsUnknown() {
+				unknown = true
+				continue
+			}
+			if !value.IsNull() {
+				values[name] = strings.TrimSpace(value.ValueString())
+			}
+		}
+		if unknown {
+			continue
+		}
+
+		rule := zeroTrustRule{
+			UserGroup:   values["user_group"],
+			Application: values["application"],
+			Action:      strings.ToUpper(values["action"]),
+		}
+		if rule.Action == "" {
+			rule.Action = "ALLOW"
+		}
+		for _, port := range strings.Split(values["ports"], ",") {
+			if port = strings.TrimSpace(port); port != "" {
+				rule.Ports = append(rule.Ports, port)
+			}
+		}
+		sort.Strings(rule.Ports)
+
+		if rule.UserGroup == "" || rule.Application == "" {
+			diags.AddAttributeError(entryPath, "Incomplete Zero-Trust Rule", "Every rule must set `user_group` and `application`.")
+			continue
+		}
+		if rule.Action != "ALLOW" && rule.Action != "DENY" {
+			diags.AddAttributeError(entryPath.AtMapKey("action"), "Invalid Zero-Trust Rule Action",
+				fmt.Sprintf("Action must be ALLOW or DENY, got %q.", values["action"]))
+			continue
+		}
+		rules[key] = rule
+	}
+
+	if unknown || diags.HasError() {
+		return nil, diags
+	}
+	return rules, diags
+}
+
+// resolveZeroTrustRules resolves the user group and application of every rule against
+// the snapshot's network, reporting each value that matches no known ID or name.
+func (d *ForwardProviderData) resolveZeroTrustRules(ctx context.Context, snapshotID string, rules map[string]zeroTrustRule) (map[string]zeroTrustIdentity, map[string]zeroTrustIdentity, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	networkID := d.NetworkID
+	if networkID == "" {
+		var err error
+		networkID, err = snapshotNetworkID(ctx, d.Client, snapshotID)
+		if err != nil {
+			addAPIErrorWithPaths(&diags, "Unable to Determine Snapshot Network", err, map[string]path.Path{"snapshotId": path.Root("snapshot_id")})
+			return nil, nil, diags
+		}
+	}
+
+	userGroups, err := d.Client.ListUserGroups(ctx, networkID)
+	if err != nil {
+		addAPIError(&diags, "Unable to Retrieve User Groups", err)
+		return nil, nil, diags
+	}
+	applications, err := d.Client.ListApplications(ctx, networkID)
+	if err != nil {
+		addAPIError(&diags, "Unable to Retrieve Applications", err)
+		return nil, nil, diags
+	}
+
+	knownGroups := make([]zeroTrustIdentity, 0, len(userGroups))
+	for _, group := range userGroups {
+		knownGroups = append(knownGroups, zeroTrustIdentity{ID: group.ID, Name: group.Name})
+	}
+	knownApplications := make([]zeroTrustIdentity, 0, len(applications))
+	for _, application := range applications {
+		knownApplications = append(knownApplications, zeroTrustIdentity{ID: application.ID, Name: application.Name})
+	}
+
+	groups := make(map[string]zeroTrustIdentity, len(rules))
+	apps := make(map[string]zeroTrustIdentity, len(rules))
+	for _, key := range sortedRuleKeys(rules) {
+		rule := rules[key]
+		rulePath := path.Root("rules").AtMapKey(key)
+		if group, ok := matchZeroTrustIdentity(rule.UserGroup, knownGroups); ok {
+			groups[key] = group
+		} else {
+			diags.AddAttributeError(rulePath.AtMapKey("user_group"), "Unrecognized User Group",
+				fmt.Sprintf("Network %s has no user group with ID or name %q.", networkID, rule.UserGroup))
+		}
+		if application, ok := matchZeroTrustIdentity(rule.Application, knownApplications); ok {
+			apps[key] = application
+		} else {
+			diags.AddAttributeError(rulePath.AtMapKey("application"), "Unrecognized Application",
+				fmt.Sprintf("Network %s has no application with ID or name %q.", networkID, rule.Application))
+		}
+	}
+
+	return groups, apps, diags
+}
+
+// matchZeroTrustIdentity finds value by exact ID, then by case-insensitive name. A name
+// shared by several identities matches none of them.
+func matchZeroTrustIdentity(value string, known []zeroTrustIdentity) (zeroTrustIdentity, bool) {
+	for _, identity := range known {
+		if identity.ID == value {
+			return identity, true
+		}
+	}
+
+	var match zeroTrustIdentity
+	matches := 0
+	for _, identity := range known {
+		if strings.EqualFold(identity.Name, value) {
+			match = identity
+			matches++
+		}
+	}
+	return match, matches == 1
+}
+
+// zeroTrustCheckRequest builds the check verifying rule: an Existential check that the
+// user group can reach the application for ALLOW, an Isolation check for DENY.
+func zeroTrustCheckRequest(prefix, key string, rule zeroTrustRule, group, application zeroTrustIdentity) fwdclient.NewCheckRequest {
+	checkType, verb := "Existential", "can reach"
+	if rule.Action == "DENY" {
+		checkType, verb = "Isolation", "cannot reach"
+	}
+
+	filters := map[string]any{
+		"from": map[string]any{"userGroupId": group.ID},
+		"to":   map[string]any{"appId": application.ID},
+	}
+	note := fmt.Sprintf("User group %s %s application %s", group.Name, verb, application.Name)
+	if len(rule.Ports) > 0 {
+		filters["flow"] = map[string]any{"dstPort": strings.Join(rule.Ports, ",")}
+		note += " over port " + strings.Join(rule.Ports, ", ")
+	}
+
+	return fwdclient.NewCheckRequest{
+		Definition: fwdclient.CheckDefinition{"checkType": checkType, "filters": filters},
+		Name:       fmt.Sprintf("%s: %s", prefix, key),
+		Note:       note + ".",
+		Tags:       []string{zeroTrustTag},
+	}
+}
+
+// zeroTrustHashes checksums each rule together with the name prefix, so a change to
+// either recreates the rule's check.
+func zeroTrustHashes(prefix string, rules map[string]zeroTrustRule) (map[string]string, error) {
+	hashes := make(map[string]string, len(rules))
+	for key, rule := range rules {
+		content, err := json.Marshal(struct {
+			Prefix string        `json:"prefix"`
+			Rule   zeroTrustRule `json:"rule"`
+		}{prefix, rule})
+		if err != nil {
+			return nil, fmt.Errorf("encode rule %q: %w", key, err)
+		}
+		hashes[key] = contentChecksum(content)
+	}
+	return hashes, nil
+}
+
+func sortedRuleKeys(rules map[string]zeroTrustRule) []string {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
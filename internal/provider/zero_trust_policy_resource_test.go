@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/fakeforward"
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func TestParseZeroTrustRules(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rules, diags := parseZeroTrustRules(ctx, zeroTrustRulesValue(t, map[string]map[string]types.String{
+		"hr_payroll": {
+			"user_group":  types.StringValue("HR"),
+			"application": types.StringValue("Payroll"),
+			"ports":       types.StringValue("8443, 443"),
+		},
+		"guest_payroll": {
+			"user_group":  types.StringValue("Guests"),
+			"application": types.StringValue("Payroll"),
+			"action":      types.StringValue("deny"),
+		},
+	}))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if rule := rules["hr_payroll"]; rule.Action != "ALLOW" || len(rule.Ports) != 2 || rule.Ports[0] != "443" {
+		t.Fatalf("unexpected rule: %+v", rule)
+	}
+	if rule := rules["guest_payroll"]; rule.Action != "DENY" || rule.Ports != nil {
+		t.Fatalf("unexpected rule: %+v", rule)
+	}
+
+	for name, entry := range map[string]map[string]types.String{
+		"missing application": {"user_group": types.StringValue("HR")},
+		"invalid action":      {"user_group": types.StringValue("HR"), "application": types.StringValue("Payroll"), "action": types.StringValue("DROP")},
+		"unknown attribute":   {"user_group": types.StringValue("HR"), "application": types.StringValue("Payroll"), "port": types.StringValue("443")},
+	} {
+		if _, diags := parseZeroTrustRules(ctx, zeroTrustRulesValue(t, map[string]map[string]types.String{"rule": entry})); !diags.HasError() {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	unknownEntry := types.MapValueMust(types.MapType{ElemType: types.StringType}, map[string]attr.Value{
+		"rule": types.MapUnknown(types.StringType),
+	})
+	for name, value := range map[string]types.Map{
+		"unknown value": zeroTrustRulesValue(t, map[string]map[string]types.String{
+			"rule": {"user_group": types.StringUnknown(), "application": types.StringValue("Payroll")},
+		}),
+		"unknown entry": unknownEntry,
+		"unknown rules": types.MapUnknown(types.MapType{ElemType: types.StringType}),
+	} {
+		if rules, diags := parseZeroTrustRules(ctx, value); diags.HasError() || rules != nil {
+			t.Errorf("%s: expected the rules to be deferred, got %v, %v", name, rules, diags)
+		}
+	}
+}
+
+func zeroTrustRulesValue(t *testing.T, entries map[string]map[string]types.String) types.Map {
+	t.Helper()
+
+	value, diags := types.MapValueFrom(context.Background(), types.MapType{ElemType: types.StringType}, entries)
+	if diags.HasError() {
+		t.Fatalf("build rules: %v", diags)
+	}
+	return value
+}
+
+func TestMatchZeroTrustIdentity(t *testing.T) {
+	t.Parallel()
+
+	known := []zeroTrustIdentity{
+		{ID: "app-1", Name: "Payroll"},
+		{ID: "app-2", Name: "Wiki"},
+		{ID: "app-3", Name: "wiki"},
+	}
+	if match, ok := matchZeroTrustIdentity("payroll", known); !ok || match.ID != "app-1" {
+		t.Fatalf("expected name match, got %+v, %v", match, ok)
+	}
+	if match, ok := matchZeroTrustIdentity("app-2", known); !ok || match.Name != "Wiki" {
+		t.Fatalf("expected ID match, got %+v, %v", match, ok)
+	}
+	if _, ok := matchZeroTrustIdentity("Wiki", known); ok {
+		t.Fatal("expected ambiguous name not to match")
+	}
+	if _, ok := matchZeroTrustIdentity("Mail", known); ok {
+		t.Fatal("expected unknown name not to match")
+	}
+}
+
+func TestZeroTrustCheckRequest(t *testing.T) {
+	t.Parallel()
+
+	request := zeroTrustCheckRequest("Zero trust", "guest_payroll",
+		zeroTrustRule{Action: "DENY", Ports: []string{"443"}},
+		zeroTrustIdentity{ID: "ug-2", Name: "Guests"},
+		zeroTrustIdentity{ID: "app-1", Name: "Payroll"})
+
+	if request.Name != "Zero trust: guest_payroll" || request.Definition["checkType"] != "Isolation" {
+		t.Fatalf("unexpected request: %+v", request)
+	}
+	filters := request.Definition["filters"].(map[string]any)
+	if filters["from"].(map[string]any)["userGroupId"] != "ug-2" || filters["to"].(map[string]any)["appId"] != "app-1" {
+		t.Fatalf("unexpected filters: %+v", filters)
+	}
+	if filters["flow"].(map[string]any)["dstPort"] != "443" {
+		t.Fatalf("unexpected flow filter: %+v", filters)
+	}
+}
+
+func TestAccZeroTrustPolicyResource(t *testing.T) {
+	server := fakeforward.New(t)
+	server.AddSnapshot("net-1", fwdclient.Snapshot{ID: "snap-1"})
+	server.AddUserGroup("net-1", fwdclient.UserGroup{ID: "ug-1", Name: "HR"})
+	server.AddApplication("net-1", fwdclient.Application{ID: "app-1", Name: "Payroll"})
+
+	config := func(group string) string {
+		return fmt.Sprintf(`
+provider "forward" {
+  base_url   = %q
+  api_key    = "token"
+  network_id = "net-1"
+}
+
+resource "forward_zero_trust_policy" "test" {
+  snapshot_id = "snap-1"
+  rules = {
+    hr_payroll = {
+      user_group  = %q
+      application = "Payroll"
+      ports       = "443"
+    }
+  }
+}
+`, server.URL, group)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("HR"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("forward_zero_trust_policy.test", "check_ids.%", "1"),
+					resource.TestCheckResourceAttrWith("forward_zero_trust_policy.test", "check_ids.hr_payroll", func(value string) error {
+						if check, ok := server.Check("snap-1", value); !ok || check.Name != "Zero trust: hr_payroll" {
+							return fmt.Errorf("expected generated check, got %+v", check)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:      config("Contractors"),
+				ExpectError: regexp.MustCompile("Unrecognized User Group"),
+			},
+		},
+	})
+}
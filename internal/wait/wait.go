@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package wait polls long-running Forward Enterprise operations, such as snapshot
+// processing and check execution, with exponential backoff and a timeout. Time is read
+// through a Clock so tests can run polling loops without sleeping.
+package wait
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is returned by Poll when the condition is not met before the timeout.
+var ErrTimeout = errors.New("timed out")
+
+// Clock is the source of time for polling loops.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// FakeClock is a Clock whose time only moves when waited on: After advances the clock
+// by d and returns a channel that has already fired, so polling loops run instantly
+// and deterministically in tests.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a FakeClock starting at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After advances the fake time by d and returns a fired channel.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// Backoff yields polling intervals that start at an initial value and double up to a
+// maximum, so long waits issue fewer requests.
+type Backoff struct {
+	initial  time.Duration
+	max      time.Duration
+	interval time.Duration
+}
+
+// NewBackoff returns a Backoff starting at initial, or one second when initial is not
+// positive, and capped at maximum.
+func NewBackoff(initial, maximum time.Duration) *Backoff {
+	if initial <= 0 {
+		initial = time.Second
+	}
+	maximum = max(maximum, initial)
+	return &Backoff{initial: initial, max: maximum, interval: initial}
+}
+
+// Next returns the interval to wait before the following poll.
+func (b *Backoff) Next() time.Duration {
+	interval := b.interval
+	b.interval = min(b.interval*2, b.max)
+	return interval
+}
+
+// Peek returns the interval Next will return without advancing the backoff.
+func (b *Backoff) Peek() time.Duration {
+	return b.interval
+}
+
+// Suggest replaces the next interval with one suggested by Forward, capped at the maximum.
+func (b *Backoff) Suggest(interval time.Duration) {
+	if interval > 0 {
+		b.interval = min(interval, b.max)
+	}
+}
+
+// Restart returns a backoff with the same bounds starting again from the initial interval.
+func (b *Backoff) Restart() *Backoff {
+	return NewBackoff(b.initial, b.max)
+}
+
+// Poller repeatedly evaluates a condition until it is met.
+type Poller struct {
+	// Backoff spaces the polls. Defaults to one second between polls.
+	Backoff *Backoff
+	// Timeout bounds the whole wait.
+	Timeout time.Duration
+	// Clock defaults to SystemClock.
+	Clock Clock
+	// DelayFirst waits one interval before the first poll, for operations that are
+	// known not to have finished yet.
+	DelayFirst bool
+}
+
+// Now returns the current time of the poller's clock.
+func (p Poller) Now() time.Time {
+	return p.clock().Now()
+}
+
+// Poll calls condition until it reports done or fails, waiting the backoff interval
+// between calls. The last wait is shortened so the condition is evaluated once more at
+// the deadline; if it is still not met, Poll returns ErrTimeout. Cancelling ctx stops
+// the wait with the context's error.
+func (p Poller) Poll(ctx context.Context, condition func(context.Context) (bool, error)) error {
+	clock := p.clock()
+	backoff := p.Backoff
+	if backoff == nil {
+		backoff = NewBackoff(time.Second, time.Second)
+	}
+	deadline := clock.Now().Add(p.Timeout)
+
+	delay := p.DelayFirst
+	for {
+		if delay {
+			remaining := deadline.Sub(clock.Now())
+			if remaining <= 0 {
+				return ErrTimeout
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-clock.After(min(backoff.Next(), remaining)):
+			}
+		}
+		delay = true
+
+		done, err := condition(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+func (p Poller) clock() Clock {
+	if p.Clock == nil {
+		return SystemClock
+	}
+	return p.Clock
+}
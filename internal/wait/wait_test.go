@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	backoff := NewBackoff(5*time.Second, time.Minute)
+	var got []time.Duration
+	for range 6 {
+		got = append(got, backoff.Next())
+	}
+	want := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("unexpected intervals: %v", got)
+	}
+
+	// A suggested interval replaces the next one but never exceeds the maximum.
+	backoff.Suggest(15 * time.Second)
+	if interval := backoff.Peek(); interval != 15*time.Second {
+		t.Fatalf("expected to peek the suggested interval, got %s", interval)
+	}
+	if interval := backoff.Next(); interval != 15*time.Second {
+		t.Fatalf("expected suggested interval, got %s", interval)
+	}
+	backoff.Suggest(10 * time.Minute)
+	if interval := backoff.Next(); interval != time.Minute {
+		t.Fatalf("expected capped interval, got %s", interval)
+	}
+	if interval := backoff.Restart().Next(); interval != 5*time.Second {
+		t.Fatalf("expected restart from the initial interval, got %s", interval)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1700000000, 0)
+	clock := NewFakeClock(start)
+	var polledAt []time.Duration
+	err := Poller{Backoff: NewBackoff(time.Second, 4*time.Second), Timeout: time.Minute, Clock: clock}.Poll(context.Background(), func(context.Context) (bool, error) {
+		polledAt = append(polledAt, clock.Now().Sub(start))
+		return len(polledAt) == 4, nil
+	})
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	want := []time.Duration{0, time.Second, 3 * time.Second, 7 * time.Second}
+	if fmt.Sprint(polledAt) != fmt.Sprint(want) {
+		t.Fatalf("unexpected poll times: %v", polledAt)
+	}
+}
+
+func TestPollDelayFirstAndTimeout(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1700000000, 0)
+	clock := NewFakeClock(start)
+	var polledAt []time.Duration
+	err := Poller{Backoff: NewBackoff(4*time.Second, 4*time.Second), Timeout: 10 * time.Second, Clock: clock, DelayFirst: true}.Poll(context.Background(), func(context.Context) (bool, error) {
+		polledAt = append(polledAt, clock.Now().Sub(start))
+		return false, nil
+	})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+	// The final wait is shortened so the condition is checked at the deadline.
+	want := []time.Duration{4 * time.Second, 8 * time.Second, 10 * time.Second}
+	if fmt.Sprint(polledAt) != fmt.Sprint(want) {
+		t.Fatalf("unexpected poll times: %v", polledAt)
+	}
+}
+
+func TestPollErrorsAndCancel(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	failure := errors.New("boom")
+	err := Poller{Timeout: time.Minute, Clock: clock}.Poll(context.Background(), func(context.Context) (bool, error) {
+		return false, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected condition error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Poller{Timeout: time.Minute}.Poll(ctx, func(context.Context) (bool, error) {
+		return false, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+}
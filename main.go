@@ -4,12 +4,10 @@
 package main
 
 import (
-	"context"
 	"flag"
 	"log"
 
 	"github.com/forwardnetworks/terraform-provider-forward/internal/provider"
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
 var (
@@ -27,14 +25,13 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		Address: "registry.terraform.io/forwardnetworks/forward",
-		Debug:   debug,
-	}
-
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	usage := &provider.UsageSummary{}
+	err := serve("registry.terraform.io/forwardnetworks/forward", debug, usage)
 
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+
+	// Serve returns once Terraform stops the provider at the end of the run.
+	usage.Log("forward")
 }
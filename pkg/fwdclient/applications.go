@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Application is an application Forward Enterprise recognizes in traffic, as used by
+// the appId filter of path searches and checks.
+type Application struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserGroup is a directory user group Forward Enterprise recognizes, as used by the
+// userGroupId filter of path searches and checks.
+type UserGroup struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ListApplications retrieves the applications known to a network.
+func (c *Client) ListApplications(ctx context.Context, networkID string) ([]Application, error) {
+	var applications []Application
+	if err := c.getNetworkList(ctx, networkID, "applications", &applications); err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+// ListUserGroups retrieves the user groups known to a network.
+func (c *Client) ListUserGroups(ctx context.Context, networkID string) ([]UserGroup, error) {
+	var groups []UserGroup
+	if err := c.getNetworkList(ctx, networkID, "userGroups", &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// getNetworkList decodes GET /api/networks/{networkID}/{resource} into out.
+func (c *Client) getNetworkList(ctx context.Context, networkID, resource string, out any) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	if networkID == "" {
+		return fmt.Errorf("networkID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/%s", url.PathEscape(networkID), resource)
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute %s request: %w", resource, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp, "retrieving "+resource)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s response: %w", resource, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListApplicationsAndUserGroups(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/networks/net-1/applications":
+			_, _ = w.Write([]byte(`[{"id":"app-1","name":"Payroll"}]`))
+		case "/api/networks/net-1/userGroups":
+			_, _ = w.Write([]byte(`[{"id":"ug-1","name":"HR"}]`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	applications, err := client.ListApplications(context.Background(), "net-1")
+	if err != nil {
+		t.Fatalf("ListApplications error: %v", err)
+	}
+	if len(applications) != 1 || applications[0].Name != "Payroll" {
+		t.Fatalf("unexpected applications: %#v", applications)
+	}
+
+	groups, err := client.ListUserGroups(context.Background(), "net-1")
+	if err != nil {
+		t.Fatalf("ListUserGroups error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].ID != "ug-1" {
+		t.Fatalf("unexpected user groups: %#v", groups)
+	}
+}
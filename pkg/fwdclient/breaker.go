@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultCircuitBreakerCooldown is how long an open circuit rejects requests before a
+// single probe request is allowed through.
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// ErrCircuitOpen matches errors returned while the client refuses to send requests
+// because the appliance failed repeatedly. Use errors.Is to detect it.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitOpenError reports that a request was not sent because the preceding requests
+// all failed. First is set on the first error returned after the circuit opened, so
+// callers can report the outage in detail once and briefly everywhere else.
+type CircuitOpenError struct {
+	Failures int
+	LastErr  error
+	RetryAt  time.Time
+	First    bool
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("not sending request: the last %d requests to Forward Enterprise failed (last error: %v); retrying after %s",
+		e.Failures, e.LastErr, e.RetryAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return e.LastErr
+}
+
+// circuitBreaker counts consecutive failed attempts across every request made by a
+// client. Once threshold is reached it rejects requests for cooldown, then lets a single
+// probe through: success closes the circuit, failure reopens it.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	failures  int
+	lastErr   error
+	openUntil time.Time
+	probing   bool
+	reported  bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow returns a *CircuitOpenError when the request must not be sent.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if !b.now().Before(b.openUntil) && !b.probing {
+		b.probing = true
+		return nil
+	}
+
+	err := &CircuitOpenError{Failures: b.failures, LastErr: b.lastErr, RetryAt: b.openUntil, First: !b.reported}
+	b.reported = true
+	return err
+}
+
+// record updates the breaker with the outcome of an attempt; err is nil on success.
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err == nil {
+		b.failures = 0
+		b.lastErr = nil
+		b.reported = false
+		return
+	}
+
+	b.failures++
+	b.lastErr = err
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+// abandon releases a probe whose outcome is unknown, such as a canceled request.
+func (b *circuitBreaker) abandon() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAndProbes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	failure := errors.New("connection refused")
+	breaker.record(failure)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected the circuit to stay closed below the threshold, got %v", err)
+	}
+	breaker.record(failure)
+
+	err := breaker.allow()
+	var open *CircuitOpenError
+	if !errors.As(err, &open) || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected an open circuit, got %v", err)
+	}
+	if !open.First || open.Failures != 2 || !errors.Is(err, failure) {
+		t.Fatalf("unexpected first open error: %+v", open)
+	}
+	if err := breaker.allow(); !errors.As(err, &open) || open.First {
+		t.Fatalf("expected later rejections not to be marked first, got %v", err)
+	}
+
+	// After the cooldown a single probe is allowed; a failed probe reopens the circuit.
+	now = now.Add(time.Minute)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected a probe after the cooldown, got %v", err)
+	}
+	if err := breaker.allow(); err == nil {
+		t.Fatal("expected only one concurrent probe")
+	}
+	breaker.record(failure)
+	if err := breaker.allow(); err == nil {
+		t.Fatal("expected a failed probe to reopen the circuit")
+	}
+
+	now = now.Add(time.Minute)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected a probe after the cooldown, got %v", err)
+	}
+	breaker.record(nil)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected a successful probe to close the circuit, got %v", err)
+	}
+}
+
+func TestClient_CircuitBreakerStopsRequests(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{
+		BaseURL:                 server.URL,
+		APIKey:                  "token",
+		MaxRetries:              2,
+		RetryDelay:              time.Millisecond,
+		CircuitBreakerThreshold: 4,
+		CircuitBreakerCooldown:  time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		req, err := client.NewRequest(context.Background(), http.MethodGet, "/test", nil)
+		if err != nil {
+			t.Fatalf("new request: %v", err)
+		}
+		if _, err := client.Do(req); err == nil {
+			t.Fatal("expected the request to fail")
+		} else if i > 0 && !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected request %d to be rejected by the open circuit, got %v", i, err)
+		}
+	}
+
+	// The first request makes three attempts and the second a fourth that opens the circuit.
+	if got := attempts.Load(); got != 4 {
+		t.Fatalf("expected 4 attempts before the circuit opened, got %d", got)
+	}
+}
+
+func TestClient_CircuitBreakerIgnoresClientErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token", CircuitBreakerThreshold: 1})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		req, err := client.NewRequest(context.Background(), http.MethodGet, "/missing", nil)
+		if err != nil {
+			t.Fatalf("new request: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("expected a response, got %v", err)
+		}
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Optional API features reported by ProbeCapabilities.
+const (
+	CapabilityNQEDiff         = "nqe_diff"
+	CapabilityNQEJobs         = "nqe_jobs"
+	CapabilityBulkPathSearch  = "bulk_path_search"
+	CapabilityL2PathSearch    = "l2_path_search"
+	CapabilityVulnerabilities = "vulnerabilities"
+	CapabilityCheckExecution  = "check_execution"
+)
+
+// capabilityProbes maps each capability to an endpoint that only exists when the
+// feature does. {network} is replaced with the probed network and other identifiers
+// are placeholders. Endpoints that only accept POST are probed with GET too: a 405
+// response shows the route exists without submitting any work.
+var capabilityProbes = map[string]string{
+	CapabilityNQEDiff:         "/api/nqe-diffs/0/0",
+	CapabilityNQEJobs:         "/api/nqe/jobs",
+	CapabilityBulkPathSearch:  "/api/networks/{network}/paths-bulk",
+	CapabilityL2PathSearch:    "/api/networks/{network}/l2paths",
+	CapabilityVulnerabilities: "/api/networks/{network}/vulnerabilities?limit=1",
+	CapabilityCheckExecution:  "/api/snapshots/0/checks/executions",
+}
+
+// ProbeCapabilities reports which optional API features the instance serves, keyed by
+// the Capability constants. Each feature is probed with a single GET request.
+func (c *Client) ProbeCapabilities(ctx context.Context, networkID string) (map[string]bool, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+	networkID = strings.TrimSpace(networkID)
+	if networkID == "" {
+		return nil, fmt.Errorf("network ID is required")
+	}
+
+	capabilities := make(map[string]bool, len(capabilityProbes))
+	for name, path := range capabilityProbes {
+		available, err := c.probeEndpoint(ctx, strings.ReplaceAll(path, "{network}", url.PathEscape(networkID)))
+		if err != nil {
+			return nil, fmt.Errorf("probe %s: %w", name, err)
+		}
+		capabilities[name] = available
+	}
+	return capabilities, nil
+}
+
+// probeEndpoint reports whether path is served. Not found and not implemented mean the
+// feature is missing, and forbidden means the API key cannot use it; any other client
+// error, such as a missing parameter or an unsupported method, still shows the route exists.
+func (c *Client) probeEndpoint(ctx context.Context, path string) (bool, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("probe request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return false, newAPIError(resp, "probing API capabilities")
+	case resp.StatusCode == http.StatusNotFound, resp.StatusCode == http.StatusNotImplemented, resp.StatusCode == http.StatusForbidden:
+		io.Copy(io.Discard, resp.Body) // best effort
+		return false, nil
+	case resp.StatusCode >= 500:
+		return false, newAPIError(resp, "probing API capabilities")
+	default:
+		io.Copy(io.Discard, resp.Body) // best effort
+		return true, nil
+	}
+}
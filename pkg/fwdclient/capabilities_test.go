@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ProbeCapabilities(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/nqe-diffs/{before}/{after}", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("probes must not submit work")
+	})
+	mux.HandleFunc("GET /api/networks/{network}/l2paths", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	mux.HandleFunc("GET /api/networks/{network}/vulnerabilities", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("network") != "net-1" || r.URL.Query().Get("limit") != "1" {
+			t.Errorf("unexpected vulnerabilities probe: %s", r.URL)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /api/snapshots/{snapshot}/checks/executions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	got, err := client.ProbeCapabilities(context.Background(), "net-1")
+	if err != nil {
+		t.Fatalf("probe capabilities: %v", err)
+	}
+	want := map[string]bool{
+		CapabilityNQEDiff:         true,
+		CapabilityNQEJobs:         false,
+		CapabilityBulkPathSearch:  false,
+		CapabilityL2PathSearch:    true,
+		CapabilityVulnerabilities: true,
+		CapabilityCheckExecution:  false,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d capabilities, got %v", len(want), got)
+	}
+	for name, available := range want {
+		if got[name] != available {
+			t.Errorf("capability %s: expected %t, got %t", name, available, got[name])
+		}
+	}
+}
+
+func TestClient_ProbeCapabilitiesUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+	if _, err := client.ProbeCapabilities(context.Background(), "net-1"); !IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+}
@@ -0,0 +1,443 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader carries a per-request key on mutating requests. Do reuses the
+// request, and therefore the key, for every retry, so an appliance that honors the
+// header applies a create at most once even when the first response was lost.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Config captures the inputs required to construct a Forward Networks API client.
+type Config struct {
+	BaseURL   string
+	APIKey    string
+	Insecure  bool
+	UserAgent string
+
+	// ProxyURL, when set, routes requests through the given HTTP(S) proxy instead of the
+	// one selected by the HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+	// CACertPEM holds additional PEM-encoded certificate authorities trusted alongside
+	// the system roots, for appliances with certificates from a private CA.
+	CACertPEM []byte
+
+	HTTPClient *http.Client
+	// MaxRetries is how many times a failed request is retried. Zero uses the default
+	// of 3; a negative value disables retries.
+	MaxRetries int
+	RetryDelay time.Duration
+
+	// Recorder, when set, records or replays every request made by the client.
+	Recorder *Recorder
+
+	// MaxConcurrentRequests caps how many requests may be in flight at once across all
+	// callers sharing the client. Zero or a negative value disables the limit.
+	MaxConcurrentRequests int
+
+	// NQENotReadyTimeout is how long RunNQEQuery keeps retrying while the target
+	// snapshot is still being processed. Zero or a negative value disables the retries.
+	NQENotReadyTimeout time.Duration
+
+	// CircuitBreakerThreshold is how many consecutive attempts, across all callers
+	// sharing the client, may fail with a network error or server error before the
+	// client stops sending requests and returns a *CircuitOpenError instead. Zero or a
+	// negative value disables the breaker.
+	CircuitBreakerThreshold int
+	// CircuitBreakerCooldown is how long an open circuit rejects requests before a
+	// single probe is allowed through. Defaults to 30 seconds.
+	CircuitBreakerCooldown time.Duration
+}
+
+// Client is a thin wrapper around http.Client that ensures each request targets
+// the configured Forward Networks appliance and carries the correct headers.
+type Client struct {
+	httpClient *http.Client
+	baseURL    *url.URL
+	apiKey     string
+	userAgent  string
+	maxRetries int
+	retryDelay time.Duration
+
+	nqeNotReadyTimeout time.Duration
+
+	// slots is a counting semaphore bounding in-flight requests; nil when unlimited.
+	slots chan struct{}
+
+	// breaker stops requests after repeated failures; nil when disabled.
+	breaker *circuitBreaker
+
+	usage *usageRecorder
+}
+
+// NewClient validates the configuration and instantiates a new Client.
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	_ = ctx // reserved for future use when requests require context during initialization.
+	if cfg.BaseURL == "" {
+		return nil, errors.New("base URL must be provided")
+	}
+
+	parsed, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse base URL: %w", err)
+	}
+
+	if parsed.Scheme == "" {
+		return nil, errors.New("base URL must include an HTTP or HTTPS scheme")
+	}
+
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+
+	if cfg.APIKey == "" {
+		return nil, errors.New("API key must be provided")
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 60 * time.Second,
+		}
+	}
+
+	if cfg.Insecure || cfg.ProxyURL != "" || len(cfg.CACertPEM) > 0 {
+		if err := configureTransport(httpClient, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Recorder != nil {
+		httpClient.Transport = cfg.Recorder.Wrap(httpClient.Transport)
+	}
+
+	userAgent := strings.TrimSpace(cfg.UserAgent)
+	if userAgent == "" {
+		userAgent = "terraform-provider-forward/dev"
+	}
+
+	maxRetries := cfg.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	} else if maxRetries == 0 {
+		maxRetries = 3
+	}
+
+	retryDelay := cfg.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = 500 * time.Millisecond
+	}
+
+	client := &Client{
+		httpClient: httpClient,
+		baseURL:    parsed,
+		apiKey:     cfg.APIKey,
+		userAgent:  userAgent,
+		maxRetries: maxRetries,
+		retryDelay: retryDelay,
+
+		nqeNotReadyTimeout: cfg.NQENotReadyTimeout,
+
+		breaker: newCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown),
+		usage:   newUsageRecorder(),
+	}
+
+	if cfg.MaxConcurrentRequests > 0 {
+		client.slots = make(chan struct{}, cfg.MaxConcurrentRequests)
+	}
+
+	return client, nil
+}
+
+// configureTransport applies the TLS and proxy settings of cfg to a copy of the
+// client's transport.
+func configureTransport(httpClient *http.Client, cfg Config) error {
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	t, ok := transport.(*http.Transport)
+	if !ok {
+		return nil
+	}
+
+	clone := t.Clone()
+	if clone.TLSClientConfig == nil {
+		clone.TLSClientConfig = &tls.Config{}
+	}
+	if cfg.Insecure {
+		clone.TLSClientConfig.InsecureSkipVerify = true // #nosec G402 -- controlled via provider config for testing only.
+	}
+	if len(cfg.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(cfg.CACertPEM) {
+			return errors.New("CA certificate bundle contains no PEM-encoded certificates")
+		}
+		clone.TLSClientConfig.RootCAs = pool
+	}
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("unable to parse proxy URL: %w", err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return errors.New("proxy URL must include a scheme and host")
+		}
+		clone.Proxy = http.ProxyURL(proxy)
+	}
+
+	httpClient.Transport = clone
+	return nil
+}
+
+// NewRequest creates an HTTP request that points at the configured Forward Networks base URL.
+func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	if c == nil {
+		return nil, errors.New("client is nil")
+	}
+
+	rel, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse request path: %w", err)
+	}
+
+	target := c.resolve(rel)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if method == http.MethodPost || method == http.MethodPatch {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+
+	return req, nil
+}
+
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// resolve appends an API path to the base URL. Unlike url.ResolveReference, an absolute
+// path such as "/api/version" keeps the base URL's path, so deployments served behind a
+// reverse proxy at a subpath (https://tools.corp/forward) are addressed correctly.
+// Escaped path segments are preserved.
+func (c *Client) resolve(rel *url.URL) *url.URL {
+	if rel.IsAbs() {
+		return rel
+	}
+
+	target := *c.baseURL
+	relPath, relRawPath := rel.Path, rel.EscapedPath()
+	if !strings.HasPrefix(relPath, "/") {
+		relPath, relRawPath = "/"+relPath, "/"+relRawPath
+	}
+	target.Path = c.baseURL.Path + relPath
+	// RawPath is only consulted when it is a valid encoding of Path, which the joined
+	// escaped forms always are.
+	target.RawPath = c.baseURL.EscapedPath() + relRawPath
+	target.RawQuery = rel.RawQuery
+	target.Fragment = ""
+	return &target
+}
+
+// Do executes the provided HTTP request using the underlying client.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c == nil {
+		return nil, errors.New("client is nil")
+	}
+
+	start := time.Now()
+	resp, err := c.do(req)
+	elapsed := time.Since(start)
+	c.usage.request(req, elapsed)
+	if usage := contextUsage(req.Context()); usage != nil {
+		usage.request(req, elapsed)
+	}
+	return resp, err
+}
+
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	httpClient := c.httpClientFor(req.Context())
+	attempt := 0
+	var lastErr error
+
+	for {
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			// A streamed body was consumed by the failed attempt and cannot be replayed.
+			if req.GetBody == nil {
+				return nil, lastErr
+			}
+			rc, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("reset request body: %w", err)
+			}
+			req.Body = rc
+		}
+
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+
+		if err := c.acquire(req.Context()); err != nil {
+			c.breaker.abandon()
+			return nil, err
+		}
+
+		resp, err := httpClient.Do(req)
+		switch {
+		case err != nil && req.Context().Err() != nil:
+			c.breaker.abandon()
+		case err != nil:
+			c.breaker.record(err)
+		case isServerFailure(resp.StatusCode):
+			c.breaker.record(fmt.Errorf("received status %d", resp.StatusCode))
+		default:
+			c.breaker.record(nil)
+		}
+		if err == nil && !shouldRetryStatus(resp.StatusCode) {
+			if c.slots != nil {
+				// The slot stays held until the caller finishes reading the body.
+				resp.Body = &releasingBody{ReadCloser: resp.Body, release: c.release}
+			}
+			return resp, nil
+		}
+
+		status := 0
+		if err != nil {
+			lastErr = err
+		} else {
+			// Consume and close before retrying.
+			io.Copy(io.Discard, resp.Body) // best effort
+			resp.Body.Close()
+			status = resp.StatusCode
+			lastErr = fmt.Errorf("received status %d", resp.StatusCode)
+		}
+		c.release()
+
+		if attempt >= c.maxRetries {
+			return nil, lastErr
+		}
+		c.usage.retry(status)
+		if usage := contextUsage(req.Context()); usage != nil {
+			usage.retry(status)
+		}
+
+		attempt++
+		backoff := c.retryDelay * time.Duration(1<<uint(attempt-1))
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// serverTimeoutMargin is added to a server-side time limit, such as a path search's
+// maxSeconds, to leave time for the response to arrive after the server stops.
+const serverTimeoutMargin = 30 * time.Second
+
+// timeoutKey is the context key of the per-request HTTP timeout set by WithTimeout.
+type timeoutKey struct{}
+
+// WithTimeout lets requests made with the returned context run for at least d, even when
+// that exceeds the HTTP client's timeout, so a long operation such as a full NQE export
+// can finish without raising the timeout of every request. Durations shorter than the
+// client's timeout, or than one already set on ctx, change nothing; use
+// context.WithTimeout to give up sooner.
+func WithTimeout(ctx context.Context, d time.Duration) context.Context {
+	if current, ok := ctx.Value(timeoutKey{}).(time.Duration); ok && current >= d {
+		return ctx
+	}
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// httpClientFor returns the HTTP client for a request made with ctx: the configured one,
+// or a copy with a longer timeout when ctx asks for more time than it allows.
+func (c *Client) httpClientFor(ctx context.Context) *http.Client {
+	d, ok := ctx.Value(timeoutKey{}).(time.Duration)
+	if !ok || c.httpClient.Timeout <= 0 || d <= c.httpClient.Timeout {
+		return c.httpClient
+	}
+	extended := *c.httpClient
+	extended.Timeout = d
+	return &extended
+}
+
+// acquire waits for a free request slot when a concurrency limit is configured.
+func (c *Client) acquire(ctx context.Context) error {
+	if c.slots == nil {
+		return nil
+	}
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) release() {
+	if c.slots != nil {
+		<-c.slots
+	}
+}
+
+// releasingBody returns the request slot the first time the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+func shouldRetryStatus(status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return isServerFailure(status)
+}
+
+// isServerFailure reports whether status indicates the appliance itself is failing,
+// as opposed to rejecting or throttling a particular request.
+func isServerFailure(status int) bool {
+	return status >= 500 && status != http.StatusNotImplemented
+}
@@ -0,0 +1,392 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Copyright (c) HashiCorp, Inc.
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_DoRetriesOnServerError(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		MaxRetries: 5,
+		RetryDelay: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("expected success after retries, got error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestClient_DoStopsAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		MaxRetries: 2,
+		RetryDelay: 1 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+
+	_, err = client.Do(req)
+	if err == nil {
+		t.Fatalf("expected error after retries")
+	}
+}
+
+func TestClient_DoRespectsContextCancel(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		MaxRetries: 5,
+		RetryDelay: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := client.NewRequest(ctx, http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+
+	cancel()
+
+	_, err = client.Do(req)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation error, got %v", err)
+	}
+}
+
+func TestClient_DoLimitsConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&peak)
+			if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{
+		BaseURL:               server.URL,
+		APIKey:                "token",
+		MaxConcurrentRequests: 2,
+	})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := client.NewRequest(context.Background(), http.MethodGet, "/test", nil)
+			if err != nil {
+				t.Errorf("new request: %v", err)
+				return
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Errorf("do: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&peak); got > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, observed %d", got)
+	}
+}
+
+func TestClient_WithTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		HTTPClient: &http.Client{Timeout: 50 * time.Millisecond},
+		MaxRetries: -1,
+	})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	get := func(ctx context.Context) error {
+		req, err := client.NewRequest(ctx, http.MethodGet, "/test", nil)
+		if err != nil {
+			t.Fatalf("new request: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(context.Background()); err == nil {
+		t.Fatal("expected the client timeout to apply")
+	}
+	if err := get(WithTimeout(context.Background(), 10*time.Millisecond)); err == nil {
+		t.Fatal("expected a shorter timeout to leave the client timeout in place")
+	}
+	// A shorter duration does not undo a longer one already set.
+	if err := get(WithTimeout(WithTimeout(context.Background(), time.Second), 10*time.Millisecond)); err != nil {
+		t.Fatalf("expected the extended timeout to apply, got %v", err)
+	}
+}
+
+func TestClient_NewRequestKeepsBasePath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		baseURL string
+		path    string
+		want    string
+	}{
+		"no prefix":       {"https://fwd.app", "/api/version", "https://fwd.app/api/version"},
+		"prefix":          {"https://tools.corp/forward", "/api/version", "https://tools.corp/forward/api/version"},
+		"trailing slash":  {"https://tools.corp/forward/", "/api/version", "https://tools.corp/forward/api/version"},
+		"port and prefix": {"http://10.0.0.5:8443/fwd/v1", "/api/networks?name=a%20b", "http://10.0.0.5:8443/fwd/v1/api/networks?name=a%20b"},
+		"escaped segment": {"https://tools.corp/forward", "/api/snapshots/a%2Fb/checks", "https://tools.corp/forward/api/snapshots/a%2Fb/checks"},
+		"relative path":   {"https://tools.corp/forward", "api/version", "https://tools.corp/forward/api/version"},
+	}
+
+	for name, tc := range tests {
+		client, err := NewClient(context.Background(), Config{BaseURL: tc.baseURL, APIKey: "token"})
+		if err != nil {
+			t.Fatalf("%s: new client: %v", name, err)
+		}
+		req, err := client.NewRequest(context.Background(), http.MethodGet, tc.path, nil)
+		if err != nil {
+			t.Fatalf("%s: new request: %v", name, err)
+		}
+		if got := req.URL.String(); got != tc.want {
+			t.Errorf("%s: got %s, want %s", name, got, tc.want)
+		}
+	}
+}
+
+func TestClient_PathPrefixedBaseURL(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL + "/forward/", APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	if err := client.DeactivateSnapshotCheck(context.Background(), "snap/1", "check-1"); err != nil {
+		t.Fatalf("DeactivateSnapshotCheck returned error: %v", err)
+	}
+	if gotPath != "/forward/api/snapshots/snap%2F1/checks/check-1" {
+		t.Fatalf("unexpected request path: %s", gotPath)
+	}
+}
+
+func TestClient_DoReusesIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token", RetryDelay: time.Millisecond})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/api/networks", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("expected success after retry, got error: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("expected the retry to reuse one idempotency key, got %q", keys)
+	}
+
+	// Each request gets its own key, and reads carry none.
+	other, err := client.NewRequest(context.Background(), http.MethodPost, "/api/networks", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if other.Header.Get(IdempotencyKeyHeader) == keys[0] {
+		t.Fatal("expected a fresh idempotency key per request")
+	}
+	get, err := client.NewRequest(context.Background(), http.MethodGet, "/api/networks", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if get.Header.Get(IdempotencyKeyHeader) != "" {
+		t.Fatal("expected no idempotency key on GET")
+	}
+}
+
+func TestClient_ProxyURL(t *testing.T) {
+	t.Parallel()
+
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A proxied plain-HTTP request carries the absolute target URL.
+		if r.URL.Host == "forward.invalid" {
+			proxied.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: "http://forward.invalid", APIKey: "token", ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/api/version", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	resp.Body.Close()
+
+	if proxied.Load() != 1 {
+		t.Fatal("expected the request to go through the proxy")
+	}
+
+	if _, err := NewClient(context.Background(), Config{BaseURL: "http://forward.invalid", APIKey: "token", ProxyURL: "proxy.corp"}); err == nil {
+		t.Fatal("expected a proxy URL without a scheme to be rejected")
+	}
+}
+
+func TestClient_CACertPEM(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	get := func(cfg Config) error {
+		client, err := NewClient(context.Background(), cfg)
+		if err != nil {
+			return err
+		}
+		req, err := client.NewRequest(context.Background(), http.MethodGet, "/api/version", nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	if err := get(Config{BaseURL: server.URL, APIKey: "token", MaxRetries: -1}); err == nil {
+		t.Fatal("expected the untrusted certificate to be rejected")
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := get(Config{BaseURL: server.URL, APIKey: "token", CACertPEM: certPEM}); err != nil {
+		t.Fatalf("expected the CA bundle to be trusted, got %v", err)
+	}
+
+	if err := get(Config{BaseURL: server.URL, APIKey: "token", CACertPEM: []byte("not a certificate")}); err == nil {
+		t.Fatal("expected an invalid CA bundle to be rejected")
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CloudSecurityGroup is an AWS security group, Azure network security group, or GCP
+// firewall policy, normalized by Forward Enterprise into a common rule model.
+type CloudSecurityGroup struct {
+	ID        string              `json:"id"`
+	Name      string              `json:"name"`
+	CloudType string              `json:"cloudType"`
+	AccountID string              `json:"accountId"`
+	Region    string              `json:"region"`
+	VPCID     string              `json:"vpcId"`
+	Rules     []CloudSecurityRule `json:"rules"`
+}
+
+// CloudSecurityRule is a single normalized security group rule.
+type CloudSecurityRule struct {
+	Direction string `json:"direction"`
+	Action    string `json:"action"`
+	Protocol  string `json:"protocol"`
+	Ports     string `json:"ports"`
+	// Remote is the peer the rule matches: a CIDR, a security group ID, or a service tag.
+	Remote   string `json:"remote"`
+	Priority *int64 `json:"priority,omitempty"`
+}
+
+// CloudSecurityGroupOptions tunes ListCloudSecurityGroups.
+type CloudSecurityGroupOptions struct {
+	// Effective returns the rules as evaluated, including implicit default rules and
+	// rules inherited from parent policies, in evaluation order.
+	Effective bool
+}
+
+// ListCloudSecurityGroups retrieves the cloud security groups modeled in a snapshot.
+func (c *Client) ListCloudSecurityGroups(ctx context.Context, snapshotID string, opts CloudSecurityGroupOptions) ([]CloudSecurityGroup, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/cloud/securityGroups", url.PathEscape(snapshotID))
+	if opts.Effective {
+		path += "?effective=true"
+	}
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute cloud security groups request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving cloud security groups")
+	}
+
+	var groups []CloudSecurityGroup
+	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
+		return nil, fmt.Errorf("decode cloud security groups response: %w", err)
+	}
+
+	return groups, nil
+}
+
+// CloudRouting is the cloud routing modeled in a snapshot: VPC and virtual network route
+// tables and the transit gateway, peering, and VPN attachments that connect them.
+type CloudRouting struct {
+	RouteTables []CloudRouteTable `json:"routeTables"`
+	Attachments []CloudAttachment `json:"attachments"`
+}
+
+// CloudRouteTable is a VPC or virtual network route table.
+type CloudRouteTable struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CloudType string `json:"cloudType"`
+	AccountID string `json:"accountId"`
+	Region    string `json:"region"`
+	VPCID     string `json:"vpcId"`
+	// Subnets are the subnets associated with the route table.
+	Subnets []string     `json:"subnets"`
+	Routes  []CloudRoute `json:"routes"`
+}
+
+// CloudRoute is a single route table entry.
+type CloudRoute struct {
+	Destination string `json:"destination"`
+	// TargetType is the kind of next hop, for example LOCAL, INTERNET_GATEWAY,
+	// NAT_GATEWAY, TRANSIT_GATEWAY, PEERING, or VPN_GATEWAY.
+	TargetType string `json:"targetType"`
+	TargetID   string `json:"targetId"`
+	// Origin is STATIC for configured routes, PROPAGATED for learned ones, and DEFAULT
+	// for routes the cloud installs implicitly.
+	Origin string `json:"origin"`
+	State  string `json:"state"`
+}
+
+// CloudAttachment connects a VPC or virtual network to a transit gateway, peer network,
+// or VPN.
+type CloudAttachment struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	CloudType string `json:"cloudType"`
+	AccountID string `json:"accountId"`
+	Region    string `json:"region"`
+	VPCID     string `json:"vpcId"`
+	// PeerID is the transit gateway, peer network, or VPN gateway on the other side.
+	PeerID string `json:"peerId"`
+	State  string `json:"state"`
+}
+
+// CloudRoutingOptions tunes GetCloudRouting.
+type CloudRoutingOptions struct {
+	// Effective returns each route table's effective routes, including propagated and
+	// default routes, instead of its configured routes.
+	Effective bool
+}
+
+// GetCloudRouting retrieves the cloud route tables and attachments of a snapshot.
+func (c *Client) GetCloudRouting(ctx context.Context, snapshotID string, opts CloudRoutingOptions) (*CloudRouting, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/cloud/routing", url.PathEscape(snapshotID))
+	if opts.Effective {
+		path += "?effective=true"
+	}
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute cloud routing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving cloud routing")
+	}
+
+	var routing CloudRouting
+	if err := json.NewDecoder(resp.Body).Decode(&routing); err != nil {
+		return nil, fmt.Errorf("decode cloud routing response: %w", err)
+	}
+
+	return &routing, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListCloudSecurityGroups(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/cloud/securityGroups" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("effective") != "true" {
+			t.Fatalf("expected effective=true, got %q", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[{"id":"sg-1","name":"web","cloudType":"AWS","vpcId":"vpc-1","rules":[{"direction":"INGRESS","action":"ALLOW","protocol":"tcp","ports":"443","remote":"0.0.0.0/0"}]}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	groups, err := client.ListCloudSecurityGroups(context.Background(), "snap-1", CloudSecurityGroupOptions{Effective: true})
+	if err != nil {
+		t.Fatalf("ListCloudSecurityGroups error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].VPCID != "vpc-1" || len(groups[0].Rules) != 1 || groups[0].Rules[0].Ports != "443" {
+		t.Fatalf("unexpected security groups: %#v", groups)
+	}
+}
+
+func TestGetCloudRouting(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/cloud/routing" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.RawQuery != "" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"routeTables":[{"id":"rtb-1","vpcId":"vpc-1","routes":[{"destination":"10.0.0.0/8","targetType":"TRANSIT_GATEWAY","targetId":"tgw-1","origin":"STATIC","state":"ACTIVE"}]}],"attachments":[{"id":"tgw-attach-1","type":"TRANSIT_GATEWAY","vpcId":"vpc-1","peerId":"tgw-1","state":"AVAILABLE"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	routing, err := client.GetCloudRouting(context.Background(), "snap-1", CloudRoutingOptions{})
+	if err != nil {
+		t.Fatalf("GetCloudRouting error: %v", err)
+	}
+	if len(routing.RouteTables) != 1 || routing.RouteTables[0].Routes[0].TargetID != "tgw-1" {
+		t.Fatalf("unexpected route tables: %#v", routing.RouteTables)
+	}
+	if len(routing.Attachments) != 1 || routing.Attachments[0].PeerID != "tgw-1" {
+		t.Fatalf("unexpected attachments: %#v", routing.Attachments)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ConnectivitySucceeded is the status of a connectivity test in which the collector
+// reached the device and logged in.
+const ConnectivitySucceeded = "SUCCESS"
+
+// ConnectivityTestRequest identifies a device and credential for the collector to try.
+type ConnectivityTestRequest struct {
+	Host            string `json:"host"`
+	Port            int    `json:"port,omitempty"`
+	DeviceType      string `json:"deviceType,omitempty"`
+	CliCredentialID string `json:"cliCredentialId,omitempty"`
+	CollectorName   string `json:"collectorName,omitempty"`
+}
+
+// ConnectivityTestResult reports how far the collector got with a device: whether it
+// answered pings and whether the credential was accepted. Status is SUCCESS,
+// UNREACHABLE, AUTH_FAILED, or TIMEOUT.
+type ConnectivityTestResult struct {
+	Status         string `json:"status"`
+	PingSucceeded  *bool  `json:"pingSucceeded"`
+	LoginSucceeded *bool  `json:"loginSucceeded"`
+	Message        string `json:"message"`
+}
+
+// TestDeviceConnectivity asks the collector of a network to ping and log in to a device
+// without collecting from it or changing any device source.
+func (c *Client) TestDeviceConnectivity(ctx context.Context, networkID string, request ConnectivityTestRequest) (*ConnectivityTestResult, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	if networkID == "" {
+		return nil, fmt.Errorf("networkID must be provided")
+	}
+
+	request.Host = strings.TrimSpace(request.Host)
+	if request.Host == "" {
+		return nil, fmt.Errorf("host must be provided")
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("encode connectivity test request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/collector/connectivityTests", url.PathEscape(networkID))
+	req, err := c.NewRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute connectivity test request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "testing device connectivity")
+	}
+
+	var result ConnectivityTestResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode connectivity test response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// CustomCommandSet is a group of extra CLI commands the collector runs on every device of
+// DeviceType that carries all DeviceTags, so NQE queries can read their output.
+type CustomCommandSet struct {
+	ID         string   `json:"id,omitempty"`
+	Name       string   `json:"name"`
+	DeviceType string   `json:"deviceType,omitempty"`
+	DeviceTags []string `json:"deviceTags,omitempty"`
+	Commands   []string `json:"commands"`
+}
+
+// CreateCustomCommandSet adds a custom command set to a network's collection settings.
+func (c *Client) CreateCustomCommandSet(ctx context.Context, networkID string, set CustomCommandSet) (*CustomCommandSet, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	if networkID == "" {
+		return nil, fmt.Errorf("networkID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/customCommands", url.PathEscape(networkID))
+	return c.sendCustomCommandSet(ctx, http.MethodPost, path, set, "creating custom commands")
+}
+
+// GetCustomCommandSet retrieves a custom command set.
+func (c *Client) GetCustomCommandSet(ctx context.Context, networkID, setID string) (*CustomCommandSet, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	setID = strings.TrimSpace(setID)
+	if networkID == "" || setID == "" {
+		return nil, fmt.Errorf("networkID and setID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/customCommands/%s", url.PathEscape(networkID), url.PathEscape(setID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute custom commands request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving custom commands")
+	}
+
+	var set CustomCommandSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decode custom commands response: %w", err)
+	}
+
+	return &set, nil
+}
+
+// UpdateCustomCommandSet replaces the settings and commands of a custom command set.
+func (c *Client) UpdateCustomCommandSet(ctx context.Context, networkID string, set CustomCommandSet) (*CustomCommandSet, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	setID := strings.TrimSpace(set.ID)
+	if networkID == "" || setID == "" {
+		return nil, fmt.Errorf("networkID and set ID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/customCommands/%s", url.PathEscape(networkID), url.PathEscape(setID))
+	return c.sendCustomCommandSet(ctx, http.MethodPut, path, set, "updating custom commands")
+}
+
+// DeleteCustomCommandSet removes a custom command set. Output already collected in
+// existing snapshots is kept.
+func (c *Client) DeleteCustomCommandSet(ctx context.Context, networkID, setID string) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	setID = strings.TrimSpace(setID)
+	if networkID == "" || setID == "" {
+		return fmt.Errorf("networkID and setID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/customCommands/%s", url.PathEscape(networkID), url.PathEscape(setID))
+	req, err := c.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute custom commands delete request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return newAPIError(resp, "deleting custom commands")
+	}
+}
+
+func (c *Client) sendCustomCommandSet(ctx context.Context, method, path string, set CustomCommandSet, action string) (*CustomCommandSet, error) {
+	bodyBytes, err := json.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("encode custom commands: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, method, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute custom commands request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		// continue
+	default:
+		return nil, newAPIError(resp, action)
+	}
+
+	var result CustomCommandSet
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode custom commands response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_TestDeviceConnectivity(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/networks/net-1/collector/connectivityTests" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var payload ConnectivityTestRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		if payload.Host != "10.0.0.5" || payload.Port != 22 || payload.CliCredentialID != "cred-1" {
+			t.Errorf("unexpected payload: %#v", payload)
+		}
+		_, _ = w.Write([]byte(`{"status":"AUTH_FAILED","pingSucceeded":true,"loginSucceeded":false,"message":"authentication failed"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	result, err := client.TestDeviceConnectivity(context.Background(), "net-1", ConnectivityTestRequest{Host: " 10.0.0.5 ", Port: 22, CliCredentialID: "cred-1"})
+	if err != nil {
+		t.Fatalf("TestDeviceConnectivity returned error: %v", err)
+	}
+	if result.Status != "AUTH_FAILED" || result.PingSucceeded == nil || !*result.PingSucceeded || *result.LoginSucceeded {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+
+	if _, err := client.TestDeviceConnectivity(context.Background(), "net-1", ConnectivityTestRequest{}); err == nil {
+		t.Fatal("expected an error without a host")
+	}
+}
+
+func TestClient_CustomCommandSetLifecycle(t *testing.T) {
+	t.Parallel()
+
+	sets := map[string]CustomCommandSet{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/networks/net-1/customCommands":
+			var set CustomCommandSet
+			_ = json.NewDecoder(r.Body).Decode(&set)
+			set.ID = "commands-1"
+			sets[set.ID] = set
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(set)
+		case r.Method == http.MethodPut && r.URL.Path == "/api/networks/net-1/customCommands/commands-1":
+			var set CustomCommandSet
+			_ = json.NewDecoder(r.Body).Decode(&set)
+			sets[set.ID] = set
+			_ = json.NewEncoder(w).Encode(set)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/networks/net-1/customCommands/commands-1":
+			set, ok := sets["commands-1"]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(set)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/networks/net-1/customCommands/commands-1":
+			delete(sets, "commands-1")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	created, err := client.CreateCustomCommandSet(context.Background(), "net-1", CustomCommandSet{Name: "bgp", Commands: []string{"show ip bgp"}})
+	if err != nil {
+		t.Fatalf("CreateCustomCommandSet returned error: %v", err)
+	}
+	if created.ID != "commands-1" {
+		t.Fatalf("unexpected created set: %#v", created)
+	}
+
+	created.Commands = append(created.Commands, "show bgp neighbors")
+	if _, err := client.UpdateCustomCommandSet(context.Background(), "net-1", *created); err != nil {
+		t.Fatalf("UpdateCustomCommandSet returned error: %v", err)
+	}
+
+	got, err := client.GetCustomCommandSet(context.Background(), "net-1", "commands-1")
+	if err != nil {
+		t.Fatalf("GetCustomCommandSet returned error: %v", err)
+	}
+	if len(got.Commands) != 2 {
+		t.Fatalf("expected the update to be stored, got %#v", got)
+	}
+
+	if err := client.DeleteCustomCommandSet(context.Background(), "net-1", "commands-1"); err != nil {
+		t.Fatalf("DeleteCustomCommandSet returned error: %v", err)
+	}
+	if _, err := client.GetCustomCommandSet(context.Background(), "net-1", "commands-1"); !IsNotFound(err) {
+		t.Fatalf("expected not found after delete, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Device describes a network device modeled in a Forward Enterprise snapshot.
+type Device struct {
+	Name          string   `json:"name"`
+	DisplayName   string   `json:"displayName"`
+	Type          string   `json:"type"`
+	Vendor        string   `json:"vendor"`
+	Platform      string   `json:"platform"`
+	Model         string   `json:"model"`
+	OSVersion     string   `json:"osVersion"`
+	SerialNumber  string   `json:"serialNumber,omitempty"`
+	ManagementIPs []string `json:"managementIps"`
+}
+
+// ListSnapshotDevices retrieves the device inventory of a snapshot.
+func (c *Client) ListSnapshotDevices(ctx context.Context, snapshotID string) ([]Device, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/devices", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute devices request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving devices")
+	}
+
+	var devices []Device
+	if err := json.NewDecoder(resp.Body).Decode(&devices); err != nil {
+		return nil, fmt.Errorf("decode devices response: %w", err)
+	}
+
+	return devices, nil
+}
+
+// Parsed device state tables available from GetDeviceState.
+const (
+	DeviceStateARP               = "arp"
+	DeviceStateMAC               = "mac"
+	DeviceStateRoutes            = "routes"
+	DeviceStateInterfaceCounters = "interfaceCounters"
+)
+
+// GetDeviceState retrieves one parsed state table of a device in a snapshot. The rows
+// are returned undecoded because their shape differs per table and vendor.
+func (c *Client) GetDeviceState(ctx context.Context, snapshotID, deviceName, table string) (json.RawMessage, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	deviceName = strings.TrimSpace(deviceName)
+	if snapshotID == "" || deviceName == "" || table == "" {
+		return nil, fmt.Errorf("snapshotID, deviceName, and table must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/devices/%s/state/%s", url.PathEscape(snapshotID), url.PathEscape(deviceName), url.PathEscape(table))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute device state request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving device state")
+	}
+
+	var rows json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("decode device state response: %w", err)
+	}
+
+	return rows, nil
+}
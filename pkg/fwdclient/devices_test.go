@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListSnapshotDevices(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/devices" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"name":"leaf1","type":"SWITCH","vendor":"ARISTA","osVersion":"4.28","managementIps":["10.0.0.1"]}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	devices, err := client.ListSnapshotDevices(context.Background(), "snap-1")
+	if err != nil {
+		t.Fatalf("ListSnapshotDevices error: %v", err)
+	}
+	if len(devices) != 1 || devices[0].Name != "leaf1" || devices[0].ManagementIPs[0] != "10.0.0.1" {
+		t.Fatalf("unexpected devices: %#v", devices)
+	}
+}
+
+func TestGetDeviceState(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/devices/leaf1/state/arp" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"ip": "10.0.0.2", "mac": "00:00:5e:00:53:01", "interface": "Ethernet1"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	rows, err := client.GetDeviceState(context.Background(), "snap-1", "leaf1", DeviceStateARP)
+	if err != nil {
+		t.Fatalf("GetDeviceState error: %v", err)
+	}
+	if !strings.Contains(string(rows), `"10.0.0.2"`) {
+		t.Fatalf("unexpected rows: %s", rows)
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fwdclient is a Go client for the Forward Enterprise REST API, shared by the
+// Terraform provider and any custom tooling that imports it.
+//
+// A Client is safe for concurrent use. It retries failed requests with backoff, can
+// bound in-flight requests and stop calling a failing appliance (see Config), and
+// decodes large responses as streams. Errors returned for non-success responses are
+// *APIError values; use errors.As to inspect them.
+//
+// Exported identifiers follow the provider's semantic version and only change
+// incompatibly in a major release.
+package fwdclient
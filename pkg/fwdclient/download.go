@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultDownloadMaxResumes = 3
+
+	// ChecksumHeader carries the hex SHA-256 checksum of a downloaded file, when the
+	// appliance reports one.
+	ChecksumHeader = "X-Checksum-Sha256"
+)
+
+// DownloadOptions tunes Download.
+type DownloadOptions struct {
+	// SHA256 is the expected hex checksum of the content. When empty, the checksum in the
+	// response's ChecksumHeader is verified instead, if present.
+	SHA256 string
+	// Accept is the media type requested. Defaults to any.
+	Accept string
+	// MaxResumes is how many times a body that ends early is resumed with a Range
+	// request from the last byte written. Defaults to 3; a negative value disables
+	// resuming.
+	MaxResumes int
+	// Progress, when set, is called as content is written with the bytes written so far
+	// and the total size, or -1 when the appliance does not report it.
+	Progress func(written, total int64)
+}
+
+// DownloadResult describes a completed download.
+type DownloadResult struct {
+	Bytes  int64
+	SHA256 string
+}
+
+// Download streams the response body of a GET to path into w without holding it in
+// memory, resuming from the last byte written when the body ends early, and verifies
+// its SHA-256 checksum. Bytes written before a failure are not retracted, so w should
+// be discarded on error. Wrap ctx with WithTimeout for downloads that outlast the
+// client's timeout.
+func (c *Client) Download(ctx context.Context, path string, w io.Writer, opts DownloadOptions) (*DownloadResult, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("path must be provided")
+	}
+	if w == nil {
+		return nil, fmt.Errorf("writer must be provided")
+	}
+
+	maxResumes := opts.MaxResumes
+	if maxResumes == 0 {
+		maxResumes = defaultDownloadMaxResumes
+	}
+	accept := opts.Accept
+	if accept == "" {
+		accept = "*/*"
+	}
+
+	hash := sha256.New()
+	sink := &downloadSink{w: io.MultiWriter(w, hash), total: -1, progress: opts.Progress}
+	expected := strings.ToLower(strings.TrimSpace(opts.SHA256))
+
+	for resumes := 0; ; resumes++ {
+		req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", accept)
+		if sink.written > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", sink.written))
+		}
+
+		resp, err := c.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("execute download request: %w", err)
+		}
+
+		switch {
+		case sink.written == 0 && resp.StatusCode == http.StatusOK:
+			sink.total = resp.ContentLength
+			if expected == "" {
+				expected = strings.ToLower(strings.TrimSpace(resp.Header.Get(ChecksumHeader)))
+			}
+		case sink.written > 0 && resp.StatusCode == http.StatusPartialContent:
+			if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", sink.written)) {
+				resp.Body.Close()
+				return nil, fmt.Errorf("resume download at byte %d: unexpected content range %q", sink.written, resp.Header.Get("Content-Range"))
+			}
+		case sink.written > 0 && resp.StatusCode == http.StatusOK:
+			resp.Body.Close()
+			return nil, fmt.Errorf("resume download at byte %d: the appliance does not support range requests", sink.written)
+		default:
+			err := newAPIError(resp, "downloading")
+			resp.Body.Close()
+			return nil, err
+		}
+
+		_, err = io.Copy(sink, resp.Body)
+		resp.Body.Close()
+		if err == nil && sink.total >= 0 && sink.written < sink.total {
+			err = io.ErrUnexpectedEOF
+		}
+		if err == nil {
+			break
+		}
+		if sink.err != nil {
+			return nil, fmt.Errorf("write download: %w", sink.err)
+		}
+		if resumes >= maxResumes || ctx.Err() != nil {
+			return nil, fmt.Errorf("read download body after %d bytes: %w", sink.written, err)
+		}
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if expected != "" && sum != expected {
+		return nil, fmt.Errorf("download checksum mismatch: expected SHA-256 %s, got %s", expected, sum)
+	}
+
+	return &DownloadResult{Bytes: sink.written, SHA256: sum}, nil
+}
+
+// downloadSink counts the bytes written through it and keeps the first write error, so
+// a failing writer is not mistaken for a dropped connection.
+type downloadSink struct {
+	w        io.Writer
+	written  int64
+	total    int64
+	err      error
+	progress func(written, total int64)
+}
+
+func (s *downloadSink) Write(p []byte) (int, error) {
+	n, err := s.w.Write(p)
+	s.written += int64(n)
+	if err != nil && s.err == nil {
+		s.err = err
+	}
+	if n > 0 && s.progress != nil {
+		s.progress(s.written, s.total)
+	}
+	return n, err
+}
+
+// ExportSnapshot streams a snapshot archive into w.
+func (c *Client) ExportSnapshot(ctx context.Context, snapshotID string, w io.Writer, opts DownloadOptions) (*DownloadResult, error) {
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	if opts.Accept == "" {
+		opts.Accept = "application/zip"
+	}
+	return c.Download(ctx, fmt.Sprintf("/api/snapshots/%s", url.PathEscape(snapshotID)), w, opts)
+}
+
+// GetDeviceFile streams one of the files collected from a device, such as its
+// configuration, into w.
+func (c *Client) GetDeviceFile(ctx context.Context, snapshotID, deviceName, fileName string, w io.Writer, opts DownloadOptions) (*DownloadResult, error) {
+	snapshotID = strings.TrimSpace(snapshotID)
+	deviceName = strings.TrimSpace(deviceName)
+	fileName = strings.TrimSpace(fileName)
+	if snapshotID == "" || deviceName == "" || fileName == "" {
+		return nil, fmt.Errorf("snapshotID, deviceName, and fileName must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/devices/%s/files/%s", url.PathEscape(snapshotID), url.PathEscape(deviceName), url.PathEscape(fileName))
+	return c.Download(ctx, path, w, opts)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestClient_DownloadResumesAndVerifies(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(strings.Repeat("hostname edge-1\n", 64))
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/devices/edge-1/files/configuration" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		if rng := r.Header.Get("Range"); rng != "" {
+			start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(content[start:])
+			return
+		}
+		// Announce the full length but stop halfway, like a dropped connection.
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Header().Set(ChecksumHeader, checksum)
+		_, _ = w.Write(content[:len(content)/2])
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	var buf bytes.Buffer
+	result, err := client.GetDeviceFile(context.Background(), "snap-1", "edge-1", "configuration", &buf, DownloadOptions{})
+	if err != nil {
+		t.Fatalf("GetDeviceFile: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), content) || result.SHA256 != checksum || result.Bytes != int64(len(content)) {
+		t.Fatalf("unexpected download %+v of %d bytes", result, buf.Len())
+	}
+	if len(ranges) != 2 || ranges[1] != fmt.Sprintf("bytes=%d-", len(content)/2) {
+		t.Fatalf("unexpected range requests: %q", ranges)
+	}
+}
+
+func TestClient_DownloadChecksumMismatch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/zip" {
+			t.Errorf("unexpected Accept header: %s", r.Header.Get("Accept"))
+		}
+		_, _ = w.Write([]byte("archive"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	var buf bytes.Buffer
+	_, err = client.ExportSnapshot(context.Background(), "snap-1", &buf, DownloadOptions{SHA256: strings.Repeat("0", 64)})
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// secretPatterns match credentials an appliance or proxy may echo back in an error body:
+// authorization schemes, key/value pairs with credential-like keys (JSON, query string,
+// or header style, including JSON escaped inside strings), and user info in URLs.
+var secretPatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`), "$1 " + redactedValue},
+	{regexp.MustCompile(`(?i)\b((?:api[_-]?key|access[_-]?token|refresh[_-]?token|token|secret|password|passwd|signature|x-amz-credential|x-amz-signature)\\?"?\s*[:=]\s*\\?"?)[^"\\&\s,;}]+`), "${1}" + redactedValue},
+	{regexp.MustCompile(`(?i)\b(https?://)[^/\s:@]+:[^/\s@]+@`), "${1}" + redactedValue + "@"},
+}
+
+// APIError describes a non-success response returned by the Forward Enterprise API.
+type APIError struct {
+	StatusCode int
+	// Operation describes what the client was doing, for example "retrieving checks".
+	Operation string
+	Method    string
+	Path      string
+	// Code, Message, Details, and Permission are parsed from the Forward JSON error
+	// envelope when present. Permission names the permission a forbidden request lacked.
+	Code       string
+	Message    string
+	Details    []APIErrorDetail
+	Permission string
+	Body       string
+}
+
+// APIErrorDetail describes an individual problem reported in a Forward error envelope.
+// Field names the offending request field (for example "definition.queryId") when known.
+type APIErrorDetail struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// UnmarshalJSON accepts either a detail object or a bare string message.
+func (d *APIErrorDetail) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		d.Message = message
+		return nil
+	}
+
+	type detail APIErrorDetail
+	var decoded detail
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*d = APIErrorDetail(decoded)
+	return nil
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status %d %s: %s", e.StatusCode, e.Operation, e.Body)
+}
+
+// IsNotFound reports whether err wraps an APIError carrying a 404 status.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err wraps an APIError carrying a 401 status.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err wraps an APIError carrying a 403 status.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotSupported reports whether err wraps an APIError carrying a 405 or 501 status,
+// returned by appliances whose release lacks the endpoint or method.
+func IsNotSupported(err error) bool {
+	return hasStatus(err, http.StatusMethodNotAllowed) || hasStatus(err, http.StatusNotImplemented)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// redactSecrets replaces every occurrence of secrets, and anything matching
+// secretPatterns, so error text can be shown in diagnostics and CI logs.
+func redactSecrets(value string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			value = strings.ReplaceAll(value, secret, redactedValue)
+		}
+	}
+	for _, p := range secretPatterns {
+		value = p.pattern.ReplaceAllString(value, p.replacement)
+	}
+	return value
+}
+
+// newAPIError captures a bounded copy of the response body into an APIError. Secrets,
+// including the API key the request was sent with, are redacted from the body.
+func newAPIError(resp *http.Response, operation string) error {
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<14))
+
+	var apiKey string
+	if resp.Request != nil {
+		apiKey = strings.TrimPrefix(resp.Request.Header.Get("Authorization"), "Bearer ")
+	}
+	body := []byte(redactSecrets(string(raw), apiKey))
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Operation:  operation,
+		Body:       strings.TrimSpace(string(body)),
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			apiErr.Path = resp.Request.URL.Path
+		}
+	}
+
+	var payload struct {
+		Code               string          `json:"code"`
+		Message            string          `json:"message"`
+		Details            json.RawMessage `json:"details"`
+		RequiredPermission string          `json:"requiredPermission"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		apiErr.Code = strings.TrimSpace(payload.Code)
+		apiErr.Message = strings.TrimSpace(payload.Message)
+		apiErr.Permission = strings.TrimSpace(payload.RequiredPermission)
+		// Details are best effort; an unexpected shape must not hide the message.
+		_ = json.Unmarshal(payload.Details, &apiErr.Details)
+	}
+
+	return apiErr
+}
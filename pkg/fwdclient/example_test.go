@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/forwardnetworks/terraform-provider-forward/pkg/fwdclient"
+)
+
+func ExampleClient_GetLatestProcessedSnapshot() {
+	ctx := context.Background()
+	client, err := fwdclient.NewClient(ctx, fwdclient.Config{
+		BaseURL: "https://fwd.example.com",
+		APIKey:  "token",
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	snapshot, err := client.GetLatestProcessedSnapshot(ctx, "net-1")
+	var apiErr *fwdclient.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		fmt.Println("no processed snapshot yet")
+		return
+	}
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(snapshot.ID)
+}
@@ -0,0 +1,613 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CheckDefinition represents the underlying definition payload for an intent check.
+type CheckDefinition map[string]any
+
+// maxSeconds returns the search time limit of a path-based check definition, if any.
+func (d CheckDefinition) maxSeconds() (int64, bool) {
+	switch v := d["maxSeconds"].(type) {
+	case int:
+		return int64(v), v > 0
+	case int64:
+		return v, v > 0
+	case float64:
+		return int64(v), v > 0
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil && n > 0
+	}
+	return 0, false
+}
+
+// NewCheckRequest models the payload to create a new check.
+type NewCheckRequest struct {
+	Definition            CheckDefinition `json:"definition"`
+	Description           string          `json:"description,omitempty"`
+	Enabled               *bool           `json:"enabled,omitempty"`
+	Name                  string          `json:"name,omitempty"`
+	Note                  string          `json:"note,omitempty"`
+	PerfMonitoringEnabled *bool           `json:"perfMonitoringEnabled,omitempty"`
+	Priority              string          `json:"priority,omitempty"`
+	Tags                  []string        `json:"tags,omitempty"`
+}
+
+// CheckUpdateRequest models the mutable fields of an existing check. Nil fields are left unchanged.
+type CheckUpdateRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Note        *string `json:"note,omitempty"`
+	Priority    *string `json:"priority,omitempty"`
+	// Tags replaces the check's tags; nil leaves them unchanged.
+	Tags                  []string `json:"tags,omitempty"`
+	Enabled               *bool    `json:"enabled,omitempty"`
+	PerfMonitoringEnabled *bool    `json:"perfMonitoringEnabled,omitempty"`
+	// Persistent promotes an ad-hoc snapshot check to one carried into future snapshots,
+	// or stops carrying it forward.
+	Persistent *bool `json:"persistent,omitempty"`
+}
+
+// CheckOwnerRequest reassigns a check to another user, identified by user ID or username.
+type CheckOwnerRequest struct {
+	Owner string `json:"owner"`
+}
+
+// CheckExecutionRequest selects the checks to re-execute. An empty list selects every
+// check on the snapshot.
+type CheckExecutionRequest struct {
+	CheckIDs []string `json:"checkIds,omitempty"`
+}
+
+// CheckResult represents the outcome of a Forward Enterprise intent check execution.
+type CheckResult struct {
+	ID                    string          `json:"id"`
+	Name                  string          `json:"name"`
+	Status                string          `json:"status"`
+	Priority              string          `json:"priority"`
+	Description           string          `json:"description"`
+	Note                  string          `json:"note"`
+	Creator               string          `json:"creator"`
+	CreatorID             string          `json:"creatorId"`
+	Editor                string          `json:"editor"`
+	EditorID              string          `json:"editorId"`
+	Enabled               *bool           `json:"enabled"`
+	PerfMonitoringEnabled *bool           `json:"perfMonitoringEnabled"`
+	Tags                  []string        `json:"tags"`
+	NumViolations         *int64          `json:"numViolations"`
+	CreationDateMillis    *int64          `json:"creationDateMillis"`
+	DefinitionDateMillis  *int64          `json:"definitionDateMillis"`
+	EditDateMillis        *int64          `json:"editDateMillis"`
+	ExecutionDateMillis   *int64          `json:"executionDateMillis"`
+	ExecutionDuration     *int64          `json:"executionDurationMillis"`
+	Definition            json.RawMessage `json:"definition"`
+}
+
+// CheckListOptions controls filtering when listing intent checks.
+type CheckListOptions struct {
+	Types      []string
+	Statuses   []string
+	Priorities []string
+
+	// Offset skips that many matching checks and Limit caps how many are returned, so
+	// large snapshots can be read a page at a time. Zero values return every check.
+	Offset int
+	Limit  int
+}
+
+// CheckResultWithDiagnosis includes diagnosis metadata for a single check lookup.
+type CheckResultWithDiagnosis struct {
+	CheckResult
+	Diagnosis *CheckDiagnosis `json:"diagnosis"`
+}
+
+// CheckDiagnosis captures the violation/details for a failed check.
+type CheckDiagnosis struct {
+	Summary           string            `json:"summary"`
+	Details           []DiagnosisDetail `json:"details"`
+	DetailsIncomplete *bool             `json:"detailsIncomplete"`
+}
+
+// DiagnosisDetail enumerates detailed findings for a violation.
+type DiagnosisDetail struct {
+	Query      string               `json:"query"`
+	References []DiagnosisReference `json:"references"`
+}
+
+// DiagnosisReference links a diagnosis detail to specific devices/files.
+type DiagnosisReference struct {
+	Key   string                 `json:"key"`
+	Value string                 `json:"value"`
+	Files map[string][]LineRange `json:"files"`
+}
+
+// LineRange indicates a span within a device file.
+type LineRange struct {
+	Start *int32 `json:"start,omitempty"`
+	End   *int32 `json:"end,omitempty"`
+}
+
+// ListSnapshotChecks retrieves check results for the specified snapshot.
+func (c *Client) ListSnapshotChecks(ctx context.Context, snapshotID string, opts CheckListOptions) ([]CheckResult, error) {
+	var checks []CheckResult
+	err := c.ForEachSnapshotCheck(ctx, snapshotID, opts, func(check *CheckResult) error {
+		checks = append(checks, *check)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+// ForEachSnapshotCheck streams the check results of a snapshot to fn as they are
+// decoded, without buffering the response. It stops at the first error fn returns.
+func (c *Client) ForEachSnapshotCheck(ctx context.Context, snapshotID string, opts CheckListOptions, fn func(*CheckResult) error) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return fmt.Errorf("snapshotID must be provided")
+	}
+
+	escapedID := url.PathEscape(snapshotID)
+	path := fmt.Sprintf("/api/snapshots/%s/checks", escapedID)
+
+	query := url.Values{}
+	for _, status := range opts.Statuses {
+		status = strings.TrimSpace(status)
+		if status != "" {
+			query.Add("status", status)
+		}
+	}
+	for _, priority := range opts.Priorities {
+		priority = strings.TrimSpace(priority)
+		if priority != "" {
+			query.Add("priority", priority)
+		}
+	}
+	for _, checkType := range opts.Types {
+		checkType = strings.TrimSpace(checkType)
+		if checkType != "" {
+			query.Add("type", checkType)
+		}
+	}
+	if opts.Offset > 0 {
+		query.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	if encoded := query.Encode(); encoded != "" {
+		path = path + "?" + encoded
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// continue
+	default:
+		return newAPIError(resp, "retrieving checks")
+	}
+
+	if err := decodeArray(resp.Body, fn); err != nil {
+		return fmt.Errorf("decode checks response: %w", err)
+	}
+
+	return nil
+}
+
+// AddSnapshotCheck creates a new intent check for the specified snapshot.
+func (c *Client) AddSnapshotCheck(ctx context.Context, snapshotID string, reqBody NewCheckRequest, persistent *bool) (*CheckResult, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	if reqBody.Definition == nil {
+		return nil, fmt.Errorf("definition must be provided")
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal check payload: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/checks", url.PathEscape(snapshotID))
+	if persistent != nil {
+		params := url.Values{}
+		params.Set("persistent", fmt.Sprintf("%t", *persistent))
+		path = path + "?" + params.Encode()
+	}
+
+	// The check is evaluated as it is created, for up to its maxSeconds when set.
+	if seconds, ok := reqBody.Definition.maxSeconds(); ok {
+		ctx = WithTimeout(ctx, time.Duration(seconds)*time.Second+serverTimeoutMargin)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("create check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "creating check")
+	}
+
+	var result CheckResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode create check response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetSnapshotCheck retrieves a specific check by ID for the given snapshot.
+func (c *Client) GetSnapshotCheck(ctx context.Context, snapshotID, checkID string) (*CheckResultWithDiagnosis, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	checkID = strings.TrimSpace(checkID)
+	if snapshotID == "" || checkID == "" {
+		return nil, fmt.Errorf("snapshotID and checkID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/checks/%s", url.PathEscape(snapshotID), url.PathEscape(checkID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving check")
+	}
+
+	var result CheckResultWithDiagnosis
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode check response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// UpdateSnapshotCheck applies in-place changes to a specific check for the given snapshot.
+func (c *Client) UpdateSnapshotCheck(ctx context.Context, snapshotID, checkID string, reqBody CheckUpdateRequest) (*CheckResult, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	checkID = strings.TrimSpace(checkID)
+	if snapshotID == "" || checkID == "" {
+		return nil, fmt.Errorf("snapshotID and checkID must be provided")
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal check update payload: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/checks/%s", url.PathEscape(snapshotID), url.PathEscape(checkID))
+	req, err := c.NewRequest(ctx, http.MethodPatch, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "updating check")
+	}
+
+	var result CheckResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode update check response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// TransferSnapshotCheckOwner makes owner the creator of record for a specific check.
+// Requires administrator permissions.
+func (c *Client) TransferSnapshotCheckOwner(ctx context.Context, snapshotID, checkID, owner string) (*CheckResult, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	checkID = strings.TrimSpace(checkID)
+	owner = strings.TrimSpace(owner)
+	if snapshotID == "" || checkID == "" {
+		return nil, fmt.Errorf("snapshotID and checkID must be provided")
+	}
+	if owner == "" {
+		return nil, fmt.Errorf("owner must be provided")
+	}
+
+	bodyBytes, err := json.Marshal(CheckOwnerRequest{Owner: owner})
+	if err != nil {
+		return nil, fmt.Errorf("marshal check owner payload: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/checks/%s/owner", url.PathEscape(snapshotID), url.PathEscape(checkID))
+	req, err := c.NewRequest(ctx, http.MethodPut, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("transfer check owner request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "transferring check owner")
+	}
+
+	var result CheckResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode check owner response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// DeactivateSnapshotCheck disables a specific check for a snapshot.
+func (c *Client) DeactivateSnapshotCheck(ctx context.Context, snapshotID, checkID string) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	checkID = strings.TrimSpace(checkID)
+	if snapshotID == "" || checkID == "" {
+		return fmt.Errorf("snapshotID and checkID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/checks/%s", url.PathEscape(snapshotID), url.PathEscape(checkID))
+	req, err := c.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("deactivate check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp, "deactivating check")
+	}
+
+	return nil
+}
+
+// CheckWebhook is an HTTP endpoint Forward calls whenever a check fails, for example
+// to launch a remediation job.
+type CheckWebhook struct {
+	URL string `json:"url"`
+}
+
+// GetCheckWebhook retrieves the webhook called when a check fails. It returns a not
+// found error when the check has no webhook.
+func (c *Client) GetCheckWebhook(ctx context.Context, snapshotID, checkID string) (*CheckWebhook, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	checkID = strings.TrimSpace(checkID)
+	if snapshotID == "" || checkID == "" {
+		return nil, fmt.Errorf("snapshotID and checkID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/checks/%s/webhook", url.PathEscape(snapshotID), url.PathEscape(checkID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve check webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving check webhook")
+	}
+
+	var webhook CheckWebhook
+	if err := json.NewDecoder(resp.Body).Decode(&webhook); err != nil {
+		return nil, fmt.Errorf("decode check webhook response: %w", err)
+	}
+
+	return &webhook, nil
+}
+
+// SetCheckWebhook registers the webhook called when a check fails, replacing any
+// existing one.
+func (c *Client) SetCheckWebhook(ctx context.Context, snapshotID, checkID string, webhook CheckWebhook) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	checkID = strings.TrimSpace(checkID)
+	if snapshotID == "" || checkID == "" {
+		return fmt.Errorf("snapshotID and checkID must be provided")
+	}
+
+	bodyBytes, err := json.Marshal(webhook)
+	if err != nil {
+		return fmt.Errorf("marshal check webhook payload: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/checks/%s/webhook", url.PathEscape(snapshotID), url.PathEscape(checkID))
+	req, err := c.NewRequest(ctx, http.MethodPut, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("set check webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp, "setting check webhook")
+	}
+
+	return nil
+}
+
+// DeleteCheckWebhook removes the webhook of a check. A check without a webhook is not
+// an error.
+func (c *Client) DeleteCheckWebhook(ctx context.Context, snapshotID, checkID string) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	checkID = strings.TrimSpace(checkID)
+	if snapshotID == "" || checkID == "" {
+		return fmt.Errorf("snapshotID and checkID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/checks/%s/webhook", url.PathEscape(snapshotID), url.PathEscape(checkID))
+	req, err := c.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete check webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return newAPIError(resp, "deleting check webhook")
+	}
+}
+
+// DeactivateSnapshotChecks disables all checks for a snapshot.
+func (c *Client) DeactivateSnapshotChecks(ctx context.Context, snapshotID string) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/checks", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("deactivate checks request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp, "deactivating checks")
+	}
+
+	return nil
+}
+
+// ExecuteSnapshotChecks starts re-executing checks on a snapshot, or every check when
+// checkIDs is empty. It returns once execution is queued; poll ListSnapshotChecks until
+// the checks report a final status.
+func (c *Client) ExecuteSnapshotChecks(ctx context.Context, snapshotID string, checkIDs []string) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return fmt.Errorf("snapshotID must be provided")
+	}
+
+	bodyBytes, err := json.Marshal(CheckExecutionRequest{CheckIDs: checkIDs})
+	if err != nil {
+		return fmt.Errorf("marshal check execution payload: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/checks/executions", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute checks request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return newAPIError(resp, "executing checks")
+	}
+
+	return nil
+}
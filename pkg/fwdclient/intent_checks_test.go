@@ -0,0 +1,397 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_AddSnapshotCheck(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/checks" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		var payload NewCheckRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload.Definition["checkType"] != "NQE" {
+			t.Fatalf("unexpected definition: %#v", payload.Definition)
+		}
+		_ = json.NewEncoder(w).Encode(CheckResult{ID: "check-1", Status: "PASS"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	req := NewCheckRequest{
+		Definition: CheckDefinition{"checkType": "NQE", "queryId": "FQ_test"},
+	}
+	result, err := client.AddSnapshotCheck(context.Background(), "snap-1", req, nil)
+	if err != nil {
+		t.Fatalf("AddSnapshotCheck returned error: %v", err)
+	}
+	if result == nil || result.ID != "check-1" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestClient_GetSnapshotCheck(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/checks/check-1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(CheckResultWithDiagnosis{CheckResult: CheckResult{ID: "check-1"}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	result, err := client.GetSnapshotCheck(context.Background(), "snap-1", "check-1")
+	if err != nil {
+		t.Fatalf("GetSnapshotCheck returned error: %v", err)
+	}
+	if result == nil || result.ID != "check-1" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestClient_UpdateSnapshotCheck(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/checks/check-1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPatch {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		var payload CheckUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload.Name == nil || *payload.Name != "renamed" || payload.Persistent == nil || !*payload.Persistent {
+			t.Fatalf("unexpected payload: %#v", payload)
+		}
+		_ = json.NewEncoder(w).Encode(CheckResult{ID: "check-1", Name: *payload.Name})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	name, persistent := "renamed", true
+	result, err := client.UpdateSnapshotCheck(context.Background(), "snap-1", "check-1", CheckUpdateRequest{Name: &name, Persistent: &persistent})
+	if err != nil {
+		t.Fatalf("UpdateSnapshotCheck returned error: %v", err)
+	}
+	if result == nil || result.ID != "check-1" || result.Name != "renamed" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestClient_TransferSnapshotCheckOwner(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/checks/check-1/owner" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPut {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		var payload CheckOwnerRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload.Owner != "alice" {
+			t.Fatalf("unexpected payload: %#v", payload)
+		}
+		_ = json.NewEncoder(w).Encode(CheckResult{ID: "check-1", Creator: "alice", CreatorID: "u-1"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	result, err := client.TransferSnapshotCheckOwner(context.Background(), "snap-1", "check-1", " alice ")
+	if err != nil {
+		t.Fatalf("TransferSnapshotCheckOwner returned error: %v", err)
+	}
+	if result == nil || result.Creator != "alice" || result.CreatorID != "u-1" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+
+	if _, err := client.TransferSnapshotCheckOwner(context.Background(), "snap-1", "check-1", " "); err == nil {
+		t.Fatal("expected error for empty owner")
+	}
+}
+
+func TestClient_DeactivateSnapshotCheck(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/api/snapshots/snap-1/checks/check-1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	if err := client.DeactivateSnapshotCheck(context.Background(), "snap-1", "check-1"); err != nil {
+		t.Fatalf("DeactivateSnapshotCheck returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestClient_DeactivateSnapshotChecks(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/api/snapshots/snap-1/checks" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	if err := client.DeactivateSnapshotChecks(context.Background(), "snap-1"); err != nil {
+		t.Fatalf("DeactivateSnapshotChecks returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestClient_ExecuteSnapshotChecks(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/checks/executions" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		var body CheckExecutionRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(body.CheckIDs) != 1 || body.CheckIDs[0] != "check-1" {
+			t.Fatalf("unexpected check IDs: %v", body.CheckIDs)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	if err := client.ExecuteSnapshotChecks(context.Background(), "snap-1", []string{"check-1"}); err != nil {
+		t.Fatalf("ExecuteSnapshotChecks returned error: %v", err)
+	}
+}
+
+func TestClient_ListSnapshotChecksPaging(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("offset"); got != "20" {
+			t.Errorf("unexpected offset: %q", got)
+		}
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("unexpected limit: %q", got)
+		}
+		_, _ = w.Write([]byte(`[{"id":"check-21","status":"PASS"},{"id":"check-22","status":"FAIL"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	checks, err := client.ListSnapshotChecks(context.Background(), "snap-1", CheckListOptions{Offset: 20, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListSnapshotChecks returned error: %v", err)
+	}
+	if len(checks) != 2 || checks[0].ID != "check-21" || checks[1].Status != "FAIL" {
+		t.Fatalf("unexpected checks: %#v", checks)
+	}
+}
+
+func TestClient_ForEachSnapshotCheck(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query, got %q", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[{"id":"a"},{"id":"b"},{"id":"c"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	var seen []string
+	stop := errors.New("stop")
+	err = client.ForEachSnapshotCheck(context.Background(), "snap-1", CheckListOptions{}, func(check *CheckResult) error {
+		seen = append(seen, check.ID)
+		if check.ID == "b" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected the callback error, got %v", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected decoding to stop after the second check, saw %q", seen)
+	}
+}
+
+func TestDecodeArray(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		body    string
+		want    int
+		wantErr bool
+	}{
+		"empty":     {body: `[]`, want: 0},
+		"null":      {body: `null`, want: 0},
+		"items":     {body: `[{"id":"a"},{"id":"b"}]`, want: 2},
+		"object":    {body: `{"id":"a"}`, wantErr: true},
+		"truncated": {body: `[{"id":"a"},{"id"`, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		count := 0
+		err := decodeArray(strings.NewReader(tc.body), func(*CheckResult) error {
+			count++
+			return nil
+		})
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if !tc.wantErr && count != tc.want {
+			t.Errorf("%s: decoded %d items, want %d", name, count, tc.want)
+		}
+	}
+}
+
+func TestClient_CheckWebhook(t *testing.T) {
+	t.Parallel()
+
+	var registered string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/checks/check-1/webhook" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			var webhook CheckWebhook
+			if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			registered = webhook.URL
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			// Deleting a webhook that does not exist is not an error.
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	if err := client.SetCheckWebhook(context.Background(), "snap-1", "check-1", CheckWebhook{URL: "https://hooks.example/fix"}); err != nil {
+		t.Fatalf("SetCheckWebhook error: %v", err)
+	}
+	if registered != "https://hooks.example/fix" {
+		t.Fatalf("unexpected webhook: %q", registered)
+	}
+	if err := client.DeleteCheckWebhook(context.Background(), "snap-1", "check-1"); err != nil {
+		t.Fatalf("DeleteCheckWebhook error: %v", err)
+	}
+}
+
+func TestCheckDefinitionMaxSeconds(t *testing.T) {
+	t.Parallel()
+
+	var decoded CheckDefinition
+	if err := json.Unmarshal([]byte(`{"checkType": "Existential", "maxSeconds": 120}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for name, tc := range map[string]struct {
+		definition CheckDefinition
+		want       int64
+		ok         bool
+	}{
+		"decoded": {decoded, 120, true},
+		"int":     {CheckDefinition{"maxSeconds": 90}, 90, true},
+		"missing": {CheckDefinition{"checkType": "NQE"}, 0, false},
+		"zero":    {CheckDefinition{"maxSeconds": 0}, 0, false},
+	} {
+		if got, ok := tc.definition.maxSeconds(); got != tc.want || ok != tc.ok {
+			t.Errorf("%s: got %d, %v; want %d, %v", name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// KubernetesInventory lists the Kubernetes clusters modeled in a snapshot. It is empty
+// when the network has no Kubernetes collection configured.
+type KubernetesInventory struct {
+	Clusters []KubernetesCluster `json:"clusters"`
+}
+
+// KubernetesCluster is a Kubernetes cluster with its nodes, services, and network
+// policies.
+type KubernetesCluster struct {
+	Name string `json:"name"`
+	// Distribution is the managed offering or distribution, for example EKS, AKS, GKE,
+	// or OPENSHIFT.
+	Distribution    string                    `json:"distribution"`
+	Version         string                    `json:"version"`
+	Nodes           []KubernetesNode          `json:"nodes"`
+	Services        []KubernetesService       `json:"services"`
+	NetworkPolicies []KubernetesNetworkPolicy `json:"networkPolicies"`
+}
+
+// KubernetesNode is a cluster node.
+type KubernetesNode struct {
+	Name       string `json:"name"`
+	InternalIP string `json:"internalIp"`
+	PodCIDR    string `json:"podCidr"`
+	Ready      bool   `json:"ready"`
+}
+
+// KubernetesService is a cluster service.
+type KubernetesService struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	ClusterIP string   `json:"clusterIp"`
+	Ports     []string `json:"ports"`
+}
+
+// KubernetesNetworkPolicy is a namespaced network policy.
+type KubernetesNetworkPolicy struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	PodSelector string `json:"podSelector"`
+	// PolicyTypes lists the directions the policy restricts: Ingress, Egress, or both.
+	PolicyTypes []string `json:"policyTypes"`
+}
+
+// GetKubernetesInventory retrieves the Kubernetes clusters modeled in a snapshot.
+func (c *Client) GetKubernetesInventory(ctx context.Context, snapshotID string) (*KubernetesInventory, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/kubernetes", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute kubernetes inventory request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving kubernetes inventory")
+	}
+
+	var inventory KubernetesInventory
+	if err := json.NewDecoder(resp.Body).Decode(&inventory); err != nil {
+		return nil, fmt.Errorf("decode kubernetes inventory response: %w", err)
+	}
+
+	return &inventory, nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetKubernetesInventory(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/kubernetes" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"clusters":[{"name":"prod","distribution":"EKS","nodes":[{"name":"node-1","internalIp":"10.0.1.10","ready":true}],"services":[{"namespace":"web","name":"frontend","type":"LoadBalancer","ports":["443/TCP"]}],"networkPolicies":[{"namespace":"web","name":"deny-all","policyTypes":["Ingress"]}]}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	inventory, err := client.GetKubernetesInventory(context.Background(), "snap-1")
+	if err != nil {
+		t.Fatalf("GetKubernetesInventory error: %v", err)
+	}
+	if len(inventory.Clusters) != 1 {
+		t.Fatalf("unexpected clusters: %#v", inventory.Clusters)
+	}
+	cluster := inventory.Clusters[0]
+	if !cluster.Nodes[0].Ready || cluster.Services[0].Ports[0] != "443/TCP" || cluster.NetworkPolicies[0].PolicyTypes[0] != "Ingress" {
+		t.Fatalf("unexpected cluster: %#v", cluster)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// VirtualServer describes a load balancer virtual server (VIP) modeled in a snapshot.
+type VirtualServer struct {
+	DeviceName string       `json:"deviceName"`
+	Name       string       `json:"name"`
+	Address    string       `json:"address"`
+	Port       *int64       `json:"port"`
+	Protocol   string       `json:"protocol"`
+	State      string       `json:"state"`
+	Pool       string       `json:"pool"`
+	Members    []PoolMember `json:"members"`
+}
+
+// PoolMember describes a backend server of a load balancer pool.
+type PoolMember struct {
+	Address string `json:"address"`
+	Port    *int64 `json:"port"`
+	State   string `json:"state"`
+}
+
+// ListVirtualServers retrieves the load balancer virtual servers of a snapshot.
+func (c *Client) ListVirtualServers(ctx context.Context, snapshotID string) ([]VirtualServer, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/loadBalancers/virtualServers", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute virtual servers request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving virtual servers")
+	}
+
+	var servers []VirtualServer
+	if err := json.NewDecoder(resp.Body).Decode(&servers); err != nil {
+		return nil, fmt.Errorf("decode virtual servers response: %w", err)
+	}
+
+	return servers, nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListVirtualServers(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/loadBalancers/virtualServers" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"deviceName":"lb1","name":"web","address":"192.0.2.10","port":443,"pool":"web-pool","members":[{"address":"10.0.0.11","port":8443,"state":"UP"}]}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	servers, err := client.ListVirtualServers(context.Background(), "snap-1")
+	if err != nil {
+		t.Fatalf("ListVirtualServers error: %v", err)
+	}
+	if len(servers) != 1 || *servers[0].Port != 443 || servers[0].Members[0].State != "UP" {
+		t.Fatalf("unexpected virtual servers: %#v", servers)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Network describes a Forward Enterprise network.
+type Network struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	OrgID string `json:"orgId"`
+}
+
+// ListNetworks retrieves every network the API key can access.
+func (c *Client) ListNetworks(ctx context.Context) ([]Network, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, "/api/networks", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute networks request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving networks")
+	}
+
+	var networks []Network
+	if err := json.NewDecoder(resp.Body).Decode(&networks); err != nil {
+		return nil, fmt.Errorf("decode networks response: %w", err)
+	}
+
+	return networks, nil
+}
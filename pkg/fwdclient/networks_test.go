@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListNetworksAndLatestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/networks":
+			_ = json.NewEncoder(w).Encode([]Network{{ID: "net-1", Name: "Campus"}, {ID: "net-2", Name: "DC"}})
+		case "/api/networks/net-2/snapshots/latestProcessed":
+			_ = json.NewEncoder(w).Encode(Snapshot{ID: "snap-9", State: "PROCESSED"})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	networks, err := client.ListNetworks(context.Background())
+	if err != nil {
+		t.Fatalf("ListNetworks returned error: %v", err)
+	}
+	if len(networks) != 2 || networks[1].Name != "DC" {
+		t.Fatalf("unexpected networks: %#v", networks)
+	}
+
+	snapshot, err := client.GetLatestProcessedSnapshot(context.Background(), "net-2")
+	if err != nil {
+		t.Fatalf("GetLatestProcessedSnapshot returned error: %v", err)
+	}
+	if snapshot.ID != "snap-9" {
+		t.Fatalf("unexpected snapshot: %#v", snapshot)
+	}
+}
@@ -0,0 +1,457 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// NqeQueryRequest captures the body parameters for executing an NQE query.
+type NqeQueryRequest struct {
+	Query        *string          `json:"query,omitempty"`
+	QueryID      *string          `json:"queryId,omitempty"`
+	CommitID     *string          `json:"commitId,omitempty"`
+	Parameters   map[string]any   `json:"parameters,omitempty"`
+	QueryOptions *NqeQueryOptions `json:"queryOptions,omitempty"`
+}
+
+// NqeQueryOptions allows limiting returned rows.
+type NqeQueryOptions struct {
+	Limit         *int           `json:"limit,omitempty"`
+	Offset        *int           `json:"offset,omitempty"`
+	SortBy        *SortOrder     `json:"sortBy,omitempty"`
+	ColumnFilters []ColumnFilter `json:"columnFilters,omitempty"`
+}
+
+// NqeRunResult represents the successful execution payload.
+type NqeRunResult struct {
+	SnapshotID    string            `json:"snapshotId"`
+	Items         []json.RawMessage `json:"items"`
+	TotalNumItems *int64            `json:"totalNumItems"`
+}
+
+// NQE job states reported by GetNQEJob.
+const (
+	NqeJobQueued    = "QUEUED"
+	NqeJobRunning   = "RUNNING"
+	NqeJobCompleted = "COMPLETED"
+	NqeJobFailed    = "FAILED"
+	NqeJobCanceled  = "CANCELED"
+)
+
+// NqeJob describes an asynchronous NQE execution.
+type NqeJob struct {
+	ID           string `json:"id"`
+	State        string `json:"state"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// NqeQuery describes a stored query from the Forward Enterprise NQE library.
+type NqeQuery struct {
+	QueryID    string `json:"queryId"`
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+	Intent     string `json:"intent"`
+	// LastCommitID identifies the latest commit that changed the query.
+	LastCommitID string `json:"lastCommitId,omitempty"`
+}
+
+// SortOrder describes how NQE results should be ordered.
+type SortOrder struct {
+	ColumnName string `json:"columnName"`
+	Order      string `json:"order,omitempty"` // ASC or DESC
+}
+
+// ColumnFilter represents a filter applied to NQE query results.
+type ColumnFilter struct {
+	ColumnName string `json:"columnName"`
+	Operator   string `json:"operator"`
+	Value      string `json:"value,omitempty"`
+	LowerBound string `json:"lowerBound,omitempty"`
+	UpperBound string `json:"upperBound,omitempty"`
+}
+
+// NqeDiffRequest captures the inputs for diffing two snapshots with an NQE query.
+type NqeDiffRequest struct {
+	QueryID    string           `json:"queryId"`
+	CommitID   *string          `json:"commitId,omitempty"`
+	Options    *NqeQueryOptions `json:"options,omitempty"`
+	Parameters map[string]any   `json:"parameters,omitempty"`
+}
+
+// NqeDiffResult represents the diff output between two snapshot executions.
+type NqeDiffResult struct {
+	Rows         []NqeDiffEntry `json:"rows"`
+	TotalNumRows *int32         `json:"totalNumRows"`
+}
+
+// NqeDiffEntry captures an individual diff row.
+type NqeDiffEntry struct {
+	Type   string     `json:"type"`
+	Before *NqeRecord `json:"before,omitempty"`
+	After  *NqeRecord `json:"after,omitempty"`
+}
+
+// NqeRecord represents a single NQE row.
+type NqeRecord struct {
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// nqeNotReadyMaxDelay caps the wait between NQE attempts while a snapshot is processing.
+const nqeNotReadyMaxDelay = 15 * time.Second
+
+// RunNQEQuery executes an NQE query against the specified network or snapshot. While the
+// snapshot is not ready to be queried the request is retried with exponential backoff,
+// for up to the client's NQENotReadyTimeout.
+func (c *Client) RunNQEQuery(ctx context.Context, networkID, snapshotID string, reqBody NqeQueryRequest) (*NqeRunResult, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	path, bodyBytes, err := nqeRequest("/api/nqe", networkID, snapshotID, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(c.nqeNotReadyTimeout)
+	delay := c.retryDelay
+	for {
+		result, err := c.runNQE(ctx, path, bodyBytes)
+		if err == nil || !isNQENotReady(err) || time.Now().Add(delay).After(deadline) {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, nqeNotReadyMaxDelay)
+	}
+}
+
+// nqeRequest validates an NQE execution request and returns its path and encoded body.
+func nqeRequest(base, networkID, snapshotID string, reqBody NqeQueryRequest) (string, []byte, error) {
+	if reqBody.Query == nil && reqBody.QueryID == nil {
+		return "", nil, fmt.Errorf("either query or query_id must be provided")
+	}
+	if snapshotID == "" && networkID == "" {
+		return "", nil, fmt.Errorf("either snapshotID or networkID must be supplied")
+	}
+
+	if reqBody.Parameters == nil {
+		reqBody.Parameters = map[string]any{}
+	}
+
+	queryParams := url.Values{}
+	if snapshotID != "" {
+		queryParams.Set("snapshotId", snapshotID)
+	}
+	if networkID != "" {
+		queryParams.Set("networkId", networkID)
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", nil, fmt.Errorf("marshal nqe request: %w", err)
+	}
+
+	return base + "?" + queryParams.Encode(), bodyBytes, nil
+}
+
+func (c *Client) runNQE(ctx context.Context, path string, body []byte) (*NqeRunResult, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute NQE request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "running NQE query")
+	}
+
+	var result NqeRunResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode NQE response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// SubmitNQEQuery starts an asynchronous NQE execution against the specified network or
+// snapshot. Poll the returned job with GetNQEJob and fetch rows with GetNQEJobResult.
+func (c *Client) SubmitNQEQuery(ctx context.Context, networkID, snapshotID string, reqBody NqeQueryRequest) (*NqeJob, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	path, bodyBytes, err := nqeRequest("/api/nqe/jobs", networkID, snapshotID, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("submit NQE job request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return nil, newAPIError(resp, "submitting NQE job")
+	}
+
+	var job NqeJob
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return nil, fmt.Errorf("decode NQE job response: %w", err)
+	}
+
+	return &job, nil
+}
+
+// GetNQEJob retrieves the state of an asynchronous NQE execution.
+func (c *Client) GetNQEJob(ctx context.Context, jobID string) (*NqeJob, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	jobID = strings.TrimSpace(jobID)
+	if jobID == "" {
+		return nil, fmt.Errorf("jobID must be provided")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/api/nqe/jobs/%s", url.PathEscape(jobID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve NQE job request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving NQE job")
+	}
+
+	var job NqeJob
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return nil, fmt.Errorf("decode NQE job response: %w", err)
+	}
+
+	return &job, nil
+}
+
+// GetNQEJobResult retrieves the rows of a completed asynchronous NQE execution.
+func (c *Client) GetNQEJobResult(ctx context.Context, jobID string) (*NqeRunResult, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	jobID = strings.TrimSpace(jobID)
+	if jobID == "" {
+		return nil, fmt.Errorf("jobID must be provided")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/api/nqe/jobs/%s/result", url.PathEscape(jobID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve NQE job result request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving NQE job result")
+	}
+
+	var result NqeRunResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode NQE job result response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// isNQENotReady reports whether err is the conflict Forward returns for NQE queries
+// against a snapshot that is still being processed or is locked.
+func isNQENotReady(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusLocked
+}
+
+// ListNQEQueries retrieves committed NQE queries, optionally filtered by directory.
+func (c *Client) ListNQEQueries(ctx context.Context, dir string) ([]NqeQuery, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	path := "/api/nqe/queries"
+	if strings.TrimSpace(dir) != "" {
+		params := url.Values{}
+		params.Set("dir", dir)
+		path = path + "?" + params.Encode()
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list NQE queries request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "listing NQE queries")
+	}
+
+	var queries []NqeQuery
+	if err := json.NewDecoder(resp.Body).Decode(&queries); err != nil {
+		return nil, fmt.Errorf("decode NQE query list: %w", err)
+	}
+
+	return queries, nil
+}
+
+// RunNQEDiff executes an NQE diff between two snapshot IDs.
+func (c *Client) RunNQEDiff(ctx context.Context, beforeSnapshotID, afterSnapshotID string, reqBody NqeDiffRequest) (*NqeDiffResult, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	before := strings.TrimSpace(beforeSnapshotID)
+	after := strings.TrimSpace(afterSnapshotID)
+	if before == "" || after == "" {
+		return nil, fmt.Errorf("beforeSnapshotID and afterSnapshotID must be provided")
+	}
+
+	if reqBody.QueryID == "" {
+		return nil, fmt.Errorf("queryId must be provided")
+	}
+
+	if reqBody.Parameters == nil {
+		reqBody.Parameters = map[string]any{}
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal NQE diff request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/nqe-diffs/%s/%s", url.PathEscape(before), url.PathEscape(after))
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute NQE diff request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "running NQE diff")
+	}
+
+	var result NqeDiffResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode NQE diff response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// NqeQueryChange describes a query to add, update, or remove in a repository commit.
+type NqeQueryChange struct {
+	Path   string `json:"path"`
+	Source string `json:"source,omitempty"`
+	Delete bool   `json:"delete,omitempty"`
+}
+
+// NqeCommitRequest captures a set of query changes applied as a single commit.
+type NqeCommitRequest struct {
+	Message string           `json:"message"`
+	Changes []NqeQueryChange `json:"changes"`
+}
+
+// NqeCommit describes a commit in an NQE repository.
+type NqeCommit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// CommitNQEQueries applies query changes to an NQE repository in a single commit, so
+// either every change is committed or none is.
+func (c *Client) CommitNQEQueries(ctx context.Context, repository string, reqBody NqeCommitRequest) (*NqeCommit, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	repository = strings.ToLower(strings.TrimSpace(repository))
+	if repository == "" {
+		return nil, fmt.Errorf("repository must be provided")
+	}
+
+	if len(reqBody.Changes) == 0 {
+		return nil, fmt.Errorf("at least one change must be provided")
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal NQE commit request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/nqe/repos/%s/commits", url.PathEscape(repository))
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute NQE commit request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, newAPIError(resp, "committing NQE queries")
+	}
+
+	var commit NqeCommit
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return nil, fmt.Errorf("decode NQE commit response: %w", err)
+	}
+
+	return &commit, nil
+}
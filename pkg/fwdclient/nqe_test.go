@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_ListNQEQueries(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/nqe/queries" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.RawQuery != "" {
+			t.Fatalf("unexpected query string: %s", r.URL.RawQuery)
+		}
+		_ = json.NewEncoder(w).Encode([]NqeQuery{{
+			QueryID:    "FQ_test",
+			Repository: "ORG",
+			Path:       "/L3/Example",
+			Intent:     "Validate MTU",
+		}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	queries, err := client.ListNQEQueries(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListNQEQueries returned error: %v", err)
+	}
+	if len(queries) != 1 || queries[0].QueryID != "FQ_test" {
+		t.Fatalf("unexpected queries: %#v", queries)
+	}
+}
+
+func TestClient_RunNQEQueryRetriesNotReady(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"snapshot not ready"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(NqeRunResult{SnapshotID: "snap-1"})
+	}))
+	defer server.Close()
+
+	query := "foreach d in network.devices select d.name"
+	for name, tc := range map[string]struct {
+		timeout  time.Duration
+		attempts int32
+		wantErr  bool
+	}{
+		"retries until ready": {timeout: time.Minute, attempts: 3},
+		"retries disabled":    {timeout: 0, attempts: 1, wantErr: true},
+	} {
+		attempts.Store(0)
+		client, err := NewClient(context.Background(), Config{
+			BaseURL:            server.URL,
+			APIKey:             "token",
+			RetryDelay:         time.Millisecond,
+			NQENotReadyTimeout: tc.timeout,
+		})
+		if err != nil {
+			t.Fatalf("construct client: %v", err)
+		}
+
+		result, err := client.RunNQEQuery(context.Background(), "", "snap-1", NqeQueryRequest{Query: &query})
+		if tc.wantErr {
+			if !isNQENotReady(err) {
+				t.Fatalf("%s: expected not ready error, got %v", name, err)
+			}
+		} else if err != nil || result.SnapshotID != "snap-1" {
+			t.Fatalf("%s: unexpected result %#v, error %v", name, result, err)
+		}
+		if got := attempts.Load(); got != tc.attempts {
+			t.Fatalf("%s: expected %d attempts, got %d", name, tc.attempts, got)
+		}
+	}
+}
+
+func TestClient_NQEJob(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/nqe/jobs", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("snapshotId") != "snap-1" {
+			t.Fatalf("unexpected query string: %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(NqeJob{ID: "job-1", State: NqeJobQueued})
+	})
+	mux.HandleFunc("GET /api/nqe/jobs/job-1", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(NqeJob{ID: "job-1", State: NqeJobCompleted})
+	})
+	mux.HandleFunc("GET /api/nqe/jobs/job-1/result", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(NqeRunResult{SnapshotID: "snap-1", Items: []json.RawMessage{json.RawMessage(`{"a":1}`)}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	query := "foreach d in network.devices select d.name"
+	job, err := client.SubmitNQEQuery(context.Background(), "", "snap-1", NqeQueryRequest{Query: &query})
+	if err != nil {
+		t.Fatalf("SubmitNQEQuery returned error: %v", err)
+	}
+	if job.ID != "job-1" || job.State != NqeJobQueued {
+		t.Fatalf("unexpected job: %#v", job)
+	}
+
+	job, err = client.GetNQEJob(context.Background(), job.ID)
+	if err != nil || job.State != NqeJobCompleted {
+		t.Fatalf("unexpected job %#v, error %v", job, err)
+	}
+
+	result, err := client.GetNQEJobResult(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("GetNQEJobResult returned error: %v", err)
+	}
+	if result.SnapshotID != "snap-1" || len(result.Items) != 1 {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestClient_RunNQEDiff(t *testing.T) {
+	t.Parallel()
+
+	var received NqeDiffRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/nqe-diffs/before/after" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(NqeDiffResult{Rows: []NqeDiffEntry{{Type: "ADDED"}}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	req := NqeDiffRequest{QueryID: "FQ_test"}
+	result, err := client.RunNQEDiff(context.Background(), "before", "after", req)
+	if err != nil {
+		t.Fatalf("RunNQEDiff returned error: %v", err)
+	}
+	if result == nil || len(result.Rows) != 1 {
+		t.Fatalf("unexpected diff result: %#v", result)
+	}
+	if received.QueryID != "FQ_test" {
+		t.Fatalf("unexpected request payload: %#v", received)
+	}
+}
+
+func TestClient_CommitNQEQueries(t *testing.T) {
+	t.Parallel()
+
+	var received NqeCommitRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/nqe/repos/org/commits" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(NqeCommit{ID: "commit-1", Message: received.Message})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	commit, err := client.CommitNQEQueries(context.Background(), "ORG", NqeCommitRequest{
+		Message: "update pack",
+		Changes: []NqeQueryChange{{Path: "/Pack/A", Source: "foreach d in network.devices select d"}, {Path: "/Pack/B", Delete: true}},
+	})
+	if err != nil {
+		t.Fatalf("CommitNQEQueries returned error: %v", err)
+	}
+	if commit.ID != "commit-1" || len(received.Changes) != 2 || !received.Changes[1].Delete {
+		t.Fatalf("unexpected commit %#v for request %#v", commit, received)
+	}
+
+	if _, err := client.CommitNQEQueries(context.Background(), "ORG", NqeCommitRequest{}); err == nil {
+		t.Fatal("expected an error for an empty commit")
+	}
+}
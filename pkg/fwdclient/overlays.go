@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Overlay describes the SD-WAN overlay modeled in a snapshot.
+type Overlay struct {
+	Edges []OverlayEdge `json:"edges"`
+	Paths []OverlayPath `json:"paths"`
+}
+
+// OverlayEdge is an SD-WAN edge device participating in an overlay.
+type OverlayEdge struct {
+	DeviceName string `json:"deviceName"`
+	Vendor     string `json:"vendor"`
+	SiteID     string `json:"siteId"`
+	SystemIP   string `json:"systemIp"`
+	Status     string `json:"status"`
+}
+
+// OverlayPath is an overlay tunnel between two edges over a given transport.
+type OverlayPath struct {
+	SrcDevice    string `json:"srcDevice"`
+	DstDevice    string `json:"dstDevice"`
+	SrcTransport string `json:"srcTransport"`
+	DstTransport string `json:"dstTransport"`
+	Status       string `json:"status"`
+}
+
+// GetOverlay retrieves the SD-WAN overlay edges and paths of a snapshot.
+func (c *Client) GetOverlay(ctx context.Context, snapshotID string) (*Overlay, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/overlays", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute overlay request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving overlay")
+	}
+
+	var overlay Overlay
+	if err := json.NewDecoder(resp.Body).Decode(&overlay); err != nil {
+		return nil, fmt.Errorf("decode overlay response: %w", err)
+	}
+
+	return &overlay, nil
+}
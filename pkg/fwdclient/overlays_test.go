@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetOverlay(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/overlays" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"edges":[{"deviceName":"edge-1","vendor":"VIPTELA","siteId":"100"}],"paths":[{"srcDevice":"edge-1","dstDevice":"edge-2","srcTransport":"mpls","dstTransport":"mpls","status":"UP"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	overlay, err := client.GetOverlay(context.Background(), "snap-1")
+	if err != nil {
+		t.Fatalf("GetOverlay error: %v", err)
+	}
+	if len(overlay.Edges) != 1 || overlay.Edges[0].SiteID != "100" || overlay.Paths[0].DstDevice != "edge-2" {
+		t.Fatalf("unexpected overlay: %#v", overlay)
+	}
+}
@@ -1,7 +1,7 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: MPL-2.0
 
-package sdk
+package fwdclient
 
 import (
 	"context"
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // PathSearchParams defines query options for path analysis.
@@ -125,6 +126,29 @@ type PathInterface struct {
 
 // SearchPaths executes a path analysis query.
 func (c *Client) SearchPaths(ctx context.Context, networkID string, params PathSearchParams) (*PathSearchResult, error) {
+	var paths, returnPaths []Path
+	result, err := c.StreamPathSearch(ctx, networkID, params, func(returnPath bool, p *Path) error {
+		if returnPath {
+			returnPaths = append(returnPaths, *p)
+		} else {
+			paths = append(paths, *p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Info.Paths = paths
+	result.ReturnPathInfo.Paths = returnPaths
+	return result, nil
+}
+
+// StreamPathSearch executes a path analysis query and passes each path to fn as it is
+// decoded, with returnPath set for return paths, so searches with thousands of results
+// are never held in memory in full. The returned result carries everything except the
+// paths. Decoding stops at the first error fn returns.
+func (c *Client) StreamPathSearch(ctx context.Context, networkID string, params PathSearchParams, fn func(returnPath bool, p *Path) error) (*PathSearchResult, error) {
 	if c == nil {
 		return nil, fmt.Errorf("client is nil")
 	}
@@ -210,6 +234,11 @@ func (c *Client) SearchPaths(ctx context.Context, networkID string, params PathS
 		path = path + "?" + enc
 	}
 
+	// The search may run for maxSeconds on the server; don't give up on it sooner.
+	if params.MaxSeconds != nil {
+		ctx = WithTimeout(ctx, time.Duration(*params.MaxSeconds)*time.Second+serverTimeoutMargin)
+	}
+
 	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
@@ -222,13 +251,159 @@ func (c *Client) SearchPaths(ctx context.Context, networkID string, params PathS
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<14))
-		return nil, fmt.Errorf("unexpected status %d searching paths: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "searching paths")
+	}
+
+	result, err := decodePathSearch(resp.Body, fn)
+	if err != nil {
+		return nil, fmt.Errorf("decode path search response: %w", err)
 	}
 
+	return result, nil
+}
+
+// decodePathSearch decodes a path search response, streaming the paths of info and
+// returnPathInfo to fn. Every other field is buffered and decoded as usual.
+func decodePathSearch(r io.Reader, fn func(returnPath bool, p *Path) error) (*PathSearchResult, error) {
 	var result PathSearchResult
+	rest := map[string]json.RawMessage{}
+
+	dec := json.NewDecoder(r)
+	err := decodeObject(dec, func(key string) error {
+		switch key {
+		case "info":
+			return decodePathCollection(dec, &result.Info, false, fn)
+		case "returnPathInfo":
+			return decodePathCollection(dec, &result.ReturnPathInfo, true, fn)
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		rest[key] = value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := unmarshalFields(rest, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodePathCollection(dec *json.Decoder, collection *PathCollection, returnPath bool, fn func(bool, *Path) error) error {
+	rest := map[string]json.RawMessage{}
+	err := decodeObject(dec, func(key string) error {
+		if key == "paths" {
+			return decodeArrayFrom(dec, func(p *Path) error {
+				return fn(returnPath, p)
+			})
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		rest[key] = value
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return unmarshalFields(rest, collection)
+}
+
+// unmarshalFields decodes the buffered fields of a streamed object into v.
+func unmarshalFields(fields map[string]json.RawMessage, v any) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// L2PathSearchParams defines query options for MAC-layer path tracing.
+type L2PathSearchParams struct {
+	SrcMAC     string
+	DstMAC     string
+	VLAN       *int
+	SnapshotID string
+	MaxResults *int
+}
+
+// L2PathSearchResult captures MAC-layer path tracing output.
+type L2PathSearchResult struct {
+	Paths    []L2Path `json:"paths"`
+	TimedOut bool     `json:"timedOut"`
+	QueryURL string   `json:"queryUrl"`
+}
+
+// L2Path represents a single switched path between two endpoints.
+type L2Path struct {
+	Outcome string      `json:"outcome"`
+	Hops    []L2PathHop `json:"hops"`
+}
+
+// L2PathHop represents a switching hop and the VLAN a frame is carried in on each side.
+type L2PathHop struct {
+	DeviceName       string `json:"deviceName"`
+	IngressInterface string `json:"ingressInterface"`
+	EgressInterface  string `json:"egressInterface"`
+	IngressVLAN      *int64 `json:"ingressVlan"`
+	EgressVLAN       *int64 `json:"egressVlan"`
+}
+
+// SearchL2Paths traces MAC-layer paths between two endpoints.
+func (c *Client) SearchL2Paths(ctx context.Context, networkID string, params L2PathSearchParams) (*L2PathSearchResult, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	if networkID == "" {
+		return nil, fmt.Errorf("networkID must be provided")
+	}
+
+	if params.SrcMAC == "" || params.DstMAC == "" {
+		return nil, fmt.Errorf("srcMac and dstMac must be provided")
+	}
+
+	query := url.Values{}
+	query.Set("srcMac", params.SrcMAC)
+	query.Set("dstMac", params.DstMAC)
+	if params.VLAN != nil {
+		query.Set("vlan", strconv.Itoa(*params.VLAN))
+	}
+	if params.SnapshotID != "" {
+		query.Set("snapshotId", params.SnapshotID)
+	}
+	if params.MaxResults != nil {
+		query.Set("maxResults", strconv.Itoa(*params.MaxResults))
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/l2paths?%s", url.PathEscape(networkID), query.Encode())
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute l2 path search request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "searching l2 paths")
+	}
+
+	var result L2PathSearchResult
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decode path search response: %w", err)
+		return nil, fmt.Errorf("decode l2 path search response: %w", err)
 	}
 
 	return &result, nil
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Copyright (c) HashiCorp, Inc.
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchPaths(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/networks/net-1/paths" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("dstIp") != "10.0.0.1" {
+			t.Fatalf("missing dstIp")
+		}
+		_ = json.NewEncoder(w).Encode(PathSearchResult{
+			SrcIPLocationType: "INTERFACE",
+			DstIPLocationType: "INTERFACE",
+			Info:              PathCollection{Paths: []Path{{ForwardingOutcome: "DELIVERED"}}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	result, err := client.SearchPaths(context.Background(), "net-1", PathSearchParams{SrcIP: "10.0.0.2", DstIP: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("SearchPaths error: %v", err)
+	}
+	if len(result.Info.Paths) != 1 {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestStreamPathSearch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"srcIpLocationType": "INTERFACE",
+			"info": {"totalHits": {"type": "EXACT", "value": 3}, "paths": [
+				{"forwardingOutcome": "DELIVERED", "hops": [{"deviceName": "a"}]},
+				{"forwardingOutcome": "DROPPED"}
+			]},
+			"returnPathInfo": {"paths": [{"forwardingOutcome": "DELIVERED"}]},
+			"timedOut": true,
+			"unrecognizedValues": {"appId": ["x"]}
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	var outcomes []string
+	result, err := client.StreamPathSearch(context.Background(), "net-1", PathSearchParams{SrcIP: "10.0.0.2", DstIP: "10.0.0.1"}, func(returnPath bool, p *Path) error {
+		outcomes = append(outcomes, fmt.Sprintf("%t:%s", returnPath, p.ForwardingOutcome))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamPathSearch error: %v", err)
+	}
+
+	if want := []string{"false:DELIVERED", "false:DROPPED", "true:DELIVERED"}; strings.Join(outcomes, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected paths: %q", outcomes)
+	}
+	if len(result.Info.Paths) != 0 {
+		t.Fatalf("expected streamed paths to be left out of the result, got %d", len(result.Info.Paths))
+	}
+	if result.SrcIPLocationType != "INTERFACE" || !result.TimedOut || result.Info.TotalHits.Value != 3 || len(result.Unrecognized.AppID) != 1 {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestSearchL2Paths(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/networks/net-1/l2paths" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("srcMac") != "00:00:5e:00:53:01" || r.URL.Query().Get("vlan") != "10" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"paths": [{"outcome": "DELIVERED", "hops": [{"deviceName": "sw-1", "ingressVlan": 10, "egressVlan": 20}]}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	vlan := 10
+	result, err := client.SearchL2Paths(context.Background(), "net-1", L2PathSearchParams{SrcMAC: "00:00:5e:00:53:01", DstMAC: "00:00:5e:00:53:02", VLAN: &vlan})
+	if err != nil {
+		t.Fatalf("SearchL2Paths error: %v", err)
+	}
+	if len(result.Paths) != 1 || *result.Paths[0].Hops[0].EgressVLAN != 20 {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestStreamPathSearchExtendsTimeoutForMaxSeconds(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"info": {"paths": []}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		HTTPClient: &http.Client{Timeout: 50 * time.Millisecond},
+		MaxRetries: -1,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	params := PathSearchParams{SrcIP: "10.0.0.2", DstIP: "10.0.0.1"}
+	if _, err := client.SearchPaths(context.Background(), "net-1", params); err == nil {
+		t.Fatal("expected the client timeout to apply without maxSeconds")
+	}
+
+	maxSeconds := 1
+	params.MaxSeconds = &maxSeconds
+	if _, err := client.SearchPaths(context.Background(), "net-1", params); err != nil {
+		t.Fatalf("SearchPaths with maxSeconds: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// RawRequest sends an authenticated request with an optional JSON body to an API path
+// the client does not model and returns the response body, which is empty for 204 No
+// Content. Non-2xx responses are returned as *APIError. The path must be relative to the
+// base URL; absolute URLs are rejected so the API key is never sent to another host.
+func (c *Client) RawRequest(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		return nil, fmt.Errorf("method must be provided")
+	}
+
+	rel, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse request path: %w", err)
+	}
+	if rel.IsAbs() || rel.Host != "" || !strings.HasPrefix(rel.Path, "/") {
+		return nil, fmt.Errorf("path must be an absolute API path such as /api/networks, got %q", path)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := c.NewRequest(ctx, method, path, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute %s %s request: %w", method, rel.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, newAPIError(resp, fmt.Sprintf("calling %s %s", method, rel.Path))
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read %s %s response: %w", method, rel.Path, err)
+	}
+	return raw, nil
+}
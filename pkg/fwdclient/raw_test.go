@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_RawRequest(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/aliases":
+			body, _ := io.ReadAll(r.Body)
+			if r.Method != http.MethodPost || string(body) != `{"name":"web"}` {
+				t.Errorf("unexpected request %s %s", r.Method, body)
+			}
+			_, _ = w.Write([]byte(`{"id":"a-1"}`))
+		case "/api/aliases/a-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	raw, err := client.RawRequest(context.Background(), "post", "/api/aliases", []byte(`{"name":"web"}`))
+	if err != nil || string(raw) != `{"id":"a-1"}` {
+		t.Fatalf("unexpected response %s, %v", raw, err)
+	}
+	if raw, err := client.RawRequest(context.Background(), http.MethodDelete, "/api/aliases/a-1", nil); err != nil || len(raw) != 0 {
+		t.Fatalf("unexpected response %s, %v", raw, err)
+	}
+	if _, err := client.RawRequest(context.Background(), http.MethodGet, "/api/missing", nil); !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	for _, path := range []string{"https://other.example.com/api/aliases", "//other.example.com/api", "api/aliases"} {
+		if _, err := client.RawRequest(context.Background(), http.MethodGet, path, nil); err == nil {
+			t.Errorf("expected %q to be rejected", path)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder captures live traffic or serves it from a cassette.
+type RecorderMode string
+
+const (
+	// RecorderModeRecord forwards requests to the appliance and appends each exchange to the cassette.
+	RecorderModeRecord RecorderMode = "record"
+	// RecorderModeReplay serves responses from the cassette without contacting the appliance.
+	RecorderModeReplay RecorderMode = "replay"
+)
+
+// redactedValue replaces secrets before an exchange is written to a cassette.
+const redactedValue = "REDACTED"
+
+// Cassette is the on-disk format of recorded API exchanges.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest holds the parts of a request used to match it during replay.
+// Headers are never recorded so credentials cannot leak into a cassette.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse holds the response replayed for a matching request.
+type RecordedResponse struct {
+	StatusCode  int    `json:"statusCode"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Recorder captures or replays Forward API traffic using a cassette file so acceptance
+// tests can be recorded once against a live appliance and replayed without credentials.
+// A Recorder is safe for concurrent use and may be shared by several clients.
+type Recorder struct {
+	mode    RecorderMode
+	path    string
+	secrets []string
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder opens the cassette at path. In replay mode the cassette must exist; in
+// record mode an existing cassette is left untouched until the first interaction is
+// recorded, then replaced. Every occurrence of secrets in recorded
+// paths and bodies is replaced before the cassette is written.
+func NewRecorder(mode RecorderMode, path string, secrets ...string) (*Recorder, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("cassette path must be provided")
+	}
+
+	r := &Recorder{mode: mode, path: path}
+	for _, secret := range secrets {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+
+	switch mode {
+	case RecorderModeRecord:
+	case RecorderModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("decode cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unsupported recorder mode %q", mode)
+	}
+
+	return r, nil
+}
+
+// Mode reports whether the recorder is recording or replaying.
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Wrap returns a RoundTripper that records or replays requests sent through next.
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &recordingTransport{recorder: r, next: next}
+}
+
+type recordingTransport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := t.recorder.scrubRequest(RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+		Body:   body,
+	})
+
+	if t.recorder.mode == RecorderModeReplay {
+		response, err := t.recorder.replay(recorded)
+		if err != nil {
+			return nil, err
+		}
+		return response.toHTTP(req), nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read response for recording: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if err := t.recorder.record(recorded, RecordedResponse{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        string(respBody),
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) record(req RecordedRequest, resp RecordedResponse) error {
+	resp.Body = r.scrub(resp.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: req, Response: resp})
+	// Persist after every exchange; the provider process may exit without notice.
+	return r.saveLocked()
+}
+
+// replay returns the first unused interaction matching req. Once every match has been
+// consumed the last one is served again, so extra refreshes or polls stay deterministic.
+func (r *Recorder) replay(req RecordedRequest) (RecordedResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !interaction.Request.matches(req) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction.Response, nil
+		}
+		last = i
+	}
+
+	if last >= 0 {
+		return r.cassette.Interactions[last].Response, nil
+	}
+
+	return RecordedResponse{}, fmt.Errorf("no recorded interaction in %s for %s %s", r.path, req.Method, req.Path)
+}
+
+func (r *Recorder) saveLocked() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+func (r *Recorder) scrubRequest(req RecordedRequest) RecordedRequest {
+	req.Path = r.scrub(req.Path)
+	req.Query = r.scrub(req.Query)
+	req.Body = r.scrub(req.Body)
+	return req
+}
+
+func (r *Recorder) scrub(value string) string {
+	for _, secret := range r.secrets {
+		value = strings.ReplaceAll(value, secret, redactedValue)
+	}
+	return value
+}
+
+func (req RecordedRequest) matches(other RecordedRequest) bool {
+	return req.Method == other.Method &&
+		req.Path == other.Path &&
+		req.Query == other.Query &&
+		sameBody(req.Body, other.Body)
+}
+
+// sameBody compares JSON bodies structurally so field ordering does not break replay.
+func sameBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var left, right any
+	if json.Unmarshal([]byte(a), &left) != nil || json.Unmarshal([]byte(b), &right) != nil {
+		return false
+	}
+	leftJSON, _ := json.Marshal(left)
+	rightJSON, _ := json.Marshal(right)
+	return bytes.Equal(leftJSON, rightJSON)
+}
+
+func (resp RecordedResponse) toHTTP(req *http.Request) *http.Response {
+	header := http.Header{}
+	if resp.ContentType != "" {
+		header.Set("Content-Type", resp.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
+
+func readRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("read request for recording: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
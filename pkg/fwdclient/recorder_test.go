@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder_RecordThenReplay(t *testing.T) {
+	t.Parallel()
+
+	const apiKey = "secret-api-key"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"build":"abc","release":"25.1","version":"25.1.0","owner":"` + apiKey + `"}`))
+	}))
+
+	cassette := filepath.Join(t.TempDir(), "fixtures", "version.json")
+	recorder, err := NewRecorder(RecorderModeRecord, cassette, apiKey)
+	if err != nil {
+		t.Fatalf("NewRecorder(record): %v", err)
+	}
+	if _, err := os.Stat(cassette); !os.IsNotExist(err) {
+		t.Fatalf("expected the cassette not to be written before traffic is recorded, got %v", err)
+	}
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: apiKey, Recorder: recorder})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+	if _, err := client.GetVersion(context.Background()); err != nil {
+		t.Fatalf("GetVersion while recording: %v", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+	if strings.Contains(string(data), apiKey) {
+		t.Fatalf("cassette leaked the API key: %s", data)
+	}
+
+	replayer, err := NewRecorder(RecorderModeReplay, cassette)
+	if err != nil {
+		t.Fatalf("NewRecorder(replay): %v", err)
+	}
+	client, err = NewClient(context.Background(), Config{
+		BaseURL:    "https://offline.invalid",
+		APIKey:     "placeholder",
+		Recorder:   replayer,
+		MaxRetries: 1,
+		RetryDelay: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("construct replay client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		version, err := client.GetVersion(context.Background())
+		if err != nil {
+			t.Fatalf("GetVersion while replaying: %v", err)
+		}
+		if version.Version != "25.1.0" {
+			t.Fatalf("unexpected replayed version: %#v", version)
+		}
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/api/unknown", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if _, err := client.Do(req); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Fatalf("expected missing interaction error, got %v", err)
+	}
+}
+
+func TestRecorder_ReplayMatchesJSONBodiesStructurally(t *testing.T) {
+	t.Parallel()
+
+	if !sameBody(`{"a":1,"b":[true]}`, `{"b":[true], "a":1}`) {
+		t.Fatal("expected reordered JSON bodies to match")
+	}
+	if sameBody(`{"a":1}`, `{"a":2}`) {
+		t.Fatal("expected different JSON bodies not to match")
+	}
+}
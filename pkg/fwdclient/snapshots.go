@@ -0,0 +1,457 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Snapshot describes a network snapshot returned by the Forward Enterprise API.
+type Snapshot struct {
+	ID                 string `json:"id"`
+	State              string `json:"state"`
+	ProcessingTrigger  string `json:"processingTrigger"`
+	ParentSnapshotID   string `json:"parentSnapshotId"`
+	Note               string `json:"note"`
+	FavoritedBy        string `json:"favoritedBy"`
+	FavoritedByUserID  string `json:"favoritedByUserId"`
+	CreationDateMillis *int64 `json:"creationDateMillis"`
+	ProcessedAtMillis  *int64 `json:"processedAtMillis"`
+	RestoredAtMillis   *int64 `json:"restoredAtMillis"`
+	FavoritedAtMillis  *int64 `json:"favoritedAtMillis"`
+	IsDraft            *bool  `json:"isDraft"`
+}
+
+// SnapshotListOptions controls the ListSnapshots behavior.
+type SnapshotListOptions struct {
+	Limit           *int
+	IncludeArchived *bool
+}
+
+// ListSnapshots retrieves snapshots for the supplied network identifier.
+func (c *Client) ListSnapshots(ctx context.Context, networkID string, opts SnapshotListOptions) ([]Snapshot, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	if networkID == "" {
+		return nil, fmt.Errorf("networkID must be provided")
+	}
+
+	escapedNetworkID := url.PathEscape(networkID)
+	path := fmt.Sprintf("/api/networks/%s/snapshots", escapedNetworkID)
+
+	query := url.Values{}
+	if opts.Limit != nil {
+		query.Set("limit", strconv.Itoa(*opts.Limit))
+	}
+
+	if opts.IncludeArchived != nil {
+		query.Set("includeArchived", strconv.FormatBool(*opts.IncludeArchived))
+	}
+
+	if enc := query.Encode(); enc != "" {
+		path = path + "?" + enc
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving snapshots")
+	}
+
+	var payload struct {
+		Snapshots []Snapshot `json:"snapshots"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode snapshots response: %w", err)
+	}
+
+	return payload.Snapshots, nil
+}
+
+// GetLatestProcessedSnapshot retrieves the most recent processed snapshot of a network.
+func (c *Client) GetLatestProcessedSnapshot(ctx context.Context, networkID string) (*Snapshot, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	if networkID == "" {
+		return nil, fmt.Errorf("networkID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/snapshots/latestProcessed", url.PathEscape(networkID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving latest processed snapshot")
+	}
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot response: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// SnapshotCreateRequest represents optional parameters when creating a snapshot.
+type SnapshotCreateRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// SnapshotDetails represents detailed snapshot information.
+type SnapshotDetails struct {
+	Snapshot
+	// NetworkID is the network the snapshot belongs to. Only set by GetSnapshotByID.
+	NetworkID string `json:"networkId,omitempty"`
+}
+
+// CreateSnapshot initiates a new snapshot collection for the given network.
+func (c *Client) CreateSnapshot(ctx context.Context, networkID string, reqBody SnapshotCreateRequest) (*SnapshotDetails, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	if networkID == "" {
+		return nil, fmt.Errorf("networkID must be provided")
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal snapshot request: %w", err)
+	}
+
+	reader := bytes.NewReader(body)
+	path := fmt.Sprintf("/api/networks/%s/snapshots", url.PathEscape(networkID))
+	req, err := c.NewRequest(ctx, http.MethodPost, path, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute snapshot create request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusCreated {
+		return nil, newAPIError(resp, "creating snapshot")
+	}
+
+	var snapshot SnapshotDetails
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot create response: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// GetSnapshot retrieves a snapshot by ID for the provided network.
+func (c *Client) GetSnapshot(ctx context.Context, networkID, snapshotID string) (*SnapshotDetails, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	snapshotID = strings.TrimSpace(snapshotID)
+	if networkID == "" || snapshotID == "" {
+		return nil, fmt.Errorf("networkID and snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/snapshots/%s", url.PathEscape(networkID), url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute snapshot get request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving snapshot")
+	}
+
+	var snapshot SnapshotDetails
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot response: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// GetSnapshotByID retrieves a snapshot by ID alone, for callers that do not know which
+// network it belongs to. The returned details include the snapshot's NetworkID.
+func (c *Client) GetSnapshotByID(ctx context.Context, snapshotID string) (*SnapshotDetails, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute snapshot get request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving snapshot")
+	}
+
+	var snapshot SnapshotDetails
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot response: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// SnapshotProcessingStatus reports how far a snapshot has progressed through processing.
+type SnapshotProcessingStatus struct {
+	State           string   `json:"state"`
+	Phase           string   `json:"phase"`
+	DevicesParsed   *int64   `json:"devicesParsed"`
+	DevicesTotal    *int64   `json:"devicesTotal"`
+	PercentComplete *float64 `json:"percentComplete"`
+	// PollIntervalSeconds is the polling interval suggested by the appliance, if any.
+	PollIntervalSeconds *int64 `json:"pollIntervalSeconds"`
+}
+
+// GetSnapshotProcessingStatus retrieves processing progress for a snapshot.
+func (c *Client) GetSnapshotProcessingStatus(ctx context.Context, snapshotID string) (*SnapshotProcessingStatus, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/processingStatus", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute processing status request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving snapshot processing status")
+	}
+
+	var status SnapshotProcessingStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("decode processing status response: %w", err)
+	}
+
+	return &status, nil
+}
+
+// CancelSnapshotProcessing aborts processing of a snapshot. Snapshots that are unknown
+// or have already finished processing are left untouched without error.
+func (c *Client) CancelSnapshotProcessing(ctx context.Context, snapshotID string) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/cancel", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute snapshot cancel request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound, http.StatusConflict:
+		return nil
+	default:
+		return newAPIError(resp, "cancelling snapshot processing")
+	}
+}
+
+// DeleteSnapshot removes a snapshot by ID.
+func (c *Client) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute snapshot delete request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp, "deleting snapshot")
+	}
+
+	return nil
+}
+
+// Snapshot retention actions.
+const (
+	SnapshotRetentionArchive = "ARCHIVE"
+	SnapshotRetentionDelete  = "DELETE"
+)
+
+// SnapshotRetentionPolicy controls how Forward automatically archives or deletes old
+// snapshots of a network. A snapshot is purged once it is older than MaxAgeDays or
+// falls outside the newest MaxCount snapshots; unset limits do not apply.
+type SnapshotRetentionPolicy struct {
+	Enabled    bool   `json:"enabled"`
+	MaxAgeDays *int64 `json:"maxAgeDays,omitempty"`
+	MaxCount   *int64 `json:"maxCount,omitempty"`
+	// Action is SnapshotRetentionArchive or SnapshotRetentionDelete.
+	Action string `json:"action,omitempty"`
+	// KeepFavorites exempts favorited snapshots from the policy.
+	KeepFavorites bool `json:"keepFavorites"`
+}
+
+// GetSnapshotRetentionPolicy retrieves the snapshot retention policy of a network.
+func (c *Client) GetSnapshotRetentionPolicy(ctx context.Context, networkID string) (*SnapshotRetentionPolicy, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	if networkID == "" {
+		return nil, fmt.Errorf("networkID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/snapshotRetention", url.PathEscape(networkID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute snapshot retention request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving snapshot retention policy")
+	}
+
+	var policy SnapshotRetentionPolicy
+	if err := json.NewDecoder(resp.Body).Decode(&policy); err != nil {
+		return nil, fmt.Errorf("decode snapshot retention response: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// SetSnapshotRetentionPolicy replaces the snapshot retention policy of a network.
+// Setting a disabled policy stops automatic purging.
+func (c *Client) SetSnapshotRetentionPolicy(ctx context.Context, networkID string, policy SnapshotRetentionPolicy) (*SnapshotRetentionPolicy, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	networkID = strings.TrimSpace(networkID)
+	if networkID == "" {
+		return nil, fmt.Errorf("networkID must be provided")
+	}
+
+	bodyBytes, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("encode snapshot retention policy: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/networks/%s/snapshotRetention", url.PathEscape(networkID))
+	req, err := c.NewRequest(ctx, http.MethodPut, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute snapshot retention update request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "updating snapshot retention policy")
+	}
+
+	var result SnapshotRetentionPolicy
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode snapshot retention response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Copyright (c) HashiCorp, Inc.
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateSnapshot(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/networks/net-1/snapshots" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(SnapshotDetails{Snapshot: Snapshot{ID: "snap-1", State: "PROCESSING", Note: payload["note"]}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	snapshot, err := client.CreateSnapshot(context.Background(), "net-1", SnapshotCreateRequest{Note: "test"})
+	if err != nil {
+		t.Fatalf("CreateSnapshot error: %v", err)
+	}
+	if snapshot.ID != "snap-1" || snapshot.Note != "test" {
+		t.Fatalf("unexpected snapshot: %#v", snapshot)
+	}
+}
+
+func TestGetSnapshot(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/networks/net-1/snapshots/snap-1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(SnapshotDetails{Snapshot: Snapshot{ID: "snap-1", State: "PROCESSED"}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	snapshot, err := client.GetSnapshot(context.Background(), "net-1", "snap-1")
+	if err != nil {
+		t.Fatalf("GetSnapshot error: %v", err)
+	}
+	if snapshot.State != "PROCESSED" {
+		t.Fatalf("unexpected snapshot state: %#v", snapshot)
+	}
+}
+
+func TestGetSnapshotByID(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/snapshots/snap-1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"snap-1","state":"PROCESSED","networkId":"net-1"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	snapshot, err := client.GetSnapshotByID(context.Background(), "snap-1")
+	if err != nil {
+		t.Fatalf("GetSnapshotByID error: %v", err)
+	}
+	if snapshot.ID != "snap-1" || snapshot.NetworkID != "net-1" {
+		t.Fatalf("unexpected snapshot: %#v", snapshot)
+	}
+}
+
+func TestDeleteSnapshot(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/snapshots/snap-1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	if err := client.DeleteSnapshot(context.Background(), "snap-1"); err != nil {
+		t.Fatalf("DeleteSnapshot error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestGetSnapshotProcessingStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/processingStatus" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"state": "PROCESSING", "phase": "PARSING", "devicesParsed": 12, "devicesTotal": 40, "percentComplete": 30}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	status, err := client.GetSnapshotProcessingStatus(context.Background(), "snap-1")
+	if err != nil {
+		t.Fatalf("GetSnapshotProcessingStatus error: %v", err)
+	}
+	if status.Phase != "PARSING" || status.DevicesParsed == nil || *status.DevicesParsed != 12 || status.PercentComplete == nil || *status.PercentComplete != 30 {
+		t.Fatalf("unexpected status: %#v", status)
+	}
+}
+
+func TestCancelSnapshotProcessing(t *testing.T) {
+	t.Parallel()
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/snapshots/snap-1/cancel" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	// Already finished snapshots report a conflict, which is not an error.
+	for _, status = range []int{http.StatusOK, http.StatusConflict} {
+		if err := client.CancelSnapshotProcessing(context.Background(), "snap-1"); err != nil {
+			t.Fatalf("CancelSnapshotProcessing with status %d: %v", status, err)
+		}
+	}
+
+	status = http.StatusForbidden
+	if err := client.CancelSnapshotProcessing(context.Background(), "snap-1"); err == nil {
+		t.Fatal("expected an error for a forbidden cancel")
+	}
+}
+
+func TestSetSnapshotRetentionPolicy(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/networks/net-1/snapshotRetention" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		var policy SnapshotRetentionPolicy
+		if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if !policy.Enabled || policy.MaxAgeDays == nil || *policy.MaxAgeDays != 30 || policy.MaxCount != nil {
+			t.Fatalf("unexpected policy: %#v", policy)
+		}
+		_ = json.NewEncoder(w).Encode(policy)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	maxAge := int64(30)
+	policy, err := client.SetSnapshotRetentionPolicy(context.Background(), "net-1", SnapshotRetentionPolicy{
+		Enabled:    true,
+		MaxAgeDays: &maxAge,
+		Action:     SnapshotRetentionArchive,
+	})
+	if err != nil {
+		t.Fatalf("SetSnapshotRetentionPolicy error: %v", err)
+	}
+	if policy.Action != SnapshotRetentionArchive {
+		t.Fatalf("unexpected policy: %#v", policy)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// decodeArray decodes a JSON array from r one element at a time, calling fn with each,
+// so large responses are never held in memory in full. A null body decodes as empty.
+// Decoding stops at the first error fn returns.
+func decodeArray[T any](r io.Reader, fn func(*T) error) error {
+	return decodeArrayFrom(json.NewDecoder(r), fn)
+}
+
+// decodeArrayFrom is decodeArray for an array that is the next value of dec.
+func decodeArrayFrom[T any](dec *json.Decoder, fn func(*T) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected a JSON array, got %v", tok)
+	}
+
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// decodeObject walks the JSON object that is the next value of dec, calling fn with
+// each key. fn must consume the key's value from dec. A null value is treated as an
+// empty object.
+func decodeObject(dec *json.Decoder, fn func(key string) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object, got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected an object key, got %v", tok)
+		}
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Tunnel describes an IPsec, GRE, or VXLAN tunnel endpoint modeled in a snapshot.
+type Tunnel struct {
+	DeviceName    string `json:"deviceName"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	LocalAddress  string `json:"localAddress"`
+	RemoteAddress string `json:"remoteAddress"`
+	RemoteDevice  string `json:"remoteDevice"`
+	VRF           string `json:"vrf"`
+	Status        string `json:"status"`
+}
+
+// ListTunnels retrieves the tunnels of a snapshot.
+func (c *Client) ListTunnels(ctx context.Context, snapshotID string) ([]Tunnel, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	snapshotID = strings.TrimSpace(snapshotID)
+	if snapshotID == "" {
+		return nil, fmt.Errorf("snapshotID must be provided")
+	}
+
+	path := fmt.Sprintf("/api/snapshots/%s/tunnels", url.PathEscape(snapshotID))
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute tunnels request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving tunnels")
+	}
+
+	var tunnels []Tunnel
+	if err := json.NewDecoder(resp.Body).Decode(&tunnels); err != nil {
+		return nil, fmt.Errorf("decode tunnels response: %w", err)
+	}
+
+	return tunnels, nil
+}
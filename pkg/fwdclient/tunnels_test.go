@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListTunnels(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/snapshots/snap-1/tunnels" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"deviceName":"branch-1","name":"Tunnel0","type":"IPSEC","localAddress":"198.51.100.1","remoteAddress":"203.0.113.1","status":"UP"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	tunnels, err := client.ListTunnels(context.Background(), "snap-1")
+	if err != nil {
+		t.Fatalf("ListTunnels error: %v", err)
+	}
+	if len(tunnels) != 1 || tunnels[0].Type != "IPSEC" || tunnels[0].RemoteAddress != "203.0.113.1" {
+		t.Fatalf("unexpected tunnels: %#v", tunnels)
+	}
+}
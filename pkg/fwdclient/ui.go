@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// UIURL returns an absolute link to route in the Forward Enterprise web UI, such as
+// "search", with query as its parameters. The link is built from the base URL, keeping
+// any path prefix, and never carries user information or the API key, so it can be
+// shared with reviewers.
+func (c *Client) UIURL(route string, query url.Values) string {
+	if c == nil || c.baseURL == nil {
+		return ""
+	}
+
+	link := c.uiBase() + "/?/" + strings.TrimPrefix(route, "/")
+	if encoded := query.Encode(); encoded != "" {
+		link += "?" + encoded
+	}
+	return link
+}
+
+// SnapshotUIURL links to a snapshot of networkID in the Forward Enterprise UI.
+func (c *Client) SnapshotUIURL(networkID, snapshotID string) string {
+	return c.UIURL("search", uiQuery(networkID, snapshotID))
+}
+
+// CheckUIURL links to an intent check on a snapshot of networkID in the Forward
+// Enterprise UI. networkID may be empty when it is not known.
+func (c *Client) CheckUIURL(networkID, snapshotID, checkID string) string {
+	query := uiQuery(networkID, snapshotID)
+	query.Set("checkId", checkID)
+	return c.UIURL("checks", query)
+}
+
+// uiCredentialParams are query parameters that may carry credentials in links returned by
+// the API. They are matched case-insensitively.
+var uiCredentialParams = map[string]bool{
+	"access_token": true,
+	"accesstoken":  true,
+	"api_key":      true,
+	"apikey":       true,
+	"auth":         true,
+	"key":          true,
+	"password":     true,
+	"secret":       true,
+	"session":      true,
+	"sessionid":    true,
+	"sig":          true,
+	"signature":    true,
+	"token":        true,
+}
+
+// ShareableUIURL turns a UI link returned by the API, such as the queryUrl of a path
+// search, into an absolute link that is safe to share. Relative links are resolved
+// against the base URL and absolute links are moved to its scheme and host, so links
+// naming an instance-internal host work from outside. User information, fragments, and
+// query parameters that may carry credentials, such as `token`, are removed. An empty
+// link stays empty.
+func (c *Client) ShareableUIURL(link string) (string, error) {
+	link = strings.TrimSpace(link)
+	if link == "" || c == nil || c.baseURL == nil {
+		return "", nil
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse UI link: %w", err)
+	}
+	if parsed.IsAbs() || parsed.Host != "" {
+		parsed.Scheme, parsed.Host = c.baseURL.Scheme, c.baseURL.Host
+	} else {
+		parsed = c.resolve(parsed)
+	}
+	parsed.User = nil
+	parsed.Fragment, parsed.RawFragment = "", ""
+	parsed.RawQuery = withoutCredentialParams(parsed.RawQuery)
+	return parsed.String(), nil
+}
+
+// withoutCredentialParams drops credential parameters from a raw query. UI links carry
+// the route in the query, as in `/search?networkId=1`, so the parameters after the route
+// are filtered and the route is kept as is.
+func withoutCredentialParams(rawQuery string) string {
+	route, params := "", rawQuery
+	if strings.HasPrefix(rawQuery, "/") {
+		route, params, _ = strings.Cut(rawQuery, "?")
+	}
+
+	kept := make([]string, 0)
+	for _, pair := range strings.Split(params, "&") {
+		if pair == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(pair, "=")
+		if decoded, err := url.QueryUnescape(name); err == nil {
+			name = decoded
+		}
+		if uiCredentialParams[strings.ToLower(name)] {
+			continue
+		}
+		kept = append(kept, pair)
+	}
+
+	filtered := strings.Join(kept, "&")
+	switch {
+	case route == "":
+		return filtered
+	case filtered == "":
+		return route
+	default:
+		return route + "?" + filtered
+	}
+}
+
+// uiBase is the scheme, host, and path prefix of the base URL.
+func (c *Client) uiBase() string {
+	base := url.URL{Scheme: c.baseURL.Scheme, Host: c.baseURL.Host, Path: c.baseURL.Path, RawPath: c.baseURL.RawPath}
+	return strings.TrimSuffix(base.String(), "/")
+}
+
+func uiQuery(networkID, snapshotID string) url.Values {
+	query := url.Values{}
+	if networkID != "" {
+		query.Set("networkId", networkID)
+	}
+	if snapshotID != "" {
+		query.Set("snapshotId", snapshotID)
+	}
+	return query
+}
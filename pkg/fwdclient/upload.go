@@ -0,0 +1,318 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultUploadChunkSize  = 8 << 20
+	defaultUploadMaxResumes = 3
+)
+
+// UploadProgressFunc is called as an upload advances with the bytes the appliance has
+// received so far and the total size.
+type UploadProgressFunc func(sent, total int64)
+
+// UploadOptions tunes UploadResumable and ResumeUpload.
+type UploadOptions struct {
+	// ChunkSize is the size of each uploaded part. Defaults to 8 MiB.
+	ChunkSize int64
+	// MaxResumes is how many times in a row a failed part is resumed from the offset
+	// the appliance reports, on top of the client's own retries. Defaults to 3; a
+	// negative value disables resuming.
+	MaxResumes int
+	// Progress, when set, is called after every part.
+	Progress UploadProgressFunc
+}
+
+// UploadSession is a resumable upload in progress on the appliance.
+type UploadSession struct {
+	ID   string `json:"id"`
+	Size int64  `json:"size"`
+	// ReceivedBytes is how much of the file the appliance has stored; the upload
+	// continues from there.
+	ReceivedBytes int64 `json:"receivedBytes"`
+}
+
+// StartUpload opens a resumable upload of a size-byte file to the endpoint at path,
+// such as a network's snapshot import endpoint.
+func (c *Client) StartUpload(ctx context.Context, path, fileName string, size int64) (*UploadSession, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("path must be provided")
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("size must not be negative")
+	}
+
+	payload, err := json.Marshal(map[string]any{"fileName": fileName, "size": size})
+	if err != nil {
+		return nil, fmt.Errorf("marshal upload payload: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute start upload request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, newAPIError(resp, "starting upload")
+	}
+
+	var session UploadSession
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, fmt.Errorf("decode start upload response: %w", err)
+	}
+	if session.ID == "" {
+		return nil, fmt.Errorf("start upload response has no upload ID")
+	}
+
+	return &session, nil
+}
+
+// GetUpload retrieves the state of a resumable upload.
+func (c *Client) GetUpload(ctx context.Context, uploadID string) (*UploadSession, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	uploadID = strings.TrimSpace(uploadID)
+	if uploadID == "" {
+		return nil, fmt.Errorf("uploadID must be provided")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/api/uploads/%s", url.PathEscape(uploadID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute upload status request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "retrieving upload")
+	}
+
+	var session UploadSession
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, fmt.Errorf("decode upload status response: %w", err)
+	}
+
+	return &session, nil
+}
+
+// UploadResumable uploads content in parts through a resumable upload session and
+// returns the appliance's response to completing it, typically the created object.
+// A part that fails after the client's retries is resumed from the offset the appliance
+// reports, so a dropped connection late in a multi-GB archive does not restart it. Errors
+// name the session, which ResumeUpload continues after a restart.
+func (c *Client) UploadResumable(ctx context.Context, path, fileName string, content io.ReaderAt, size int64, opts UploadOptions) (json.RawMessage, error) {
+	session, err := c.StartUpload(ctx, path, fileName, size)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.ResumeUpload(ctx, session.ID, content, size, opts)
+	if err != nil {
+		return nil, fmt.Errorf("upload %s: %w", session.ID, err)
+	}
+	return result, nil
+}
+
+// ResumeUpload sends the parts of content the appliance has not yet received for an
+// existing upload session, then completes it.
+func (c *Client) ResumeUpload(ctx context.Context, uploadID string, content io.ReaderAt, size int64, opts UploadOptions) (json.RawMessage, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultUploadChunkSize
+	}
+	maxResumes := opts.MaxResumes
+	if maxResumes == 0 {
+		maxResumes = defaultUploadMaxResumes
+	}
+
+	session, err := c.GetUpload(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Size != 0 && session.Size != size {
+		return nil, fmt.Errorf("upload %s expects %d bytes, got %d", uploadID, session.Size, size)
+	}
+
+	offset := session.ReceivedBytes
+	resumes := 0
+	for offset < size {
+		length := min(chunkSize, size-offset)
+		err := c.putUploadPart(ctx, uploadID, content, offset, length, size)
+		if err != nil {
+			if resumes >= maxResumes || ctx.Err() != nil {
+				return nil, err
+			}
+			resumes++
+			current, statusErr := c.GetUpload(ctx, uploadID)
+			if statusErr != nil {
+				return nil, errors.Join(err, statusErr)
+			}
+			offset = current.ReceivedBytes
+			continue
+		}
+
+		resumes = 0
+		offset += length
+		if opts.Progress != nil {
+			opts.Progress(offset, size)
+		}
+	}
+
+	return c.completeUpload(ctx, uploadID)
+}
+
+// putUploadPart sends bytes [offset, offset+length) of content.
+func (c *Client) putUploadPart(ctx context.Context, uploadID string, content io.ReaderAt, offset, length, size int64) error {
+	req, err := c.NewRequest(ctx, http.MethodPut, fmt.Sprintf("/api/uploads/%s", url.PathEscape(uploadID)), io.NewSectionReader(content, offset, length))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = length
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(io.NewSectionReader(content, offset, length)), nil
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, size))
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute upload part request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp, "uploading part")
+	}
+	return nil
+}
+
+func (c *Client) completeUpload(ctx context.Context, uploadID string) (json.RawMessage, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("/api/uploads/%s/complete", url.PathEscape(uploadID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute complete upload request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, newAPIError(resp, "completing upload")
+	}
+
+	return readRawResult(resp.Body, "complete upload")
+}
+
+// UploadMultipart streams content to path as the file field of a multipart/form-data
+// request, for endpoints without resumable uploads. The body is never buffered, so the
+// request cannot be retried; wrap ctx with WithTimeout for large files.
+func (c *Client) UploadMultipart(ctx context.Context, path, field, fileName string, content io.Reader, size int64, progress UploadProgressFunc) (json.RawMessage, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("path must be provided")
+	}
+
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		part, err := form.CreateFormFile(field, fileName)
+		if err == nil {
+			_, err = io.Copy(part, &progressReader{Reader: content, total: size, progress: progress})
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, body)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := c.Do(req)
+	body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("execute multipart upload request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, newAPIError(resp, "uploading file")
+	}
+
+	return readRawResult(resp.Body, "multipart upload")
+}
+
+// progressReader reports the bytes read through it to progress.
+type progressReader struct {
+	io.Reader
+	read     int64
+	total    int64
+	progress UploadProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 && r.progress != nil {
+		r.read += int64(n)
+		r.progress(r.read, r.total)
+	}
+	return n, err
+}
+
+// readRawResult returns a JSON response body as is, or nil when it is empty.
+func readRawResult(body io.Reader, operation string) (json.RawMessage, error) {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("read %s response: %w", operation, err)
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(raw) {
+		return nil, fmt.Errorf("decode %s response: invalid JSON", operation)
+	}
+	return raw, nil
+}
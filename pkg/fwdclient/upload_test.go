@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_UploadResumable(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(strings.Repeat("0123456789", 10))
+	var (
+		mu       sync.Mutex
+		received []byte
+		failed   bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/networks/net-1/snapshots/import":
+			_, _ = w.Write([]byte(`{"id": "up-1", "size": 100}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/uploads/up-1":
+			_ = json.NewEncoder(w).Encode(UploadSession{ID: "up-1", Size: 100, ReceivedBytes: int64(len(received))})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/uploads/up-1":
+			var start, end, total int
+			if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &total); err != nil || start != len(received) {
+				http.Error(w, "range mismatch", http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			part, _ := io.ReadAll(r.Body)
+			if start == 40 && !failed {
+				// Store half of the part, as if the connection dropped midway.
+				failed = true
+				received = append(received, part[:len(part)/2]...)
+				http.Error(w, "interrupted", http.StatusConflict)
+				return
+			}
+			received = append(received, part...)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/uploads/up-1/complete":
+			_, _ = w.Write([]byte(`{"id": "snap-9"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	var progress []int64
+	result, err := client.UploadResumable(context.Background(), "/api/networks/net-1/snapshots/import", "snap.zip",
+		bytes.NewReader(content), int64(len(content)), UploadOptions{
+			ChunkSize: 20,
+			Progress:  func(sent, total int64) { progress = append(progress, sent) },
+		})
+	if err != nil {
+		t.Fatalf("UploadResumable: %v", err)
+	}
+	if string(result) != `{"id": "snap-9"}` {
+		t.Fatalf("unexpected result: %s", result)
+	}
+	if !bytes.Equal(received, content) {
+		t.Fatalf("server received %q", received)
+	}
+	if last := progress[len(progress)-1]; last != 100 {
+		t.Fatalf("expected progress to end at 100, got %v", progress)
+	}
+}
+
+func TestClient_UploadMultipart(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("read form file: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		body, _ := io.ReadAll(file)
+		if header.Filename != "pack.zip" || string(body) != "archive" {
+			t.Errorf("unexpected upload %s: %q", header.Filename, body)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	var sent int64
+	result, err := client.UploadMultipart(context.Background(), "/api/nqe/import", "file", "pack.zip",
+		strings.NewReader("archive"), 7, func(n, total int64) { sent = n })
+	if err != nil {
+		t.Fatalf("UploadMultipart: %v", err)
+	}
+	if result != nil || sent != 7 {
+		t.Fatalf("unexpected result %s after %d bytes", result, sent)
+	}
+}
+
+func TestClient_DoDoesNotReplayStreamedBody(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token", RetryDelay: 1})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	body, writer := io.Pipe()
+	go func() {
+		_, _ = writer.Write([]byte("streamed"))
+		writer.Close()
+	}()
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/test", body)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("expected a single attempt, got %d", got)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Usage summarizes the requests a client has made since it was created.
+type Usage struct {
+	Requests    int
+	Retries     int
+	RateLimited int
+	// Endpoints is ordered slowest first by the longest single request.
+	Endpoints []EndpointUsage
+}
+
+// EndpointUsage aggregates requests to one endpoint. Path segments that look like
+// identifiers are replaced with {id} so requests for different objects share an entry.
+type EndpointUsage struct {
+	Method   string
+	Path     string
+	Requests int
+	Total    time.Duration
+	Max      time.Duration
+}
+
+// usageRecorder accumulates Usage for a client; it is safe for concurrent use.
+type usageRecorder struct {
+	mu          sync.Mutex
+	requests    int
+	retries     int
+	rateLimited int
+	endpoints   map[string]*EndpointUsage
+}
+
+func newUsageRecorder() *usageRecorder {
+	return &usageRecorder{endpoints: map[string]*EndpointUsage{}}
+}
+
+// request records a completed call to Do, including the time spent on retries.
+func (u *usageRecorder) request(req *http.Request, elapsed time.Duration) {
+	path := endpointPath(req.URL.Path)
+	key := req.Method + " " + path
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.requests++
+	endpoint, ok := u.endpoints[key]
+	if !ok {
+		endpoint = &EndpointUsage{Method: req.Method, Path: path}
+		u.endpoints[key] = endpoint
+	}
+	endpoint.Requests++
+	endpoint.Total += elapsed
+	if elapsed > endpoint.Max {
+		endpoint.Max = elapsed
+	}
+}
+
+// retry records an attempt that is about to be retried; status is zero for network errors.
+func (u *usageRecorder) retry(status int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.retries++
+	if status == http.StatusTooManyRequests {
+		u.rateLimited++
+	}
+}
+
+func (u *usageRecorder) snapshot() Usage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage := Usage{Requests: u.requests, Retries: u.retries, RateLimited: u.rateLimited}
+	for _, endpoint := range u.endpoints {
+		usage.Endpoints = append(usage.Endpoints, *endpoint)
+	}
+	sortEndpoints(usage.Endpoints)
+	return usage
+}
+
+// Merge returns the combined usage of u and other, as if one client had made both sets
+// of requests.
+func (u Usage) Merge(other Usage) Usage {
+	merged := Usage{
+		Requests:    u.Requests + other.Requests,
+		Retries:     u.Retries + other.Retries,
+		RateLimited: u.RateLimited + other.RateLimited,
+	}
+	index := map[string]int{}
+	for _, endpoint := range append(append([]EndpointUsage(nil), u.Endpoints...), other.Endpoints...) {
+		key := endpoint.Method + " " + endpoint.Path
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged.Endpoints)
+			merged.Endpoints = append(merged.Endpoints, endpoint)
+			continue
+		}
+		existing := &merged.Endpoints[i]
+		existing.Requests += endpoint.Requests
+		existing.Total += endpoint.Total
+		existing.Max = max(existing.Max, endpoint.Max)
+	}
+	sortEndpoints(merged.Endpoints)
+	return merged
+}
+
+// sortEndpoints orders endpoints slowest first by the longest single request.
+func sortEndpoints(endpoints []EndpointUsage) {
+	sort.Slice(endpoints, func(i, j int) bool {
+		a, b := endpoints[i], endpoints[j]
+		if a.Max != b.Max {
+			return a.Max > b.Max
+		}
+		return a.Method+" "+a.Path < b.Method+" "+b.Path
+	})
+}
+
+// endpointPath replaces path segments containing digits, which identify networks,
+// snapshots, and other objects, with {id}.
+func endpointPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.IndexFunc(segment, unicode.IsDigit) >= 0 {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Usage returns the requests, retries, and per-endpoint latencies recorded so far.
+func (c *Client) Usage() Usage {
+	if c == nil {
+		return Usage{}
+	}
+	return c.usage.snapshot()
+}
+
+type usageContextKey struct{}
+
+// WithUsage returns a context whose requests are also recorded separately from the
+// client totals, and a function that reports them. Use it to attribute API usage to a
+// single operation.
+func WithUsage(ctx context.Context) (context.Context, func() Usage) {
+	recorder := newUsageRecorder()
+	return context.WithValue(ctx, usageContextKey{}, recorder), recorder.snapshot
+}
+
+// contextUsage returns the recorder installed by WithUsage, if any.
+func contextUsage(ctx context.Context) *usageRecorder {
+	recorder, _ := ctx.Value(usageContextKey{}).(*usageRecorder)
+	return recorder
+}
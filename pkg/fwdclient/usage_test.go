@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwdclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Usage(t *testing.T) {
+	t.Parallel()
+
+	var throttled atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/snapshots/123/checks" && throttled.CompareAndSwap(false, true) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if r.URL.Path == "/api/snapshots/456/checks" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token", RetryDelay: time.Millisecond})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	for _, path := range []string{"/api/snapshots/123/checks", "/api/snapshots/456/checks", "/api/networks"} {
+		req, err := client.NewRequest(context.Background(), http.MethodGet, path+"?type=NQE", nil)
+		if err != nil {
+			t.Fatalf("new request: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("do %s: %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	usage := client.Usage()
+	if usage.Requests != 3 || usage.Retries != 1 || usage.RateLimited != 1 {
+		t.Fatalf("unexpected totals: %+v", usage)
+	}
+	if len(usage.Endpoints) != 2 {
+		t.Fatalf("expected snapshot paths to share an endpoint, got %+v", usage.Endpoints)
+	}
+	slowest := usage.Endpoints[0]
+	if slowest.Method != http.MethodGet || slowest.Path != "/api/snapshots/{id}/checks" || slowest.Requests != 2 {
+		t.Fatalf("unexpected slowest endpoint: %+v", slowest)
+	}
+	if slowest.Max < 20*time.Millisecond || slowest.Total < slowest.Max {
+		t.Fatalf("unexpected latencies: %+v", slowest)
+	}
+}
+
+func TestWithUsage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{BaseURL: server.URL, APIKey: "token"})
+	if err != nil {
+		t.Fatalf("construct client: %v", err)
+	}
+
+	get := func(ctx context.Context) {
+		t.Helper()
+		req, err := client.NewRequest(ctx, http.MethodGet, "/api/networks", nil)
+		if err != nil {
+			t.Fatalf("new request: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("do: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	get(context.Background())
+	ctx, usage := WithUsage(context.Background())
+	get(ctx)
+	get(ctx)
+
+	if got := usage(); got.Requests != 2 || len(got.Endpoints) != 1 {
+		t.Fatalf("expected only the context's requests, got %+v", got)
+	}
+	if got := client.Usage(); got.Requests != 3 {
+		t.Fatalf("expected the client totals to include every request, got %+v", got)
+	}
+}
+
+func TestUsageMerge(t *testing.T) {
+	t.Parallel()
+
+	a := Usage{Requests: 2, Retries: 1, Endpoints: []EndpointUsage{
+		{Method: http.MethodGet, Path: "/api/networks", Requests: 2, Total: 2 * time.Second, Max: 1500 * time.Millisecond},
+	}}
+	b := Usage{Requests: 3, RateLimited: 1, Endpoints: []EndpointUsage{
+		{Method: http.MethodGet, Path: "/api/networks", Requests: 1, Total: 3 * time.Second, Max: 3 * time.Second},
+		{Method: http.MethodPost, Path: "/api/snapshots/{id}/checks", Requests: 2, Total: 4 * time.Second, Max: 2 * time.Second},
+	}}
+
+	merged := a.Merge(b)
+	if merged.Requests != 5 || merged.Retries != 1 || merged.RateLimited != 1 || len(merged.Endpoints) != 2 {
+		t.Fatalf("unexpected merged usage: %+v", merged)
+	}
+	if got := merged.Endpoints[0]; got.Path != "/api/networks" || got.Requests != 3 || got.Total != 5*time.Second || got.Max != 3*time.Second {
+		t.Fatalf("unexpected slowest endpoint: %+v", got)
+	}
+	if len(a.Endpoints) != 1 || a.Endpoints[0].Requests != 2 {
+		t.Fatalf("expected Merge not to modify its receiver, got %+v", a)
+	}
+}
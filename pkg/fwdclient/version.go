@@ -1,13 +1,12 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: MPL-2.0
 
-package sdk
+package fwdclient
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 )
 
@@ -36,8 +35,7 @@ func (c *Client) GetVersion(ctx context.Context) (*Version, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<14))
-		return nil, fmt.Errorf("unexpected status %d retrieving version: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "retrieving version")
 	}
 
 	var payload Version
@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !protocol5
+
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/provider"
+)
+
+// serve runs the provider over plugin protocol 6, which requires Terraform 1.0 or later.
+// Build with -tags protocol5 for Terraform 0.13 through 1.0.
+func serve(address string, debug bool, usage *provider.UsageSummary) error {
+	var opts []tf6server.ServeOpt
+	if debug {
+		opts = append(opts, tf6server.WithManagedDebug())
+	}
+	return tf6server.Serve(address, provider.NewProtocol6Server(version, usage), opts...)
+}
@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build protocol5
+
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+
+	"github.com/forwardnetworks/terraform-provider-forward/internal/provider"
+)
+
+// serve runs the provider over plugin protocol 5 for Terraform versions that predate
+// protocol 6.
+func serve(address string, debug bool, usage *provider.UsageSummary) error {
+	var opts []tf5server.ServeOpt
+	if debug {
+		opts = append(opts, tf5server.WithManagedDebug())
+	}
+	return tf5server.Serve(address, provider.NewProtocol5Server(version, usage), opts...)
+}